@@ -0,0 +1,8 @@
+package proto
+
+import "embed"
+
+// FS contains protocol definitions, used as schemas in the schema registry.
+//
+//go:embed companiespb/v1/*.proto
+var FS embed.FS
@@ -1,10 +1,16 @@
 package main
 
 import (
+	stdContext "context"
+	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/018bf/companies"
 	"github.com/018bf/companies/internal/containers"
+	"github.com/018bf/companies/internal/entity"
+	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/utils"
 	"github.com/urfave/cli/v2"
 )
 
@@ -32,10 +38,61 @@ func main() {
 		Action: runApp,
 		Commands: []*cli.Command{
 			{
-				Name:      "migrate",
-				Usage:     "Run migrations",
-				Action:    runMigrations,
-				ArgsUsage: "",
+				Name:   "migrate",
+				Usage:  "Manage migrations, applies all of them without a subcommand",
+				Action: runMigrations,
+				Subcommands: []*cli.Command{
+					{
+						Name:      "up",
+						Usage:     "Apply N or all pending migrations",
+						Action:    runMigrateUp,
+						ArgsUsage: "[N]",
+					},
+					{
+						Name:      "down",
+						Usage:     "Roll back N migrations, 1 by default",
+						Action:    runMigrateDown,
+						ArgsUsage: "[N]",
+						Flags: []cli.Flag{
+							&cli.BoolFlag{
+								Name:  "all",
+								Usage: "Roll back all migrations",
+							},
+						},
+					},
+					{
+						Name:      "goto",
+						Usage:     "Migrate up or down to version V",
+						Action:    runMigrateGoto,
+						ArgsUsage: "V",
+					},
+					{
+						Name:      "force",
+						Usage:     "Set version V without migrating and clear the dirty state, -1 is no version",
+						Action:    runMigrateForce,
+						ArgsUsage: "V",
+					},
+					{
+						Name:      "status",
+						Aliases:   []string{"version"},
+						Usage:     "List the applied and pending migrations",
+						Action:    runMigrateStatus,
+						ArgsUsage: "",
+					},
+					{
+						Name:      "create",
+						Usage:     "Create empty up and down migrations",
+						Action:    runMigrateCreate,
+						ArgsUsage: "NAME",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "dir",
+								Usage: "Create the migrations in `DIR`",
+								Value: "internal/interfaces/postgres/migrations",
+							},
+						},
+					},
+				},
 			},
 			{
 				Name:      "grpc",
@@ -49,6 +106,90 @@ func main() {
 				Action:    runREST,
 				ArgsUsage: "",
 			},
+			{
+				Name:      "consume",
+				Usage:     "Run commands consumer",
+				Action:    runConsumer,
+				ArgsUsage: "",
+			},
+			{
+				Name:      "webhooks",
+				Usage:     "Run webhooks delivery worker",
+				Action:    runWebhooks,
+				ArgsUsage: "",
+			},
+			{
+				Name:  "changes",
+				Usage: "Manage the company changes feed",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "prune",
+						Usage:     "Delete the tombstones and superseded changes older than the retention",
+						Action:    runPruneChanges,
+						ArgsUsage: "",
+					},
+				},
+			},
+			{
+				Name:  "stats",
+				Usage: "Manage the company statistics",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "refresh",
+						Usage:     "Run the worker refreshing the materialized statistics",
+						Action:    runStatsRefresh,
+						ArgsUsage: "",
+					},
+				},
+			},
+			{
+				Name:  "events",
+				Usage: "Manage company events",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "replay",
+						Usage:     "Publish snapshots of the existing companies",
+						Action:    runReplay,
+						ArgsUsage: "",
+						Flags: []cli.Flag{
+							&cli.StringSliceFlag{
+								Name:  "id",
+								Usage: "Replay only the company with `ID`",
+							},
+							&cli.IntSliceFlag{
+								Name:  "type",
+								Usage: "Replay only companies of `TYPE`",
+							},
+							&cli.StringFlag{
+								Name:  "registered",
+								Usage: "Replay only registered (true) or unregistered (false) companies",
+							},
+							&cli.StringFlag{
+								Name:  "search",
+								Usage: "Replay only companies matching `QUERY`",
+							},
+							&cli.Float64Flag{
+								Name:  "rate",
+								Usage: "Publish at most `N` events per second, 0 is unlimited",
+							},
+							&cli.Uint64Flag{
+								Name:  "batch-size",
+								Usage: "Read `N` companies per query",
+								Value: 100,
+							},
+							&cli.StringFlag{
+								Name:      "checkpoint",
+								Usage:     "Resume from and save progress to `FILE`",
+								TakesFile: true,
+							},
+							&cli.StringFlag{
+								Name:  "topic",
+								Usage: "Publish to `TOPIC` instead of the configured one",
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 	if err := app.Run(os.Args); err != nil {
@@ -77,9 +218,176 @@ func runREST(context *cli.Context) error {
 	return nil
 }
 
+// runConsumer - apply commands from kafka
+func runConsumer(context *cli.Context) error {
+	app := containers.NewConsumerContainer(configPath)
+	app.Run()
+	return nil
+}
+
+// runWebhooks - deliver webhooks
+func runWebhooks(context *cli.Context) error {
+	app := containers.NewWebhooksContainer(configPath)
+	app.Run()
+	return nil
+}
+
+// runPruneChanges - delete the changes older than the retention
+func runPruneChanges(context *cli.Context) error {
+	app := containers.NewPruneChangesContainer(configPath)
+	app.Run()
+	return nil
+}
+
+// runStatsRefresh - refresh the materialized statistics on a schedule
+func runStatsRefresh(context *cli.Context) error {
+	app := containers.NewStatsRefreshContainer(configPath)
+	app.Run()
+	return nil
+}
+
+// runReplay - publish snapshots of the existing companies
+func runReplay(context *cli.Context) error {
+	filter := &entity.CompanyFilter{}
+	for _, id := range context.StringSlice("id") {
+		filter.IDs = append(filter.IDs, entity.UUID(id))
+	}
+	for _, companyType := range context.IntSlice("type") {
+		filter.Types = append(filter.Types, entity.CompanyType(companyType))
+	}
+	if context.IsSet("registered") {
+		registered, err := strconv.ParseBool(context.String("registered"))
+		if err != nil {
+			return err
+		}
+		filter.Registered = utils.Pointer(registered)
+	}
+	if context.IsSet("search") {
+		filter.Search = utils.Pointer(context.String("search"))
+	}
+	app := containers.NewReplayContainer(configPath, containers.ReplayOptions{
+		Filter:     filter,
+		Rate:       context.Float64("rate"),
+		BatchSize:  context.Uint64("batch-size"),
+		Checkpoint: context.String("checkpoint"),
+		Topic:      context.String("topic"),
+	})
+	app.Run()
+	return nil
+}
+
 // runMigrations - migrate database
 func runMigrations(context *cli.Context) error {
-	app := containers.NewMigrateContainer(configPath)
+	return migrate(func(ctx stdContext.Context, manager *postgresInterface.MigrateManager) error {
+		return manager.Up(ctx)
+	})
+}
+
+// runMigrateUp - apply N or all pending migrations
+func runMigrateUp(context *cli.Context) error {
+	if context.NArg() == 0 {
+		return runMigrations(context)
+	}
+	n, err := migrateArg(context)
+	if err != nil {
+		return err
+	}
+	return migrate(func(ctx stdContext.Context, manager *postgresInterface.MigrateManager) error {
+		return manager.Steps(ctx, n)
+	})
+}
+
+// runMigrateDown - roll back N or all migrations
+func runMigrateDown(context *cli.Context) error {
+	if context.Bool("all") {
+		return migrate(func(ctx stdContext.Context, manager *postgresInterface.MigrateManager) error {
+			return manager.Down(ctx)
+		})
+	}
+	n := 1
+	if context.NArg() > 0 {
+		var err error
+		if n, err = migrateArg(context); err != nil {
+			return err
+		}
+	}
+	return migrate(func(ctx stdContext.Context, manager *postgresInterface.MigrateManager) error {
+		return manager.Steps(ctx, -n)
+	})
+}
+
+// runMigrateGoto - migrate to the version
+func runMigrateGoto(context *cli.Context) error {
+	version, err := strconv.ParseUint(context.Args().First(), 10, 0)
+	if err != nil {
+		return err
+	}
+	return migrate(func(ctx stdContext.Context, manager *postgresInterface.MigrateManager) error {
+		return manager.Goto(ctx, uint(version))
+	})
+}
+
+// runMigrateForce - set the version without migrating
+func runMigrateForce(context *cli.Context) error {
+	version, err := strconv.Atoi(context.Args().First())
+	if err != nil {
+		return err
+	}
+	return migrate(func(ctx stdContext.Context, manager *postgresInterface.MigrateManager) error {
+		return manager.Force(ctx, version)
+	})
+}
+
+// runMigrateStatus - print the applied version and the migrations
+func runMigrateStatus(context *cli.Context) error {
+	return migrate(func(ctx stdContext.Context, manager *postgresInterface.MigrateManager) error {
+		status, err := manager.Status(ctx)
+		if err != nil {
+			return err
+		}
+		dirty := ""
+		if status.Dirty {
+			dirty = " (dirty)"
+		}
+		fmt.Fprintf(context.App.Writer, "version: %d%s\n", status.Version, dirty)
+		for _, migration := range status.Migrations {
+			state := "pending"
+			if migration.Applied {
+				state = "applied"
+			}
+			fmt.Fprintf(context.App.Writer, "%06d %-8s %s\n", migration.Version, state, migration.Name)
+		}
+		return nil
+	})
+}
+
+// runMigrateCreate - create empty migrations
+func runMigrateCreate(context *cli.Context) error {
+	paths, err := postgresInterface.CreateMigration(context.String("dir"), context.Args().First())
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		fmt.Fprintln(context.App.Writer, path)
+	}
+	return nil
+}
+
+// migrateArg parses the number of migrations
+func migrateArg(context *cli.Context) (int, error) {
+	n, err := strconv.Atoi(context.Args().First())
+	if err != nil {
+		return 0, err
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("number of migrations must be positive, got %d", n)
+	}
+	return n, nil
+}
+
+// migrate runs the migrations container
+func migrate(run func(ctx stdContext.Context, manager *postgresInterface.MigrateManager) error) error {
+	app := containers.NewMigrateContainer(configPath, run)
 	app.Run()
 	return nil
 }
@@ -19,6 +19,7 @@ type authService interface {
 		object any,
 	) error
 	ValidateToken(ctx context.Context, access *entity.Token) error
+	GetSubject(ctx context.Context, token *entity.Token) (string, error)
 }
 
 type AuthInterceptor struct {
@@ -45,3 +46,11 @@ func (i *AuthInterceptor) ValidateToken(ctx context.Context, token *entity.Token
 	}
 	return nil
 }
+
+func (i *AuthInterceptor) GetSubject(ctx context.Context, token *entity.Token) (string, error) {
+	subject, err := i.authService.GetSubject(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return subject, nil
+}
@@ -8,7 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
-	models "github.com/018bf/companies/internal/entity"
+	entity "github.com/018bf/companies/internal/entity"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,8 +35,23 @@ func (m *MockauthService) EXPECT() *MockauthServiceMockRecorder {
 	return m.recorder
 }
 
+// GetSubject mocks base method.
+func (m *MockauthService) GetSubject(ctx context.Context, token *entity.Token) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubject", ctx, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubject indicates an expected call of GetSubject.
+func (mr *MockauthServiceMockRecorder) GetSubject(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubject", reflect.TypeOf((*MockauthService)(nil).GetSubject), ctx, token)
+}
+
 // HasObjectPermission mocks base method.
-func (m *MockauthService) HasObjectPermission(ctx context.Context, token *models.Token, permission models.PermissionID, object any) error {
+func (m *MockauthService) HasObjectPermission(ctx context.Context, token *entity.Token, permission entity.PermissionID, object any) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HasObjectPermission", ctx, token, permission, object)
 	ret0, _ := ret[0].(error)
@@ -50,7 +65,7 @@ func (mr *MockauthServiceMockRecorder) HasObjectPermission(ctx, token, permissio
 }
 
 // HasPermission mocks base method.
-func (m *MockauthService) HasPermission(ctx context.Context, token *models.Token, permission models.PermissionID) error {
+func (m *MockauthService) HasPermission(ctx context.Context, token *entity.Token, permission entity.PermissionID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HasPermission", ctx, token, permission)
 	ret0, _ := ret[0].(error)
@@ -64,7 +79,7 @@ func (mr *MockauthServiceMockRecorder) HasPermission(ctx, token, permission inte
 }
 
 // ValidateToken mocks base method.
-func (m *MockauthService) ValidateToken(ctx context.Context, access *models.Token) error {
+func (m *MockauthService) ValidateToken(ctx context.Context, access *entity.Token) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ValidateToken", ctx, access)
 	ret0, _ := ret[0].(error)
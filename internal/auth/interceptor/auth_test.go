@@ -135,3 +135,52 @@ func TestAuthInterceptor_ValidateToken(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthInterceptor_GetSubject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthService := NewMockauthService(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	token := utils.Pointer(entity.Token("this_is_valid_token"))
+	tests := []struct {
+		name    string
+		setup   func()
+		want    string
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockAuthService.EXPECT().GetSubject(ctx, token).Return("user", nil)
+			},
+			want:    "user",
+			wantErr: nil,
+		},
+		{
+			name: "service error",
+			setup: func() {
+				mockAuthService.EXPECT().GetSubject(ctx, token).Return("", errs.NewBadToken())
+			},
+			want:    "",
+			wantErr: errs.NewBadToken(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			i := AuthInterceptor{
+				authService: mockAuthService,
+				logger:      logger,
+			}
+			got, err := i.GetSubject(ctx, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetSubject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetSubject() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -93,6 +93,11 @@ var hasObjectPermission = map[entity.PermissionID][]objectPermissionChecker{
 	entity.PermissionIDCompanyCreate: {objectUser},
 	entity.PermissionIDCompanyUpdate: {objectUser},
 	entity.PermissionIDCompanyDelete: {objectUser},
+	entity.PermissionIDWebhookList:   {objectAdmin},
+	entity.PermissionIDWebhookDetail: {objectAdmin},
+	entity.PermissionIDWebhookCreate: {objectAdmin},
+	entity.PermissionIDWebhookUpdate: {objectAdmin},
+	entity.PermissionIDWebhookDelete: {objectAdmin},
 }
 
 var hasPermission = map[entity.PermissionID][]permissionChecker{
@@ -101,6 +106,11 @@ var hasPermission = map[entity.PermissionID][]permissionChecker{
 	entity.PermissionIDCompanyCreate: {user},
 	entity.PermissionIDCompanyUpdate: {user},
 	entity.PermissionIDCompanyDelete: {user},
+	entity.PermissionIDWebhookList:   {admin},
+	entity.PermissionIDWebhookDetail: {admin},
+	entity.PermissionIDWebhookCreate: {admin},
+	entity.PermissionIDWebhookUpdate: {admin},
+	entity.PermissionIDWebhookDelete: {admin},
 }
 
 func (r *AuthRepository) HasPermission(
@@ -134,7 +144,6 @@ func (r *AuthRepository) HasObjectPermission(
 	return errs.NewPermissionDenied()
 }
 
-// nolint: unused
 func objectAdmin(_ any, token *jwt.Token) error {
 	if token == nil {
 		return errs.NewPermissionDenied()
@@ -163,7 +172,6 @@ func objectAnybody(_ any, _ *jwt.Token) error {
 	return nil
 }
 
-// nolint: unused
 func admin(token *jwt.Token) error {
 	if token == nil {
 		return errs.NewPermissionDenied()
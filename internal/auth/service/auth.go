@@ -47,6 +47,15 @@ func (u AuthService) ValidateToken(ctx context.Context, token *entity.Token) err
 	return nil
 }
 
+// GetSubject returns who the token is issued to.
+func (u AuthService) GetSubject(ctx context.Context, token *entity.Token) (string, error) {
+	subject, err := u.authRepository.GetSubject(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return subject, nil
+}
+
 func (u AuthService) HasPermission(
 	ctx context.Context,
 	token *entity.Token,
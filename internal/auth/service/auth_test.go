@@ -300,3 +300,52 @@ func TestAuthService_HasObjectPermission(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_GetSubject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthRepository := NewMockauthRepository(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	token := entity.NewToken("this_is_valid_token")
+	tests := []struct {
+		name    string
+		setup   func()
+		want    string
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockAuthRepository.EXPECT().GetSubject(ctx, token).Return("user", nil)
+			},
+			want:    "user",
+			wantErr: nil,
+		},
+		{
+			name: "invalid token",
+			setup: func() {
+				mockAuthRepository.EXPECT().GetSubject(ctx, token).Return("", errs.NewBadToken())
+			},
+			want:    "",
+			wantErr: errs.NewBadToken(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := AuthService{
+				authRepository: mockAuthRepository,
+				logger:         logger,
+			}
+			got, err := u.GetSubject(ctx, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetSubject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("GetSubject() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
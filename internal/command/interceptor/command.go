@@ -0,0 +1,123 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/log"
+)
+
+//go:generate mockgen -source=command.go -package=interceptor -destination=command_mock.go
+
+type commandService interface {
+	Start(ctx context.Context, command *entity.Command) error
+	Reply(ctx context.Context, reply *entity.CommandReply) error
+}
+
+type companyInterceptor interface {
+	Create(ctx context.Context, create *entity.CompanyCreate, token *entity.Token) (*entity.Company, error)
+	Update(ctx context.Context, update *entity.CompanyUpdate, token *entity.Token) (*entity.Company, error)
+	Delete(ctx context.Context, id entity.UUID, token *entity.Token) error
+}
+
+type txManager interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type CommandInterceptor struct {
+	commandService     commandService
+	companyInterceptor companyInterceptor
+	txManager          txManager
+	token              *entity.Token
+	logger             log.Logger
+}
+
+func NewCommandInterceptor(
+	commandService commandService,
+	companyInterceptor companyInterceptor,
+	txManager txManager,
+	config *configs.Config,
+	logger log.Logger,
+) *CommandInterceptor {
+	return &CommandInterceptor{
+		commandService:     commandService,
+		companyInterceptor: companyInterceptor,
+		txManager:          txManager,
+		token:              entity.NewToken(config.Consumer.Token),
+		logger:             logger,
+	}
+}
+
+// Handle applies the command with the service account token and replies with the result.
+// The command is marked as processed in the transaction of the company write,
+// so redelivered commands are skipped and failed commands may be handled again.
+// The company events are sent after the commit, so the rolled back attempts send none.
+func (i *CommandInterceptor) Handle(ctx context.Context, command *entity.Command) error {
+	var company *entity.Company
+	var started, processed bool
+	err := i.txManager.Transaction(ctx, func(ctx context.Context) error {
+		started, processed = false, false
+		if err := i.commandService.Start(ctx, command); err != nil {
+			var e *errs.Error
+			if errors.As(err, &e) && e.Code == errs.ErrorCodeAlreadyExists {
+				processed = true
+				return nil
+			}
+			return err
+		}
+		started = true
+		var err error
+		company, err = i.apply(ctx, command)
+		return err
+	})
+	if err != nil {
+		if !started || errs.IsTemporary(err) {
+			return err
+		}
+		reply := &entity.CommandReply{
+			CommandID: command.ID,
+			Operation: command.Operation,
+			Status:    entity.CommandStatusFailed,
+		}
+		if !errors.As(err, &reply.Error) {
+			reply.Error = errs.NewUnexpectedBehaviorError(err.Error())
+		}
+		i.reply(ctx, reply)
+		return err
+	}
+	if processed {
+		i.logger.Info(
+			"command is already processed",
+			log.Context(ctx),
+			log.String("command_id", string(command.ID)),
+		)
+		return nil
+	}
+	i.reply(ctx, &entity.CommandReply{
+		CommandID: command.ID,
+		Operation: command.Operation,
+		Status:    entity.CommandStatusSucceeded,
+		Company:   company,
+	})
+	return nil
+}
+
+func (i *CommandInterceptor) apply(ctx context.Context, command *entity.Command) (*entity.Company, error) {
+	switch command.Operation {
+	case entity.CommandOperationCreate:
+		return i.companyInterceptor.Create(ctx, command.Create, i.token)
+	case entity.CommandOperationUpdate:
+		return i.companyInterceptor.Update(ctx, command.Update, i.token)
+	default:
+		return nil, i.companyInterceptor.Delete(ctx, command.CompanyID, i.token)
+	}
+}
+
+func (i *CommandInterceptor) reply(ctx context.Context, reply *entity.CommandReply) {
+	if err := i.commandService.Reply(ctx, reply); err != nil {
+		i.logger.Error("can't send command reply", log.Context(ctx), log.Error(err))
+	}
+}
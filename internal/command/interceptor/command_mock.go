@@ -0,0 +1,168 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: command.go
+
+// Package interceptor is a generated GoMock package.
+package interceptor
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockcommandService is a mock of commandService interface.
+type MockcommandService struct {
+	ctrl     *gomock.Controller
+	recorder *MockcommandServiceMockRecorder
+}
+
+// MockcommandServiceMockRecorder is the mock recorder for MockcommandService.
+type MockcommandServiceMockRecorder struct {
+	mock *MockcommandService
+}
+
+// NewMockcommandService creates a new mock instance.
+func NewMockcommandService(ctrl *gomock.Controller) *MockcommandService {
+	mock := &MockcommandService{ctrl: ctrl}
+	mock.recorder = &MockcommandServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcommandService) EXPECT() *MockcommandServiceMockRecorder {
+	return m.recorder
+}
+
+// Reply mocks base method.
+func (m *MockcommandService) Reply(ctx context.Context, reply *entity.CommandReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reply", ctx, reply)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reply indicates an expected call of Reply.
+func (mr *MockcommandServiceMockRecorder) Reply(ctx, reply interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reply", reflect.TypeOf((*MockcommandService)(nil).Reply), ctx, reply)
+}
+
+// Start mocks base method.
+func (m *MockcommandService) Start(ctx context.Context, command *entity.Command) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, command)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockcommandServiceMockRecorder) Start(ctx, command interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockcommandService)(nil).Start), ctx, command)
+}
+
+// MockcompanyInterceptor is a mock of companyInterceptor interface.
+type MockcompanyInterceptor struct {
+	ctrl     *gomock.Controller
+	recorder *MockcompanyInterceptorMockRecorder
+}
+
+// MockcompanyInterceptorMockRecorder is the mock recorder for MockcompanyInterceptor.
+type MockcompanyInterceptorMockRecorder struct {
+	mock *MockcompanyInterceptor
+}
+
+// NewMockcompanyInterceptor creates a new mock instance.
+func NewMockcompanyInterceptor(ctrl *gomock.Controller) *MockcompanyInterceptor {
+	mock := &MockcompanyInterceptor{ctrl: ctrl}
+	mock.recorder = &MockcompanyInterceptorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcompanyInterceptor) EXPECT() *MockcompanyInterceptorMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockcompanyInterceptor) Create(ctx context.Context, create *entity.CompanyCreate, token *entity.Token) (*entity.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, create, token)
+	ret0, _ := ret[0].(*entity.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockcompanyInterceptorMockRecorder) Create(ctx, create, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockcompanyInterceptor)(nil).Create), ctx, create, token)
+}
+
+// Delete mocks base method.
+func (m *MockcompanyInterceptor) Delete(ctx context.Context, id entity.UUID, token *entity.Token) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockcompanyInterceptorMockRecorder) Delete(ctx, id, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockcompanyInterceptor)(nil).Delete), ctx, id, token)
+}
+
+// Update mocks base method.
+func (m *MockcompanyInterceptor) Update(ctx context.Context, update *entity.CompanyUpdate, token *entity.Token) (*entity.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, update, token)
+	ret0, _ := ret[0].(*entity.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockcompanyInterceptorMockRecorder) Update(ctx, update, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockcompanyInterceptor)(nil).Update), ctx, update, token)
+}
+
+// MocktxManager is a mock of txManager interface.
+type MocktxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MocktxManagerMockRecorder
+}
+
+// MocktxManagerMockRecorder is the mock recorder for MocktxManager.
+type MocktxManagerMockRecorder struct {
+	mock *MocktxManager
+}
+
+// NewMocktxManager creates a new mock instance.
+func NewMocktxManager(ctrl *gomock.Controller) *MocktxManager {
+	mock := &MocktxManager{ctrl: ctrl}
+	mock.recorder = &MocktxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktxManager) EXPECT() *MocktxManagerMockRecorder {
+	return m.recorder
+}
+
+// Transaction mocks base method.
+func (m *MocktxManager) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MocktxManagerMockRecorder) Transaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MocktxManager)(nil).Transaction), ctx, fn)
+}
@@ -0,0 +1,239 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCommandInterceptor_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCommandService := NewMockcommandService(ctrl)
+	mockCompanyInterceptor := NewMockcompanyInterceptor(ctrl)
+	ctx := context.Background()
+	mockTxManager := NewMocktxManager(ctrl)
+	mockTxManager.EXPECT().
+		Transaction(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+	logger := mock_log.NewMockLogger(ctrl)
+	token := entity.NewToken("service-account")
+	create := mock_models.NewCommand(t, entity.CommandOperationCreate)
+	update := mock_models.NewCommand(t, entity.CommandOperationUpdate)
+	remove := mock_models.NewCommand(t, entity.CommandOperationDelete)
+	company := mock_models.NewCompany(t)
+	type fields struct {
+		commandService     commandService
+		companyInterceptor companyInterceptor
+		txManager          txManager
+		token              *entity.Token
+		logger             log.Logger
+	}
+	type args struct {
+		ctx     context.Context
+		command *entity.Command
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "create",
+			setup: func() {
+				mockCommandService.EXPECT().Start(ctx, create).Return(nil)
+				mockCompanyInterceptor.EXPECT().Create(ctx, create.Create, token).Return(company, nil)
+				mockCommandService.EXPECT().Reply(ctx, &entity.CommandReply{
+					CommandID: create.ID,
+					Operation: entity.CommandOperationCreate,
+					Status:    entity.CommandStatusSucceeded,
+					Company:   company,
+				}).Return(nil)
+			},
+			fields: fields{
+				commandService:     mockCommandService,
+				companyInterceptor: mockCompanyInterceptor,
+				txManager:          mockTxManager,
+				token:              token,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     ctx,
+				command: create,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "update",
+			setup: func() {
+				mockCommandService.EXPECT().Start(ctx, update).Return(nil)
+				mockCompanyInterceptor.EXPECT().Update(ctx, update.Update, token).Return(company, nil)
+				mockCommandService.EXPECT().Reply(ctx, &entity.CommandReply{
+					CommandID: update.ID,
+					Operation: entity.CommandOperationUpdate,
+					Status:    entity.CommandStatusSucceeded,
+					Company:   company,
+				}).Return(nil)
+			},
+			fields: fields{
+				commandService:     mockCommandService,
+				companyInterceptor: mockCompanyInterceptor,
+				txManager:          mockTxManager,
+				token:              token,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     ctx,
+				command: update,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "delete with reply error",
+			setup: func() {
+				mockCommandService.EXPECT().Start(ctx, remove).Return(nil)
+				mockCompanyInterceptor.EXPECT().Delete(ctx, remove.CompanyID, token).Return(nil)
+				mockCommandService.EXPECT().Reply(ctx, &entity.CommandReply{
+					CommandID: remove.ID,
+					Operation: entity.CommandOperationDelete,
+					Status:    entity.CommandStatusSucceeded,
+				}).Return(errs.NewUnexpectedBehaviorError("err 1"))
+				logger.EXPECT().Error(
+					"can't send command reply",
+					log.Context(ctx),
+					log.Error(errs.NewUnexpectedBehaviorError("err 1")),
+				)
+			},
+			fields: fields{
+				commandService:     mockCommandService,
+				companyInterceptor: mockCompanyInterceptor,
+				txManager:          mockTxManager,
+				token:              token,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     ctx,
+				command: remove,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "already processed",
+			setup: func() {
+				mockCommandService.EXPECT().
+					Start(ctx, create).
+					Return(errs.NewError(errs.ErrorCodeAlreadyExists, "Command is already processed."))
+				logger.EXPECT().Info(
+					"command is already processed",
+					log.Context(ctx),
+					log.String("command_id", string(create.ID)),
+				)
+			},
+			fields: fields{
+				commandService:     mockCommandService,
+				companyInterceptor: mockCompanyInterceptor,
+				txManager:          mockTxManager,
+				token:              token,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     ctx,
+				command: create,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "invalid command",
+			setup: func() {
+				mockCommandService.EXPECT().
+					Start(ctx, create).
+					Return(errs.NewInvalidFormError())
+			},
+			fields: fields{
+				commandService:     mockCommandService,
+				companyInterceptor: mockCompanyInterceptor,
+				txManager:          mockTxManager,
+				token:              token,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     ctx,
+				command: create,
+			},
+			wantErr: errs.NewInvalidFormError(),
+		},
+		{
+			name: "permission denied",
+			setup: func() {
+				mockCommandService.EXPECT().Start(ctx, update).Return(nil)
+				mockCompanyInterceptor.EXPECT().
+					Update(ctx, update.Update, token).
+					Return(nil, errs.NewPermissionDenied())
+				mockCommandService.EXPECT().Reply(ctx, &entity.CommandReply{
+					CommandID: update.ID,
+					Operation: entity.CommandOperationUpdate,
+					Status:    entity.CommandStatusFailed,
+					Error:     errs.NewPermissionDenied(),
+				}).Return(nil)
+			},
+			fields: fields{
+				commandService:     mockCommandService,
+				companyInterceptor: mockCompanyInterceptor,
+				txManager:          mockTxManager,
+				token:              token,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     ctx,
+				command: update,
+			},
+			wantErr: errs.NewPermissionDenied(),
+		},
+		{
+			name: "temporary error",
+			setup: func() {
+				mockCommandService.EXPECT().Start(ctx, remove).Return(nil)
+				mockCompanyInterceptor.EXPECT().
+					Delete(ctx, remove.CompanyID, token).
+					Return(errs.NewError(errs.ErrorCodeUnavailable, "Unavailable."))
+			},
+			fields: fields{
+				commandService:     mockCommandService,
+				companyInterceptor: mockCompanyInterceptor,
+				txManager:          mockTxManager,
+				token:              token,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     ctx,
+				command: remove,
+			},
+			wantErr: errs.NewError(errs.ErrorCodeUnavailable, "Unavailable."),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			i := &CommandInterceptor{
+				commandService:     tt.fields.commandService,
+				companyInterceptor: tt.fields.companyInterceptor,
+				txManager:          tt.fields.txManager,
+				token:              tt.fields.token,
+				logger:             tt.fields.logger,
+			}
+			if err := i.Handle(tt.args.ctx, tt.args.command); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Handle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
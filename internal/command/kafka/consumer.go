@@ -0,0 +1,157 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/018bf/companies/internal/command/interceptor"
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/Shopify/sarama"
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -source=consumer.go -package=kafka -destination=consumer_mock.go
+
+// Headers attached to the messages sent to the dead-letter topic.
+const (
+	HeaderError             = "error"
+	HeaderOriginalTopic     = "original_topic"
+	HeaderOriginalPartition = "original_partition"
+	HeaderOriginalOffset    = "original_offset"
+)
+
+// retryDelay is a pause before a message which failed temporarily is consumed again.
+const retryDelay = time.Second
+
+type commandInterceptor interface {
+	Handle(ctx context.Context, command *entity.Command) error
+}
+
+// Consumer applies commands from the commands topic.
+// Messages which can't be applied are sent to the dead-letter topic.
+type Consumer struct {
+	group              sarama.ConsumerGroup
+	producer           sarama.SyncProducer
+	commandInterceptor commandInterceptor
+	logger             log.Logger
+	topic              string
+	dlqTopic           string
+}
+
+func NewConsumer(
+	group sarama.ConsumerGroup,
+	producer sarama.SyncProducer,
+	commandInterceptor *interceptor.CommandInterceptor,
+	config *configs.Config,
+	logger log.Logger,
+) *Consumer {
+	return &Consumer{
+		group:              group,
+		producer:           producer,
+		commandInterceptor: commandInterceptor,
+		logger:             logger,
+		topic:              config.Consumer.Topic,
+		dlqTopic:           config.Consumer.DLQTopic,
+	}
+}
+
+// Start consumes the topic until ctx is done or the consumer is stopped.
+func (c *Consumer) Start(ctx context.Context) error {
+	for {
+		if err := c.group.Consume(ctx, []string{c.topic}, c); err != nil {
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (c *Consumer) Stop(_ context.Context) error {
+	return c.group.Close()
+}
+
+func (c *Consumer) Setup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (c *Consumer) Cleanup(_ sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim marks every handled message. On a temporary failure it ends the session,
+// so the message is consumed again from the last committed offset.
+func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for message := range claim.Messages() {
+		if err := c.handle(session.Context(), message); err != nil {
+			select {
+			case <-time.After(retryDelay):
+			case <-session.Context().Done():
+			}
+			return err
+		}
+		session.MarkMessage(message, "")
+	}
+	return nil
+}
+
+func (c *Consumer) handle(ctx context.Context, message *sarama.ConsumerMessage) error {
+	ctx = context.WithValue(ctx, log.RequestIDKey, uuid.New().String())
+	command := &entity.Command{}
+	if err := json.Unmarshal(message.Value, command); err != nil {
+		return c.deadLetter(ctx, message, errs.NewInvalidFormError().WithParam("error", err.Error()))
+	}
+	err := c.commandInterceptor.Handle(ctx, command)
+	if err == nil {
+		return nil
+	}
+	if errs.IsTemporary(err) {
+		c.logger.Warn("can't handle command, retrying", log.Context(ctx), log.Error(err))
+		return err
+	}
+	var e *errs.Error
+	if !errors.As(err, &e) {
+		e = errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	return c.deadLetter(ctx, message, e)
+}
+
+func (c *Consumer) deadLetter(ctx context.Context, message *sarama.ConsumerMessage, e *errs.Error) error {
+	c.logger.Error("command is sent to the dead-letter topic", log.Context(ctx), log.Error(e))
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+4)
+	for _, header := range message.Headers {
+		headers = append(headers, *header)
+	}
+	headers = append(
+		headers,
+		sarama.RecordHeader{Key: []byte(HeaderError), Value: data},
+		sarama.RecordHeader{Key: []byte(HeaderOriginalTopic), Value: []byte(message.Topic)},
+		sarama.RecordHeader{Key: []byte(HeaderOriginalPartition), Value: []byte(fmt.Sprint(message.Partition))},
+		sarama.RecordHeader{Key: []byte(HeaderOriginalOffset), Value: []byte(fmt.Sprint(message.Offset))},
+	)
+	dead := &sarama.ProducerMessage{
+		Topic:   c.dlqTopic,
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+	if message.Key != nil {
+		dead.Key = sarama.ByteEncoder(message.Key)
+	}
+	if _, _, err := c.producer.SendMessage(dead); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: consumer.go
+
+// Package kafka is a generated GoMock package.
+package kafka
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockcommandInterceptor is a mock of commandInterceptor interface.
+type MockcommandInterceptor struct {
+	ctrl     *gomock.Controller
+	recorder *MockcommandInterceptorMockRecorder
+}
+
+// MockcommandInterceptorMockRecorder is the mock recorder for MockcommandInterceptor.
+type MockcommandInterceptorMockRecorder struct {
+	mock *MockcommandInterceptor
+}
+
+// NewMockcommandInterceptor creates a new mock instance.
+func NewMockcommandInterceptor(ctrl *gomock.Controller) *MockcommandInterceptor {
+	mock := &MockcommandInterceptor{ctrl: ctrl}
+	mock.recorder = &MockcommandInterceptorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcommandInterceptor) EXPECT() *MockcommandInterceptorMockRecorder {
+	return m.recorder
+}
+
+// Handle mocks base method.
+func (m *MockcommandInterceptor) Handle(ctx context.Context, command *entity.Command) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", ctx, command)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockcommandInterceptorMockRecorder) Handle(ctx, command interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockcommandInterceptor)(nil).Handle), ctx, command)
+}
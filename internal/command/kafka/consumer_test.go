@@ -0,0 +1,226 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	mock_sarama "github.com/018bf/companies/internal/event/repositories/kafka/mock"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/Shopify/sarama"
+	"github.com/golang/mock/gomock"
+)
+
+func TestConsumer_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCommandInterceptor := NewMockcommandInterceptor(ctrl)
+	syncProducer := mock_sarama.NewMockSyncProducer(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	command := mock_models.NewCommand(t, entity.CommandOperationDelete)
+	value, err := json.Marshal(command)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := &sarama.ConsumerMessage{
+		Headers:   []*sarama.RecordHeader{{Key: []byte("source"), Value: []byte("crm")}},
+		Key:       []byte(command.ID),
+		Value:     value,
+		Topic:     "companies-commands",
+		Partition: 2,
+		Offset:    42,
+	}
+	malformed := &sarama.ConsumerMessage{
+		Value:     []byte("{"),
+		Topic:     "companies-commands",
+		Partition: 0,
+		Offset:    7,
+	}
+	deadLetter := func(message *sarama.ConsumerMessage, want *errs.Error) func(*sarama.ProducerMessage) (int32, int64, error) {
+		return func(dead *sarama.ProducerMessage) (int32, int64, error) {
+			if dead.Topic != "companies-commands-dlq" {
+				t.Errorf("topic = %s", dead.Topic)
+			}
+			if got, _ := dead.Value.Encode(); string(got) != string(message.Value) {
+				t.Errorf("value = %s, want %s", got, message.Value)
+			}
+			headers := map[string]string{}
+			for _, header := range dead.Headers {
+				headers[string(header.Key)] = string(header.Value)
+			}
+			data, _ := json.Marshal(want)
+			if headers[HeaderError] != string(data) {
+				t.Errorf("error = %s, want %s", headers[HeaderError], data)
+			}
+			if headers[HeaderOriginalTopic] != message.Topic {
+				t.Errorf("original topic = %s", headers[HeaderOriginalTopic])
+			}
+			for _, header := range message.Headers {
+				if headers[string(header.Key)] != string(header.Value) {
+					t.Errorf("header %s is lost", header.Key)
+				}
+			}
+			return 0, 0, nil
+		}
+	}
+	type fields struct {
+		producer           sarama.SyncProducer
+		commandInterceptor commandInterceptor
+		logger             log.Logger
+	}
+	type args struct {
+		ctx     context.Context
+		message *sarama.ConsumerMessage
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCommandInterceptor.EXPECT().Handle(gomock.Any(), command).Return(nil)
+			},
+			fields: fields{
+				producer:           syncProducer,
+				commandInterceptor: mockCommandInterceptor,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				message: message,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "poison message",
+			setup: func() {
+				mockCommandInterceptor.EXPECT().
+					Handle(gomock.Any(), command).
+					Return(errs.NewEntityNotFound())
+				logger.EXPECT().Error("command is sent to the dead-letter topic", gomock.Any(), gomock.Any())
+				syncProducer.EXPECT().
+					SendMessage(gomock.Any()).
+					DoAndReturn(deadLetter(message, errs.NewEntityNotFound()))
+			},
+			fields: fields{
+				producer:           syncProducer,
+				commandInterceptor: mockCommandInterceptor,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				message: message,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unknown error",
+			setup: func() {
+				mockCommandInterceptor.EXPECT().
+					Handle(gomock.Any(), command).
+					Return(errors.New("err 1"))
+				logger.EXPECT().Error("command is sent to the dead-letter topic", gomock.Any(), gomock.Any())
+				syncProducer.EXPECT().
+					SendMessage(gomock.Any()).
+					DoAndReturn(deadLetter(message, errs.NewUnexpectedBehaviorError("err 1")))
+			},
+			fields: fields{
+				producer:           syncProducer,
+				commandInterceptor: mockCommandInterceptor,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				message: message,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "malformed message",
+			setup: func() {
+				logger.EXPECT().Error("command is sent to the dead-letter topic", gomock.Any(), gomock.Any())
+				syncProducer.EXPECT().
+					SendMessage(gomock.Any()).
+					DoAndReturn(deadLetter(
+						malformed,
+						errs.NewInvalidFormError().WithParam("error", "unexpected end of JSON input"),
+					))
+			},
+			fields: fields{
+				producer:           syncProducer,
+				commandInterceptor: mockCommandInterceptor,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				message: malformed,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "temporary error",
+			setup: func() {
+				mockCommandInterceptor.EXPECT().
+					Handle(gomock.Any(), command).
+					Return(errs.NewError(errs.ErrorCodeUnavailable, "Unavailable."))
+				logger.EXPECT().Warn("can't handle command, retrying", gomock.Any(), gomock.Any())
+			},
+			fields: fields{
+				producer:           syncProducer,
+				commandInterceptor: mockCommandInterceptor,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				message: message,
+			},
+			wantErr: errs.NewError(errs.ErrorCodeUnavailable, "Unavailable."),
+		},
+		{
+			name: "dead-letter error",
+			setup: func() {
+				mockCommandInterceptor.EXPECT().
+					Handle(gomock.Any(), command).
+					Return(errs.NewPermissionDenied())
+				logger.EXPECT().Error("command is sent to the dead-letter topic", gomock.Any(), gomock.Any())
+				syncProducer.EXPECT().
+					SendMessage(gomock.Any()).
+					Return(int32(0), int64(0), errs.NewUnexpectedBehaviorError("err 1"))
+			},
+			fields: fields{
+				producer:           syncProducer,
+				commandInterceptor: mockCommandInterceptor,
+				logger:             logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				message: message,
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("err 1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			c := &Consumer{
+				producer:           tt.fields.producer,
+				commandInterceptor: tt.fields.commandInterceptor,
+				logger:             tt.fields.logger,
+				topic:              "companies-commands",
+				dlqTopic:           "companies-commands-dlq",
+			}
+			if err := c.handle(tt.args.ctx, tt.args.message); !errors.Is(err, tt.wantErr) {
+				t.Errorf("handle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/pkg/clock"
+	"github.com/018bf/companies/pkg/cloudevents"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/Shopify/sarama"
+	"github.com/google/uuid"
+)
+
+const replyTypePrefix = "com.companies.command."
+
+// ReplyRepository publishes command replies as structured CloudEvents.
+type ReplyRepository struct {
+	producer sarama.SyncProducer
+	clock    clock.Clock
+	logger   log.Logger
+	topic    string
+	source   string
+}
+
+func NewReplyRepository(
+	producer sarama.SyncProducer,
+	config *configs.Config,
+	clock clock.Clock,
+	logger log.Logger,
+) *ReplyRepository {
+	return &ReplyRepository{
+		producer: producer,
+		clock:    clock,
+		logger:   logger,
+		topic:    config.Consumer.ReplyTopic,
+		source:   config.Kafka.Source,
+	}
+}
+
+func (r *ReplyRepository) Send(ctx context.Context, reply *entity.CommandReply) error {
+	data, err := json.Marshal(reply)
+	if err != nil {
+		return err
+	}
+	cloudEvent := cloudevents.Event{
+		ID:              uuid.NewString(),
+		Source:          r.source,
+		Type:            replyTypePrefix + string(reply.Status),
+		Subject:         string(reply.CommandID),
+		Time:            r.clock.Now().UTC(),
+		DataContentType: cloudevents.ContentTypeJSON,
+		Extensions:      map[string]string{},
+		Data:            data,
+	}
+	if requestID := ctx.Value(log.RequestIDKey); requestID != nil {
+		cloudEvent.Extensions["requestid"] = fmt.Sprint(requestID)
+	}
+	envelope, err := json.Marshal(cloudEvent)
+	if err != nil {
+		return err
+	}
+	message := &sarama.ProducerMessage{
+		Topic: r.topic,
+		Key:   sarama.StringEncoder(reply.CommandID),
+		Headers: []sarama.RecordHeader{
+			{
+				Key:   []byte(cloudevents.HeaderContentType),
+				Value: []byte(cloudevents.ContentTypeStructuredJSON),
+			},
+		},
+		Value: sarama.ByteEncoder(envelope),
+	}
+	if _, _, err := r.producer.SendMessage(message); err != nil {
+		return err
+	}
+	return nil
+}
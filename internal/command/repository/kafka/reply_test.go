@@ -0,0 +1,137 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	mock_sarama "github.com/018bf/companies/internal/event/repositories/kafka/mock"
+	"github.com/018bf/companies/pkg/clock"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/Shopify/sarama"
+	"github.com/golang/mock/gomock"
+)
+
+func TestReplyRepository_Send(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	syncProducer := mock_sarama.NewMockSyncProducer(ctrl)
+	mockClock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	config := configs.NewMockConfig(t)
+	ctx := context.WithValue(context.Background(), log.RequestIDKey, "request-1")
+	command := mock_models.NewCommand(t, entity.CommandOperationCreate)
+	reply := &entity.CommandReply{
+		CommandID: command.ID,
+		Operation: command.Operation,
+		Status:    entity.CommandStatusSucceeded,
+		Company:   mock_models.NewCompany(t),
+	}
+	now := time.Now().UTC()
+	type fields struct {
+		producer sarama.SyncProducer
+		clock    clock.Clock
+		logger   log.Logger
+		topic    string
+		source   string
+	}
+	type args struct {
+		ctx   context.Context
+		reply *entity.CommandReply
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+				syncProducer.EXPECT().
+					SendMessage(gomock.Any()).
+					DoAndReturn(func(message *sarama.ProducerMessage) (int32, int64, error) {
+						if message.Topic != config.Consumer.ReplyTopic {
+							t.Errorf("topic = %s, want %s", message.Topic, config.Consumer.ReplyTopic)
+						}
+						if key, _ := message.Key.Encode(); string(key) != string(command.ID) {
+							t.Errorf("key = %s, want %s", key, command.ID)
+						}
+						value, _ := message.Value.Encode()
+						envelope := map[string]any{}
+						if err := json.Unmarshal(value, &envelope); err != nil {
+							t.Fatal(err)
+						}
+						if envelope["type"] != "com.companies.command.succeeded" {
+							t.Errorf("type = %v", envelope["type"])
+						}
+						if envelope["subject"] != string(command.ID) {
+							t.Errorf("subject = %v", envelope["subject"])
+						}
+						if envelope["requestid"] != "request-1" {
+							t.Errorf("requestid = %v", envelope["requestid"])
+						}
+						return 0, 0, nil
+					})
+			},
+			fields: fields{
+				producer: syncProducer,
+				clock:    mockClock,
+				logger:   logger,
+				topic:    config.Consumer.ReplyTopic,
+				source:   config.Kafka.Source,
+			},
+			args: args{
+				ctx:   ctx,
+				reply: reply,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "producer error",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+				syncProducer.EXPECT().
+					SendMessage(gomock.Any()).
+					Return(int32(0), int64(0), errs.NewUnexpectedBehaviorError("err 1"))
+			},
+			fields: fields{
+				producer: syncProducer,
+				clock:    mockClock,
+				logger:   logger,
+				topic:    config.Consumer.ReplyTopic,
+				source:   config.Kafka.Source,
+			},
+			args: args{
+				ctx:   ctx,
+				reply: reply,
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("err 1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &ReplyRepository{
+				producer: tt.fields.producer,
+				clock:    tt.fields.clock,
+				logger:   tt.fields.logger,
+				topic:    tt.fields.topic,
+				source:   tt.fields.source,
+			}
+			if err := r.Send(tt.args.ctx, tt.args.reply); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/log"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type CommandRepository struct {
+	database     *sqlx.DB
+	logger       log.Logger
+	writeTimeout time.Duration
+}
+
+func NewCommandRepository(database *sqlx.DB, config *configs.Config, logger log.Logger) *CommandRepository {
+	return &CommandRepository{
+		database:     database,
+		logger:       logger,
+		writeTimeout: time.Duration(config.Database.WriteTimeout) * time.Millisecond,
+	}
+}
+
+// Create stores the command ID in the transaction of the context, if any.
+// It returns an already exists error if the command has been stored before.
+func (r *CommandRepository) Create(ctx context.Context, command *entity.Command) error {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+	q := sq.Insert("public.commands").
+		Columns("id", "operation").
+		Values(command.ID, command.Operation).
+		Suffix("ON CONFLICT (id) DO NOTHING")
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	result, err := postgresInterface.QuerierFromContext(ctx, r.database).ExecContext(ctx, query, args...)
+	if err != nil {
+		e := errs.FromPostgresError(err).WithParam("command_id", fmt.Sprint(command.ID))
+		return e
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		e := errs.FromPostgresError(err).WithParam("command_id", fmt.Sprint(command.ID))
+		return e
+	}
+	if affected == 0 {
+		e := errs.NewError(errs.ErrorCodeAlreadyExists, "Command is already processed.").
+			WithParam("command_id", fmt.Sprint(command.ID))
+		return e
+	}
+	return nil
+}
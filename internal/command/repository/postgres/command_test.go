@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestCommandRepository_Create(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	query := "INSERT INTO public.commands \\(id,operation\\) VALUES \\(\\$1,\\$2\\) ON CONFLICT \\(id\\) DO NOTHING"
+	command := mock_models.NewCommand(t, entity.CommandOperationCreate)
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx     context.Context
+		command *entity.Command
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectExec(query).
+					WithArgs(command.ID, command.Operation).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				command: command,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "already processed",
+			setup: func() {
+				mock.ExpectExec(query).
+					WithArgs(command.ID, command.Operation).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				command: command,
+			},
+			wantErr: errs.NewError(errs.ErrorCodeAlreadyExists, "Command is already processed.").
+				WithParam("command_id", string(command.ID)),
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectExec(query).
+					WithArgs(command.ID, command.Operation).
+					WillReturnError(errors.New("test error"))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				command: command,
+			},
+			wantErr: errs.FromPostgresError(errors.New("test error")).
+				WithParam("command_id", string(command.ID)),
+		},
+		{
+			name: "result error",
+			setup: func() {
+				mock.ExpectExec(query).
+					WithArgs(command.ID, command.Operation).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("test error")))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				command: command,
+			},
+			wantErr: errs.FromPostgresError(errors.New("test error")).
+				WithParam("command_id", string(command.ID)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &CommandRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			if err := r.Create(tt.args.ctx, tt.args.command); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/pkg/log"
+)
+
+//go:generate mockgen -source=command.go -package=service -destination=command_mock.go
+
+type commandRepository interface {
+	Create(ctx context.Context, command *entity.Command) error
+}
+
+type replyRepository interface {
+	Send(ctx context.Context, reply *entity.CommandReply) error
+}
+
+type CommandService struct {
+	commandRepository commandRepository
+	replyRepository   replyRepository
+	logger            log.Logger
+}
+
+func NewCommandService(
+	commandRepository commandRepository,
+	replyRepository replyRepository,
+	logger log.Logger,
+) *CommandService {
+	return &CommandService{
+		commandRepository: commandRepository,
+		replyRepository:   replyRepository,
+		logger:            logger,
+	}
+}
+
+// Start marks the command as processed.
+// It fails with an already exists error for a redelivered command.
+func (u *CommandService) Start(ctx context.Context, command *entity.Command) error {
+	if err := command.Validate(); err != nil {
+		return err
+	}
+	if err := u.commandRepository.Create(ctx, command); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *CommandService) Reply(ctx context.Context, reply *entity.CommandReply) error {
+	if err := u.replyRepository.Send(ctx, reply); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: command.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockcommandRepository is a mock of commandRepository interface.
+type MockcommandRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcommandRepositoryMockRecorder
+}
+
+// MockcommandRepositoryMockRecorder is the mock recorder for MockcommandRepository.
+type MockcommandRepositoryMockRecorder struct {
+	mock *MockcommandRepository
+}
+
+// NewMockcommandRepository creates a new mock instance.
+func NewMockcommandRepository(ctrl *gomock.Controller) *MockcommandRepository {
+	mock := &MockcommandRepository{ctrl: ctrl}
+	mock.recorder = &MockcommandRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcommandRepository) EXPECT() *MockcommandRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockcommandRepository) Create(ctx context.Context, command *entity.Command) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, command)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockcommandRepositoryMockRecorder) Create(ctx, command interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockcommandRepository)(nil).Create), ctx, command)
+}
+
+// MockreplyRepository is a mock of replyRepository interface.
+type MockreplyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockreplyRepositoryMockRecorder
+}
+
+// MockreplyRepositoryMockRecorder is the mock recorder for MockreplyRepository.
+type MockreplyRepositoryMockRecorder struct {
+	mock *MockreplyRepository
+}
+
+// NewMockreplyRepository creates a new mock instance.
+func NewMockreplyRepository(ctrl *gomock.Controller) *MockreplyRepository {
+	mock := &MockreplyRepository{ctrl: ctrl}
+	mock.recorder = &MockreplyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreplyRepository) EXPECT() *MockreplyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockreplyRepository) Send(ctx context.Context, reply *entity.CommandReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, reply)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockreplyRepositoryMockRecorder) Send(ctx, reply interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockreplyRepository)(nil).Send), ctx, reply)
+}
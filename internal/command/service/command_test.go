@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCommandService_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCommandRepository := NewMockcommandRepository(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	command := mock_models.NewCommand(t, entity.CommandOperationDelete)
+	type fields struct {
+		commandRepository commandRepository
+		logger            log.Logger
+	}
+	type args struct {
+		ctx     context.Context
+		command *entity.Command
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCommandRepository.EXPECT().Create(ctx, command).Return(nil)
+			},
+			fields: fields{
+				commandRepository: mockCommandRepository,
+				logger:            logger,
+			},
+			args: args{
+				ctx:     ctx,
+				command: command,
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "invalid command",
+			setup: func() {},
+			fields: fields{
+				commandRepository: mockCommandRepository,
+				logger:            logger,
+			},
+			args: args{
+				ctx: ctx,
+				command: &entity.Command{
+					ID:        command.ID,
+					Operation: entity.CommandOperationDelete,
+				},
+			},
+			wantErr: errs.NewInvalidFormError().WithParam("company_id", "cannot be blank"),
+		},
+		{
+			name: "already processed",
+			setup: func() {
+				mockCommandRepository.EXPECT().
+					Create(ctx, command).
+					Return(errs.NewError(errs.ErrorCodeAlreadyExists, "Command is already processed."))
+			},
+			fields: fields{
+				commandRepository: mockCommandRepository,
+				logger:            logger,
+			},
+			args: args{
+				ctx:     ctx,
+				command: command,
+			},
+			wantErr: errs.NewError(errs.ErrorCodeAlreadyExists, "Command is already processed."),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := &CommandService{
+				commandRepository: tt.fields.commandRepository,
+				logger:            tt.fields.logger,
+			}
+			if err := u.Start(tt.args.ctx, tt.args.command); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Start() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommandService_Reply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockReplyRepository := NewMockreplyRepository(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	reply := &entity.CommandReply{
+		CommandID: mock_models.NewCommand(t, entity.CommandOperationCreate).ID,
+		Operation: entity.CommandOperationCreate,
+		Status:    entity.CommandStatusSucceeded,
+		Company:   mock_models.NewCompany(t),
+	}
+	type fields struct {
+		replyRepository replyRepository
+		logger          log.Logger
+	}
+	type args struct {
+		ctx   context.Context
+		reply *entity.CommandReply
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockReplyRepository.EXPECT().Send(ctx, reply).Return(nil)
+			},
+			fields: fields{
+				replyRepository: mockReplyRepository,
+				logger:          logger,
+			},
+			args: args{
+				ctx:   ctx,
+				reply: reply,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "repository error",
+			setup: func() {
+				mockReplyRepository.EXPECT().
+					Send(ctx, reply).
+					Return(errs.NewUnexpectedBehaviorError("err 2"))
+			},
+			fields: fields{
+				replyRepository: mockReplyRepository,
+				logger:          logger,
+			},
+			args: args{
+				ctx:   ctx,
+				reply: reply,
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("err 2"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := &CommandService{
+				replyRepository: tt.fields.replyRepository,
+				logger:          tt.fields.logger,
+			}
+			if err := u.Reply(tt.args.ctx, tt.args.reply); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Reply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
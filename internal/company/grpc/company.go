@@ -21,7 +21,7 @@ type companyInterceptor interface {
 		ctx context.Context,
 		filter *entity.CompanyFilter,
 		token *entity.Token,
-	) ([]*entity.Company, uint64, error) // deprecated
+	) (*entity.CompanyList, error) // deprecated
 	Update(
 		ctx context.Context,
 		update *entity.CompanyUpdate,
@@ -33,6 +33,24 @@ type companyInterceptor interface {
 		token *entity.Token,
 	) (*entity.Company, error)
 	Delete(ctx context.Context, id entity.UUID, token *entity.Token) error
+	Watch(
+		ctx context.Context,
+		filter *entity.CompanyFilter,
+		position *uint64,
+		stream entity.CompanyChangeStream,
+		token *entity.Token,
+	) error
+	ListChanges(ctx context.Context, since string, token *entity.Token) (*entity.CompanyChangeList, error)
+	Suggest(
+		ctx context.Context,
+		suggest *entity.CompanySuggest,
+		token *entity.Token,
+	) ([]*entity.CompanySuggestion, error)
+	Stats(
+		ctx context.Context,
+		filter *entity.CompanyStatsFilter,
+		token *entity.Token,
+	) (*entity.CompanyStats, error)
 }
 
 type CompanyServiceServer struct {
@@ -84,7 +102,7 @@ func (s *CompanyServiceServer) List(
 	ctx context.Context,
 	filter *companiespb.CompanyFilter,
 ) (*companiespb.ListCompany, error) {
-	listCompanies, count, err := s.companyInterceptor.List(
+	list, err := s.companyInterceptor.List(
 		ctx,
 		encodeCompanyFilter(filter),
 		ctx.Value(grpc2.TokenKey).(*entity.Token),
@@ -92,7 +110,7 @@ func (s *CompanyServiceServer) List(
 	if err != nil {
 		return nil, grpc2.DecodeError(err)
 	}
-	return decodeListCompany(listCompanies, count), nil
+	return decodeListCompany(list), nil
 }
 
 func (s *CompanyServiceServer) Update(
@@ -123,6 +141,92 @@ func (s *CompanyServiceServer) Delete(
 	}
 	return &emptypb.Empty{}, nil
 }
+
+func (s *CompanyServiceServer) ListCompanyChanges(
+	ctx context.Context,
+	input *companiespb.CompanyChangesRequest,
+) (*companiespb.CompanyChangeList, error) {
+	list, err := s.companyInterceptor.ListChanges(
+		ctx,
+		input.GetSince(),
+		ctx.Value(grpc2.TokenKey).(*entity.Token),
+	)
+	if err != nil {
+		return nil, grpc2.DecodeError(err)
+	}
+	return decodeCompanyChangeList(list), nil
+}
+
+func (s *CompanyServiceServer) GetCompanyStats(
+	ctx context.Context,
+	input *companiespb.CompanyStatsRequest,
+) (*companiespb.CompanyStats, error) {
+	stats, err := s.companyInterceptor.Stats(
+		ctx,
+		encodeCompanyStatsFilter(input),
+		ctx.Value(grpc2.TokenKey).(*entity.Token),
+	)
+	if err != nil {
+		return nil, grpc2.DecodeError(err)
+	}
+	return decodeCompanyStats(stats), nil
+}
+
+func (s *CompanyServiceServer) SuggestCompanies(
+	ctx context.Context,
+	input *companiespb.CompanySuggest,
+) (*companiespb.CompanySuggestionList, error) {
+	suggest := &entity.CompanySuggest{Query: input.GetQuery()}
+	if input.GetLimit() != nil {
+		suggest.Limit = utils.Pointer(input.GetLimit().GetValue())
+	}
+	suggestions, err := s.companyInterceptor.Suggest(ctx, suggest, ctx.Value(grpc2.TokenKey).(*entity.Token))
+	if err != nil {
+		return nil, grpc2.DecodeError(err)
+	}
+	return decodeCompanySuggestionList(suggestions), nil
+}
+
+func (s *CompanyServiceServer) WatchCompanies(
+	input *companiespb.CompanyWatch,
+	stream companiespb.CompanyService_WatchCompaniesServer,
+) error {
+	ctx := stream.Context()
+	var position *uint64
+	if input.GetPosition() != nil {
+		position = utils.Pointer(input.GetPosition().GetValue())
+	}
+	if err := s.companyInterceptor.Watch(
+		ctx,
+		encodeCompanyFilter(input.GetFilter()),
+		position,
+		&changeStream{stream: stream},
+		ctx.Value(grpc2.TokenKey).(*entity.Token),
+	); err != nil {
+		return grpc2.DecodeError(err)
+	}
+	return nil
+}
+
+// changeStream sends the watched changes to the gRPC stream.
+type changeStream struct {
+	stream companiespb.CompanyService_WatchCompaniesServer
+}
+
+func (s *changeStream) Send(change *entity.CompanyChange) error {
+	return s.stream.Send(&companiespb.CompanyWatchEvent{
+		Event: &companiespb.CompanyWatchEvent_Change{Change: decodeCompanyChange(change)},
+	})
+}
+
+func (s *changeStream) Heartbeat(position uint64) error {
+	return s.stream.Send(&companiespb.CompanyWatchEvent{
+		Event: &companiespb.CompanyWatchEvent_Heartbeat{
+			Heartbeat: &companiespb.CompanyHeartbeat{Position: position},
+		},
+	})
+}
+
 func encodeCompanyCreate(input *companiespb.CompanyCreate) *entity.CompanyCreate {
 	create := &entity.CompanyCreate{
 		Name:              input.GetName(),
@@ -167,6 +271,18 @@ func encodeCompanyFilter(input *companiespb.CompanyFilter) *entity.CompanyFilter
 	if input.GetSearch() != nil {
 		filter.Search = utils.Pointer(input.GetSearch().GetValue())
 	}
+	if input.GetSearchLanguage() != nil {
+		filter.SearchLanguage = utils.Pointer(input.GetSearchLanguage().GetValue())
+	}
+	if input.GetFilter() != nil {
+		filter.Filter = utils.Pointer(input.GetFilter().GetValue())
+	}
+	for _, facet := range input.GetFacets() {
+		filter.Facets = append(filter.Facets, entity.CompanyFacet(facet))
+	}
+	if input.GetCount() != nil {
+		filter.Count = utils.Pointer(entity.CompanyCount(input.GetCount().GetValue()))
+	}
 	if len(input.GetTypes()) > 0 {
 		filter.Types = make([]entity.CompanyType, len(input.GetTypes()))
 		for i, companyType := range input.GetTypes() {
@@ -207,9 +323,105 @@ func decodeCompany(company *entity.Company) *companiespb.Company {
 		AmountOfEmployees: int32(company.AmountOfEmployees),
 		Registered:        company.Registered,
 		Type:              decodeCompanyType(company.Type),
+		Version:           company.Version,
+	}
+	if company.Search != nil {
+		response.Search = &companiespb.CompanySearch{
+			Score:               company.Search.Score,
+			NameHeadline:        company.Search.NameHeadline,
+			DescriptionHeadline: company.Search.DescriptionHeadline,
+		}
 	}
 	return response
 }
+func decodeCompanyChange(change *entity.CompanyChange) *companiespb.CompanyChange {
+	response := &companiespb.CompanyChange{
+		Position:  change.Position,
+		Operation: decodeCompanyChangeOperation(change.Operation),
+		CompanyId: string(change.CompanyID),
+		ChangedAt: timestamppb.New(change.ChangedAt),
+	}
+	if change.Company != nil {
+		response.Company = decodeCompany(change.Company)
+	}
+	return response
+}
+func decodeCompanyChangeList(list *entity.CompanyChangeList) *companiespb.CompanyChangeList {
+	response := &companiespb.CompanyChangeList{
+		Items:   make([]*companiespb.CompanyChange, 0, len(list.Items)),
+		Token:   list.Token,
+		HasMore: list.HasMore,
+	}
+	for _, change := range list.Items {
+		response.Items = append(response.Items, decodeCompanyChange(change))
+	}
+	return response
+}
+func encodeCompanyStatsFilter(input *companiespb.CompanyStatsRequest) *entity.CompanyStatsFilter {
+	filter := &entity.CompanyStatsFilter{CompanyFilter: *encodeCompanyFilter(input.GetFilter())}
+	for _, bound := range input.GetEmployeeBuckets() {
+		filter.EmployeeBuckets = append(filter.EmployeeBuckets, int(bound))
+	}
+	switch input.GetInterval() {
+	case companiespb.CompanyStatsInterval_COMPANY_STATS_INTERVAL_DAY:
+		filter.Interval = utils.Pointer(entity.StatsIntervalDay)
+	case companiespb.CompanyStatsInterval_COMPANY_STATS_INTERVAL_WEEK:
+		filter.Interval = utils.Pointer(entity.StatsIntervalWeek)
+	case companiespb.CompanyStatsInterval_COMPANY_STATS_INTERVAL_MONTH:
+		filter.Interval = utils.Pointer(entity.StatsIntervalMonth)
+	}
+	return filter
+}
+func decodeCompanyStats(stats *entity.CompanyStats) *companiespb.CompanyStats {
+	response := &companiespb.CompanyStats{
+		Total:     stats.Total,
+		Groups:    make([]*companiespb.CompanyStatsGroup, 0, len(stats.Groups)),
+		Employees: make([]*companiespb.CompanyStatsBucket, 0, len(stats.Employees)),
+		Created:   make([]*companiespb.CompanyStatsPeriod, 0, len(stats.Created)),
+	}
+	for _, group := range stats.Groups {
+		response.Groups = append(response.Groups, &companiespb.CompanyStatsGroup{
+			Type:       decodeCompanyType(group.Type),
+			Registered: group.Registered,
+			Count:      group.Count,
+		})
+	}
+	for _, bucket := range stats.Employees {
+		response.Employees = append(response.Employees, decodeCompanyStatsBucket(bucket))
+	}
+	for _, period := range stats.Created {
+		response.Created = append(response.Created, &companiespb.CompanyStatsPeriod{
+			Start: timestamppb.New(period.Start),
+			Count: period.Count,
+		})
+	}
+	return response
+}
+func decodeCompanySuggestionList(suggestions []*entity.CompanySuggestion) *companiespb.CompanySuggestionList {
+	response := &companiespb.CompanySuggestionList{
+		Items: make([]*companiespb.CompanySuggestion, 0, len(suggestions)),
+	}
+	for _, suggestion := range suggestions {
+		response.Items = append(response.Items, &companiespb.CompanySuggestion{
+			Id:    string(suggestion.ID),
+			Name:  suggestion.Name,
+			Score: suggestion.Score,
+		})
+	}
+	return response
+}
+func decodeCompanyChangeOperation(operation entity.EventOperation) companiespb.CompanyChangeOperation {
+	switch operation {
+	case entity.EventTypeCreated:
+		return companiespb.CompanyChangeOperation_COMPANY_CHANGE_OPERATION_CREATED
+	case entity.EventTypeUpdated:
+		return companiespb.CompanyChangeOperation_COMPANY_CHANGE_OPERATION_UPDATED
+	case entity.EventTypeDeleted:
+		return companiespb.CompanyChangeOperation_COMPANY_CHANGE_OPERATION_DELETED
+	default:
+		return 0
+	}
+}
 func decodeCompanyType(companyType entity.CompanyType) companiespb.CompanyType {
 	switch companyType {
 	case entity.CompanyTypeCorporations:
@@ -224,14 +436,55 @@ func decodeCompanyType(companyType entity.CompanyType) companiespb.CompanyType {
 		return 0
 	}
 }
-func decodeListCompany(listCompanies []*entity.Company, count uint64) *companiespb.ListCompany {
+func decodeCompanyStatsBucket(bucket *entity.CompanyStatsBucket) *companiespb.CompanyStatsBucket {
+	decoded := &companiespb.CompanyStatsBucket{Count: bucket.Count}
+	if bucket.From != nil {
+		decoded.From = wrapperspb.Int64(int64(*bucket.From))
+	}
+	if bucket.To != nil {
+		decoded.To = wrapperspb.Int64(int64(*bucket.To))
+	}
+	return decoded
+}
+
+func decodeListCompany(list *entity.CompanyList) *companiespb.ListCompany {
 	response := &companiespb.ListCompany{
-		Items: make([]*companiespb.Company, 0, len(listCompanies)),
-		Count: count,
+		Items:     make([]*companiespb.Company, 0, len(list.Items)),
+		Estimated: list.Estimated,
+	}
+	if list.Count != nil {
+		response.Count = *list.Count
 	}
-	for _, company := range listCompanies {
+	for _, company := range list.Items {
 		response.Items = append(response.Items, decodeCompany(company))
 	}
+	if list.Facets != nil {
+		response.Facets = decodeCompanyFacets(list.Facets)
+	}
+	return response
+}
+
+func decodeCompanyFacets(facets *entity.CompanyFacets) *companiespb.CompanyFacets {
+	response := &companiespb.CompanyFacets{
+		Types:      make([]*companiespb.CompanyTypeFacet, 0, len(facets.Types)),
+		Registered: make([]*companiespb.CompanyRegisteredFacet, 0, len(facets.Registered)),
+		Employees:  make([]*companiespb.CompanyStatsBucket, 0, len(facets.Employees)),
+	}
+	for _, facet := range facets.Types {
+		response.Types = append(response.Types, &companiespb.CompanyTypeFacet{
+			Type:  decodeCompanyType(facet.Type),
+			Count: facet.Count,
+		})
+	}
+	for _, facet := range facets.Registered {
+		response.Registered = append(response.Registered, &companiespb.CompanyRegisteredFacet{
+			Registered: facet.Registered,
+			Count:      facet.Count,
+		})
+	}
+	for _, bucket := range facets.Employees {
+		response.Employees = append(response.Employees, decodeCompanyStatsBucket(bucket))
+	}
 	return response
 }
 func decodeCompanyUpdate(update *entity.CompanyUpdate) *companiespb.CompanyUpdate {
@@ -8,7 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
-	models "github.com/018bf/companies/internal/entity"
+	entity "github.com/018bf/companies/internal/entity"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -36,10 +36,10 @@ func (m *MockcompanyInterceptor) EXPECT() *MockcompanyInterceptorMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockcompanyInterceptor) Create(ctx context.Context, create *models.CompanyCreate, token *models.Token) (*models.Company, error) {
+func (m *MockcompanyInterceptor) Create(ctx context.Context, create *entity.CompanyCreate, token *entity.Token) (*entity.Company, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", ctx, create, token)
-	ret0, _ := ret[0].(*models.Company)
+	ret0, _ := ret[0].(*entity.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -51,7 +51,7 @@ func (mr *MockcompanyInterceptorMockRecorder) Create(ctx, create, token interfac
 }
 
 // Delete mocks base method.
-func (m *MockcompanyInterceptor) Delete(ctx context.Context, id models.UUID, token *models.Token) error {
+func (m *MockcompanyInterceptor) Delete(ctx context.Context, id entity.UUID, token *entity.Token) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", ctx, id, token)
 	ret0, _ := ret[0].(error)
@@ -65,10 +65,10 @@ func (mr *MockcompanyInterceptorMockRecorder) Delete(ctx, id, token interface{})
 }
 
 // Get mocks base method.
-func (m *MockcompanyInterceptor) Get(ctx context.Context, id models.UUID, token *models.Token) (*models.Company, error) {
+func (m *MockcompanyInterceptor) Get(ctx context.Context, id entity.UUID, token *entity.Token) (*entity.Company, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", ctx, id, token)
-	ret0, _ := ret[0].(*models.Company)
+	ret0, _ := ret[0].(*entity.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -80,13 +80,12 @@ func (mr *MockcompanyInterceptorMockRecorder) Get(ctx, id, token interface{}) *g
 }
 
 // List mocks base method.
-func (m *MockcompanyInterceptor) List(ctx context.Context, filter *models.CompanyFilter, token *models.Token) ([]*models.Company, uint64, error) {
+func (m *MockcompanyInterceptor) List(ctx context.Context, filter *entity.CompanyFilter, token *entity.Token) (*entity.CompanyList, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", ctx, filter, token)
-	ret0, _ := ret[0].([]*models.Company)
-	ret1, _ := ret[1].(uint64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret0, _ := ret[0].(*entity.CompanyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // List indicates an expected call of List.
@@ -95,11 +94,56 @@ func (mr *MockcompanyInterceptorMockRecorder) List(ctx, filter, token interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockcompanyInterceptor)(nil).List), ctx, filter, token)
 }
 
+// ListChanges mocks base method.
+func (m *MockcompanyInterceptor) ListChanges(ctx context.Context, since string, token *entity.Token) (*entity.CompanyChangeList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChanges", ctx, since, token)
+	ret0, _ := ret[0].(*entity.CompanyChangeList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListChanges indicates an expected call of ListChanges.
+func (mr *MockcompanyInterceptorMockRecorder) ListChanges(ctx, since, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChanges", reflect.TypeOf((*MockcompanyInterceptor)(nil).ListChanges), ctx, since, token)
+}
+
+// Stats mocks base method.
+func (m *MockcompanyInterceptor) Stats(ctx context.Context, filter *entity.CompanyStatsFilter, token *entity.Token) (*entity.CompanyStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, filter, token)
+	ret0, _ := ret[0].(*entity.CompanyStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockcompanyInterceptorMockRecorder) Stats(ctx, filter, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockcompanyInterceptor)(nil).Stats), ctx, filter, token)
+}
+
+// Suggest mocks base method.
+func (m *MockcompanyInterceptor) Suggest(ctx context.Context, suggest *entity.CompanySuggest, token *entity.Token) ([]*entity.CompanySuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, suggest, token)
+	ret0, _ := ret[0].([]*entity.CompanySuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockcompanyInterceptorMockRecorder) Suggest(ctx, suggest, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockcompanyInterceptor)(nil).Suggest), ctx, suggest, token)
+}
+
 // Update mocks base method.
-func (m *MockcompanyInterceptor) Update(ctx context.Context, update *models.CompanyUpdate, token *models.Token) (*models.Company, error) {
+func (m *MockcompanyInterceptor) Update(ctx context.Context, update *entity.CompanyUpdate, token *entity.Token) (*entity.Company, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, update, token)
-	ret0, _ := ret[0].(*models.Company)
+	ret0, _ := ret[0].(*entity.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -109,3 +153,17 @@ func (mr *MockcompanyInterceptorMockRecorder) Update(ctx, update, token interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockcompanyInterceptor)(nil).Update), ctx, update, token)
 }
+
+// Watch mocks base method.
+func (m *MockcompanyInterceptor) Watch(ctx context.Context, filter *entity.CompanyFilter, position *uint64, stream entity.CompanyChangeStream, token *entity.Token) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, filter, position, stream, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockcompanyInterceptorMockRecorder) Watch(ctx, filter, position, stream, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockcompanyInterceptor)(nil).Watch), ctx, filter, position, stream, token)
+}
@@ -6,6 +6,7 @@ import (
 	grpc2 "github.com/018bf/companies/internal/interfaces/grpc"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/018bf/companies/internal/entity"
 	mock_models "github.com/018bf/companies/internal/entity/mock"
@@ -17,6 +18,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/jaswdr/faker"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -355,6 +357,35 @@ func TestCompanyServiceServer_List(t *testing.T) {
 		response.Items = append(response.Items, decodeCompany(a))
 	}
 	filter.IDs = ids
+	list := &entity.CompanyList{Items: listCompanies, Count: utils.Pointer(count)}
+	facetedFilter := &entity.CompanyFilter{
+		Facets: []entity.CompanyFacet{entity.CompanyFacetType, entity.CompanyFacetEmployees},
+	}
+	facetedList := &entity.CompanyList{
+		Items: listCompanies,
+		Count: utils.Pointer(count),
+		Facets: &entity.CompanyFacets{
+			Types: []*entity.CompanyTypeFacet{{Type: entity.CompanyTypeNonProfit, Count: count}},
+			Employees: []*entity.CompanyStatsBucket{
+				{To: utils.Pointer(10), Count: count},
+				{From: utils.Pointer(10), Count: 0},
+			},
+		},
+	}
+	facetedResponse := &companiespb.ListCompany{
+		Items: response.Items,
+		Count: count,
+		Facets: &companiespb.CompanyFacets{
+			Types: []*companiespb.CompanyTypeFacet{
+				{Type: companiespb.CompanyType_COMPANY_TYPE_NON_PROFIT, Count: count},
+			},
+			Registered: []*companiespb.CompanyRegisteredFacet{},
+			Employees: []*companiespb.CompanyStatsBucket{
+				{To: wrapperspb.Int64(10), Count: count},
+				{From: wrapperspb.Int64(10), Count: 0},
+			},
+		},
+	}
 	type fields struct {
 		UnimplementedCompanyServiceServer companiespb.UnimplementedCompanyServiceServer
 		companyInterceptor                companyInterceptor
@@ -377,7 +408,7 @@ func TestCompanyServiceServer_List(t *testing.T) {
 			setup: func() {
 				mockCompanyInterceptor.EXPECT().
 					List(ctx, filter, user).
-					Return(listCompanies, count, nil)
+					Return(list, nil)
 			},
 			fields: fields{
 				UnimplementedCompanyServiceServer: companiespb.UnimplementedCompanyServiceServer{},
@@ -399,13 +430,70 @@ func TestCompanyServiceServer_List(t *testing.T) {
 			want:    response,
 			wantErr: nil,
 		},
+		{
+			name: "facets",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					List(ctx, facetedFilter, user).
+					Return(facetedList, nil)
+			},
+			fields: fields{
+				UnimplementedCompanyServiceServer: companiespb.UnimplementedCompanyServiceServer{},
+				companyInterceptor:                mockCompanyInterceptor,
+				logger:                            logger,
+			},
+			args: args{
+				ctx:   ctx,
+				input: &companiespb.CompanyFilter{Facets: []string{"type", "employees"}},
+			},
+			want:    facetedResponse,
+			wantErr: nil,
+		},
+		{
+			name: "estimated count",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					List(ctx, &entity.CompanyFilter{Count: utils.Pointer(entity.CompanyCountEstimated)}, user).
+					Return(&entity.CompanyList{Items: listCompanies, Count: utils.Pointer(uint64(1000)), Estimated: true}, nil)
+			},
+			fields: fields{
+				UnimplementedCompanyServiceServer: companiespb.UnimplementedCompanyServiceServer{},
+				companyInterceptor:                mockCompanyInterceptor,
+				logger:                            logger,
+			},
+			args: args{
+				ctx:   ctx,
+				input: &companiespb.CompanyFilter{Count: wrapperspb.String("estimated")},
+			},
+			want:    &companiespb.ListCompany{Items: response.Items, Count: 1000, Estimated: true},
+			wantErr: nil,
+		},
+		{
+			name: "count skipped",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					List(ctx, &entity.CompanyFilter{Count: utils.Pointer(entity.CompanyCountNone)}, user).
+					Return(&entity.CompanyList{Items: listCompanies}, nil)
+			},
+			fields: fields{
+				UnimplementedCompanyServiceServer: companiespb.UnimplementedCompanyServiceServer{},
+				companyInterceptor:                mockCompanyInterceptor,
+				logger:                            logger,
+			},
+			args: args{
+				ctx:   ctx,
+				input: &companiespb.CompanyFilter{Count: wrapperspb.String("none")},
+			},
+			want:    &companiespb.ListCompany{Items: response.Items},
+			wantErr: nil,
+		},
 		{
 			name: "interceptor error",
 			setup: func() {
 				mockCompanyInterceptor.
 					EXPECT().
 					List(ctx, filter, user).
-					Return(nil, uint64(0), errs.NewUnexpectedBehaviorError("i error")).
+					Return(nil, errs.NewUnexpectedBehaviorError("i error")).
 					Times(1)
 			},
 			fields: fields{
@@ -449,6 +537,312 @@ func TestCompanyServiceServer_List(t *testing.T) {
 	}
 }
 
+func TestCompanyServiceServer_ListCompanyChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyInterceptor := NewMockcompanyInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	user := utils.Pointer(mock_models.NewToken(t))
+	ctx := context.WithValue(context.Background(), grpc2.TokenKey, user)
+	change := mock_models.NewCompanyChange(t)
+	list := &entity.CompanyChangeList{
+		Items:   []*entity.CompanyChange{change},
+		Token:   "next",
+		HasMore: true,
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *companiespb.CompanyChangeList
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().ListChanges(ctx, "since", user).Return(list, nil)
+			},
+			want: &companiespb.CompanyChangeList{
+				Items:   []*companiespb.CompanyChange{decodeCompanyChange(change)},
+				Token:   "next",
+				HasMore: true,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "interceptor error",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					ListChanges(ctx, "since", user).
+					Return(nil, errs.NewPermissionDenied())
+			},
+			want:    nil,
+			wantErr: grpc2.DecodeError(errs.NewPermissionDenied()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			s := CompanyServiceServer{
+				companyInterceptor: mockCompanyInterceptor,
+				logger:             logger,
+			}
+			got, err := s.ListCompanyChanges(ctx, &companiespb.CompanyChangesRequest{Since: "since"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ListCompanyChanges() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListCompanyChanges() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompanyServiceServer_GetCompanyStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyInterceptor := NewMockcompanyInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	user := utils.Pointer(mock_models.NewToken(t))
+	ctx := context.WithValue(context.Background(), grpc2.TokenKey, user)
+	month := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	input := &companiespb.CompanyStatsRequest{
+		Filter:          &companiespb.CompanyFilter{Registered: wrapperspb.Bool(true)},
+		EmployeeBuckets: []int64{10},
+		Interval:        companiespb.CompanyStatsInterval_COMPANY_STATS_INTERVAL_WEEK,
+	}
+	filter := &entity.CompanyStatsFilter{
+		CompanyFilter:   *encodeCompanyFilter(input.GetFilter()),
+		EmployeeBuckets: []int{10},
+		Interval:        utils.Pointer(entity.StatsIntervalWeek),
+	}
+	stats := &entity.CompanyStats{
+		Total:  3,
+		Groups: []*entity.CompanyStatsGroup{{Type: entity.CompanyTypeCooperative, Registered: true, Count: 3}},
+		Employees: []*entity.CompanyStatsBucket{
+			{To: utils.Pointer(10), Count: 1},
+			{From: utils.Pointer(10), Count: 2},
+		},
+		Created: []*entity.CompanyStatsPeriod{{Start: month, Count: 3}},
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *companiespb.CompanyStats
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().Stats(ctx, filter, user).Return(stats, nil)
+			},
+			want: &companiespb.CompanyStats{
+				Total: 3,
+				Groups: []*companiespb.CompanyStatsGroup{
+					{Type: companiespb.CompanyType_COMPANY_TYPE_COOPERATIVE, Registered: true, Count: 3},
+				},
+				Employees: []*companiespb.CompanyStatsBucket{
+					{To: wrapperspb.Int64(10), Count: 1},
+					{From: wrapperspb.Int64(10), Count: 2},
+				},
+				Created: []*companiespb.CompanyStatsPeriod{{Start: timestamppb.New(month), Count: 3}},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "interceptor error",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().Stats(ctx, filter, user).Return(nil, errs.NewPermissionDenied())
+			},
+			want:    nil,
+			wantErr: grpc2.DecodeError(errs.NewPermissionDenied()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			s := CompanyServiceServer{
+				companyInterceptor: mockCompanyInterceptor,
+				logger:             logger,
+			}
+			got, err := s.GetCompanyStats(ctx, input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetCompanyStats() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetCompanyStats() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompanyServiceServer_SuggestCompanies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyInterceptor := NewMockcompanyInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	user := utils.Pointer(mock_models.NewToken(t))
+	ctx := context.WithValue(context.Background(), grpc2.TokenKey, user)
+	suggestion := mock_models.NewCompanySuggestion(t)
+	type args struct {
+		input *companiespb.CompanySuggest
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		args    args
+		want    *companiespb.CompanySuggestionList
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					Suggest(ctx, &entity.CompanySuggest{Query: "acme", Limit: utils.Pointer(uint64(5))}, user).
+					Return([]*entity.CompanySuggestion{suggestion}, nil)
+			},
+			args: args{
+				input: &companiespb.CompanySuggest{Query: "acme", Limit: wrapperspb.UInt64(5)},
+			},
+			want: &companiespb.CompanySuggestionList{
+				Items: []*companiespb.CompanySuggestion{
+					{Id: string(suggestion.ID), Name: suggestion.Name, Score: suggestion.Score},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "interceptor error",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					Suggest(ctx, &entity.CompanySuggest{Query: "acme"}, user).
+					Return(nil, errs.NewPermissionDenied())
+			},
+			args: args{
+				input: &companiespb.CompanySuggest{Query: "acme"},
+			},
+			want:    nil,
+			wantErr: grpc2.DecodeError(errs.NewPermissionDenied()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			s := CompanyServiceServer{
+				companyInterceptor: mockCompanyInterceptor,
+				logger:             logger,
+			}
+			got, err := s.SuggestCompanies(ctx, tt.args.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SuggestCompanies() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SuggestCompanies() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type watchCompaniesServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events []*companiespb.CompanyWatchEvent
+}
+
+func (s *watchCompaniesServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchCompaniesServer) Send(event *companiespb.CompanyWatchEvent) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func TestCompanyServiceServer_WatchCompanies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyInterceptor := NewMockcompanyInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	user := utils.Pointer(mock_models.NewToken(t))
+	ctx := context.WithValue(context.Background(), grpc2.TokenKey, user)
+	change := mock_models.NewCompanyChange(t)
+	position := change.Position - 1
+	tests := []struct {
+		name    string
+		setup   func()
+		input   *companiespb.CompanyWatch
+		want    []*companiespb.CompanyWatchEvent
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					Watch(ctx, &entity.CompanyFilter{Registered: utils.Pointer(true)}, &position, gomock.Any(), user).
+					DoAndReturn(func(
+						_ context.Context,
+						_ *entity.CompanyFilter,
+						_ *uint64,
+						stream entity.CompanyChangeStream,
+						_ *entity.Token,
+					) error {
+						if err := stream.Send(change); err != nil {
+							return err
+						}
+						return stream.Heartbeat(change.Position)
+					})
+			},
+			input: &companiespb.CompanyWatch{
+				Filter:   &companiespb.CompanyFilter{Registered: wrapperspb.Bool(true)},
+				Position: wrapperspb.UInt64(position),
+			},
+			want: []*companiespb.CompanyWatchEvent{
+				{
+					Event: &companiespb.CompanyWatchEvent_Change{Change: decodeCompanyChange(change)},
+				},
+				{
+					Event: &companiespb.CompanyWatchEvent_Heartbeat{
+						Heartbeat: &companiespb.CompanyHeartbeat{Position: change.Position},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "interceptor error",
+			setup: func() {
+				var nilPosition *uint64
+				mockCompanyInterceptor.EXPECT().
+					Watch(ctx, &entity.CompanyFilter{}, nilPosition, gomock.Any(), user).
+					Return(errs.NewPermissionDenied())
+			},
+			input:   &companiespb.CompanyWatch{},
+			want:    nil,
+			wantErr: grpc2.DecodeError(errs.NewPermissionDenied()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			s := CompanyServiceServer{
+				companyInterceptor: mockCompanyInterceptor,
+				logger:             logger,
+			}
+			stream := &watchCompaniesServer{ctx: ctx}
+			err := s.WatchCompanies(tt.input, stream)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("WatchCompanies() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(stream.events, tt.want) {
+				t.Errorf("WatchCompanies() events = %v, want %v", stream.events, tt.want)
+			}
+		})
+	}
+}
+
 func TestCompanyServiceServer_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -546,6 +940,29 @@ func Test_decodeCompany(t *testing.T) {
 		AmountOfEmployees: int32(company.AmountOfEmployees),
 		Registered:        company.Registered,
 		Type:              decodeCompanyType(company.Type),
+		Version:           company.Version,
+	}
+	found := mock_models.NewCompany(t)
+	found.Search = &entity.CompanySearch{
+		Score:               0.25,
+		NameHeadline:        "<b>name</b>",
+		DescriptionHeadline: "<b>description</b>",
+	}
+	foundResult := decodeCompany(&entity.Company{
+		ID:                found.ID,
+		UpdatedAt:         found.UpdatedAt,
+		CreatedAt:         found.CreatedAt,
+		Name:              found.Name,
+		Description:       found.Description,
+		AmountOfEmployees: found.AmountOfEmployees,
+		Registered:        found.Registered,
+		Type:              found.Type,
+		Version:           found.Version,
+	})
+	foundResult.Search = &companiespb.CompanySearch{
+		Score:               0.25,
+		NameHeadline:        "<b>name</b>",
+		DescriptionHeadline: "<b>description</b>",
 	}
 	type args struct {
 		company *entity.Company
@@ -562,6 +979,13 @@ func Test_decodeCompany(t *testing.T) {
 			},
 			want: result,
 		},
+		{
+			name: "found by search",
+			args: args{
+				company: found,
+			},
+			want: foundResult,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -586,19 +1010,23 @@ func Test_encodeCompanyFilter(t *testing.T) {
 			name: "ok",
 			args: args{
 				input: &companiespb.CompanyFilter{
-					PageNumber: wrapperspb.UInt64(2),
-					PageSize:   wrapperspb.UInt64(5),
-					Search:     wrapperspb.String("my name is"),
-					OrderBy:    []string{"created_at", "id"},
-					Ids:        []string{string(id)},
+					PageNumber:     wrapperspb.UInt64(2),
+					PageSize:       wrapperspb.UInt64(5),
+					Search:         wrapperspb.String("my name is"),
+					SearchLanguage: wrapperspb.String("german"),
+					OrderBy:        []string{"created_at", "id"},
+					Ids:            []string{string(id)},
+					Filter:         wrapperspb.String("amount_of_employees >= 50"),
 				},
 			},
 			want: &entity.CompanyFilter{
-				PageSize:   utils.Pointer(uint64(5)),
-				PageNumber: utils.Pointer(uint64(2)),
-				OrderBy:    []string{"created_at", "id"},
-				Search:     utils.Pointer("my name is"),
-				IDs:        []entity.UUID{id},
+				PageSize:       utils.Pointer(uint64(5)),
+				PageNumber:     utils.Pointer(uint64(2)),
+				OrderBy:        []string{"created_at", "id"},
+				Search:         utils.Pointer("my name is"),
+				SearchLanguage: utils.Pointer("german"),
+				IDs:            []entity.UUID{id},
+				Filter:         utils.Pointer("amount_of_employees >= 50"),
 			},
 		},
 	}
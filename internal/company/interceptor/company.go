@@ -4,10 +4,11 @@ import (
 	"context"
 
 	"github.com/018bf/companies/internal/entity"
+	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
 	"github.com/018bf/companies/pkg/log"
 )
 
-//go:generate mockgen -source=company.go -package=interceptors -destination=company_mock.go
+//go:generate mockgen -source=company.go -package=interceptor -destination=company_mock.go
 
 type authService interface {
 	HasPermission(ctx context.Context, token *entity.Token, permission entity.PermissionID) error
@@ -21,20 +22,37 @@ type authService interface {
 
 type companyService interface {
 	Get(ctx context.Context, id entity.UUID) (*entity.Company, error)
-	List(ctx context.Context, filter *entity.CompanyFilter) ([]*entity.Company, uint64, error) //deprecated
-	Update(ctx context.Context, update *entity.CompanyUpdate) (*entity.Company, error)
+	List(ctx context.Context, filter *entity.CompanyFilter) (*entity.CompanyList, error) //deprecated
+	Update(ctx context.Context, update *entity.CompanyUpdate) (*entity.Company, *entity.Company, error)
 	Create(ctx context.Context, create *entity.CompanyCreate) (*entity.Company, error)
 	Delete(ctx context.Context, id entity.UUID) error
+	Suggest(ctx context.Context, suggest *entity.CompanySuggest) ([]*entity.CompanySuggestion, error)
+}
+
+type changeService interface {
+	Watch(
+		ctx context.Context,
+		filter *entity.CompanyFilter,
+		position *uint64,
+		stream entity.CompanyChangeStream,
+	) error
+	List(ctx context.Context, since string) (*entity.CompanyChangeList, error)
+}
+
+type statsService interface {
+	Get(ctx context.Context, filter *entity.CompanyStatsFilter) (*entity.CompanyStats, error)
 }
 
 type eventService interface {
 	CompanyCreated(ctx context.Context, company *entity.Company) error
-	CompanyUpdated(ctx context.Context, company *entity.Company) error
+	CompanyUpdated(ctx context.Context, before, after *entity.Company) error
 	CompanyDeleted(ctx context.Context, company *entity.Company) error
 }
 
 type CompanyInterceptor struct {
 	companyService companyService
+	changeService  changeService
+	statsService   statsService
 	authService    authService
 	eventService   eventService
 	logger         log.Logger
@@ -42,12 +60,16 @@ type CompanyInterceptor struct {
 
 func NewCompanyInterceptor(
 	companyService companyService,
+	changeService changeService,
+	statsService statsService,
 	authService authService,
 	eventService eventService,
 	logger log.Logger,
 ) *CompanyInterceptor {
 	return &CompanyInterceptor{
 		companyService: companyService,
+		changeService:  changeService,
+		statsService:   statsService,
 		authService:    authService,
 		eventService:   eventService,
 		logger:         logger,
@@ -69,9 +91,11 @@ func (i *CompanyInterceptor) Create(
 	if err != nil {
 		return nil, err
 	}
-	if err := i.eventService.CompanyCreated(ctx, company); err != nil {
-		i.logger.Error("can't send 'company created' event", log.Context(ctx), log.Error(err))
-	}
+	postgresInterface.AfterCommit(ctx, func(ctx context.Context) {
+		if err := i.eventService.CompanyCreated(ctx, company); err != nil {
+			i.logger.Error("can't send 'company created' event", log.Context(ctx), log.Error(err))
+		}
+	})
 	return company, nil
 }
 
@@ -99,18 +123,74 @@ func (i *CompanyInterceptor) List(
 	ctx context.Context,
 	filter *entity.CompanyFilter,
 	token *entity.Token,
-) ([]*entity.Company, uint64, error) {
+) (*entity.CompanyList, error) {
 	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDCompanyList); err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	if err := i.authService.HasObjectPermission(ctx, token, entity.PermissionIDCompanyList, filter); err != nil {
-		return nil, 0, err
+		return nil, err
 	}
-	listCompanies, count, err := i.companyService.List(ctx, filter)
+	list, err := i.companyService.List(ctx, filter)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	return list, nil
+}
+
+// Watch sends the changes of the companies matching filter to stream until ctx is done.
+func (i *CompanyInterceptor) Watch(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+	position *uint64,
+	stream entity.CompanyChangeStream,
+	token *entity.Token,
+) error {
+	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDCompanyList); err != nil {
+		return err
+	}
+	if err := i.authService.HasObjectPermission(ctx, token, entity.PermissionIDCompanyList, filter); err != nil {
+		return err
 	}
-	return listCompanies, count, nil
+	return i.changeService.Watch(ctx, filter, position, stream)
+}
+
+// ListChanges returns the next page of the change feed after the continuation token.
+func (i *CompanyInterceptor) ListChanges(
+	ctx context.Context,
+	since string,
+	token *entity.Token,
+) (*entity.CompanyChangeList, error) {
+	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDCompanyList); err != nil {
+		return nil, err
+	}
+	return i.changeService.List(ctx, since)
+}
+
+// Stats returns the counts and distributions of the companies matching filter.
+func (i *CompanyInterceptor) Stats(
+	ctx context.Context,
+	filter *entity.CompanyStatsFilter,
+	token *entity.Token,
+) (*entity.CompanyStats, error) {
+	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDCompanyList); err != nil {
+		return nil, err
+	}
+	if err := i.authService.HasObjectPermission(ctx, token, entity.PermissionIDCompanyList, &filter.CompanyFilter); err != nil {
+		return nil, err
+	}
+	return i.statsService.Get(ctx, filter)
+}
+
+// Suggest returns the companies whose names complete or resemble the query.
+func (i *CompanyInterceptor) Suggest(
+	ctx context.Context,
+	suggest *entity.CompanySuggest,
+	token *entity.Token,
+) ([]*entity.CompanySuggestion, error) {
+	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDCompanyList); err != nil {
+		return nil, err
+	}
+	return i.companyService.Suggest(ctx, suggest)
 }
 
 func (i *CompanyInterceptor) Update(
@@ -128,13 +208,16 @@ func (i *CompanyInterceptor) Update(
 	if err := i.authService.HasObjectPermission(ctx, token, entity.PermissionIDCompanyUpdate, company); err != nil {
 		return nil, err
 	}
-	updated, err := i.companyService.Update(ctx, update)
+	// The event is built from the company read by the update, the one read above may be stale.
+	before, updated, err := i.companyService.Update(ctx, update)
 	if err != nil {
 		return nil, err
 	}
-	if err := i.eventService.CompanyUpdated(ctx, company); err != nil {
-		i.logger.Error("can't send 'company updated' event", log.Context(ctx), log.Error(err))
-	}
+	postgresInterface.AfterCommit(ctx, func(ctx context.Context) {
+		if err := i.eventService.CompanyUpdated(ctx, before, updated); err != nil {
+			i.logger.Error("can't send 'company updated' event", log.Context(ctx), log.Error(err))
+		}
+	})
 	return updated, nil
 }
 
@@ -156,8 +239,10 @@ func (i *CompanyInterceptor) Delete(
 	if err := i.companyService.Delete(ctx, id); err != nil {
 		return err
 	}
-	if err := i.eventService.CompanyDeleted(ctx, company); err != nil {
-		i.logger.Error("can't send 'company deleted' event", log.Context(ctx), log.Error(err))
-	}
+	postgresInterface.AfterCommit(ctx, func(ctx context.Context) {
+		if err := i.eventService.CompanyDeleted(ctx, company); err != nil {
+			i.logger.Error("can't send 'company deleted' event", log.Context(ctx), log.Error(err))
+		}
+	})
 	return nil
 }
@@ -1,14 +1,14 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: company.go
 
-// Package interceptors is a generated GoMock package.
+// Package interceptor is a generated GoMock package.
 package interceptor
 
 import (
 	context "context"
 	reflect "reflect"
 
-	models "github.com/018bf/companies/internal/entity"
+	entity "github.com/018bf/companies/internal/entity"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -36,7 +36,7 @@ func (m *MockauthService) EXPECT() *MockauthServiceMockRecorder {
 }
 
 // HasObjectPermission mocks base method.
-func (m *MockauthService) HasObjectPermission(ctx context.Context, token *models.Token, permission models.PermissionID, object any) error {
+func (m *MockauthService) HasObjectPermission(ctx context.Context, token *entity.Token, permission entity.PermissionID, object any) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HasObjectPermission", ctx, token, permission, object)
 	ret0, _ := ret[0].(error)
@@ -50,7 +50,7 @@ func (mr *MockauthServiceMockRecorder) HasObjectPermission(ctx, token, permissio
 }
 
 // HasPermission mocks base method.
-func (m *MockauthService) HasPermission(ctx context.Context, token *models.Token, permission models.PermissionID) error {
+func (m *MockauthService) HasPermission(ctx context.Context, token *entity.Token, permission entity.PermissionID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HasPermission", ctx, token, permission)
 	ret0, _ := ret[0].(error)
@@ -87,10 +87,10 @@ func (m *MockcompanyService) EXPECT() *MockcompanyServiceMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockcompanyService) Create(ctx context.Context, create *models.CompanyCreate) (*models.Company, error) {
+func (m *MockcompanyService) Create(ctx context.Context, create *entity.CompanyCreate) (*entity.Company, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", ctx, create)
-	ret0, _ := ret[0].(*models.Company)
+	ret0, _ := ret[0].(*entity.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -102,7 +102,7 @@ func (mr *MockcompanyServiceMockRecorder) Create(ctx, create interface{}) *gomoc
 }
 
 // Delete mocks base method.
-func (m *MockcompanyService) Delete(ctx context.Context, id models.UUID) error {
+func (m *MockcompanyService) Delete(ctx context.Context, id entity.UUID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
@@ -116,10 +116,10 @@ func (mr *MockcompanyServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Ca
 }
 
 // Get mocks base method.
-func (m *MockcompanyService) Get(ctx context.Context, id models.UUID) (*models.Company, error) {
+func (m *MockcompanyService) Get(ctx context.Context, id entity.UUID) (*entity.Company, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", ctx, id)
-	ret0, _ := ret[0].(*models.Company)
+	ret0, _ := ret[0].(*entity.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -131,13 +131,12 @@ func (mr *MockcompanyServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call
 }
 
 // List mocks base method.
-func (m *MockcompanyService) List(ctx context.Context, filter *models.CompanyFilter) ([]*models.Company, uint64, error) {
+func (m *MockcompanyService) List(ctx context.Context, filter *entity.CompanyFilter) (*entity.CompanyList, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", ctx, filter)
-	ret0, _ := ret[0].([]*models.Company)
-	ret1, _ := ret[1].(uint64)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret0, _ := ret[0].(*entity.CompanyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // List indicates an expected call of List.
@@ -146,21 +145,127 @@ func (mr *MockcompanyServiceMockRecorder) List(ctx, filter interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockcompanyService)(nil).List), ctx, filter)
 }
 
-// Update mocks base method.
-func (m *MockcompanyService) Update(ctx context.Context, update *models.CompanyUpdate) (*models.Company, error) {
+// Suggest mocks base method.
+func (m *MockcompanyService) Suggest(ctx context.Context, suggest *entity.CompanySuggest) ([]*entity.CompanySuggestion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, update)
-	ret0, _ := ret[0].(*models.Company)
+	ret := m.ctrl.Call(m, "Suggest", ctx, suggest)
+	ret0, _ := ret[0].([]*entity.CompanySuggestion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// Suggest indicates an expected call of Suggest.
+func (mr *MockcompanyServiceMockRecorder) Suggest(ctx, suggest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockcompanyService)(nil).Suggest), ctx, suggest)
+}
+
+// Update mocks base method.
+func (m *MockcompanyService) Update(ctx context.Context, update *entity.CompanyUpdate) (*entity.Company, *entity.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, update)
+	ret0, _ := ret[0].(*entity.Company)
+	ret1, _ := ret[1].(*entity.Company)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // Update indicates an expected call of Update.
 func (mr *MockcompanyServiceMockRecorder) Update(ctx, update interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockcompanyService)(nil).Update), ctx, update)
 }
 
+// MockchangeService is a mock of changeService interface.
+type MockchangeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockchangeServiceMockRecorder
+}
+
+// MockchangeServiceMockRecorder is the mock recorder for MockchangeService.
+type MockchangeServiceMockRecorder struct {
+	mock *MockchangeService
+}
+
+// NewMockchangeService creates a new mock instance.
+func NewMockchangeService(ctrl *gomock.Controller) *MockchangeService {
+	mock := &MockchangeService{ctrl: ctrl}
+	mock.recorder = &MockchangeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockchangeService) EXPECT() *MockchangeServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockchangeService) List(ctx context.Context, since string) (*entity.CompanyChangeList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, since)
+	ret0, _ := ret[0].(*entity.CompanyChangeList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockchangeServiceMockRecorder) List(ctx, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockchangeService)(nil).List), ctx, since)
+}
+
+// Watch mocks base method.
+func (m *MockchangeService) Watch(ctx context.Context, filter *entity.CompanyFilter, position *uint64, stream entity.CompanyChangeStream) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", ctx, filter, position, stream)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockchangeServiceMockRecorder) Watch(ctx, filter, position, stream interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockchangeService)(nil).Watch), ctx, filter, position, stream)
+}
+
+// MockstatsService is a mock of statsService interface.
+type MockstatsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockstatsServiceMockRecorder
+}
+
+// MockstatsServiceMockRecorder is the mock recorder for MockstatsService.
+type MockstatsServiceMockRecorder struct {
+	mock *MockstatsService
+}
+
+// NewMockstatsService creates a new mock instance.
+func NewMockstatsService(ctrl *gomock.Controller) *MockstatsService {
+	mock := &MockstatsService{ctrl: ctrl}
+	mock.recorder = &MockstatsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstatsService) EXPECT() *MockstatsServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockstatsService) Get(ctx context.Context, filter *entity.CompanyStatsFilter) (*entity.CompanyStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].(*entity.CompanyStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockstatsServiceMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockstatsService)(nil).Get), ctx, filter)
+}
+
 // MockeventService is a mock of eventService interface.
 type MockeventService struct {
 	ctrl     *gomock.Controller
@@ -185,7 +290,7 @@ func (m *MockeventService) EXPECT() *MockeventServiceMockRecorder {
 }
 
 // CompanyCreated mocks base method.
-func (m *MockeventService) CompanyCreated(ctx context.Context, company *models.Company) error {
+func (m *MockeventService) CompanyCreated(ctx context.Context, company *entity.Company) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CompanyCreated", ctx, company)
 	ret0, _ := ret[0].(error)
@@ -199,7 +304,7 @@ func (mr *MockeventServiceMockRecorder) CompanyCreated(ctx, company interface{})
 }
 
 // CompanyDeleted mocks base method.
-func (m *MockeventService) CompanyDeleted(ctx context.Context, company *models.Company) error {
+func (m *MockeventService) CompanyDeleted(ctx context.Context, company *entity.Company) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CompanyDeleted", ctx, company)
 	ret0, _ := ret[0].(error)
@@ -213,15 +318,15 @@ func (mr *MockeventServiceMockRecorder) CompanyDeleted(ctx, company interface{})
 }
 
 // CompanyUpdated mocks base method.
-func (m *MockeventService) CompanyUpdated(ctx context.Context, company *models.Company) error {
+func (m *MockeventService) CompanyUpdated(ctx context.Context, before, after *entity.Company) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CompanyUpdated", ctx, company)
+	ret := m.ctrl.Call(m, "CompanyUpdated", ctx, before, after)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CompanyUpdated indicates an expected call of CompanyUpdated.
-func (mr *MockeventServiceMockRecorder) CompanyUpdated(ctx, company interface{}) *gomock.Call {
+func (mr *MockeventServiceMockRecorder) CompanyUpdated(ctx, before, after interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompanyUpdated", reflect.TypeOf((*MockeventService)(nil).CompanyUpdated), ctx, company)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompanyUpdated", reflect.TypeOf((*MockeventService)(nil).CompanyUpdated), ctx, before, after)
 }
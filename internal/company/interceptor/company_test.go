@@ -7,8 +7,10 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/018bf/companies/internal/configs"
 	mock_models "github.com/018bf/companies/internal/entity/mock"
 	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/interfaces/postgres"
 	mock_log "github.com/018bf/companies/pkg/log/mock"
 	"github.com/golang/mock/gomock"
 	"github.com/jaswdr/faker"
@@ -23,10 +25,14 @@ func TestNewCompanyInterceptor(t *testing.T) {
 	mockAuthService := NewMockauthService(ctrl)
 	mockEventService := NewMockeventService(ctrl)
 	mockCompanyService := NewMockcompanyService(ctrl)
+	mockChangeService := NewMockchangeService(ctrl)
+	mockStatsService := NewMockstatsService(ctrl)
 	logger := mock_log.NewMockLogger(ctrl)
 	type args struct {
 		authService    authService
 		companyService companyService
+		changeService  changeService
+		statsService   statsService
 		logger         log.Logger
 		eventService   eventService
 	}
@@ -41,12 +47,16 @@ func TestNewCompanyInterceptor(t *testing.T) {
 			setup: func() {},
 			args: args{
 				companyService: mockCompanyService,
+				changeService:  mockChangeService,
+				statsService:   mockStatsService,
 				authService:    mockAuthService,
 				logger:         logger,
 				eventService:   mockEventService,
 			},
 			want: &CompanyInterceptor{
 				companyService: mockCompanyService,
+				changeService:  mockChangeService,
+				statsService:   mockStatsService,
 				authService:    mockAuthService,
 				eventService:   mockEventService,
 				logger:         logger,
@@ -56,7 +66,7 @@ func TestNewCompanyInterceptor(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			if got := NewCompanyInterceptor(tt.args.companyService, tt.args.authService, tt.args.eventService, tt.args.logger); !reflect.DeepEqual(
+			if got := NewCompanyInterceptor(tt.args.companyService, tt.args.changeService, tt.args.statsService, tt.args.authService, tt.args.eventService, tt.args.logger); !reflect.DeepEqual(
 				got,
 				tt.want,
 			) {
@@ -66,6 +76,75 @@ func TestNewCompanyInterceptor(t *testing.T) {
 	}
 }
 
+// TestCompanyInterceptor_Create_transaction checks the event is sent once the transaction is committed.
+func TestCompanyInterceptor_Create_transaction(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthService := NewMockauthService(ctrl)
+	mockCompanyService := NewMockcompanyService(ctrl)
+	mockEventService := NewMockeventService(ctrl)
+	token := utils.Pointer(mock_models.NewToken(t))
+	company := mock_models.NewCompany(t)
+	create := mock_models.NewCompanyCreate(t)
+	txManager := postgres.NewTxManager(db, configs.NewMockConfig(t), mock_log.NewMockLogger(ctrl))
+	i := &CompanyInterceptor{
+		companyService: mockCompanyService,
+		authService:    mockAuthService,
+		eventService:   mockEventService,
+	}
+	mockAuthService.EXPECT().HasPermission(gomock.Any(), token, entity.PermissionIDCompanyCreate).Return(nil).Times(2)
+	mockAuthService.EXPECT().
+		HasObjectPermission(gomock.Any(), token, entity.PermissionIDCompanyCreate, create).
+		Return(nil).
+		Times(2)
+	mockCompanyService.EXPECT().Create(gomock.Any(), create).Return(company, nil).Times(2)
+	// The event of the rolled back transaction isn't sent.
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	rollback := errs.NewUnexpectedBehaviorError("err 1")
+	err = txManager.Transaction(context.Background(), func(ctx context.Context) error {
+		if _, err := i.Create(ctx, create, token); err != nil {
+			return err
+		}
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Errorf("CompanyInterceptor.Create() error = %v, wantErr %v", err, rollback)
+	}
+	// The event of the committed one is sent after the commit.
+	committed := false
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	mockEventService.EXPECT().
+		CompanyCreated(gomock.Any(), company).
+		DoAndReturn(func(ctx context.Context, _ *entity.Company) error {
+			committed = true
+			return nil
+		})
+	err = txManager.Transaction(context.Background(), func(ctx context.Context) error {
+		_, err := i.Create(ctx, create, token)
+		if committed {
+			t.Error("CompanyInterceptor.Create() sends the event before the commit")
+		}
+		return err
+	})
+	if err != nil {
+		t.Errorf("CompanyInterceptor.Create() error = %v", err)
+	}
+	if !committed {
+		t.Error("CompanyInterceptor.Create() doesn't send the event after the commit")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestCompanyInterceptor_Get(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -402,6 +481,9 @@ func TestCompanyInterceptor_Update(t *testing.T) {
 	logger := mock_log.NewMockLogger(ctrl)
 	ctx := context.Background()
 	company := mock_models.NewCompany(t)
+	// The company read by the update differs from the one read for the permission check.
+	before := mock_models.NewCompany(t)
+	updated := mock_models.NewCompany(t)
 	update := mock_models.NewCompanyUpdate(t)
 	mockEventService := NewMockeventService(ctrl)
 	type fields struct {
@@ -435,8 +517,8 @@ func TestCompanyInterceptor_Update(t *testing.T) {
 				mockAuthService.EXPECT().
 					HasObjectPermission(ctx, token, entity.PermissionIDCompanyUpdate, company).
 					Return(nil)
-				mockCompanyService.EXPECT().Update(ctx, update).Return(company, nil)
-				mockEventService.EXPECT().CompanyUpdated(ctx, company).Return(nil)
+				mockCompanyService.EXPECT().Update(ctx, update).Return(before, updated, nil)
+				mockEventService.EXPECT().CompanyUpdated(ctx, before, updated).Return(nil)
 			},
 			fields: fields{
 				companyService: mockCompanyService,
@@ -449,7 +531,7 @@ func TestCompanyInterceptor_Update(t *testing.T) {
 				update: update,
 				token:  token,
 			},
-			want:    company,
+			want:    updated,
 			wantErr: nil,
 		},
 		{
@@ -466,9 +548,9 @@ func TestCompanyInterceptor_Update(t *testing.T) {
 					Return(nil)
 				mockCompanyService.EXPECT().
 					Update(ctx, update).
-					Return(company, nil)
+					Return(before, updated, nil)
 				mockEventService.EXPECT().
-					CompanyUpdated(ctx, company).
+					CompanyUpdated(ctx, before, updated).
 					Return(errs.NewUnexpectedBehaviorError("err 235"))
 				logger.EXPECT().
 					Error(
@@ -488,7 +570,7 @@ func TestCompanyInterceptor_Update(t *testing.T) {
 				update: update,
 				token:  token,
 			},
-			want:    company,
+			want:    updated,
 			wantErr: nil,
 		},
 		{
@@ -556,7 +638,7 @@ func TestCompanyInterceptor_Update(t *testing.T) {
 					Return(nil)
 				mockCompanyService.EXPECT().
 					Update(ctx, update).
-					Return(nil, errs.NewUnexpectedBehaviorError("d 2"))
+					Return(nil, nil, errs.NewUnexpectedBehaviorError("d 2"))
 			},
 			fields: fields{
 				companyService: mockCompanyService,
@@ -838,6 +920,7 @@ func TestCompanyInterceptor_List(t *testing.T) {
 	for i := uint64(0); i < count; i++ {
 		listCompanies = append(listCompanies, mock_models.NewCompany(t))
 	}
+	list := &entity.CompanyList{Items: listCompanies, Count: utils.Pointer(count)}
 	type fields struct {
 		companyService companyService
 		authService    authService
@@ -853,8 +936,7 @@ func TestCompanyInterceptor_List(t *testing.T) {
 		setup   func()
 		fields  fields
 		args    args
-		want    []*entity.Company
-		want1   uint64
+		want    *entity.CompanyList
 		wantErr error
 	}{
 		{
@@ -868,7 +950,7 @@ func TestCompanyInterceptor_List(t *testing.T) {
 					Return(nil)
 				mockCompanyService.EXPECT().
 					List(ctx, filter).
-					Return(listCompanies, count, nil)
+					Return(list, nil)
 			},
 			fields: fields{
 				companyService: mockCompanyService,
@@ -880,8 +962,7 @@ func TestCompanyInterceptor_List(t *testing.T) {
 				filter: filter,
 				token:  token,
 			},
-			want:    listCompanies,
-			want1:   count,
+			want:    list,
 			wantErr: nil,
 		},
 		{
@@ -905,7 +986,6 @@ func TestCompanyInterceptor_List(t *testing.T) {
 				token:  token,
 			},
 			want:    nil,
-			want1:   0,
 			wantErr: errs.NewPermissionDenied(),
 		},
 		{
@@ -926,7 +1006,6 @@ func TestCompanyInterceptor_List(t *testing.T) {
 				token:  token,
 			},
 			want:    nil,
-			want1:   0,
 			wantErr: errs.NewPermissionDenied(),
 		},
 		{
@@ -940,7 +1019,7 @@ func TestCompanyInterceptor_List(t *testing.T) {
 					Return(nil)
 				mockCompanyService.EXPECT().
 					List(ctx, filter).
-					Return(nil, uint64(0), errs.NewUnexpectedBehaviorError("l e"))
+					Return(nil, errs.NewUnexpectedBehaviorError("l e"))
 			},
 			fields: fields{
 				companyService: mockCompanyService,
@@ -953,7 +1032,6 @@ func TestCompanyInterceptor_List(t *testing.T) {
 				token:  token,
 			},
 			want:    nil,
-			want1:   0,
 			wantErr: errs.NewUnexpectedBehaviorError("l e"),
 		},
 	}
@@ -965,7 +1043,7 @@ func TestCompanyInterceptor_List(t *testing.T) {
 				authService:    tt.fields.authService,
 				logger:         tt.fields.logger,
 			}
-			got, got1, err := i.List(tt.args.ctx, tt.args.filter, tt.args.token)
+			got, err := i.List(tt.args.ctx, tt.args.filter, tt.args.token)
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("CompanyInterceptor.List() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -973,8 +1051,374 @@ func TestCompanyInterceptor_List(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CompanyInterceptor.List() got = %v, want %v", got, tt.want)
 			}
-			if got1 != tt.want1 {
-				t.Errorf("CompanyInterceptor.List() got1 = %v, want %v", got1, tt.want1)
+		})
+	}
+}
+
+func TestCompanyInterceptor_Watch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthService := NewMockauthService(ctrl)
+	mockChangeService := NewMockchangeService(ctrl)
+	token := utils.Pointer(mock_models.NewToken(t))
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	filter := mock_models.NewCompanyFilter(t)
+	position := utils.Pointer(uint64(10))
+	var stream entity.CompanyChangeStream
+	type fields struct {
+		changeService changeService
+		authService   authService
+		logger        log.Logger
+	}
+	type args struct {
+		ctx      context.Context
+		filter   *entity.CompanyFilter
+		position *uint64
+		token    *entity.Token
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(nil)
+				mockAuthService.EXPECT().
+					HasObjectPermission(ctx, token, entity.PermissionIDCompanyList, filter).
+					Return(nil)
+				mockChangeService.EXPECT().
+					Watch(ctx, filter, position, stream).
+					Return(nil)
+			},
+			fields: fields{
+				changeService: mockChangeService,
+				authService:   mockAuthService,
+				logger:        logger,
+			},
+			args: args{
+				ctx:      ctx,
+				filter:   filter,
+				position: position,
+				token:    token,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "object permission denied",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(nil)
+				mockAuthService.EXPECT().
+					HasObjectPermission(ctx, token, entity.PermissionIDCompanyList, filter).
+					Return(errs.NewPermissionDenied())
+			},
+			fields: fields{
+				changeService: mockChangeService,
+				authService:   mockAuthService,
+				logger:        logger,
+			},
+			args: args{
+				ctx:      ctx,
+				filter:   filter,
+				position: position,
+				token:    token,
+			},
+			wantErr: errs.NewPermissionDenied(),
+		},
+		{
+			name: "permission denied",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(errs.NewPermissionDenied())
+			},
+			fields: fields{
+				changeService: mockChangeService,
+				authService:   mockAuthService,
+				logger:        logger,
+			},
+			args: args{
+				ctx:      ctx,
+				filter:   filter,
+				position: position,
+				token:    token,
+			},
+			wantErr: errs.NewPermissionDenied(),
+		},
+		{
+			name: "watch error",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(nil)
+				mockAuthService.EXPECT().
+					HasObjectPermission(ctx, token, entity.PermissionIDCompanyList, filter).
+					Return(nil)
+				mockChangeService.EXPECT().
+					Watch(ctx, filter, position, stream).
+					Return(errs.NewUnexpectedBehaviorError("w e"))
+			},
+			fields: fields{
+				changeService: mockChangeService,
+				authService:   mockAuthService,
+				logger:        logger,
+			},
+			args: args{
+				ctx:      ctx,
+				filter:   filter,
+				position: position,
+				token:    token,
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("w e"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			i := &CompanyInterceptor{
+				changeService: tt.fields.changeService,
+				authService:   tt.fields.authService,
+				logger:        tt.fields.logger,
+			}
+			err := i.Watch(tt.args.ctx, tt.args.filter, tt.args.position, stream, tt.args.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CompanyInterceptor.Watch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompanyInterceptor_ListChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthService := NewMockauthService(ctrl)
+	mockChangeService := NewMockchangeService(ctrl)
+	token := utils.Pointer(mock_models.NewToken(t))
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	list := &entity.CompanyChangeList{
+		Items: []*entity.CompanyChange{mock_models.NewCompanyChange(t)},
+		Token: "token",
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *entity.CompanyChangeList
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(nil)
+				mockChangeService.EXPECT().List(ctx, "since").Return(list, nil)
+			},
+			want:    list,
+			wantErr: nil,
+		},
+		{
+			name: "permission denied",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(errs.NewPermissionDenied())
+			},
+			want:    nil,
+			wantErr: errs.NewPermissionDenied(),
+		},
+		{
+			name: "list error",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(nil)
+				mockChangeService.EXPECT().List(ctx, "since").Return(nil, errs.NewUnexpectedBehaviorError("l e"))
+			},
+			want:    nil,
+			wantErr: errs.NewUnexpectedBehaviorError("l e"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			i := &CompanyInterceptor{
+				changeService: mockChangeService,
+				authService:   mockAuthService,
+				logger:        logger,
+			}
+			got, err := i.ListChanges(ctx, "since", token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CompanyInterceptor.ListChanges() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompanyInterceptor.ListChanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompanyInterceptor_Suggest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthService := NewMockauthService(ctrl)
+	mockCompanyService := NewMockcompanyService(ctrl)
+	token := utils.Pointer(mock_models.NewToken(t))
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	suggest := mock_models.NewCompanySuggest(t)
+	suggestions := []*entity.CompanySuggestion{mock_models.NewCompanySuggestion(t)}
+	tests := []struct {
+		name    string
+		setup   func()
+		want    []*entity.CompanySuggestion
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(nil)
+				mockCompanyService.EXPECT().Suggest(ctx, suggest).Return(suggestions, nil)
+			},
+			want:    suggestions,
+			wantErr: nil,
+		},
+		{
+			name: "permission denied",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(errs.NewPermissionDenied())
+			},
+			want:    nil,
+			wantErr: errs.NewPermissionDenied(),
+		},
+		{
+			name: "suggest error",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(nil)
+				mockCompanyService.EXPECT().Suggest(ctx, suggest).Return(nil, errs.NewUnexpectedBehaviorError("s e"))
+			},
+			want:    nil,
+			wantErr: errs.NewUnexpectedBehaviorError("s e"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			i := &CompanyInterceptor{
+				companyService: mockCompanyService,
+				authService:    mockAuthService,
+				logger:         logger,
+			}
+			got, err := i.Suggest(ctx, suggest, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CompanyInterceptor.Suggest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompanyInterceptor.Suggest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompanyInterceptor_Stats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthService := NewMockauthService(ctrl)
+	mockStatsService := NewMockstatsService(ctrl)
+	token := utils.Pointer(mock_models.NewToken(t))
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	filter := &entity.CompanyStatsFilter{CompanyFilter: *mock_models.NewCompanyFilter(t)}
+	stats := &entity.CompanyStats{Total: 7}
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *entity.CompanyStats
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(nil)
+				mockAuthService.EXPECT().
+					HasObjectPermission(ctx, token, entity.PermissionIDCompanyList, &filter.CompanyFilter).
+					Return(nil)
+				mockStatsService.EXPECT().Get(ctx, filter).Return(stats, nil)
+			},
+			want:    stats,
+			wantErr: nil,
+		},
+		{
+			name: "permission denied",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(errs.NewPermissionDenied())
+			},
+			want:    nil,
+			wantErr: errs.NewPermissionDenied(),
+		},
+		{
+			name: "object permission denied",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(nil)
+				mockAuthService.EXPECT().
+					HasObjectPermission(ctx, token, entity.PermissionIDCompanyList, &filter.CompanyFilter).
+					Return(errs.NewPermissionDenied())
+			},
+			want:    nil,
+			wantErr: errs.NewPermissionDenied(),
+		},
+		{
+			name: "stats error",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDCompanyList).
+					Return(nil)
+				mockAuthService.EXPECT().
+					HasObjectPermission(ctx, token, entity.PermissionIDCompanyList, &filter.CompanyFilter).
+					Return(nil)
+				mockStatsService.EXPECT().Get(ctx, filter).Return(nil, errs.NewUnexpectedBehaviorError("s e"))
+			},
+			want:    nil,
+			wantErr: errs.NewUnexpectedBehaviorError("s e"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			i := &CompanyInterceptor{
+				statsService: mockStatsService,
+				authService:  mockAuthService,
+				logger:       logger,
+			}
+			got, err := i.Stats(ctx, filter, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CompanyInterceptor.Stats() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompanyInterceptor.Stats() = %v, want %v", got, tt.want)
 			}
 		})
 	}
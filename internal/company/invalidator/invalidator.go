@@ -0,0 +1,25 @@
+package invalidator
+
+import (
+	"context"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/pkg/log"
+)
+
+//go:generate mockgen -source=invalidator.go -package=invalidator -destination=invalidator_mock.go
+
+// companyCache forgets the cached companies.
+type companyCache interface {
+	Invalidate(ctx context.Context, ids ...entity.UUID) error
+}
+
+// invalidate forgets the company of an event, the failure is logged and the company expires later.
+func invalidate(ctx context.Context, cache companyCache, logger log.Logger, id string) {
+	if id == "" {
+		return
+	}
+	if err := cache.Invalidate(ctx, entity.UUID(id)); err != nil {
+		logger.Warn("can't invalidate cached company", log.Context(ctx), log.Error(err), log.Any("company_id", id))
+	}
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: invalidator.go
+
+// Package invalidator is a generated GoMock package.
+package invalidator
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockcompanyCache is a mock of companyCache interface.
+type MockcompanyCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockcompanyCacheMockRecorder
+}
+
+// MockcompanyCacheMockRecorder is the mock recorder for MockcompanyCache.
+type MockcompanyCacheMockRecorder struct {
+	mock *MockcompanyCache
+}
+
+// NewMockcompanyCache creates a new mock instance.
+func NewMockcompanyCache(ctrl *gomock.Controller) *MockcompanyCache {
+	mock := &MockcompanyCache{ctrl: ctrl}
+	mock.recorder = &MockcompanyCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcompanyCache) EXPECT() *MockcompanyCacheMockRecorder {
+	return m.recorder
+}
+
+// Invalidate mocks base method.
+func (m *MockcompanyCache) Invalidate(ctx context.Context, ids ...entity.UUID) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Invalidate", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invalidate indicates an expected call of Invalidate.
+func (mr *MockcompanyCacheMockRecorder) Invalidate(ctx interface{}, ids ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invalidate", reflect.TypeOf((*MockcompanyCache)(nil).Invalidate), varargs...)
+}
@@ -0,0 +1,74 @@
+package invalidator
+
+import (
+	"context"
+	"sync"
+
+	cacheRepository "github.com/018bf/companies/internal/company/repository/cache"
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/Shopify/sarama"
+)
+
+// KafkaInvalidator forgets the companies of the events sent to the topic by every instance.
+// The events keyed by the company ID are read from all partitions by each instance, not by a consumer group.
+type KafkaInvalidator struct {
+	consumer   sarama.Consumer
+	cache      companyCache
+	logger     log.Logger
+	topic      string
+	mu         sync.Mutex
+	partitions []sarama.PartitionConsumer
+	wg         sync.WaitGroup
+}
+
+func NewKafkaInvalidator(
+	consumer sarama.Consumer,
+	cache *cacheRepository.CompanyRepository,
+	config *configs.Config,
+	logger log.Logger,
+) *KafkaInvalidator {
+	return &KafkaInvalidator{
+		consumer: consumer,
+		cache:    cache,
+		logger:   logger,
+		topic:    config.Kafka.Topic,
+	}
+}
+
+// Start consumes the events sent from now on in the background until the invalidator is stopped.
+func (i *KafkaInvalidator) Start(ctx context.Context) error {
+	partitions, err := i.consumer.Partitions(i.topic)
+	if err != nil {
+		return err
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, partition := range partitions {
+		partitionConsumer, err := i.consumer.ConsumePartition(i.topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return err
+		}
+		i.partitions = append(i.partitions, partitionConsumer)
+		i.wg.Add(1)
+		go func() {
+			defer i.wg.Done()
+			for message := range partitionConsumer.Messages() {
+				invalidate(ctx, i.cache, i.logger, string(message.Key))
+			}
+		}()
+	}
+	return nil
+}
+
+// Stop closes the partition consumers before the consumer, as sarama requires.
+func (i *KafkaInvalidator) Stop(_ context.Context) error {
+	i.mu.Lock()
+	for _, partitionConsumer := range i.partitions {
+		partitionConsumer.AsyncClose()
+	}
+	i.partitions = nil
+	i.mu.Unlock()
+	i.wg.Wait()
+	return i.consumer.Close()
+}
@@ -0,0 +1,73 @@
+package invalidator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func TestKafkaInvalidator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCache := NewMockcompanyCache(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.SetTopicMetadata(map[string][]int32{"companies": {0, 1}})
+	first := consumer.ExpectConsumePartition("companies", 0, sarama.OffsetNewest)
+	second := consumer.ExpectConsumePartition("companies", 1, sarama.OffsetNewest)
+	first.YieldMessage(&sarama.ConsumerMessage{Key: []byte("first")})
+	first.YieldMessage(&sarama.ConsumerMessage{Key: nil})
+	second.YieldMessage(&sarama.ConsumerMessage{Key: []byte("second")})
+	first.ExpectMessagesDrainedOnClose()
+	second.ExpectMessagesDrainedOnClose()
+	invalidated := make(chan entity.UUID, 2)
+	record := func(_ context.Context, ids ...entity.UUID) error {
+		invalidated <- ids[0]
+		return nil
+	}
+	mockCache.EXPECT().Invalidate(ctx, entity.UUID("first")).DoAndReturn(record)
+	mockCache.EXPECT().Invalidate(ctx, entity.UUID("second")).DoAndReturn(func(ctx context.Context, ids ...entity.UUID) error {
+		_ = record(ctx, ids...)
+		return errors.New("connection refused")
+	})
+	logger.EXPECT().Warn("can't invalidate cached company", gomock.Any(), gomock.Any(), gomock.Any())
+	i := &KafkaInvalidator{
+		consumer: consumer,
+		cache:    mockCache,
+		logger:   logger,
+		topic:    "companies",
+	}
+	if err := i.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	<-invalidated
+	<-invalidated
+	if err := i.Stop(ctx); err != nil {
+		t.Errorf("Stop() error = %v", err)
+	}
+}
+
+func TestKafkaInvalidator_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCache := NewMockcompanyCache(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.SetTopicMetadata(map[string][]int32{"companies": {0}})
+	i := &KafkaInvalidator{
+		consumer: consumer,
+		cache:    mockCache,
+		logger:   logger,
+		topic:    "unknown",
+	}
+	if err := i.Start(context.Background()); !errors.Is(err, sarama.ErrUnknownTopicOrPartition) {
+		t.Errorf("Start() error = %v, wantErr %v", err, sarama.ErrUnknownTopicOrPartition)
+	}
+}
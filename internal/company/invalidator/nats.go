@@ -0,0 +1,74 @@
+package invalidator
+
+import (
+	"context"
+	"encoding/json"
+
+	cacheRepository "github.com/018bf/companies/internal/company/repository/cache"
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/pkg/cloudevents"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/nats-io/nats.go"
+)
+
+//go:generate mockgen -source=nats.go -package=invalidator -destination=nats_mock.go
+
+type subscriber interface {
+	Subscribe(subject string, handler nats.MsgHandler) (*nats.Subscription, error)
+}
+
+// NATSInvalidator forgets the companies of the events published to "<subject>.<operation>" by every instance.
+// The company ID is the subject of the cloud event, in the headers in binary mode or in the envelope otherwise.
+type NATSInvalidator struct {
+	subscriber   subscriber
+	cache        companyCache
+	logger       log.Logger
+	subject      string
+	subscription *nats.Subscription
+}
+
+func NewNATSInvalidator(
+	conn *nats.Conn,
+	cache *cacheRepository.CompanyRepository,
+	config *configs.Config,
+	logger log.Logger,
+) *NATSInvalidator {
+	return &NATSInvalidator{
+		subscriber: conn,
+		cache:      cache,
+		logger:     logger,
+		subject:    config.Events.NATS.Subject,
+	}
+}
+
+// Start subscribes to the events published from now on until the invalidator is stopped.
+func (i *NATSInvalidator) Start(ctx context.Context) error {
+	subscription, err := i.subscriber.Subscribe(i.subject+".>", func(message *nats.Msg) {
+		invalidate(ctx, i.cache, i.logger, companyID(message))
+	})
+	if err != nil {
+		return err
+	}
+	i.subscription = subscription
+	return nil
+}
+
+func (i *NATSInvalidator) Stop(_ context.Context) error {
+	if i.subscription == nil {
+		return nil
+	}
+	return i.subscription.Unsubscribe()
+}
+
+func companyID(message *nats.Msg) string {
+	if message.Header.Get(cloudevents.HeaderContentType) != cloudevents.ContentTypeStructuredJSON {
+		return message.Header.Get(cloudevents.HeaderPrefixNATS + "subject")
+	}
+	envelope := struct {
+		Subject string `json:"subject"`
+	}{}
+	if err := json.Unmarshal(message.Data, &envelope); err != nil {
+		return ""
+	}
+	return envelope.Subject
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: nats.go
+
+// Package invalidator is a generated GoMock package.
+package invalidator
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	nats "github.com/nats-io/nats.go"
+)
+
+// Mocksubscriber is a mock of subscriber interface.
+type Mocksubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MocksubscriberMockRecorder
+}
+
+// MocksubscriberMockRecorder is the mock recorder for Mocksubscriber.
+type MocksubscriberMockRecorder struct {
+	mock *Mocksubscriber
+}
+
+// NewMocksubscriber creates a new mock instance.
+func NewMocksubscriber(ctrl *gomock.Controller) *Mocksubscriber {
+	mock := &Mocksubscriber{ctrl: ctrl}
+	mock.recorder = &MocksubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocksubscriber) EXPECT() *MocksubscriberMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *Mocksubscriber) Subscribe(subject string, handler nats.MsgHandler) (*nats.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", subject, handler)
+	ret0, _ := ret[0].(*nats.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MocksubscriberMockRecorder) Subscribe(subject, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*Mocksubscriber)(nil).Subscribe), subject, handler)
+}
@@ -0,0 +1,91 @@
+package invalidator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/pkg/cloudevents"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/nats-io/nats.go"
+)
+
+func TestNATSInvalidator_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSubscriber := NewMocksubscriber(ctrl)
+	mockCache := NewMockcompanyCache(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	newMessage := func(headers map[string]string, data string) *nats.Msg {
+		message := nats.NewMsg("companies.updated")
+		for key, value := range headers {
+			message.Header.Set(key, value)
+		}
+		message.Data = []byte(data)
+		return message
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		message *nats.Msg
+	}{
+		{
+			name: "binary",
+			setup: func() {
+				mockCache.EXPECT().Invalidate(ctx, entity.UUID("first")).Return(nil)
+			},
+			message: newMessage(map[string]string{
+				cloudevents.HeaderContentType:            "application/json",
+				cloudevents.HeaderPrefixNATS + "subject": "first",
+			}, `{"subject": "second"}`),
+		},
+		{
+			name: "structured",
+			setup: func() {
+				mockCache.EXPECT().Invalidate(ctx, entity.UUID("second")).Return(nil)
+			},
+			message: newMessage(map[string]string{
+				cloudevents.HeaderContentType: cloudevents.ContentTypeStructuredJSON,
+			}, `{"subject": "second"}`),
+		},
+		{
+			name:  "malformed",
+			setup: func() {},
+			message: newMessage(map[string]string{
+				cloudevents.HeaderContentType: cloudevents.ContentTypeStructuredJSON,
+			}, `{`),
+		},
+		{
+			name: "cache error",
+			setup: func() {
+				mockCache.EXPECT().Invalidate(ctx, entity.UUID("first")).Return(errors.New("connection refused"))
+				logger.EXPECT().Warn("can't invalidate cached company", gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			message: newMessage(map[string]string{cloudevents.HeaderPrefixNATS + "subject": "first"}, ""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			var handler nats.MsgHandler
+			mockSubscriber.EXPECT().Subscribe("companies.>", gomock.Any()).
+				DoAndReturn(func(_ string, h nats.MsgHandler) (*nats.Subscription, error) {
+					handler = h
+					return nil, nil
+				})
+			i := &NATSInvalidator{
+				subscriber: mockSubscriber,
+				cache:      mockCache,
+				logger:     logger,
+				subject:    "companies",
+			}
+			if err := i.Start(ctx); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			handler(tt.message)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/log"
+	"golang.org/x/sync/singleflight"
+)
+
+//go:generate mockgen -source=company.go -package=cache -destination=company_mock.go
+
+type companyRepository interface {
+	Get(ctx context.Context, id entity.UUID) (*entity.Company, error)
+	ListPage(ctx context.Context, filter *entity.CompanyFilter) (*entity.CompanyList, error)
+	Estimate(ctx context.Context, filter *entity.CompanyFilter) (uint64, error)
+	Update(ctx context.Context, update *entity.Company) error
+	Create(ctx context.Context, create *entity.Company) error
+	Delete(ctx context.Context, id entity.UUID) error
+	Suggest(ctx context.Context, query string, threshold float64, limit uint64) ([]*entity.CompanySuggestion, error)
+}
+
+// store keeps the cached values until their ttl passes.
+type store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// CompanyRepository reads the companies through the store and forgets them on the writes.
+// A missing company is remembered as an empty value for the shorter negative ttl.
+// The store failures are logged and the companies are read from the repository then.
+type CompanyRepository struct {
+	companyRepository companyRepository
+	store             store
+	group             singleflight.Group
+	logger            log.Logger
+	ttl               time.Duration
+	negativeTTL       time.Duration
+	loadTimeout       time.Duration
+}
+
+func NewCompanyRepository(
+	companyRepository companyRepository,
+	store store,
+	config *configs.Config,
+	logger log.Logger,
+) *CompanyRepository {
+	return &CompanyRepository{
+		companyRepository: companyRepository,
+		store:             store,
+		logger:            logger,
+		ttl:               time.Duration(config.Cache.TTL) * time.Second,
+		negativeTTL:       time.Duration(config.Cache.NegativeTTL) * time.Second,
+		loadTimeout:       time.Duration(config.Database.ReadTimeout) * time.Millisecond,
+	}
+}
+
+// Get returns the cached company, the concurrent misses of the same company share one read.
+// Within a transaction the company is read from the repository, so it's consistent with the transaction.
+func (r *CompanyRepository) Get(ctx context.Context, id entity.UUID) (*entity.Company, error) {
+	if _, ok := postgresInterface.TxFromContext(ctx); ok {
+		return r.companyRepository.Get(ctx, id)
+	}
+	value, ok, err := r.store.Get(ctx, string(id))
+	if err != nil {
+		r.logger.Warn("can't read company from cache", log.Context(ctx), log.Error(err))
+	}
+	if !ok {
+		shared, err, _ := r.group.Do(string(id), func() (any, error) {
+			return r.load(ctx, id)
+		})
+		if err != nil {
+			return nil, err
+		}
+		value = shared.([]byte)
+	}
+	if len(value) == 0 {
+		return nil, errs.NewEntityNotFound().WithParam("company_id", string(id))
+	}
+	// Every caller decodes its own copy, so the cached company can't be changed by them.
+	company := &entity.Company{}
+	if err := json.Unmarshal(value, company); err != nil {
+		return nil, errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	return company, nil
+}
+
+// load reads the company from the primary and caches it, an empty value if it's not found,
+// so a lagging replica doesn't cache a stale company for the whole ttl.
+// The load is shared by the concurrent misses, so it doesn't stop when the first of them is canceled.
+func (r *CompanyRepository) load(ctx context.Context, id entity.UUID) ([]byte, error) {
+	ctx, cancel := postgresInterface.WithTimeout(postgresInterface.WithPrimary(detached{ctx}), r.loadTimeout)
+	defer cancel()
+	company, err := r.companyRepository.Get(ctx, id)
+	var e *errs.Error
+	if errors.As(err, &e) && e.Code == errs.ErrorCodeNotFound {
+		r.set(ctx, id, []byte{}, r.negativeTTL)
+		return []byte{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	value, err := json.Marshal(company)
+	if err != nil {
+		return nil, errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	r.set(ctx, id, value, r.ttl)
+	return value, nil
+}
+
+func (r *CompanyRepository) set(ctx context.Context, id entity.UUID, value []byte, ttl time.Duration) {
+	if err := r.store.Set(ctx, string(id), value, ttl); err != nil {
+		r.logger.Warn("can't write company to cache", log.Context(ctx), log.Error(err))
+	}
+}
+
+func (r *CompanyRepository) ListPage(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+) (*entity.CompanyList, error) {
+	return r.companyRepository.ListPage(ctx, filter)
+}
+
+func (r *CompanyRepository) Estimate(ctx context.Context, filter *entity.CompanyFilter) (uint64, error) {
+	return r.companyRepository.Estimate(ctx, filter)
+}
+
+func (r *CompanyRepository) Suggest(
+	ctx context.Context,
+	query string,
+	threshold float64,
+	limit uint64,
+) ([]*entity.CompanySuggestion, error) {
+	return r.companyRepository.Suggest(ctx, query, threshold, limit)
+}
+
+// Create forgets the company, a lookup of its id may have been cached as not found.
+func (r *CompanyRepository) Create(ctx context.Context, create *entity.Company) error {
+	if err := r.companyRepository.Create(ctx, create); err != nil {
+		return err
+	}
+	r.forget(ctx, create.ID)
+	return nil
+}
+
+// Update forgets the company once the transaction of ctx is committed,
+// so it isn't cached again from the snapshot before the update.
+func (r *CompanyRepository) Update(ctx context.Context, update *entity.Company) error {
+	if err := r.companyRepository.Update(ctx, update); err != nil {
+		return err
+	}
+	r.forget(ctx, update.ID)
+	return nil
+}
+
+func (r *CompanyRepository) Delete(ctx context.Context, id entity.UUID) error {
+	if err := r.companyRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.forget(ctx, id)
+	return nil
+}
+
+// Invalidate forgets the companies changed by another instance, it's called on their events.
+func (r *CompanyRepository) Invalidate(ctx context.Context, ids ...entity.UUID) error {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = string(id)
+	}
+	return r.store.Delete(ctx, keys...)
+}
+
+// forget deletes the company from the store after the commit of the transaction of ctx, if any.
+func (r *CompanyRepository) forget(ctx context.Context, id entity.UUID) {
+	postgresInterface.AfterCommit(ctx, func(ctx context.Context) {
+		if err := r.Invalidate(ctx, id); err != nil {
+			r.logger.Warn("can't delete company from cache", log.Context(ctx), log.Error(err))
+		}
+	})
+}
+
+// detached keeps the values of ctx without its deadline and cancellation.
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detached) Done() <-chan struct{} {
+	return nil
+}
+
+func (detached) Err() error {
+	return nil
+}
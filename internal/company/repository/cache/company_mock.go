@@ -0,0 +1,211 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: company.go
+
+// Package cache is a generated GoMock package.
+package cache
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockcompanyRepository is a mock of companyRepository interface.
+type MockcompanyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcompanyRepositoryMockRecorder
+}
+
+// MockcompanyRepositoryMockRecorder is the mock recorder for MockcompanyRepository.
+type MockcompanyRepositoryMockRecorder struct {
+	mock *MockcompanyRepository
+}
+
+// NewMockcompanyRepository creates a new mock instance.
+func NewMockcompanyRepository(ctrl *gomock.Controller) *MockcompanyRepository {
+	mock := &MockcompanyRepository{ctrl: ctrl}
+	mock.recorder = &MockcompanyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcompanyRepository) EXPECT() *MockcompanyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockcompanyRepository) Create(ctx context.Context, create *entity.Company) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, create)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockcompanyRepositoryMockRecorder) Create(ctx, create interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockcompanyRepository)(nil).Create), ctx, create)
+}
+
+// Delete mocks base method.
+func (m *MockcompanyRepository) Delete(ctx context.Context, id entity.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockcompanyRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockcompanyRepository)(nil).Delete), ctx, id)
+}
+
+// Estimate mocks base method.
+func (m *MockcompanyRepository) Estimate(ctx context.Context, filter *entity.CompanyFilter) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Estimate", ctx, filter)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Estimate indicates an expected call of Estimate.
+func (mr *MockcompanyRepositoryMockRecorder) Estimate(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Estimate", reflect.TypeOf((*MockcompanyRepository)(nil).Estimate), ctx, filter)
+}
+
+// Get mocks base method.
+func (m *MockcompanyRepository) Get(ctx context.Context, id entity.UUID) (*entity.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockcompanyRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockcompanyRepository)(nil).Get), ctx, id)
+}
+
+// ListPage mocks base method.
+func (m *MockcompanyRepository) ListPage(ctx context.Context, filter *entity.CompanyFilter) (*entity.CompanyList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPage", ctx, filter)
+	ret0, _ := ret[0].(*entity.CompanyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPage indicates an expected call of ListPage.
+func (mr *MockcompanyRepositoryMockRecorder) ListPage(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockcompanyRepository)(nil).ListPage), ctx, filter)
+}
+
+// Suggest mocks base method.
+func (m *MockcompanyRepository) Suggest(ctx context.Context, query string, threshold float64, limit uint64) ([]*entity.CompanySuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, query, threshold, limit)
+	ret0, _ := ret[0].([]*entity.CompanySuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockcompanyRepositoryMockRecorder) Suggest(ctx, query, threshold, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockcompanyRepository)(nil).Suggest), ctx, query, threshold, limit)
+}
+
+// Update mocks base method.
+func (m *MockcompanyRepository) Update(ctx context.Context, update *entity.Company) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockcompanyRepositoryMockRecorder) Update(ctx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockcompanyRepository)(nil).Update), ctx, update)
+}
+
+// Mockstore is a mock of store interface.
+type Mockstore struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreMockRecorder
+}
+
+// MockstoreMockRecorder is the mock recorder for Mockstore.
+type MockstoreMockRecorder struct {
+	mock *Mockstore
+}
+
+// NewMockstore creates a new mock instance.
+func NewMockstore(ctrl *gomock.Controller) *Mockstore {
+	mock := &Mockstore{ctrl: ctrl}
+	mock.recorder = &MockstoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstore) EXPECT() *MockstoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *Mockstore) Delete(ctx context.Context, keys ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockstoreMockRecorder) Delete(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*Mockstore)(nil).Delete), varargs...)
+}
+
+// Get mocks base method.
+func (m *Mockstore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockstoreMockRecorder) Get(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*Mockstore)(nil).Get), ctx, key)
+}
+
+// Set mocks base method.
+func (m *Mockstore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, key, value, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockstoreMockRecorder) Set(ctx, key, value, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*Mockstore)(nil).Set), ctx, key, value, ttl)
+}
@@ -0,0 +1,382 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func newCompany() *entity.Company {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &entity.Company{
+		ID:                "1f1f4b1e-0e0b-4d52-9a0c-5b0c3a3c7a11",
+		UpdatedAt:         now,
+		CreatedAt:         now,
+		Name:              "Acme",
+		Description:       "Anvils",
+		AmountOfEmployees: 10,
+		Registered:        true,
+		Type:              entity.CompanyTypeCorporations,
+		Version:           2,
+	}
+}
+
+// primaryContext matches the contexts of the loads, which read from the primary and aren't canceled with the callers.
+type primaryContext struct{}
+
+func (primaryContext) Matches(x any) bool {
+	ctx, ok := x.(context.Context)
+	return ok && ctx.Err() == nil && postgresInterface.PrimaryFromContext(ctx)
+}
+
+func (primaryContext) String() string {
+	return "is a context reading from the primary"
+}
+
+func TestNewCompanyRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockStore := NewMockstore(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	config := configs.NewMockConfig(t)
+	want := &CompanyRepository{
+		companyRepository: mockCompanyRepository,
+		store:             mockStore,
+		logger:            logger,
+		ttl:               time.Minute,
+		negativeTTL:       5 * time.Second,
+		loadTimeout:       time.Second,
+	}
+	if got := NewCompanyRepository(mockCompanyRepository, mockStore, config, logger); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewCompanyRepository() = %v, want %v", got, want)
+	}
+}
+
+func TestCompanyRepository_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockStore := NewMockstore(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	company := newCompany()
+	value, _ := json.Marshal(company)
+	key := string(company.ID)
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *entity.Company
+		wantErr error
+	}{
+		{
+			name: "cached",
+			setup: func() {
+				mockStore.EXPECT().Get(ctx, key).Return(value, true, nil)
+			},
+			want:    company,
+			wantErr: nil,
+		},
+		{
+			name: "read and cached",
+			setup: func() {
+				mockStore.EXPECT().Get(ctx, key).Return(nil, false, nil)
+				mockCompanyRepository.EXPECT().Get(primaryContext{}, company.ID).Return(newCompany(), nil)
+				mockStore.EXPECT().Set(primaryContext{}, key, value, time.Minute).Return(nil)
+			},
+			want:    company,
+			wantErr: nil,
+		},
+		{
+			name: "not found is cached",
+			setup: func() {
+				mockStore.EXPECT().Get(ctx, key).Return(nil, false, nil)
+				mockCompanyRepository.EXPECT().Get(primaryContext{}, company.ID).
+					Return(nil, errs.NewEntityNotFound().WithParam("company_id", key))
+				mockStore.EXPECT().Set(primaryContext{}, key, []byte{}, 5*time.Second).Return(nil)
+			},
+			want:    nil,
+			wantErr: errs.NewEntityNotFound().WithParam("company_id", key),
+		},
+		{
+			name: "cached not found",
+			setup: func() {
+				mockStore.EXPECT().Get(ctx, key).Return([]byte{}, true, nil)
+			},
+			want:    nil,
+			wantErr: errs.NewEntityNotFound().WithParam("company_id", key),
+		},
+		{
+			name: "repository error isn't cached",
+			setup: func() {
+				mockStore.EXPECT().Get(ctx, key).Return(nil, false, nil)
+				mockCompanyRepository.EXPECT().Get(primaryContext{}, company.ID).
+					Return(nil, errs.NewUnexpectedBehaviorError("test error"))
+			},
+			want:    nil,
+			wantErr: errs.NewUnexpectedBehaviorError("test error"),
+		},
+		{
+			name: "store errors",
+			setup: func() {
+				mockStore.EXPECT().Get(ctx, key).Return(nil, false, errors.New("connection refused"))
+				logger.EXPECT().Warn("can't read company from cache", gomock.Any(), gomock.Any())
+				mockCompanyRepository.EXPECT().Get(primaryContext{}, company.ID).Return(newCompany(), nil)
+				mockStore.EXPECT().Set(primaryContext{}, key, value, time.Minute).Return(errors.New("connection refused"))
+				logger.EXPECT().Warn("can't write company to cache", gomock.Any(), gomock.Any())
+			},
+			want:    company,
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &CompanyRepository{
+				companyRepository: mockCompanyRepository,
+				store:             mockStore,
+				logger:            logger,
+				ttl:               time.Minute,
+				negativeTTL:       5 * time.Second,
+			}
+			got, err := r.Get(ctx, company.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompanyRepository_Get_canceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockStore := NewMockstore(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	company := newCompany()
+	value, _ := json.Marshal(company)
+	ctx, cancel := context.WithCancel(context.Background())
+	// The caller is canceled while the company is read, the load shared with the others still completes.
+	mockStore.EXPECT().Get(ctx, string(company.ID)).Return(nil, false, nil)
+	mockCompanyRepository.EXPECT().Get(primaryContext{}, company.ID).
+		DoAndReturn(func(context.Context, entity.UUID) (*entity.Company, error) {
+			cancel()
+			return newCompany(), nil
+		})
+	mockStore.EXPECT().Set(primaryContext{}, string(company.ID), value, time.Minute).Return(nil)
+	r := NewCompanyRepository(mockCompanyRepository, mockStore, configs.NewMockConfig(t), logger)
+	got, err := r.Get(ctx, company.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, company) {
+		t.Errorf("Get() = %v, want %v", got, company)
+	}
+}
+
+func TestCompanyRepository_Get_concurrent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockStore := NewMockstore(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	company := newCompany()
+	const callers = 10
+	var waiting sync.WaitGroup
+	waiting.Add(callers)
+	mockStore.EXPECT().Get(ctx, string(company.ID)).
+		DoAndReturn(func(context.Context, string) ([]byte, bool, error) {
+			waiting.Done()
+			return nil, false, nil
+		}).
+		Times(callers)
+	mockCompanyRepository.EXPECT().Get(primaryContext{}, company.ID).
+		DoAndReturn(func(context.Context, entity.UUID) (*entity.Company, error) {
+			// Every caller has missed the cache before the company is read.
+			waiting.Wait()
+			time.Sleep(10 * time.Millisecond)
+			return newCompany(), nil
+		}).
+		MinTimes(1).
+		MaxTimes(callers)
+	mockStore.EXPECT().Set(primaryContext{}, string(company.ID), gomock.Any(), time.Minute).Return(nil).AnyTimes()
+	r := NewCompanyRepository(mockCompanyRepository, mockStore, configs.NewMockConfig(t), logger)
+	got := make([]*entity.Company, callers)
+	var done sync.WaitGroup
+	done.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func(i int) {
+			defer done.Done()
+			got[i], _ = r.Get(ctx, company.ID)
+		}(i)
+	}
+	done.Wait()
+	for i := range got {
+		if !reflect.DeepEqual(got[i], company) {
+			t.Errorf("Get() = %v, want %v", got[i], company)
+		}
+		if i > 0 && got[i] == got[0] {
+			t.Error("Get() shares the company between the callers")
+		}
+	}
+}
+
+func TestCompanyRepository_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockStore := NewMockstore(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	company := newCompany()
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCompanyRepository.EXPECT().Update(ctx, company).Return(nil)
+				mockStore.EXPECT().Delete(ctx, string(company.ID)).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "store error",
+			setup: func() {
+				mockCompanyRepository.EXPECT().Update(ctx, company).Return(nil)
+				mockStore.EXPECT().Delete(ctx, string(company.ID)).Return(errors.New("connection refused"))
+				logger.EXPECT().Warn("can't delete company from cache", gomock.Any(), gomock.Any())
+			},
+			wantErr: nil,
+		},
+		{
+			name: "repository error",
+			setup: func() {
+				mockCompanyRepository.EXPECT().Update(ctx, company).
+					Return(errs.NewUnexpectedBehaviorError("test error"))
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("test error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &CompanyRepository{
+				companyRepository: mockCompanyRepository,
+				store:             mockStore,
+				logger:            logger,
+			}
+			if err := r.Update(ctx, company); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompanyRepository_Update_transaction(t *testing.T) {
+	db, mock, err := postgresInterface.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockStore := NewMockstore(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	company := newCompany()
+	forgotten := false
+	mockCompanyRepository.EXPECT().Update(gomock.Any(), company).Return(nil)
+	mockStore.EXPECT().Delete(gomock.Any(), string(company.ID)).DoAndReturn(
+		func(context.Context, ...string) error {
+			forgotten = true
+			return nil
+		},
+	)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	r := &CompanyRepository{
+		companyRepository: mockCompanyRepository,
+		store:             mockStore,
+		logger:            logger,
+	}
+	txManager := postgresInterface.NewTxManager(db, &configs.Config{}, logger)
+	err = txManager.Transaction(context.Background(), func(ctx context.Context) error {
+		if err := r.Update(ctx, company); err != nil {
+			return err
+		}
+		// A read before the commit would cache the company again from the old snapshot.
+		if forgotten {
+			t.Error("Update() forgets the company before the commit")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !forgotten {
+		t.Error("Update() doesn't forget the company after the commit")
+	}
+}
+
+func TestCompanyRepository_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockStore := NewMockstore(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	id := newCompany().ID
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCompanyRepository.EXPECT().Delete(ctx, id).Return(nil)
+				mockStore.EXPECT().Delete(ctx, string(id)).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mockCompanyRepository.EXPECT().Delete(ctx, id).Return(errs.NewEntityNotFound())
+			},
+			wantErr: errs.NewEntityNotFound(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &CompanyRepository{
+				companyRepository: mockCompanyRepository,
+				store:             mockStore,
+				logger:            logger,
+			}
+			if err := r.Delete(ctx, id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
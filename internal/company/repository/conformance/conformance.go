@@ -0,0 +1,438 @@
+// Package conformance is the test suite of the company storages,
+// which proves they behave as the Postgres CompanyRepository.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// EmployeeBuckets are the bounds of the employees facet the repositories are configured with.
+var EmployeeBuckets = []int{0, 10, 50, 250, 1000}
+
+// Repository is a storage of the companies.
+type Repository interface {
+	Get(ctx context.Context, id entity.UUID) (*entity.Company, error)
+	ListPage(ctx context.Context, filter *entity.CompanyFilter) (*entity.CompanyList, error)
+	Estimate(ctx context.Context, filter *entity.CompanyFilter) (uint64, error)
+	ListAfter(ctx context.Context, filter *entity.CompanyFilter, after entity.UUID, limit uint64) ([]*entity.Company, error)
+	Suggest(ctx context.Context, query string, threshold float64, limit uint64) ([]*entity.CompanySuggestion, error)
+	Create(ctx context.Context, create *entity.Company) error
+	Update(ctx context.Context, update *entity.Company) error
+	Delete(ctx context.Context, id entity.UUID) error
+}
+
+// Run runs the suite, newRepository returns an empty repository configured with EmployeeBuckets.
+func Run(t *testing.T, newRepository func(t *testing.T) Repository) {
+	t.Run("create and get", func(t *testing.T) { testCreate(t, newRepository(t)) })
+	t.Run("update", func(t *testing.T) { testUpdate(t, newRepository(t)) })
+	t.Run("delete", func(t *testing.T) { testDelete(t, newRepository(t)) })
+	t.Run("list page", func(t *testing.T) { testListPage(t, newRepository(t)) })
+	t.Run("facets", func(t *testing.T) { testFacets(t, newRepository(t)) })
+	t.Run("estimate", func(t *testing.T) { testEstimate(t, newRepository(t)) })
+	t.Run("list after", func(t *testing.T) { testListAfter(t, newRepository(t)) })
+	t.Run("suggest", func(t *testing.T) { testSuggest(t, newRepository(t)) })
+}
+
+func newCompany(name string, description string, employees int, registered bool, companyType entity.CompanyType) *entity.Company {
+	// Postgres keeps the microseconds of the time only.
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC).Add(time.Duration(employees) * time.Hour)
+	return &entity.Company{
+		UpdatedAt:         now,
+		CreatedAt:         now,
+		Name:              name,
+		Description:       description,
+		AmountOfEmployees: employees,
+		Registered:        registered,
+		Type:              companyType,
+	}
+}
+
+// seed creates the companies of the listing tests and returns them by name.
+func seed(t *testing.T, r Repository) map[string]*entity.Company {
+	t.Helper()
+	companies := []*entity.Company{
+		newCompany("Acme", "Anvils and rockets", 5, true, entity.CompanyTypeCorporations),
+		newCompany("Bolt", "Bolts", 50, false, entity.CompanyTypeNonProfit),
+		newCompany("Crane", "Cranes and rockets", 120, true, entity.CompanyTypeNonProfit),
+		newCompany("Drill", "Drills", 300, false, entity.CompanyTypeCooperative),
+		newCompany("Engine", "Rocket engines", 1000, true, entity.CompanyTypeCorporations),
+	}
+	byName := map[string]*entity.Company{}
+	for _, company := range companies {
+		if err := r.Create(context.Background(), company); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		byName[company.Name] = company
+	}
+	return byName
+}
+
+// assertCompany compares the companies with their times as the instants.
+func assertCompany(t *testing.T, got, want *entity.Company) {
+	t.Helper()
+	if got == nil || !got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Errorf("company = %v, want %v", got, want)
+		return
+	}
+	g, w := *got, *want
+	g.CreatedAt, g.UpdatedAt, g.Search = w.CreatedAt, w.UpdatedAt, w.Search
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("company = %v, want %v", got, want)
+	}
+}
+
+func names(companies []*entity.Company) []string {
+	result := make([]string, len(companies))
+	for i, company := range companies {
+		result[i] = company.Name
+	}
+	return result
+}
+
+func notFound(id entity.UUID) error {
+	return errs.NewEntityNotFound().WithParam("company_id", string(id))
+}
+
+func testCreate(t *testing.T, r Repository) {
+	ctx := context.Background()
+	company := newCompany("Acme", "Anvils", 5, true, entity.CompanyTypeCorporations)
+	if err := r.Create(ctx, company); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := uuid.Parse(string(company.ID)); err != nil {
+		t.Errorf("Create() id = %q, want UUID", company.ID)
+	}
+	if company.Version != 1 {
+		t.Errorf("Create() version = %d, want 1", company.Version)
+	}
+	got, err := r.Get(ctx, company.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	assertCompany(t, got, company)
+	duplicate := newCompany("Acme", "Another", 1, false, entity.CompanyTypeNonProfit)
+	wantErr := errs.NewInvalidFormError().WithParam("companies_name_key", "Key (name)=(Acme) already exists.")
+	if err := r.Create(ctx, duplicate); !errors.Is(err, wantErr) {
+		t.Errorf("Create() error = %v, wantErr %v", err, wantErr)
+	}
+	missing := entity.UUID(uuid.NewString())
+	if _, err := r.Get(ctx, missing); !errors.Is(err, notFound(missing)) {
+		t.Errorf("Get() error = %v, wantErr %v", err, notFound(missing))
+	}
+}
+
+func testUpdate(t *testing.T, r Repository) {
+	ctx := context.Background()
+	companies := seed(t, r)
+	company := companies["Acme"]
+	company.Name = "Acme Rockets"
+	company.Description = "Rockets"
+	company.AmountOfEmployees = 7
+	company.Registered = false
+	company.Type = entity.CompanyTypeCooperative
+	if err := r.Update(ctx, company); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if company.Version != 2 {
+		t.Errorf("Update() version = %d, want 2", company.Version)
+	}
+	got, err := r.Get(ctx, company.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	// The update time is set by Postgres.
+	got.UpdatedAt = company.UpdatedAt
+	assertCompany(t, got, company)
+	company.Name = "Bolt"
+	wantErr := errs.NewInvalidFormError().
+		WithParam("companies_name_key", "Key (name)=(Bolt) already exists.").
+		WithParam("company_id", string(company.ID))
+	if err := r.Update(ctx, company); !errors.Is(err, wantErr) {
+		t.Errorf("Update() error = %v, wantErr %v", err, wantErr)
+	}
+	missing := newCompany("Missing", "", 1, false, entity.CompanyTypeNonProfit)
+	missing.ID = entity.UUID(uuid.NewString())
+	if err := r.Update(ctx, missing); !errors.Is(err, notFound(missing.ID)) {
+		t.Errorf("Update() error = %v, wantErr %v", err, notFound(missing.ID))
+	}
+}
+
+func testDelete(t *testing.T, r Repository) {
+	ctx := context.Background()
+	companies := seed(t, r)
+	id := companies["Bolt"].ID
+	if err := r.Delete(ctx, id); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := r.Get(ctx, id); !errors.Is(err, notFound(id)) {
+		t.Errorf("Get() error = %v, wantErr %v", err, notFound(id))
+	}
+	if err := r.Delete(ctx, id); !errors.Is(err, notFound(id)) {
+		t.Errorf("Delete() error = %v, wantErr %v", err, notFound(id))
+	}
+	if _, err := r.Get(ctx, companies["Acme"].ID); err != nil {
+		t.Errorf("Get() error = %v", err)
+	}
+}
+
+func testListPage(t *testing.T, r Repository) {
+	ctx := context.Background()
+	companies := seed(t, r)
+	byName := []string{"name ASC"}
+	tests := []struct {
+		name      string
+		filter    *entity.CompanyFilter
+		want      []string
+		wantCount *uint64
+	}{
+		{
+			name:      "default page size",
+			filter:    &entity.CompanyFilter{OrderBy: byName},
+			want:      []string{"Acme", "Bolt", "Crane", "Drill", "Engine"},
+			wantCount: utils.Pointer(uint64(5)),
+		},
+		{
+			name: "page",
+			filter: &entity.CompanyFilter{
+				OrderBy:    []string{"amount_of_employees DESC"},
+				PageSize:   utils.Pointer(uint64(2)),
+				PageNumber: utils.Pointer(uint64(2)),
+			},
+			want:      []string{"Crane", "Bolt"},
+			wantCount: utils.Pointer(uint64(5)),
+		},
+		{
+			name: "past the last page",
+			filter: &entity.CompanyFilter{
+				OrderBy:    byName,
+				PageSize:   utils.Pointer(uint64(2)),
+				PageNumber: utils.Pointer(uint64(4)),
+			},
+			want:      []string{},
+			wantCount: utils.Pointer(uint64(5)),
+		},
+		{
+			name: "order by several fields",
+			filter: &entity.CompanyFilter{
+				OrderBy: []string{"registered ASC", "type DESC", "name DESC"},
+			},
+			want:      []string{"Drill", "Bolt", "Crane", "Engine", "Acme"},
+			wantCount: utils.Pointer(uint64(5)),
+		},
+		{
+			name: "types and registered",
+			filter: &entity.CompanyFilter{
+				OrderBy:    byName,
+				Types:      []entity.CompanyType{entity.CompanyTypeNonProfit, entity.CompanyTypeCooperative},
+				Registered: utils.Pointer(false),
+			},
+			want:      []string{"Bolt", "Drill"},
+			wantCount: utils.Pointer(uint64(2)),
+		},
+		{
+			name: "ids",
+			filter: &entity.CompanyFilter{
+				OrderBy: byName,
+				IDs:     []entity.UUID{companies["Engine"].ID, companies["Acme"].ID},
+			},
+			want:      []string{"Acme", "Engine"},
+			wantCount: utils.Pointer(uint64(2)),
+		},
+		{
+			name: "filter expression",
+			filter: &entity.CompanyFilter{
+				OrderBy: byName,
+				Filter:  utils.Pointer(`amount_of_employees >= 50 AND (name:"*r*" OR registered = false)`),
+			},
+			want:      []string{"Bolt", "Crane", "Drill"},
+			wantCount: utils.Pointer(uint64(3)),
+		},
+		{
+			name: "filter by time",
+			filter: &entity.CompanyFilter{
+				OrderBy: byName,
+				Filter:  utils.Pointer(`created_at < "2026-03-10T00:00:00Z" AND NOT description:"bolt*"`),
+			},
+			want:      []string{"Acme", "Crane"},
+			wantCount: utils.Pointer(uint64(2)),
+		},
+		{
+			name: "search",
+			filter: &entity.CompanyFilter{
+				OrderBy: byName,
+				Search:  utils.Pointer("rocket"),
+			},
+			want:      []string{"Acme", "Crane", "Engine"},
+			wantCount: utils.Pointer(uint64(3)),
+		},
+		{
+			name: "count skipped",
+			filter: &entity.CompanyFilter{
+				OrderBy:  byName,
+				PageSize: utils.Pointer(uint64(1)),
+				Count:    utils.Pointer(entity.CompanyCountNone),
+			},
+			want:      []string{"Acme"},
+			wantCount: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.ListPage(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("ListPage() error = %v", err)
+			}
+			if !reflect.DeepEqual(names(got.Items), tt.want) {
+				t.Errorf("ListPage() = %v, want %v", names(got.Items), tt.want)
+			}
+			if !reflect.DeepEqual(got.Count, tt.wantCount) {
+				t.Errorf("ListPage() count = %v, want %v", got.Count, tt.wantCount)
+			}
+			for _, company := range got.Items {
+				assertCompany(t, company, companies[company.Name])
+				if (company.Search != nil) != (tt.filter.Search != nil) {
+					t.Errorf("ListPage() search = %v, want it with the search only", company.Search)
+				}
+			}
+		})
+	}
+}
+
+func testFacets(t *testing.T, r Repository) {
+	ctx := context.Background()
+	seed(t, r)
+	filter := &entity.CompanyFilter{
+		OrderBy:    []string{"name ASC"},
+		PageSize:   utils.Pointer(uint64(1)),
+		Types:      []entity.CompanyType{entity.CompanyTypeCorporations},
+		Registered: utils.Pointer(true),
+		Facets: []entity.CompanyFacet{
+			entity.CompanyFacetType,
+			entity.CompanyFacetRegistered,
+			entity.CompanyFacetEmployees,
+		},
+	}
+	employees := entity.NewCompanyStatsBuckets(EmployeeBuckets)
+	employees[1].Count = 1
+	employees[5].Count = 1
+	want := &entity.CompanyFacets{
+		Types: []*entity.CompanyTypeFacet{
+			{Type: entity.CompanyTypeCorporations, Count: 2},
+			{Type: entity.CompanyTypeNonProfit, Count: 1},
+		},
+		Registered: []*entity.CompanyRegisteredFacet{
+			{Registered: true, Count: 2},
+		},
+		Employees: employees,
+	}
+	got, err := r.ListPage(ctx, filter)
+	if err != nil {
+		t.Fatalf("ListPage() error = %v", err)
+	}
+	if !reflect.DeepEqual(names(got.Items), []string{"Acme"}) {
+		t.Errorf("ListPage() = %v, want [Acme]", names(got.Items))
+	}
+	if !reflect.DeepEqual(got.Facets, want) {
+		t.Errorf("ListPage() facets = %v, want %v", got.Facets, want)
+	}
+	// The facets are counted on an empty page too.
+	filter.PageNumber = utils.Pointer(uint64(3))
+	got, err = r.ListPage(ctx, filter)
+	if err != nil {
+		t.Fatalf("ListPage() error = %v", err)
+	}
+	if len(got.Items) != 0 || !reflect.DeepEqual(got.Facets, want) || got.Count == nil || *got.Count != 2 {
+		t.Errorf("ListPage() = %v, %v, %v, want no companies of 2 with the facets", got.Items, got.Count, got.Facets)
+	}
+}
+
+func testEstimate(t *testing.T, r Repository) {
+	ctx := context.Background()
+	seed(t, r)
+	// The planner's estimates are not exact, the storages without a planner count exactly.
+	for _, filter := range []*entity.CompanyFilter{{}, {Registered: utils.Pointer(true)}} {
+		if _, err := r.Estimate(ctx, filter); err != nil {
+			t.Errorf("Estimate() error = %v", err)
+		}
+	}
+	if _, err := r.Estimate(ctx, &entity.CompanyFilter{Filter: utils.Pointer("name:")}); err == nil {
+		t.Error("Estimate() error = nil on the invalid filter")
+	}
+}
+
+func testListAfter(t *testing.T, r Repository) {
+	ctx := context.Background()
+	seed(t, r)
+	var ids []entity.UUID
+	var after entity.UUID
+	for {
+		page, err := r.ListAfter(ctx, &entity.CompanyFilter{Registered: utils.Pointer(true)}, after, 2)
+		if err != nil {
+			t.Fatalf("ListAfter() error = %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, company := range page {
+			if company.ID <= after {
+				t.Errorf("ListAfter() = %v after %v, want ordered by id", company.ID, after)
+			}
+			ids = append(ids, company.ID)
+			after = company.ID
+		}
+	}
+	if len(ids) != 3 {
+		t.Errorf("ListAfter() = %v, want the 3 registered companies", ids)
+	}
+}
+
+func testSuggest(t *testing.T, r Repository) {
+	ctx := context.Background()
+	companies := seed(t, r)
+	for _, name := range []string{"Acmeco", "Bacme"} {
+		company := newCompany(name, "", 1, false, entity.CompanyTypeNonProfit)
+		if err := r.Create(ctx, company); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		companies[name] = company
+	}
+	tests := []struct {
+		name      string
+		query     string
+		threshold float64
+		limit     uint64
+		want      []string
+	}{
+		{name: "prefix", query: "acm", threshold: 0.3, limit: 10, want: []string{"Acme", "Acmeco"}},
+		{name: "similar", query: "acm", threshold: 0.2, limit: 10, want: []string{"Acme", "Acmeco", "Bacme"}},
+		{name: "limit", query: "acm", threshold: 0.2, limit: 1, want: []string{"Acme"}},
+		{name: "nothing similar", query: "zzz", threshold: 0.3, limit: 10, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Suggest(ctx, tt.query, tt.threshold, tt.limit)
+			if err != nil {
+				t.Fatalf("Suggest() error = %v", err)
+			}
+			gotNames := make([]string, len(got))
+			for i, suggestion := range got {
+				gotNames[i] = suggestion.Name
+				if suggestion.ID != companies[suggestion.Name].ID {
+					t.Errorf("Suggest() id = %v, want %v", suggestion.ID, companies[suggestion.Name].ID)
+				}
+			}
+			if !reflect.DeepEqual(gotNames, tt.want) {
+				t.Errorf("Suggest() = %v, want %v", gotNames, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/filtering"
+	"github.com/018bf/companies/pkg/textsearch"
+	"github.com/018bf/companies/pkg/utils"
+	"github.com/google/uuid"
+)
+
+const pageSize = uint64(10)
+
+// CompanyRepository keeps the companies in process, for the demos and the tests.
+// It filters, orders and pages them as the Postgres repository does, the search matches the words
+// of the query as substrings and the estimates are exact.
+type CompanyRepository struct {
+	mu              sync.RWMutex
+	companies       map[entity.UUID]entity.Company
+	employeeBuckets []int
+}
+
+func NewCompanyRepository(config *configs.Config) *CompanyRepository {
+	return &CompanyRepository{
+		companies:       map[entity.UUID]entity.Company{},
+		employeeBuckets: config.Stats.EmployeeBuckets,
+	}
+}
+
+func (r *CompanyRepository) Create(_ context.Context, company *entity.Company) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.unique(company.Name, ""); err != nil {
+		return err
+	}
+	stored := *company
+	stored.ID = entity.UUID(uuid.NewString())
+	stored.Version = 1
+	stored.CreatedAt = truncate(company.CreatedAt)
+	stored.UpdatedAt = truncate(company.UpdatedAt)
+	stored.Search = nil
+	r.companies[stored.ID] = stored
+	company.ID = stored.ID
+	company.Version = stored.Version
+	return nil
+}
+
+func (r *CompanyRepository) Get(_ context.Context, id entity.UUID) (*entity.Company, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	company, ok := r.companies[id]
+	if !ok {
+		return nil, errs.NewEntityNotFound().WithParam("company_id", string(id))
+	}
+	return &company, nil
+}
+
+// ListPage returns the page of the companies matching filter with the facets it requests.
+func (r *CompanyRepository) ListPage(
+	_ context.Context,
+	filter *entity.CompanyFilter,
+) (*entity.CompanyList, error) {
+	expr, err := filter.Expression()
+	if err != nil {
+		return nil, err
+	}
+	if filter.PageSize == nil {
+		filter.PageSize = utils.Pointer(pageSize)
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	companies := r.matching(filter, expr)
+	sortCompanies(companies, pageOrder(filter))
+	list := &entity.CompanyList{Items: page(companies, filter)}
+	if filter.CountMode() == entity.CompanyCountExact {
+		list.Count = utils.Pointer(uint64(len(companies)))
+	}
+	if len(filter.Facets) > 0 {
+		list.Facets = r.facets(filter, expr)
+	}
+	return list, nil
+}
+
+func (r *CompanyRepository) Count(_ context.Context, filter *entity.CompanyFilter) (uint64, error) {
+	expr, err := filter.Expression()
+	if err != nil {
+		return 0, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return uint64(len(r.matching(filter, expr))), nil
+}
+
+// Estimate counts the companies exactly, there's no planner to estimate it.
+func (r *CompanyRepository) Estimate(ctx context.Context, filter *entity.CompanyFilter) (uint64, error) {
+	return r.Count(ctx, filter)
+}
+
+// ListAfter returns up to limit companies matching filter with ID greater than after, ordered by ID.
+func (r *CompanyRepository) ListAfter(
+	_ context.Context,
+	filter *entity.CompanyFilter,
+	after entity.UUID,
+	limit uint64,
+) ([]*entity.Company, error) {
+	expr, err := filter.Expression()
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	companies := r.matching(filter, expr)
+	sortCompanies(companies, []string{"id"})
+	result := make([]*entity.Company, 0, limit)
+	for _, company := range companies {
+		if company.ID > after && uint64(len(result)) < limit {
+			result = append(result, company)
+		}
+	}
+	return result, nil
+}
+
+// Suggest returns up to limit companies whose name starts with the query or is similar to it.
+// The companies matched by the prefix go first, then the most similar ones.
+func (r *CompanyRepository) Suggest(
+	_ context.Context,
+	query string,
+	threshold float64,
+	limit uint64,
+) ([]*entity.CompanySuggestion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var suggestions []*entity.CompanySuggestion
+	prefixed := map[entity.UUID]bool{}
+	for _, company := range r.companies {
+		score := textsearch.WordSimilarity(query, company.Name)
+		prefixed[company.ID] = strings.HasPrefix(strings.ToLower(company.Name), strings.ToLower(query))
+		if prefixed[company.ID] || score >= threshold {
+			suggestions = append(suggestions, &entity.CompanySuggestion{ID: company.ID, Name: company.Name, Score: score})
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		switch {
+		case prefixed[a.ID] != prefixed[b.ID]:
+			return prefixed[a.ID]
+		case a.Score != b.Score:
+			return a.Score > b.Score
+		}
+		return a.Name < b.Name
+	})
+	if uint64(len(suggestions)) > limit {
+		suggestions = suggestions[:limit]
+	}
+	if suggestions == nil {
+		suggestions = []*entity.CompanySuggestion{}
+	}
+	return suggestions, nil
+}
+
+func (r *CompanyRepository) Update(_ context.Context, company *entity.Company) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.companies[company.ID]
+	if !ok {
+		return errs.NewEntityNotFound().WithParam("company_id", string(company.ID))
+	}
+	if err := r.unique(company.Name, company.ID); err != nil {
+		return err.WithParam("company_id", string(company.ID))
+	}
+	stored.UpdatedAt = truncate(company.UpdatedAt)
+	stored.Name = company.Name
+	stored.Description = company.Description
+	stored.AmountOfEmployees = company.AmountOfEmployees
+	stored.Registered = company.Registered
+	stored.Type = company.Type
+	stored.Version++
+	r.companies[company.ID] = stored
+	company.Version = stored.Version
+	return nil
+}
+
+func (r *CompanyRepository) Delete(_ context.Context, id entity.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.companies[id]; !ok {
+		return errs.NewEntityNotFound().WithParam("company_id", string(id))
+	}
+	delete(r.companies, id)
+	return nil
+}
+
+// unique fails as the unique constraint of Postgres when another company than id has the name.
+func (r *CompanyRepository) unique(name string, id entity.UUID) *errs.Error {
+	for _, company := range r.companies {
+		if company.Name == name && company.ID != id {
+			return errs.NewInvalidFormError().
+				WithParam("companies_name_key", fmt.Sprintf("Key (name)=(%s) already exists.", name))
+		}
+	}
+	return nil
+}
+
+// matching returns the copies of the companies matching filter, with the rank of the search when it's set.
+func (r *CompanyRepository) matching(filter *entity.CompanyFilter, expr filtering.Expr) []*entity.Company {
+	var query textsearch.Query
+	if filter.Search != nil {
+		query = textsearch.NewQuery(*filter.Search)
+	}
+	var companies []*entity.Company
+	for _, company := range r.companies {
+		company := company
+		if !matches(&company, filter, expr, query) {
+			continue
+		}
+		if filter.Search != nil {
+			company.Search = &entity.CompanySearch{
+				Score:               query.Rank(company.Name, company.Description),
+				NameHeadline:        query.Headline(company.Name),
+				DescriptionHeadline: query.Headline(company.Description),
+			}
+		}
+		companies = append(companies, &company)
+	}
+	return companies
+}
+
+func matches(
+	company *entity.Company,
+	filter *entity.CompanyFilter,
+	expr filtering.Expr,
+	query textsearch.Query,
+) bool {
+	if len(filter.IDs) > 0 && !contains(filter.IDs, company.ID) {
+		return false
+	}
+	if len(filter.Types) > 0 && !contains(filter.Types, company.Type) {
+		return false
+	}
+	if filter.Registered != nil && *filter.Registered != company.Registered {
+		return false
+	}
+	if filter.Search != nil && !query.Matches(company.Name, company.Description) {
+		return false
+	}
+	return filtering.Match(expr, map[string]any{
+		"name":                company.Name,
+		"description":         company.Description,
+		"amount_of_employees": int64(company.AmountOfEmployees),
+		"registered":          company.Registered,
+		"type":                int64(company.Type),
+		"created_at":          company.CreatedAt,
+		"updated_at":          company.UpdatedAt,
+	})
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// facets counts the companies matching filter without the dimension of each requested facet.
+func (r *CompanyRepository) facets(filter *entity.CompanyFilter, expr filtering.Expr) *entity.CompanyFacets {
+	facets := &entity.CompanyFacets{}
+	for _, facet := range filter.Facets {
+		without := *filter
+		switch facet {
+		case entity.CompanyFacetType:
+			without.Types = nil
+			counts := map[entity.CompanyType]uint64{}
+			for _, company := range r.matching(&without, filtering.Without(expr, "type")) {
+				counts[company.Type]++
+			}
+			facets.Types = []*entity.CompanyTypeFacet{}
+			for companyType, count := range counts {
+				facets.Types = append(facets.Types, &entity.CompanyTypeFacet{Type: companyType, Count: count})
+			}
+			sort.Slice(facets.Types, func(i, j int) bool { return facets.Types[i].Type < facets.Types[j].Type })
+		case entity.CompanyFacetRegistered:
+			without.Registered = nil
+			counts := map[bool]uint64{}
+			for _, company := range r.matching(&without, filtering.Without(expr, "registered")) {
+				counts[company.Registered]++
+			}
+			facets.Registered = []*entity.CompanyRegisteredFacet{}
+			for _, registered := range []bool{false, true} {
+				if count, ok := counts[registered]; ok {
+					facets.Registered = append(facets.Registered, &entity.CompanyRegisteredFacet{Registered: registered, Count: count})
+				}
+			}
+		case entity.CompanyFacetEmployees:
+			facets.Employees = entity.NewCompanyStatsBuckets(r.employeeBuckets)
+			for _, company := range r.matching(&without, filtering.Without(expr, "amount_of_employees")) {
+				facets.Employees[entity.CompanyStatsBucketOf(r.employeeBuckets, company.AmountOfEmployees)].Count++
+			}
+		}
+	}
+	return facets
+}
+
+// pageOrder returns the order of filter, by the rank of the search or by ID without one.
+func pageOrder(filter *entity.CompanyFilter) []string {
+	switch {
+	case len(filter.OrderBy) > 0:
+		return filter.OrderBy
+	case filter.Search != nil:
+		return []string{"score DESC", "id"}
+	default:
+		return []string{"id"}
+	}
+}
+
+// sortCompanies sorts the companies by the fields of the order, each optionally followed by ASC or DESC.
+func sortCompanies(companies []*entity.Company, order []string) {
+	sort.SliceStable(companies, func(i, j int) bool {
+		for _, term := range order {
+			field, direction, _ := strings.Cut(term, " ")
+			c := compare(companies[i], companies[j], field)
+			if direction == "DESC" {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+func compare(a, b *entity.Company, field string) int {
+	switch field {
+	case "id":
+		return strings.Compare(string(a.ID), string(b.ID))
+	case "updated_at":
+		return a.UpdatedAt.Compare(b.UpdatedAt)
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	case "description":
+		return strings.Compare(a.Description, b.Description)
+	case "amount_of_employees":
+		return a.AmountOfEmployees - b.AmountOfEmployees
+	case "registered":
+		switch {
+		case a.Registered == b.Registered:
+			return 0
+		case b.Registered:
+			return -1
+		}
+		return 1
+	case "type":
+		return int(a.Type) - int(b.Type)
+	case "score":
+		switch {
+		case a.Search == nil || b.Search == nil || a.Search.Score == b.Search.Score:
+			return 0
+		case a.Search.Score < b.Search.Score:
+			return -1
+		}
+		return 1
+	default:
+		return 0
+	}
+}
+
+func page(companies []*entity.Company, filter *entity.CompanyFilter) []*entity.Company {
+	offset := uint64(0)
+	if filter.PageNumber != nil && *filter.PageNumber > 1 {
+		offset = (*filter.PageNumber - 1) * *filter.PageSize
+	}
+	if offset >= uint64(len(companies)) {
+		return []*entity.Company{}
+	}
+	end := offset + *filter.PageSize
+	if end > uint64(len(companies)) {
+		end = uint64(len(companies))
+	}
+	return companies[offset:end]
+}
+
+// truncate keeps the microseconds of the time in UTC, as the timestamp of Postgres does.
+func truncate(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Microsecond)
+}
@@ -0,0 +1,16 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/018bf/companies/internal/company/repository/conformance"
+	"github.com/018bf/companies/internal/configs"
+)
+
+func TestCompanyRepository(t *testing.T) {
+	conformance.Run(t, func(t *testing.T) conformance.Repository {
+		config := configs.NewMockConfig(t)
+		config.Stats.EmployeeBuckets = conformance.EmployeeBuckets
+		return NewCompanyRepository(config)
+	})
+}
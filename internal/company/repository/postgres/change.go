@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/log"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type ChangeRepository struct {
+	database       *sqlx.DB
+	logger         log.Logger
+	searchLanguage string
+	readTimeout    time.Duration
+	listTimeout    time.Duration
+	writeTimeout   time.Duration
+}
+
+func NewChangeRepository(database *sqlx.DB, config *configs.Config, logger log.Logger) *ChangeRepository {
+	return &ChangeRepository{
+		database:       database,
+		logger:         logger,
+		searchLanguage: config.Search.Language,
+		readTimeout:    time.Duration(config.Database.ReadTimeout) * time.Millisecond,
+		listTimeout:    time.Duration(config.Database.ListTimeout) * time.Millisecond,
+		writeTimeout:   time.Duration(config.Database.WriteTimeout) * time.Millisecond,
+	}
+}
+
+// List returns up to limit changes after the position, which match filter.
+// The filter is applied to the state of the company recorded with the change.
+func (r *ChangeRepository) List(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+	after uint64,
+	limit uint64,
+) ([]*entity.CompanyChange, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	watermark, err := r.watermark(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var dto CompanyChangeListDTO
+	q := selectChanges().
+		Where(sq.Gt{"changes.position": after}).
+		Where(sq.LtOrEq{"changes.position": watermark}).
+		OrderBy("changes.position").
+		Limit(limit)
+	q, err = applyFilter(q, filter, r.searchLanguage)
+	if err != nil {
+		return nil, err
+	}
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.database.SelectContext(ctx, &dto, query, args...); err != nil {
+		e := errs.FromPostgresError(err)
+		return nil, e
+	}
+	return dto.ToModels(), nil
+}
+
+// ListLatest returns up to limit changes after the position, which are the latest changes of their companies.
+// Superseded changes are skipped, so a company appears once with its current state or as a tombstone.
+func (r *ChangeRepository) ListLatest(
+	ctx context.Context,
+	after uint64,
+	limit uint64,
+) ([]*entity.CompanyChange, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	watermark, err := r.watermark(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var dto CompanyChangeListDTO
+	q := selectChanges().
+		Where(sq.Gt{"changes.position": after}).
+		Where(sq.LtOrEq{"changes.position": watermark}).
+		Where("NOT EXISTS (SELECT 1 FROM public.company_changes AS later WHERE later.company_id = changes.company_id AND later.position > changes.position)").
+		OrderBy("changes.position").
+		Limit(limit)
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.database.SelectContext(ctx, &dto, query, args...); err != nil {
+		e := errs.FromPostgresError(err)
+		return nil, e
+	}
+	return dto.ToModels(), nil
+}
+
+// Prune deletes the tombstones and the superseded changes made before the time.
+// The latest change of an existing company is kept, so the feed can always be read from the start.
+func (r *ChangeRepository) Prune(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+	query, args := sq.Delete("public.company_changes AS changes").
+		Where(sq.Lt{"changes.changed_at": before}).
+		Where(sq.Or{
+			sq.Eq{"changes.operation": string(entity.EventTypeDeleted)},
+			sq.Expr("EXISTS (SELECT 1 FROM public.company_changes AS later WHERE later.company_id = changes.company_id AND later.position > changes.position)"),
+		}).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+	result, err := r.database.ExecContext(ctx, query, args...)
+	if err != nil {
+		e := errs.FromPostgresError(err)
+		return 0, e
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		e := errs.FromPostgresError(err)
+		return 0, e
+	}
+	return affected, nil
+}
+
+// LastPosition returns the position of the latest committed change or zero if there are none.
+func (r *ChangeRepository) LastPosition(ctx context.Context) (uint64, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+	return r.watermark(ctx)
+}
+
+// watermark returns the position up to which all changes are committed.
+// The positions are taken at commit, so a later one may become visible before an earlier one.
+// It runs in its own transaction, so the changes read after it are seen by a new snapshot.
+func (r *ChangeRepository) watermark(ctx context.Context) (uint64, error) {
+	var watermark uint64
+	if err := r.database.QueryRowxContext(ctx, "SELECT company_changes_watermark()").Scan(&watermark); err != nil {
+		e := errs.FromPostgresError(err)
+		return 0, e
+	}
+	return watermark, nil
+}
+
+func selectChanges() sq.SelectBuilder {
+	return sq.Select(
+		"changes.position",
+		"changes.operation",
+		"changes.changed_at",
+		"companies.id",
+		"companies.updated_at",
+		"companies.created_at",
+		"companies.name",
+		"companies.description",
+		"companies.amount_of_employees",
+		"companies.registered",
+		"companies.type",
+		"companies.version",
+	).
+		From("public.company_changes AS changes").
+		Join("jsonb_populate_record(NULL::public.companies, changes.company) AS companies ON true")
+}
+
+type CompanyChangeDTO struct {
+	Position  uint64    `db:"position"`
+	Operation string    `db:"operation"`
+	ChangedAt time.Time `db:"changed_at"`
+	CompanyDTO
+}
+type CompanyChangeListDTO []*CompanyChangeDTO
+
+func (list CompanyChangeListDTO) ToModels() []*entity.CompanyChange {
+	changes := make([]*entity.CompanyChange, len(list))
+	for i := range list {
+		changes[i] = list[i].ToModel()
+	}
+	return changes
+}
+func (dto *CompanyChangeDTO) ToModel() *entity.CompanyChange {
+	company := dto.CompanyDTO.ToModel()
+	return &entity.CompanyChange{
+		Position:  dto.Position,
+		Operation: entity.EventOperation(dto.Operation),
+		CompanyID: company.ID,
+		ChangedAt: dto.ChangedAt,
+		Company:   company,
+	}
+}
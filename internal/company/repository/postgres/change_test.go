@@ -0,0 +1,356 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/018bf/companies/pkg/utils"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestChangeRepository_List(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	changes := []*entity.CompanyChange{mock_models.NewCompanyChange(t), mock_models.NewCompanyChange(t)}
+	filter := &entity.CompanyFilter{Registered: utils.Pointer(true)}
+	query := regexp.QuoteMeta("SELECT changes.position, changes.operation, changes.changed_at, companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type, companies.version FROM public.company_changes AS changes JOIN jsonb_populate_record(NULL::public.companies, changes.company) AS companies ON true WHERE changes.position > $1 AND changes.position <= $2 AND companies.registered = $3 ORDER BY changes.position LIMIT 100")
+	ids := []entity.UUID{changes[0].Company.ID, changes[1].Company.ID}
+	idsQuery := regexp.QuoteMeta("SELECT changes.position, changes.operation, changes.changed_at, companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type, companies.version FROM public.company_changes AS changes JOIN jsonb_populate_record(NULL::public.companies, changes.company) AS companies ON true WHERE changes.position > $1 AND changes.position <= $2 AND companies.id IN ($3,$4) ORDER BY changes.position LIMIT 100")
+	watermark := regexp.QuoteMeta("SELECT company_changes_watermark()")
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.CompanyFilter
+		after  uint64
+		limit  uint64
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		want    []*entity.CompanyChange
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectQuery(watermark).
+					WillReturnRows(sqlmock.NewRows([]string{"company_changes_watermark"}).AddRow(50))
+				mock.ExpectQuery(query).
+					WithArgs(uint64(10), uint64(50), true).
+					WillReturnRows(newChangeRows(t, changes))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: filter,
+				after:  10,
+				limit:  100,
+			},
+			want:    changes,
+			wantErr: nil,
+		},
+		{
+			name: "ids",
+			setup: func() {
+				mock.ExpectQuery(watermark).
+					WillReturnRows(sqlmock.NewRows([]string{"company_changes_watermark"}).AddRow(50))
+				mock.ExpectQuery(idsQuery).
+					WithArgs(uint64(10), uint64(50), ids[0], ids[1]).
+					WillReturnRows(newChangeRows(t, changes))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: &entity.CompanyFilter{IDs: ids},
+				after:  10,
+				limit:  100,
+			},
+			want:    changes,
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(watermark).
+					WillReturnRows(sqlmock.NewRows([]string{"company_changes_watermark"}).AddRow(50))
+				mock.ExpectQuery(query).
+					WithArgs(uint64(10), uint64(50), true).
+					WillReturnError(errors.New("test error"))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: filter,
+				after:  10,
+				limit:  100,
+			},
+			want:    nil,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &ChangeRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			got, err := r.List(tt.args.ctx, tt.args.filter, tt.args.after, tt.args.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ChangeRepository.List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChangeRepository.List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeRepository_ListLatest(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	changes := []*entity.CompanyChange{mock_models.NewCompanyChange(t), mock_models.NewCompanyChange(t)}
+	query := regexp.QuoteMeta("SELECT changes.position, changes.operation, changes.changed_at, companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type, companies.version FROM public.company_changes AS changes JOIN jsonb_populate_record(NULL::public.companies, changes.company) AS companies ON true WHERE changes.position > $1 AND changes.position <= $2 AND NOT EXISTS (SELECT 1 FROM public.company_changes AS later WHERE later.company_id = changes.company_id AND later.position > changes.position) ORDER BY changes.position LIMIT 101")
+	watermark := regexp.QuoteMeta("SELECT company_changes_watermark()")
+	tests := []struct {
+		name    string
+		setup   func()
+		want    []*entity.CompanyChange
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectQuery(watermark).
+					WillReturnRows(sqlmock.NewRows([]string{"company_changes_watermark"}).AddRow(50))
+				mock.ExpectQuery(query).
+					WithArgs(uint64(10), uint64(50)).
+					WillReturnRows(newChangeRows(t, changes))
+			},
+			want:    changes,
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(watermark).
+					WillReturnRows(sqlmock.NewRows([]string{"company_changes_watermark"}).AddRow(50))
+				mock.ExpectQuery(query).
+					WithArgs(uint64(10), uint64(50)).
+					WillReturnError(errors.New("test error"))
+			},
+			want:    nil,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &ChangeRepository{
+				database: db,
+				logger:   logger,
+			}
+			got, err := r.ListLatest(ctx, 10, 101)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ChangeRepository.ListLatest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChangeRepository.ListLatest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeRepository_Prune(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	before := time.Now().UTC()
+	query := regexp.QuoteMeta("DELETE FROM public.company_changes AS changes WHERE changes.changed_at < $1 AND (changes.operation = $2 OR EXISTS (SELECT 1 FROM public.company_changes AS later WHERE later.company_id = changes.company_id AND later.position > changes.position))")
+	tests := []struct {
+		name    string
+		setup   func()
+		want    int64
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectExec(query).
+					WithArgs(before, "deleted").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			want:    3,
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectExec(query).
+					WithArgs(before, "deleted").
+					WillReturnError(errors.New("test error"))
+			},
+			want:    0,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &ChangeRepository{
+				database: db,
+				logger:   logger,
+			}
+			got, err := r.Prune(ctx, before)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ChangeRepository.Prune() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ChangeRepository.Prune() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeRepository_LastPosition(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	query := regexp.QuoteMeta("SELECT company_changes_watermark()")
+	tests := []struct {
+		name    string
+		setup   func()
+		want    uint64
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WillReturnRows(sqlmock.NewRows([]string{"company_changes_watermark"}).AddRow(42))
+			},
+			want:    42,
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WillReturnError(errors.New("test error"))
+			},
+			want:    0,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &ChangeRepository{
+				database: db,
+				logger:   logger,
+			}
+			got, err := r.LastPosition(ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ChangeRepository.LastPosition() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ChangeRepository.LastPosition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func newChangeRows(t *testing.T, changes []*entity.CompanyChange) *sqlmock.Rows {
+	t.Helper()
+	rows := sqlmock.NewRows([]string{
+		"position",
+		"operation",
+		"changed_at",
+		"id",
+		"updated_at",
+		"created_at",
+		"name",
+		"description",
+		"amount_of_employees",
+		"registered",
+		"type",
+		"version",
+	})
+	for _, change := range changes {
+		rows.AddRow(
+			change.Position,
+			change.Operation,
+			change.ChangedAt,
+			change.Company.ID,
+			change.Company.UpdatedAt,
+			change.Company.CreatedAt,
+			change.Company.Name,
+			change.Company.Description,
+			change.Company.AmountOfEmployees,
+			change.Company.Registered,
+			change.Company.Type,
+			change.Company.Version,
+		)
+	}
+	return rows
+}
@@ -2,11 +2,18 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/018bf/companies/internal/configs"
 	"github.com/018bf/companies/internal/entity"
 	"github.com/018bf/companies/internal/errs"
+	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/filtering"
 	"github.com/018bf/companies/pkg/log"
 	"github.com/018bf/companies/pkg/postgresql"
 	"github.com/018bf/companies/pkg/utils"
@@ -14,16 +21,72 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+//go:generate mockgen -source=company.go -package=postgres -destination=company_mock.go
+
+// router picks the database to read from, the replicas or the primary.
+type router interface {
+	Read(ctx context.Context) *sqlx.DB
+	Wrote(ctx context.Context)
+}
+
 type CompanyRepository struct {
-	database *sqlx.DB
-	logger   log.Logger
+	database        *sqlx.DB
+	router          router
+	logger          log.Logger
+	searchLanguage  string
+	employeeBuckets []int
+	readTimeout     time.Duration
+	listTimeout     time.Duration
+	writeTimeout    time.Duration
 }
 
-func NewCompanyRepository(database *sqlx.DB, logger log.Logger) *CompanyRepository {
-	return &CompanyRepository{database: database, logger: logger}
+func NewCompanyRepository(
+	database *sqlx.DB,
+	router router,
+	config *configs.Config,
+	logger log.Logger,
+) *CompanyRepository {
+	return &CompanyRepository{
+		database:        database,
+		router:          router,
+		logger:          logger,
+		searchLanguage:  config.Search.Language,
+		employeeBuckets: config.Stats.EmployeeBuckets,
+		readTimeout:     time.Duration(config.Database.ReadTimeout) * time.Millisecond,
+		listTimeout:     time.Duration(config.Database.ListTimeout) * time.Millisecond,
+		writeTimeout:    time.Duration(config.Database.WriteTimeout) * time.Millisecond,
+	}
+}
+
+// reader returns what to read with in ctx: its transaction, otherwise the read database.
+func (r *CompanyRepository) reader(ctx context.Context) postgresInterface.Querier {
+	if tx, ok := postgresInterface.TxFromContext(ctx); ok {
+		return tx
+	}
+	return r.readDatabase(ctx)
+}
+
+// readDatabase returns the database picked by the router, the primary without it.
+func (r *CompanyRepository) readDatabase(ctx context.Context) *sqlx.DB {
+	if r.router == nil {
+		return r.database
+	}
+	return r.router.Read(ctx)
+}
+
+// writer returns what to write with in ctx: its transaction or the primary.
+func (r *CompanyRepository) writer(ctx context.Context) postgresInterface.Querier {
+	return postgresInterface.QuerierFromContext(ctx, r.database)
+}
+
+// wrote keeps the following reads of the caller on the primary.
+func (r *CompanyRepository) wrote(ctx context.Context) {
+	if r.router != nil {
+		r.router.Wrote(ctx)
+	}
 }
 func (r *CompanyRepository) Create(ctx context.Context, company *entity.Company) error {
-	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
 	defer cancel()
 	dto := NewCompanyDTOFromModel(company)
 	q := sq.Insert("public.companies").
@@ -45,17 +108,19 @@ func (r *CompanyRepository) Create(ctx context.Context, company *entity.Company)
 			dto.Registered,
 			dto.Type,
 		).
-		Suffix("RETURNING id")
+		Suffix("RETURNING id, version")
 	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
-	if err := r.database.QueryRowxContext(ctx, query, args...).StructScan(dto); err != nil {
+	if err := r.writer(ctx).QueryRowxContext(ctx, query, args...).StructScan(dto); err != nil {
 		e := errs.FromPostgresError(err)
 		return e
 	}
 	company.ID = entity.UUID(dto.ID)
+	company.Version = dto.Version
+	r.wrote(ctx)
 	return nil
 }
 func (r *CompanyRepository) Get(ctx context.Context, id entity.UUID) (*entity.Company, error) {
-	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.readTimeout)
 	defer cancel()
 	dto := &CompanyDTO{}
 	q := sq.Select(
@@ -67,12 +132,13 @@ func (r *CompanyRepository) Get(ctx context.Context, id entity.UUID) (*entity.Co
 		"companies.amount_of_employees",
 		"companies.registered",
 		"companies.type",
+		"companies.version",
 	).
 		From("public.companies").
 		Where(sq.Eq{"id": id}).
 		Limit(1)
 	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
-	if err := r.database.GetContext(ctx, dto, query, args...); err != nil {
+	if err := r.reader(ctx).GetContext(ctx, dto, query, args...); err != nil {
 		e := errs.FromPostgresError(err).WithParam("company_id", string(id))
 		return nil, e
 	}
@@ -83,9 +149,110 @@ func (r *CompanyRepository) List(
 	ctx context.Context,
 	filter *entity.CompanyFilter,
 ) ([]*entity.Company, error) {
-	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
 	defer cancel()
 	var dto CompanyListDTO
+	expr, err := filter.Expression()
+	if err != nil {
+		return nil, err
+	}
+	q := r.page(filter, expr)
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.reader(ctx).SelectContext(ctx, &dto, query, args...); err != nil {
+		e := errs.FromPostgresError(err)
+		return nil, e
+	}
+	return dto.ToModels(), nil
+}
+
+// ListPage returns the page of the companies matching filter with the facets it requests in one query.
+// When the filter counts exactly, the same query counts all the matching companies by a window over them.
+// The facets are joined to the page as their single row, so they are repeated on each company of the page
+// and still returned when the page is empty.
+func (r *CompanyRepository) ListPage(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+) (*entity.CompanyList, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	expr, err := filter.Expression()
+	if err != nil {
+		return nil, err
+	}
+	counted := filter.CountMode() == entity.CompanyCountExact
+	q := r.page(filter, expr)
+	if counted {
+		q = q.Column("count(*) OVER() AS total")
+	}
+	if len(filter.Facets) > 0 {
+		q = r.faceted(filter, expr, q, counted)
+	}
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	var dto CompanyPageListDTO
+	if err := r.reader(ctx).SelectContext(ctx, &dto, query, args...); err != nil {
+		return nil, errs.FromPostgresError(err)
+	}
+	companies, facetsDTO, total := dto.ToModels()
+	list := &entity.CompanyList{Items: companies}
+	if len(filter.Facets) > 0 {
+		list.Facets, err = facetsDTO.ToModel(r.employeeBuckets)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if counted {
+		// There are no rows for the window to count past the last page.
+		if len(companies) == 0 && filter.PageNumber != nil && *filter.PageNumber > 1 {
+			total, err = r.Count(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+		}
+		list.Count = &total
+	}
+	return list, nil
+}
+
+// faceted joins the page to the single row of the facets requested by filter.
+func (r *CompanyRepository) faceted(
+	filter *entity.CompanyFilter,
+	expr filtering.Expr,
+	page sq.SelectBuilder,
+	counted bool,
+) sq.SelectBuilder {
+	facets := sq.Select()
+	for _, facet := range filter.Facets {
+		facets = facets.Column(sq.Alias(r.facet(filter, expr, facet), string(facet)+"_facet"))
+	}
+	columns := []string{
+		"companies.id",
+		"companies.updated_at",
+		"companies.created_at",
+		"companies.name",
+		"companies.description",
+		"companies.amount_of_employees",
+		"companies.registered",
+		"companies.type",
+		"companies.version",
+	}
+	if filter.Search != nil {
+		columns = append(columns, "companies.score", "companies.name_headline", "companies.description_headline")
+	}
+	if counted {
+		columns = append(columns, "companies.total")
+	}
+	q := sq.Select(columns...).
+		FromSelect(facets, "facets").
+		JoinClause(sq.Expr("LEFT JOIN (?) AS companies ON true", page))
+	for _, facet := range filter.Facets {
+		q = q.Column("facets." + string(facet) + "_facet")
+	}
+	// The join doesn't keep the order of the page.
+	return q.OrderBy(pageOrder(filter)...)
+}
+
+// page selects the page of the companies matching filter.
+func (r *CompanyRepository) page(filter *entity.CompanyFilter, expr filtering.Expr) sq.SelectBuilder {
 	const pageSize = uint64(10)
 	if filter.PageSize == nil {
 		filter.PageSize = utils.Pointer(pageSize)
@@ -99,69 +266,78 @@ func (r *CompanyRepository) List(
 		"companies.amount_of_employees",
 		"companies.registered",
 		"companies.type",
+		"companies.version",
 	).
-		From("public.companies").
-		Limit(pageSize)
+		From("public.companies")
+	q = applyConditions(q, filter, expr, r.searchLanguage)
 	if filter.Search != nil {
-		q = q.Where(
-			postgresql.Search{
-				Lang:   "english",
-				Query:  *filter.Search,
-				Fields: []string{"name", "description"},
-			},
-		)
-	}
-	if len(filter.IDs) > 0 {
-		q = q.Where(sq.Eq{"id": filter.IDs})
-	}
-	if len(filter.Types) > 0 {
-		q = q.Where(sq.Eq{"type": filter.Types})
-	}
-	if filter.Registered != nil {
-		q = q.Where(sq.Eq{"registered": *filter.Registered})
+		search := newSearch(filter, r.searchLanguage)
+		q = q.Column(sq.Alias(search.Rank(), "score")).
+			Column(sq.Alias(search.Headline("companies.name"), "name_headline")).
+			Column(sq.Alias(search.Headline("companies.description"), "description_headline"))
 	}
 	if filter.PageNumber != nil && *filter.PageNumber > 1 {
 		q = q.Offset((*filter.PageNumber - 1) * *filter.PageSize)
 	}
-	q = q.Limit(*filter.PageSize)
-	if len(filter.OrderBy) > 0 {
-		q = q.OrderBy(filter.OrderBy...)
+	return q.Limit(*filter.PageSize).OrderBy(pageOrder(filter)...)
+}
+
+func pageOrder(filter *entity.CompanyFilter) []string {
+	switch {
+	case len(filter.OrderBy) > 0:
+		return filter.OrderBy
+	case filter.Search != nil:
+		return []string{"score DESC", "companies.id"}
+	default:
+		return nil
 	}
-	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
-	if err := r.database.SelectContext(ctx, &dto, query, args...); err != nil {
-		e := errs.FromPostgresError(err)
-		return nil, e
+}
+
+// facet aggregates the counts of the companies matching filter without the dimension of the facet
+// into a JSON array of the keys and the counts.
+func (r *CompanyRepository) facet(
+	filter *entity.CompanyFilter,
+	expr filtering.Expr,
+	facet entity.CompanyFacet,
+) sq.SelectBuilder {
+	without := *filter
+	var key sq.Sqlizer
+	switch facet {
+	case entity.CompanyFacetType:
+		without.Types = nil
+		expr = filtering.Without(expr, "type")
+		key = sq.Expr("companies.type")
+	case entity.CompanyFacetRegistered:
+		without.Registered = nil
+		expr = filtering.Without(expr, "registered")
+		key = sq.Expr("companies.registered")
+	case entity.CompanyFacetEmployees:
+		expr = filtering.Without(expr, "amount_of_employees")
+		key = employeeBucket(r.employeeBuckets)
 	}
-	return dto.ToModels(), nil
+	counts := sq.Select().
+		Column(sq.Alias(key, "key")).
+		Column("count(*) AS count").
+		From("public.companies").
+		GroupBy("key")
+	counts = applyConditions(counts, &without, expr, r.searchLanguage)
+	return sq.Select("coalesce(json_agg(json_build_object('key', counts.key, 'count', counts.count) ORDER BY counts.key), '[]')").
+		FromSelect(counts, "counts")
 }
 
 func (r *CompanyRepository) Count(
 	ctx context.Context,
 	filter *entity.CompanyFilter,
 ) (uint64, error) {
-	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
 	defer cancel()
 	q := sq.Select("count(id)").From("public.companies")
-	if filter.Search != nil {
-		q = q.Where(
-			postgresql.Search{
-				Lang:   "english",
-				Query:  *filter.Search,
-				Fields: []string{"name", "description"},
-			},
-		)
-	}
-	if len(filter.IDs) > 0 {
-		q = q.Where(sq.Eq{"id": filter.IDs})
-	}
-	if len(filter.Types) > 0 {
-		q = q.Where(sq.Eq{"type": filter.Types})
-	}
-	if filter.Registered != nil {
-		q = q.Where(sq.Eq{"registered": *filter.Registered})
+	q, err := applyFilter(q, filter, r.searchLanguage)
+	if err != nil {
+		return 0, err
 	}
 	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
-	result := r.database.QueryRowxContext(ctx, query, args...)
+	result := r.reader(ctx).QueryRowxContext(ctx, query, args...)
 	if err := result.Err(); err != nil {
 		e := errs.FromPostgresError(err)
 		return 0, e
@@ -173,8 +349,132 @@ func (r *CompanyRepository) Count(
 	}
 	return count, nil
 }
+
+// Estimate returns the number of the companies matching filter estimated by the planner:
+// the statistics of the table without any condition, otherwise the rows expected by the plan.
+// The table is counted exactly until it is analyzed for the first time.
+func (r *CompanyRepository) Estimate(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+) (uint64, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	expr, err := filter.Expression()
+	if err != nil {
+		return 0, err
+	}
+	if !filtered(filter, expr) {
+		var reltuples float64
+		if err := r.reader(ctx).GetContext(
+			ctx,
+			&reltuples,
+			"SELECT reltuples FROM pg_class WHERE oid = 'public.companies'::regclass",
+		); err != nil {
+			return 0, errs.FromPostgresError(err)
+		}
+		if reltuples < 0 {
+			return r.Count(ctx, filter)
+		}
+		return uint64(reltuples), nil
+	}
+	q := applyConditions(sq.Select("companies.id").From("public.companies"), filter, expr, r.searchLanguage)
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	var dto ExplainDTO
+	if err := r.reader(ctx).GetContext(ctx, &dto, "EXPLAIN (FORMAT JSON) "+query, args...); err != nil {
+		return 0, errs.FromPostgresError(err)
+	}
+	return dto.Rows()
+}
+
+// ListAfter returns up to limit companies matching filter with ID greater than after, ordered by ID.
+// Paging by the last seen ID keeps the query cheap on any offset.
+func (r *CompanyRepository) ListAfter(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+	after entity.UUID,
+	limit uint64,
+) ([]*entity.Company, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	var dto CompanyListDTO
+	q := sq.Select(
+		"companies.id",
+		"companies.updated_at",
+		"companies.created_at",
+		"companies.name",
+		"companies.description",
+		"companies.amount_of_employees",
+		"companies.registered",
+		"companies.type",
+		"companies.version",
+	).
+		From("public.companies").
+		OrderBy("companies.id").
+		Limit(limit)
+	q, err := applyFilter(q, filter, r.searchLanguage)
+	if err != nil {
+		return nil, err
+	}
+	if after != "" {
+		q = q.Where(sq.Gt{"id": after})
+	}
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.database.SelectContext(ctx, &dto, query, args...); err != nil {
+		e := errs.FromPostgresError(err)
+		return nil, e
+	}
+	return dto.ToModels(), nil
+}
+
+// Suggest returns up to limit companies whose name starts with the query or is similar to it.
+// The companies matched by the prefix go first, then the most similar ones.
+func (r *CompanyRepository) Suggest(
+	ctx context.Context,
+	query string,
+	threshold float64,
+	limit uint64,
+) ([]*entity.CompanySuggestion, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	// The <% operator, which can use the trigram index, takes its threshold from the setting,
+	// so it is set for the transaction only, a read-only one on the read database out of a transaction.
+	tx, ok := postgresInterface.TxFromContext(ctx)
+	if !ok {
+		var err error
+		tx, err = r.readDatabase(ctx).BeginTxx(ctx, &sql.TxOptions{ReadOnly: true})
+		if err != nil {
+			return nil, errs.FromPostgresError(err)
+		}
+		defer func() { _ = tx.Rollback() }()
+	}
+	if _, err := tx.ExecContext(
+		ctx,
+		"SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)",
+		strconv.FormatFloat(threshold, 'f', -1, 64),
+	); err != nil {
+		return nil, errs.FromPostgresError(err)
+	}
+	prefix := escapeLike(query) + "%"
+	q := sq.Select("companies.id", "companies.name").
+		Column(sq.Alias(sq.Expr("word_similarity(?, companies.name)", query), "score")).
+		From("public.companies").
+		Where(sq.Or{
+			sq.ILike{"companies.name": prefix},
+			sq.Expr("? <% companies.name", query),
+		}).
+		OrderByClause("companies.name ILIKE ? DESC", prefix).
+		OrderBy("score DESC", "companies.name").
+		Limit(limit)
+	statement, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	var dto CompanySuggestionListDTO
+	if err := tx.SelectContext(ctx, &dto, statement, args...); err != nil {
+		return nil, errs.FromPostgresError(err)
+	}
+	return dto.ToModels(), nil
+}
+
 func (r *CompanyRepository) Update(ctx context.Context, company *entity.Company) error {
-	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
 	defer cancel()
 	dto := NewCompanyDTOFromModel(company)
 	q := sq.Update("public.companies").Where(sq.Eq{"id": company.ID}).
@@ -183,29 +483,24 @@ func (r *CompanyRepository) Update(ctx context.Context, company *entity.Company)
 		Set("description", dto.Description).
 		Set("amount_of_employees", dto.AmountOfEmployees).
 		Set("registered", dto.Registered).
-		Set("type", dto.Type)
+		Set("type", dto.Type).
+		Set("version", sq.Expr("version + 1")).
+		Suffix("RETURNING version")
 	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
-	result, err := r.database.ExecContext(ctx, query, args...)
-	if err != nil {
+	if err := r.writer(ctx).QueryRowxContext(ctx, query, args...).Scan(&dto.Version); err != nil {
 		e := errs.FromPostgresError(err).WithParam("company_id", fmt.Sprint(company.ID))
 		return e
 	}
-	affected, err := result.RowsAffected()
-	if err != nil {
-		return errs.FromPostgresError(err).WithParam("company_id", fmt.Sprint(company.ID))
-	}
-	if affected == 0 {
-		e := errs.NewEntityNotFound().WithParam("company_id", fmt.Sprint(company.ID))
-		return e
-	}
+	company.Version = dto.Version
+	r.wrote(ctx)
 	return nil
 }
 func (r *CompanyRepository) Delete(ctx context.Context, id entity.UUID) error {
-	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
 	defer cancel()
 	q := sq.Delete("public.companies").Where(sq.Eq{"id": id})
 	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
-	result, err := r.database.ExecContext(ctx, query, args...)
+	result, err := r.writer(ctx).ExecContext(ctx, query, args...)
 	if err != nil {
 		e := errs.FromPostgresError(err).WithParam("company_id", fmt.Sprint(id))
 		return e
@@ -219,9 +514,127 @@ func (r *CompanyRepository) Delete(ctx context.Context, id entity.UUID) error {
 		e := errs.NewEntityNotFound().WithParam("company_id", fmt.Sprint(id))
 		return e
 	}
+	r.wrote(ctx)
 	return nil
 }
 
+func applyFilter(
+	q sq.SelectBuilder,
+	filter *entity.CompanyFilter,
+	searchLanguage string,
+) (sq.SelectBuilder, error) {
+	expr, err := filter.Expression()
+	if err != nil {
+		return q, err
+	}
+	return applyConditions(q, filter, expr, searchLanguage), nil
+}
+
+// filtered tells whether filter has any condition on the companies.
+func filtered(filter *entity.CompanyFilter, expr filtering.Expr) bool {
+	return expr != nil ||
+		filter.Search != nil ||
+		len(filter.IDs) > 0 ||
+		len(filter.Types) > 0 ||
+		filter.Registered != nil
+}
+
+// applyConditions restricts q by the parsed filter expression and the other conditions of filter.
+func applyConditions(
+	q sq.SelectBuilder,
+	filter *entity.CompanyFilter,
+	expr filtering.Expr,
+	searchLanguage string,
+) sq.SelectBuilder {
+	if expr != nil {
+		q = q.Where(compileFilter(expr))
+	}
+	if filter.Search != nil {
+		q = q.Where(newSearch(filter, searchLanguage))
+	}
+	if len(filter.IDs) > 0 {
+		q = q.Where(sq.Eq{"companies.id": filter.IDs})
+	}
+	if len(filter.Types) > 0 {
+		q = q.Where(sq.Eq{"companies.type": filter.Types})
+	}
+	if filter.Registered != nil {
+		q = q.Where(sq.Eq{"companies.registered": *filter.Registered})
+	}
+	return q
+}
+
+// compileFilter compiles the filter expression, which fields are the columns of the companies.
+func compileFilter(expr filtering.Expr) sq.Sqlizer {
+	switch expr := expr.(type) {
+	case *filtering.And:
+		return sq.And{compileFilter(expr.Left), compileFilter(expr.Right)}
+	case *filtering.Or:
+		return sq.Or{compileFilter(expr.Left), compileFilter(expr.Right)}
+	case *filtering.Not:
+		return sq.Expr("NOT (?)", compileFilter(expr.Expr))
+	case *filtering.Restriction:
+		column := "companies." + expr.Field
+		predicates := make(sq.Or, len(expr.Values))
+		for i, value := range expr.Values {
+			predicates[i] = compileRestriction(column, expr.Operator, value.Value)
+		}
+		if len(predicates) == 1 {
+			return predicates[0]
+		}
+		return predicates
+	default:
+		panic(fmt.Sprintf("unexpected filter expression %T", expr))
+	}
+}
+
+func compileRestriction(column string, operator filtering.Operator, value any) sq.Sqlizer {
+	switch operator {
+	case filtering.OperatorHas:
+		if text, ok := value.(string); ok {
+			return sq.ILike{column: strings.ReplaceAll(escapeLike(text), "*", "%")}
+		}
+		return sq.Eq{column: value}
+	case filtering.OperatorNotEqual:
+		return sq.NotEq{column: value}
+	case filtering.OperatorLess:
+		return sq.Lt{column: value}
+	case filtering.OperatorLessEqual:
+		return sq.LtOrEq{column: value}
+	case filtering.OperatorGreater:
+		return sq.Gt{column: value}
+	case filtering.OperatorGreaterEqual:
+		return sq.GtOrEq{column: value}
+	default:
+		return sq.Eq{column: value}
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike escapes the LIKE wildcards, so the value is matched literally.
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// newSearch matches the indexed search vector, which is built for the configured language.
+// Another language requested by the filter is matched against a vector built on the fly.
+func newSearch(filter *entity.CompanyFilter, searchLanguage string) postgresql.Search {
+	if filter.SearchLanguage == nil || *filter.SearchLanguage == searchLanguage {
+		return postgresql.Search{
+			Lang:   postgresInterface.SearchConfiguration,
+			Vector: "companies.search_vector",
+			Query:  *filter.Search,
+		}
+	}
+	return postgresql.Search{
+		Lang:     *filter.SearchLanguage,
+		Fields:   []string{"companies.name", "companies.description"},
+		Unaccent: true,
+		Query:    *filter.Search,
+	}
+}
+
 type CompanyDTO struct {
 	ID                string    `db:"id,omitempty"`
 	UpdatedAt         time.Time `db:"updated_at,omitempty"`
@@ -231,6 +644,11 @@ type CompanyDTO struct {
 	AmountOfEmployees int       `db:"amount_of_employees"`
 	Registered        bool      `db:"registered"`
 	Type              uint8     `db:"type"`
+	Version           uint64    `db:"version"`
+	// Score and the headlines are selected only by the search.
+	Score               sql.NullFloat64 `db:"score"`
+	NameHeadline        sql.NullString  `db:"name_headline"`
+	DescriptionHeadline sql.NullString  `db:"description_headline"`
 }
 type CompanyListDTO []*CompanyDTO
 
@@ -251,6 +669,7 @@ func NewCompanyDTOFromModel(company *entity.Company) *CompanyDTO {
 		AmountOfEmployees: company.AmountOfEmployees,
 		Registered:        company.Registered,
 		Type:              uint8(company.Type),
+		Version:           company.Version,
 	}
 	return dto
 }
@@ -264,6 +683,162 @@ func (dto *CompanyDTO) ToModel() *entity.Company {
 		AmountOfEmployees: dto.AmountOfEmployees,
 		Registered:        dto.Registered,
 		Type:              entity.CompanyType(dto.Type),
+		Version:           dto.Version,
+	}
+	if dto.Score.Valid {
+		model.Search = &entity.CompanySearch{
+			Score:               dto.Score.Float64,
+			NameHeadline:        dto.NameHeadline.String,
+			DescriptionHeadline: dto.DescriptionHeadline.String,
+		}
 	}
 	return model
 }
+
+// CompanyPageDTO is a row of the listing. The columns of the company are null
+// when the page of the faceted listing is empty, the facets and the total are the same on every row.
+type CompanyPageDTO struct {
+	ID                  sql.NullString  `db:"id"`
+	UpdatedAt           sql.NullTime    `db:"updated_at"`
+	CreatedAt           sql.NullTime    `db:"created_at"`
+	Name                sql.NullString  `db:"name"`
+	Description         sql.NullString  `db:"description"`
+	AmountOfEmployees   sql.NullInt64   `db:"amount_of_employees"`
+	Registered          sql.NullBool    `db:"registered"`
+	Type                sql.NullInt16   `db:"type"`
+	Version             sql.NullInt64   `db:"version"`
+	Score               sql.NullFloat64 `db:"score"`
+	NameHeadline        sql.NullString  `db:"name_headline"`
+	DescriptionHeadline sql.NullString  `db:"description_headline"`
+	Total               sql.NullInt64   `db:"total"`
+	CompanyFacetsDTO
+}
+
+type CompanyPageListDTO []*CompanyPageDTO
+
+// ToModels returns the companies of the page, the facets and the total, 0 when the page is empty.
+func (list CompanyPageListDTO) ToModels() ([]*entity.Company, *CompanyFacetsDTO, uint64) {
+	companies := make([]*entity.Company, 0, len(list))
+	facets := &CompanyFacetsDTO{}
+	var total uint64
+	for _, row := range list {
+		facets = &row.CompanyFacetsDTO
+		total = uint64(row.Total.Int64)
+		if !row.ID.Valid {
+			continue
+		}
+		dto := &CompanyDTO{
+			ID:                  row.ID.String,
+			UpdatedAt:           row.UpdatedAt.Time,
+			CreatedAt:           row.CreatedAt.Time,
+			Name:                row.Name.String,
+			Description:         row.Description.String,
+			AmountOfEmployees:   int(row.AmountOfEmployees.Int64),
+			Registered:          row.Registered.Bool,
+			Type:                uint8(row.Type.Int16),
+			Version:             uint64(row.Version.Int64),
+			Score:               row.Score,
+			NameHeadline:        row.NameHeadline,
+			DescriptionHeadline: row.DescriptionHeadline,
+		}
+		companies = append(companies, dto.ToModel())
+	}
+	return companies, facets, total
+}
+
+// CompanyFacetsDTO are the JSON arrays of the keys and the counts of the requested facets.
+type CompanyFacetsDTO struct {
+	Types      []byte `db:"type_facet"`
+	Registered []byte `db:"registered_facet"`
+	Employees  []byte `db:"employees_facet"`
+}
+
+type facetCountDTO[K any] struct {
+	Key   K      `json:"key"`
+	Count uint64 `json:"count"`
+}
+
+func unmarshalFacet[K any](data []byte) ([]facetCountDTO[K], error) {
+	var counts []facetCountDTO[K]
+	if len(data) == 0 {
+		return counts, nil
+	}
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return nil, errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	return counts, nil
+}
+
+// ToModel returns the requested facets, the facets that aren't requested have no column.
+func (dto *CompanyFacetsDTO) ToModel(buckets []int) (*entity.CompanyFacets, error) {
+	facets := &entity.CompanyFacets{}
+	if dto.Types != nil {
+		counts, err := unmarshalFacet[entity.CompanyType](dto.Types)
+		if err != nil {
+			return nil, err
+		}
+		facets.Types = make([]*entity.CompanyTypeFacet, len(counts))
+		for i, count := range counts {
+			facets.Types[i] = &entity.CompanyTypeFacet{Type: count.Key, Count: count.Count}
+		}
+	}
+	if dto.Registered != nil {
+		counts, err := unmarshalFacet[bool](dto.Registered)
+		if err != nil {
+			return nil, err
+		}
+		facets.Registered = make([]*entity.CompanyRegisteredFacet, len(counts))
+		for i, count := range counts {
+			facets.Registered[i] = &entity.CompanyRegisteredFacet{Registered: count.Key, Count: count.Count}
+		}
+	}
+	if dto.Employees != nil {
+		counts, err := unmarshalFacet[int](dto.Employees)
+		if err != nil {
+			return nil, err
+		}
+		facets.Employees = entity.NewCompanyStatsBuckets(buckets)
+		for _, count := range counts {
+			if count.Key >= 0 && count.Key < len(facets.Employees) {
+				facets.Employees[count.Key].Count = count.Count
+			}
+		}
+	}
+	return facets, nil
+}
+
+type CompanySuggestionDTO struct {
+	ID    string  `db:"id"`
+	Name  string  `db:"name"`
+	Score float64 `db:"score"`
+}
+
+// ExplainDTO is the plan of a query explained in JSON.
+type ExplainDTO []byte
+
+// Rows returns the number of the rows the plan expects.
+func (dto ExplainDTO) Rows() (uint64, error) {
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(dto, &plans); err != nil || len(plans) == 0 {
+		return 0, errs.NewUnexpectedBehaviorError("Invalid query plan.")
+	}
+	return uint64(plans[0].Plan.Rows), nil
+}
+
+type CompanySuggestionListDTO []*CompanySuggestionDTO
+
+func (list CompanySuggestionListDTO) ToModels() []*entity.CompanySuggestion {
+	suggestions := make([]*entity.CompanySuggestion, len(list))
+	for i, dto := range list {
+		suggestions[i] = &entity.CompanySuggestion{
+			ID:    entity.UUID(dto.ID),
+			Name:  dto.Name,
+			Score: dto.Score,
+		}
+	}
+	return suggestions
+}
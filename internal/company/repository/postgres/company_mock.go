@@ -0,0 +1,62 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: company.go
+
+// Package postgres is a generated GoMock package.
+package postgres
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	sqlx "github.com/jmoiron/sqlx"
+)
+
+// Mockrouter is a mock of router interface.
+type Mockrouter struct {
+	ctrl     *gomock.Controller
+	recorder *MockrouterMockRecorder
+}
+
+// MockrouterMockRecorder is the mock recorder for Mockrouter.
+type MockrouterMockRecorder struct {
+	mock *Mockrouter
+}
+
+// NewMockrouter creates a new mock instance.
+func NewMockrouter(ctrl *gomock.Controller) *Mockrouter {
+	mock := &Mockrouter{ctrl: ctrl}
+	mock.recorder = &MockrouterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockrouter) EXPECT() *MockrouterMockRecorder {
+	return m.recorder
+}
+
+// Read mocks base method.
+func (m *Mockrouter) Read(ctx context.Context) *sqlx.DB {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Read", ctx)
+	ret0, _ := ret[0].(*sqlx.DB)
+	return ret0
+}
+
+// Read indicates an expected call of Read.
+func (mr *MockrouterMockRecorder) Read(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*Mockrouter)(nil).Read), ctx)
+}
+
+// Wrote mocks base method.
+func (m *Mockrouter) Wrote(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Wrote", ctx)
+}
+
+// Wrote indicates an expected call of Wrote.
+func (mr *MockrouterMockRecorder) Wrote(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wrote", reflect.TypeOf((*Mockrouter)(nil).Wrote), ctx)
+}
@@ -3,18 +3,24 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
 	mock_models "github.com/018bf/companies/internal/entity/mock"
 	"github.com/018bf/companies/internal/errs"
 	"github.com/018bf/companies/internal/interfaces/postgres"
 	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/018bf/companies/pkg/utils"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	"github.com/jaswdr/faker"
 
+	"github.com/018bf/companies/internal/configs"
 	"github.com/018bf/companies/internal/entity"
 	"github.com/018bf/companies/pkg/log"
 	"github.com/jmoiron/sqlx"
@@ -27,8 +33,14 @@ func TestNewCompanyRepository(t *testing.T) {
 		return
 	}
 	defer mockDB.Close()
+	config := configs.NewMockConfig(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRouter := NewMockrouter(ctrl)
 	type args struct {
 		database *sqlx.DB
+		router   router
+		config   *configs.Config
 		logger   log.Logger
 	}
 	tests := []struct {
@@ -42,16 +54,24 @@ func TestNewCompanyRepository(t *testing.T) {
 			setup: func() {},
 			args: args{
 				database: mockDB,
+				router:   mockRouter,
+				config:   config,
 			},
 			want: &CompanyRepository{
-				database: mockDB,
+				database:        mockDB,
+				router:          mockRouter,
+				searchLanguage:  "english",
+				employeeBuckets: []int{0, 10, 50, 250, 1000},
+				readTimeout:     time.Second,
+				listTimeout:     5 * time.Second,
+				writeTimeout:    time.Second,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			if got := NewCompanyRepository(tt.args.database, tt.args.logger); !reflect.DeepEqual(
+			if got := NewCompanyRepository(tt.args.database, tt.args.router, tt.args.config, tt.args.logger); !reflect.DeepEqual(
 				got,
 				tt.want,
 			) {
@@ -165,7 +185,7 @@ func TestCompanyRepository_Get(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	logger := mock_log.NewMockLogger(ctrl)
-	query := "SELECT companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type FROM public.companies WHERE id = \\$1 LIMIT 1"
+	query := "SELECT companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type, companies.version FROM public.companies WHERE id = \\$1 LIMIT 1"
 	company := mock_models.NewCompany(t)
 	ctx := context.Background()
 	type fields struct {
@@ -272,7 +292,28 @@ func TestCompanyRepository_List(t *testing.T) {
 		listCompanies = append(listCompanies, mock_models.NewCompany(t))
 	}
 	filter := mock_models.NewCompanyFilter(t)
-	query := "SELECT companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type FROM public.companies"
+	query := "SELECT companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type, companies.version"
+	searchFilter := &entity.CompanyFilter{Search: utils.Pointer(`"sole trader" -bank`)}
+	searchQuery := regexp.QuoteMeta("SELECT companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type, companies.version, (ts_rank_cd(companies.search_vector, websearch_to_tsquery($1::regconfig, $2))) AS score, (ts_headline($3::regconfig, companies.name, websearch_to_tsquery($4::regconfig, $5))) AS name_headline, (ts_headline($6::regconfig, companies.description, websearch_to_tsquery($7::regconfig, $8))) AS description_headline FROM public.companies WHERE companies.search_vector @@ websearch_to_tsquery($9::regconfig, $10) ORDER BY score DESC, companies.id LIMIT 10")
+	searchArgs := []driver.Value{
+		"public.companies_search", *searchFilter.Search,
+		"public.companies_search", "public.companies_search", *searchFilter.Search,
+		"public.companies_search", "public.companies_search", *searchFilter.Search,
+		"public.companies_search", *searchFilter.Search,
+	}
+	languageFilter := &entity.CompanyFilter{Search: utils.Pointer("société"), SearchLanguage: utils.Pointer("french")}
+	languageQuery := regexp.QuoteMeta("FROM public.companies WHERE (setweight(to_tsvector($11::regconfig, unaccent(companies.name)), 'A') || setweight(to_tsvector($12::regconfig, unaccent(companies.description)), 'B')) @@ websearch_to_tsquery($13::regconfig, unaccent($14))")
+	expressionFilter := &entity.CompanyFilter{
+		Filter: utils.Pointer(`amount_of_employees >= 50 AND created_at > "2026-01-01" AND type:(1 OR 3) AND -name:"Acme*"`),
+	}
+	expressionQuery := regexp.QuoteMeta("FROM public.companies WHERE (((companies.amount_of_employees >= $1 AND companies.created_at > $2) AND (companies.type = $3 OR companies.type = $4)) AND NOT (companies.name ILIKE $5)) LIMIT 10")
+	invalidFilter := &entity.CompanyFilter{Filter: utils.Pointer("owner = 1")}
+	found := mock_models.NewCompany(t)
+	found.Search = &entity.CompanySearch{
+		Score:               0.5,
+		NameHeadline:        "<b>Sole</b> <b>trader</b>",
+		DescriptionHeadline: "A <b>sole</b> <b>trader</b>",
+	}
 	type fields struct {
 		database *sqlx.DB
 		logger   log.Logger
@@ -323,6 +364,76 @@ func TestCompanyRepository_List(t *testing.T) {
 			want:    listCompanies,
 			wantErr: nil,
 		},
+		{
+			name: "search",
+			setup: func() {
+				mock.ExpectQuery(searchQuery).
+					WithArgs(searchArgs...).
+					WillReturnRows(newSearchRows(t, []*entity.Company{found}))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: searchFilter,
+			},
+			want:    []*entity.Company{found},
+			wantErr: nil,
+		},
+		{
+			name: "filter expression",
+			setup: func() {
+				mock.ExpectQuery(expressionQuery).
+					WithArgs(int64(50), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), int64(1), int64(3), "Acme%").
+					WillReturnRows(newCompanyRows(t, listCompanies))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: expressionFilter,
+			},
+			want:    listCompanies,
+			wantErr: nil,
+		},
+		{
+			name:  "invalid filter expression",
+			setup: func() {},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: invalidFilter,
+			},
+			want: nil,
+			wantErr: errs.NewInvalidParameter("Invalid filter.").WithParams(map[string]string{
+				"filter":   `unknown field "owner"`,
+				"position": "1",
+			}),
+		},
+		{
+			name: "search in another language",
+			setup: func() {
+				mock.ExpectQuery(languageQuery).
+					WillReturnRows(newSearchRows(t, []*entity.Company{found}))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: languageFilter,
+			},
+			want:    []*entity.Company{found},
+			wantErr: nil,
+		},
 		{
 			name: "unexpected behavior",
 			setup: func() {
@@ -367,8 +478,9 @@ func TestCompanyRepository_List(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
 			r := &CompanyRepository{
-				database: tt.fields.database,
-				logger:   tt.fields.logger,
+				database:       tt.fields.database,
+				logger:         tt.fields.logger,
+				searchLanguage: "english",
 			}
 			got, err := r.List(tt.args.ctx, tt.args.filter)
 			if !errors.Is(err, tt.wantErr) {
@@ -382,63 +494,563 @@ func TestCompanyRepository_List(t *testing.T) {
 	}
 }
 
-func TestCompanyRepository_Update(t *testing.T) {
+func TestCompanyRepository_ListPage(t *testing.T) {
 	db, mock, err := postgres.NewMockPostgreSQL(t)
 	if err != nil {
 		t.Fatal(err)
 		return
 	}
 	defer db.Close()
+	ctx := context.Background()
+	company := mock_models.NewCompany(t)
+	filter := &entity.CompanyFilter{
+		Types:      []entity.CompanyType{entity.CompanyTypeNonProfit},
+		Registered: utils.Pointer(true),
+		Filter:     utils.Pointer("type = 1 AND amount_of_employees >= 10"),
+		Facets: []entity.CompanyFacet{
+			entity.CompanyFacetType,
+			entity.CompanyFacetRegistered,
+			entity.CompanyFacetEmployees,
+		},
+	}
+	query := regexp.QuoteMeta("SELECT companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type, companies.version, companies.total, facets.type_facet, facets.registered_facet, facets.employees_facet " +
+		"FROM (SELECT (SELECT coalesce(json_agg(json_build_object('key', counts.key, 'count', counts.count) ORDER BY counts.key), '[]') FROM (SELECT (companies.type) AS key, count(*) AS count FROM public.companies WHERE companies.amount_of_employees >= $1 AND companies.registered = $2 GROUP BY key) AS counts) AS type_facet, " +
+		"(SELECT coalesce(json_agg(json_build_object('key', counts.key, 'count', counts.count) ORDER BY counts.key), '[]') FROM (SELECT (companies.registered) AS key, count(*) AS count FROM public.companies WHERE (companies.type = $3 AND companies.amount_of_employees >= $4) AND companies.type IN ($5) GROUP BY key) AS counts) AS registered_facet, " +
+		"(SELECT coalesce(json_agg(json_build_object('key', counts.key, 'count', counts.count) ORDER BY counts.key), '[]') FROM (SELECT (width_bucket(companies.amount_of_employees, $6::int[])) AS key, count(*) AS count FROM public.companies WHERE companies.type = $7 AND companies.type IN ($8) AND companies.registered = $9 GROUP BY key) AS counts) AS employees_facet) AS facets " +
+		"LEFT JOIN (SELECT companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type, companies.version, count(*) OVER() AS total FROM public.companies WHERE (companies.type = $10 AND companies.amount_of_employees >= $11) AND companies.type IN ($12) AND companies.registered = $13 LIMIT 10) AS companies ON true")
+	args := []driver.Value{
+		int64(10), true,
+		int64(1), int64(10), entity.CompanyTypeNonProfit,
+		[]int64{10, 100}, int64(1), entity.CompanyTypeNonProfit, true,
+		int64(1), int64(10), entity.CompanyTypeNonProfit, true,
+	}
+	columns := []string{
+		"id", "updated_at", "created_at", "name", "description", "amount_of_employees", "registered", "type", "version",
+		"total", "type_facet", "registered_facet", "employees_facet",
+	}
+	pageColumns := []string{
+		"id", "updated_at", "created_at", "name", "description", "amount_of_employees", "registered", "type", "version",
+	}
+	page := regexp.QuoteMeta("SELECT companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type, companies.version")
+	facets := []driver.Value{
+		`[{"key": 1, "count": 3}, {"key": 3, "count": 1}]`,
+		`[{"key": true, "count": 3}]`,
+		`[{"key": 0, "count": 2}, {"key": 2, "count": 1}]`,
+	}
+	wantFacets := &entity.CompanyFacets{
+		Types: []*entity.CompanyTypeFacet{
+			{Type: entity.CompanyTypeCorporations, Count: 3},
+			{Type: entity.CompanyTypeCooperative, Count: 1},
+		},
+		Registered: []*entity.CompanyRegisteredFacet{
+			{Registered: true, Count: 3},
+		},
+		Employees: []*entity.CompanyStatsBucket{
+			{From: nil, To: utils.Pointer(10), Count: 2},
+			{From: utils.Pointer(10), To: utils.Pointer(100), Count: 0},
+			{From: utils.Pointer(100), To: nil, Count: 1},
+		},
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		filter  *entity.CompanyFilter
+		want    *entity.CompanyList
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(args...).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(append([]driver.Value{
+						company.ID,
+						company.UpdatedAt,
+						company.CreatedAt,
+						company.Name,
+						company.Description,
+						company.AmountOfEmployees,
+						company.Registered,
+						company.Type,
+						company.Version,
+						5,
+					}, facets...)...))
+			},
+			filter:  filter,
+			want:    &entity.CompanyList{Items: []*entity.Company{company}, Count: utils.Pointer(uint64(5)), Facets: wantFacets},
+			wantErr: nil,
+		},
+		{
+			name: "empty page",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(args...).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(append([]driver.Value{
+						nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+					}, facets...)...))
+			},
+			filter:  filter,
+			want:    &entity.CompanyList{Items: []*entity.Company{}, Count: utils.Pointer(uint64(0)), Facets: wantFacets},
+			wantErr: nil,
+		},
+		{
+			name: "past the last page",
+			setup: func() {
+				mock.ExpectQuery(page + regexp.QuoteMeta(", count(*) OVER() AS total FROM public.companies LIMIT 10 OFFSET 20")).
+					WillReturnRows(sqlmock.NewRows(append(pageColumns, "total")))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT count(id) FROM public.companies")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+			},
+			filter:  &entity.CompanyFilter{PageNumber: utils.Pointer(uint64(3))},
+			want:    &entity.CompanyList{Items: []*entity.Company{}, Count: utils.Pointer(uint64(12))},
+			wantErr: nil,
+		},
+		{
+			name: "count skipped",
+			setup: func() {
+				mock.ExpectQuery(page + regexp.QuoteMeta(" FROM public.companies LIMIT 10")).
+					WillReturnRows(sqlmock.NewRows(pageColumns).AddRow(
+						company.ID,
+						company.UpdatedAt,
+						company.CreatedAt,
+						company.Name,
+						company.Description,
+						company.AmountOfEmployees,
+						company.Registered,
+						company.Type,
+						company.Version,
+					))
+			},
+			filter:  &entity.CompanyFilter{Count: utils.Pointer(entity.CompanyCountNone)},
+			want:    &entity.CompanyList{Items: []*entity.Company{company}},
+			wantErr: nil,
+		},
+		{
+			name:   "invalid filter expression",
+			setup:  func() {},
+			filter: &entity.CompanyFilter{Filter: utils.Pointer("owner = 1")},
+			want:   nil,
+			wantErr: errs.NewInvalidParameter("Invalid filter.").WithParams(map[string]string{
+				"filter":   `unknown field "owner"`,
+				"position": "1",
+			}),
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(args...).
+					WillReturnError(errors.New("test error"))
+			},
+			filter:  filter,
+			want:    nil,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &CompanyRepository{
+				database:        db,
+				searchLanguage:  "english",
+				employeeBuckets: []int{10, 100},
+			}
+			got, err := r.ListPage(ctx, tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CompanyRepository.ListPage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompanyRepository.ListPage() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestCompanyRepository_router(t *testing.T) {
+	primary, primaryMock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer primary.Close()
+	replica, replicaMock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer replica.Close()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	logger := mock_log.NewMockLogger(ctrl)
+	mockRouter := NewMockrouter(ctrl)
+	ctx := context.Background()
 	company := mock_models.NewCompany(t)
-	query := `UPDATE public.companies`
+	r := &CompanyRepository{database: primary, router: mockRouter}
+	// The reads go to the database picked by the router.
+	mockRouter.EXPECT().Read(gomock.Any()).Return(replica)
+	replicaMock.ExpectQuery(regexp.QuoteMeta("FROM public.companies WHERE id = $1 LIMIT 1")).
+		WithArgs(company.ID).
+		WillReturnRows(newCompanyRows(t, []*entity.Company{company}))
+	got, err := r.Get(ctx, company.ID)
+	if err != nil {
+		t.Fatalf("CompanyRepository.Get() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, company) {
+		t.Errorf("CompanyRepository.Get() = %v, want %v", got, company)
+	}
+	// So do the suggestions, in their own read-only transaction.
+	mockRouter.EXPECT().Read(gomock.Any()).Return(replica)
+	replicaMock.ExpectBegin()
+	replicaMock.ExpectExec(regexp.QuoteMeta("SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)")).
+		WithArgs("0.3").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	replicaMock.ExpectQuery(regexp.QuoteMeta("FROM public.companies WHERE")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score"}))
+	replicaMock.ExpectRollback()
+	if _, err := r.Suggest(ctx, "acme", 0.3, 10); err != nil {
+		t.Fatalf("CompanyRepository.Suggest() error = %v", err)
+	}
+	// The writes go to the primary and start the read-your-writes window.
+	primaryMock.ExpectExec(regexp.QuoteMeta("DELETE FROM public.companies WHERE id = $1")).
+		WithArgs(company.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mockRouter.EXPECT().Wrote(gomock.Any())
+	if err := r.Delete(ctx, company.ID); err != nil {
+		t.Fatalf("CompanyRepository.Delete() error = %v", err)
+	}
+	// The reads within a transaction use it instead of the router.
+	primaryMock.ExpectBegin()
+	primaryMock.ExpectQuery(regexp.QuoteMeta("FROM public.companies WHERE id = $1 LIMIT 1")).
+		WithArgs(company.ID).
+		WillReturnRows(newCompanyRows(t, []*entity.Company{company}))
+	primaryMock.ExpectCommit()
+	err = postgres.NewTxManager(primary, configs.NewMockConfig(t), nil).
+		Transaction(ctx, func(ctx context.Context) error {
+			_, err := r.Get(ctx, company.ID)
+			return err
+		})
+	if err != nil {
+		t.Fatalf("CompanyRepository.Get() in a transaction error = %v", err)
+	}
+	if err := primaryMock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+	if err := replicaMock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCompanyRepository_ListAfter(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
 	ctx := context.Background()
+	var listCompanies []*entity.Company
+	for i := 0; i < faker.New().IntBetween(2, 20); i++ {
+		listCompanies = append(listCompanies, mock_models.NewCompany(t))
+	}
+	after := entity.UUID(uuid.NewString())
+	query := regexp.QuoteMeta("SELECT companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type, companies.version FROM public.companies WHERE companies.registered = $1 AND id > $2 ORDER BY companies.id LIMIT 100")
+	firstQuery := regexp.QuoteMeta("SELECT companies.id, companies.updated_at, companies.created_at, companies.name, companies.description, companies.amount_of_employees, companies.registered, companies.type, companies.version FROM public.companies WHERE companies.registered = $1 ORDER BY companies.id LIMIT 100")
+	filter := &entity.CompanyFilter{Registered: utils.Pointer(true)}
 	type fields struct {
 		database *sqlx.DB
 		logger   log.Logger
 	}
 	type args struct {
-		ctx  context.Context
-		card *entity.Company
+		ctx    context.Context
+		filter *entity.CompanyFilter
+		after  entity.UUID
+		limit  uint64
 	}
 	tests := []struct {
 		name    string
 		setup   func()
 		fields  fields
 		args    args
+		want    []*entity.Company
 		wantErr error
 	}{
 		{
 			name: "ok",
 			setup: func() {
-				mock.ExpectExec(query).
-					WithArgs(
-						company.UpdatedAt,
-						company.Name,
-						company.Description,
-						company.AmountOfEmployees,
-						company.Registered,
-						company.Type,
-						company.ID,
-					).
+				mock.ExpectQuery(query).
+					WithArgs(true, after).
+					WillReturnRows(newCompanyRows(t, listCompanies))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: filter,
+				after:  after,
+				limit:  100,
+			},
+			want:    listCompanies,
+			wantErr: nil,
+		},
+		{
+			name: "first page",
+			setup: func() {
+				mock.ExpectQuery(firstQuery).
+					WithArgs(true).
+					WillReturnRows(newCompanyRows(t, listCompanies))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: filter,
+				after:  "",
+				limit:  100,
+			},
+			want:    listCompanies,
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(true, after).
+					WillReturnError(errors.New("test error"))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: filter,
+				after:  after,
+				limit:  100,
+			},
+			want:    nil,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &CompanyRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			got, err := r.ListAfter(tt.args.ctx, tt.args.filter, tt.args.after, tt.args.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CompanyRepository.ListAfter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompanyRepository.ListAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompanyRepository_Suggest(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	var suggestions []*entity.CompanySuggestion
+	for i := 0; i < faker.New().IntBetween(2, 20); i++ {
+		suggestions = append(suggestions, mock_models.NewCompanySuggestion(t))
+	}
+	setConfig := regexp.QuoteMeta("SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)")
+	query := regexp.QuoteMeta("SELECT companies.id, companies.name, (word_similarity($1, companies.name)) AS score FROM public.companies WHERE (companies.name ILIKE $2 OR $3 <% companies.name) ORDER BY companies.name ILIKE $4 DESC, score DESC, companies.name LIMIT 10")
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx       context.Context
+		query     string
+		threshold float64
+		limit     uint64
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		want    []*entity.CompanySuggestion
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				rows := sqlmock.NewRows([]string{"id", "name", "score"})
+				for _, suggestion := range suggestions {
+					rows.AddRow(suggestion.ID, suggestion.Name, suggestion.Score)
+				}
+				mock.ExpectBegin()
+				mock.ExpectExec(setConfig).
+					WithArgs("0.3").
 					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(query).
+					WithArgs("acme", "acme%", "acme", "acme%").
+					WillReturnRows(rows)
+				mock.ExpectRollback()
 			},
 			fields: fields{
 				database: db,
 				logger:   logger,
 			},
 			args: args{
-				ctx:  ctx,
-				card: company,
+				ctx:       ctx,
+				query:     "acme",
+				threshold: 0.3,
+				limit:     10,
 			},
+			want:    suggestions,
 			wantErr: nil,
 		},
 		{
-			name: "not found",
+			name: "wildcards are escaped",
+			setup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(setConfig).
+					WithArgs("0.5").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(query).
+					WithArgs(`5%_\`, `5\%\_\\%`, `5%_\`, `5\%\_\\%`).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "score"}))
+				mock.ExpectRollback()
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:       ctx,
+				query:     `5%_\`,
+				threshold: 0.5,
+				limit:     10,
+			},
+			want:    []*entity.CompanySuggestion{},
+			wantErr: nil,
+		},
+		{
+			name: "set config error",
+			setup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(setConfig).
+					WithArgs("0.3").
+					WillReturnError(errors.New("test error"))
+				mock.ExpectRollback()
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:       ctx,
+				query:     "acme",
+				threshold: 0.3,
+				limit:     10,
+			},
+			want:    nil,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(setConfig).
+					WithArgs("0.3").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(query).
+					WithArgs("acme", "acme%", "acme", "acme%").
+					WillReturnError(errors.New("test error"))
+				mock.ExpectRollback()
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:       ctx,
+				query:     "acme",
+				threshold: 0.3,
+				limit:     10,
+			},
+			want:    nil,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &CompanyRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			got, err := r.Suggest(tt.args.ctx, tt.args.query, tt.args.threshold, tt.args.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CompanyRepository.Suggest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompanyRepository.Suggest() = %v, want %v", got, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestCompanyRepository_Update(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	company := mock_models.NewCompany(t)
+	query := `UPDATE public.companies`
+	ctx := context.Background()
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx  context.Context
+		card *entity.Company
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
 			setup: func() {
-				mock.ExpectExec(query).
+				mock.ExpectQuery(query).
 					WithArgs(
 						company.UpdatedAt,
 						company.Name,
@@ -448,7 +1060,7 @@ func TestCompanyRepository_Update(t *testing.T) {
 						company.Type,
 						company.ID,
 					).
-					WillReturnResult(sqlmock.NewResult(0, 0))
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(company.Version + 1))
 			},
 			fields: fields{
 				database: db,
@@ -458,12 +1070,12 @@ func TestCompanyRepository_Update(t *testing.T) {
 				ctx:  ctx,
 				card: company,
 			},
-			wantErr: errs.NewEntityNotFound().WithParam("company_id", string(company.ID)),
+			wantErr: nil,
 		},
 		{
-			name: "database error",
+			name: "not found",
 			setup: func() {
-				mock.ExpectExec(query).
+				mock.ExpectQuery(query).
 					WithArgs(
 						company.UpdatedAt,
 						company.Name,
@@ -473,7 +1085,7 @@ func TestCompanyRepository_Update(t *testing.T) {
 						company.Type,
 						company.ID,
 					).
-					WillReturnError(errors.New("test error"))
+					WillReturnError(sql.ErrNoRows)
 			},
 			fields: fields{
 				database: db,
@@ -483,13 +1095,12 @@ func TestCompanyRepository_Update(t *testing.T) {
 				ctx:  ctx,
 				card: company,
 			},
-			wantErr: errs.FromPostgresError(errors.New("test error")).
-				WithParam("company_id", string(company.ID)),
+			wantErr: errs.NewEntityNotFound().WithParam("company_id", string(company.ID)),
 		},
 		{
-			name: "unexpected error",
+			name: "database error",
 			setup: func() {
-				mock.ExpectExec(query).
+				mock.ExpectQuery(query).
 					WithArgs(
 						company.UpdatedAt,
 						company.Name,
@@ -513,9 +1124,9 @@ func TestCompanyRepository_Update(t *testing.T) {
 				WithParam("company_id", string(company.ID)),
 		},
 		{
-			name: "result error",
+			name: "unexpected error",
 			setup: func() {
-				mock.ExpectExec(query).
+				mock.ExpectQuery(query).
 					WithArgs(
 						company.UpdatedAt,
 						company.Name,
@@ -525,7 +1136,7 @@ func TestCompanyRepository_Update(t *testing.T) {
 						company.Type,
 						company.ID,
 					).
-					WillReturnResult(sqlmock.NewErrorResult(errors.New("test error")))
+					WillReturnError(errors.New("test error"))
 			},
 			fields: fields{
 				database: db,
@@ -777,6 +1388,39 @@ func newCompanyRows(t *testing.T, listCompanies []*entity.Company) *sqlmock.Rows
 		"type",
 		"updated_at",
 		"created_at",
+		"version",
+	})
+	for _, company := range listCompanies {
+		rows.AddRow(
+			company.ID,
+			company.Name,
+			company.Description,
+			company.AmountOfEmployees,
+			company.Registered,
+			company.Type,
+			company.UpdatedAt,
+			company.CreatedAt,
+			company.Version,
+		)
+	}
+	return rows
+}
+
+func newSearchRows(t *testing.T, listCompanies []*entity.Company) *sqlmock.Rows {
+	t.Helper()
+	rows := sqlmock.NewRows([]string{
+		"id",
+		"name",
+		"description",
+		"amount_of_employees",
+		"registered",
+		"type",
+		"updated_at",
+		"created_at",
+		"version",
+		"score",
+		"name_headline",
+		"description_headline",
 	})
 	for _, company := range listCompanies {
 		rows.AddRow(
@@ -788,7 +1432,100 @@ func newCompanyRows(t *testing.T, listCompanies []*entity.Company) *sqlmock.Rows
 			company.Type,
 			company.UpdatedAt,
 			company.CreatedAt,
+			company.Version,
+			company.Search.Score,
+			company.Search.NameHeadline,
+			company.Search.DescriptionHeadline,
 		)
 	}
 	return rows
 }
+
+func TestCompanyRepository_Estimate(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctx := context.Background()
+	reltuples := regexp.QuoteMeta("SELECT reltuples FROM pg_class WHERE oid = 'public.companies'::regclass")
+	explain := regexp.QuoteMeta("EXPLAIN (FORMAT JSON) SELECT companies.id FROM public.companies WHERE companies.registered = $1")
+	filtered := &entity.CompanyFilter{Registered: utils.Pointer(true)}
+	tests := []struct {
+		name    string
+		setup   func()
+		filter  *entity.CompanyFilter
+		want    uint64
+		wantErr error
+	}{
+		{
+			name: "table statistics",
+			setup: func() {
+				mock.ExpectQuery(reltuples).
+					WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(float64(1234567)))
+			},
+			filter:  &entity.CompanyFilter{},
+			want:    1234567,
+			wantErr: nil,
+		},
+		{
+			name: "never analyzed",
+			setup: func() {
+				mock.ExpectQuery(reltuples).
+					WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(float64(-1)))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT count(id) FROM public.companies")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			},
+			filter:  &entity.CompanyFilter{},
+			want:    3,
+			wantErr: nil,
+		},
+		{
+			name: "plan rows",
+			setup: func() {
+				mock.ExpectQuery(explain).
+					WithArgs(true).
+					WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).
+						AddRow([]byte(`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 5021}}]`)))
+			},
+			filter:  filtered,
+			want:    5021,
+			wantErr: nil,
+		},
+		{
+			name: "invalid plan",
+			setup: func() {
+				mock.ExpectQuery(explain).
+					WithArgs(true).
+					WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow([]byte(`[]`)))
+			},
+			filter:  filtered,
+			want:    0,
+			wantErr: errs.NewUnexpectedBehaviorError("Invalid query plan."),
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(reltuples).WillReturnError(errors.New("test error"))
+			},
+			filter:  &entity.CompanyFilter{},
+			want:    0,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &CompanyRepository{database: db, searchLanguage: "english"}
+			got, err := r.Estimate(ctx, tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CompanyRepository.Estimate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CompanyRepository.Estimate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
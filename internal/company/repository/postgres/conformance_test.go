@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/018bf/companies/internal/company/repository/conformance"
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/interfaces/postgres"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+)
+
+// TestCompanyRepository_conformance runs the suite of the storages against the database of the test config,
+// see the test task. It's skipped in the short mode, the unit tests don't need a database.
+func TestCompanyRepository_conformance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the database isn't available in the short mode")
+	}
+	configPath := os.Getenv("COMPANIES_CONFIG_PATH")
+	if configPath == "" {
+		t.Skip("COMPANIES_CONFIG_PATH isn't set")
+	}
+	config, err := configs.ParseConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Storage.Driver = "postgres"
+	config.Stats.EmployeeBuckets = conformance.EmployeeBuckets
+	ctx := context.Background()
+	pool, err := postgres.NewPool(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close(ctx)
+	database := postgres.NewDatabase(pool)
+	if err := postgres.NewMigrateManager(database, config).UpLocked(ctx); err != nil {
+		t.Fatal(err)
+	}
+	conformance.Run(t, func(t *testing.T) conformance.Repository {
+		if _, err := database.ExecContext(ctx, "TRUNCATE public.companies"); err != nil {
+			t.Fatal(err)
+		}
+		return NewCompanyRepository(database, nil, config, mock_log.NewMockLogger(gomock.NewController(t)))
+	})
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/log"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+// StatsSourceView reads the statistics from the materialized view instead of the companies table.
+const StatsSourceView = "view"
+
+// The GROUPING bits of the grouping sets of the statistics query.
+const (
+	statsGroupingGroups    = 3
+	statsGroupingEmployees = 13
+	statsGroupingCreated   = 14
+	statsGroupingTotal     = 15
+)
+
+type StatsRepository struct {
+	database       *sqlx.DB
+	logger         log.Logger
+	searchLanguage string
+	source         string
+	listTimeout    time.Duration
+}
+
+func NewStatsRepository(database *sqlx.DB, config *configs.Config, logger log.Logger) *StatsRepository {
+	return &StatsRepository{
+		database:       database,
+		logger:         logger,
+		searchLanguage: config.Search.Language,
+		source:         config.Stats.Source,
+		listTimeout:    time.Duration(config.Database.ListTimeout) * time.Millisecond,
+	}
+}
+
+// Get aggregates the companies matching filter in a single scan.
+// The buckets and the interval have to be set.
+func (r *StatsRepository) Get(ctx context.Context, filter *entity.CompanyStatsFilter) (*entity.CompanyStats, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	companies := sq.Select("companies.type", "companies.registered").
+		Column(sq.Alias(employeeBucket(filter.EmployeeBuckets), "bucket")).
+		Column(sq.Alias(sq.Expr("date_trunc(?, companies.created_at)", string(*filter.Interval)), "period"))
+	if r.fromView(filter) {
+		companies = companies.Column("companies.companies_count AS weight").From("public.companies_stats AS companies")
+	} else {
+		companies = companies.Column("1 AS weight").From("public.companies")
+	}
+	companies, err := applyFilter(companies, &filter.CompanyFilter, r.searchLanguage)
+	if err != nil {
+		return nil, err
+	}
+	q := sq.Select(
+		"companies.type",
+		"companies.registered",
+		"companies.bucket",
+		"companies.period",
+		"GROUPING(companies.type, companies.registered, companies.bucket, companies.period) AS grouping",
+		"coalesce(sum(companies.weight), 0)::bigint AS count",
+	).
+		FromSelect(companies, "companies").
+		GroupBy("GROUPING SETS ((companies.type, companies.registered), (companies.bucket), (companies.period), ())").
+		OrderBy("grouping", "companies.type", "companies.registered", "companies.bucket", "companies.period")
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	var dto []*CompanyStatsRowDTO
+	if err := r.database.SelectContext(ctx, &dto, query, args...); err != nil {
+		return nil, errs.FromPostgresError(err)
+	}
+	return newCompanyStats(dto, filter.EmployeeBuckets), nil
+}
+
+// fromView reports whether the view can answer the filter exactly:
+// it has neither the identifiers nor the names, and its creation time is truncated to the day.
+func (r *StatsRepository) fromView(filter *entity.CompanyStatsFilter) bool {
+	return r.source == StatsSourceView &&
+		len(filter.IDs) == 0 &&
+		filter.Search == nil &&
+		filter.Filter == nil
+}
+
+// Refresh recomputes the materialized view without blocking the reads.
+func (r *StatsRepository) Refresh(ctx context.Context) error {
+	if _, err := r.database.ExecContext(
+		ctx,
+		"REFRESH MATERIALIZED VIEW CONCURRENTLY public.companies_stats",
+	); err != nil {
+		return errs.FromPostgresError(err)
+	}
+	return nil
+}
+
+type CompanyStatsRowDTO struct {
+	Type       sql.NullInt64 `db:"type"`
+	Registered sql.NullBool  `db:"registered"`
+	Bucket     sql.NullInt64 `db:"bucket"`
+	Period     sql.NullTime  `db:"period"`
+	Grouping   int           `db:"grouping"`
+	Count      uint64        `db:"count"`
+}
+
+func newCompanyStats(rows []*CompanyStatsRowDTO, buckets []int) *entity.CompanyStats {
+	stats := &entity.CompanyStats{
+		Groups:    []*entity.CompanyStatsGroup{},
+		Employees: entity.NewCompanyStatsBuckets(buckets),
+		Created:   []*entity.CompanyStatsPeriod{},
+	}
+	for _, row := range rows {
+		switch row.Grouping {
+		case statsGroupingGroups:
+			stats.Groups = append(stats.Groups, &entity.CompanyStatsGroup{
+				Type:       entity.CompanyType(row.Type.Int64),
+				Registered: row.Registered.Bool,
+				Count:      row.Count,
+			})
+		case statsGroupingEmployees:
+			if row.Bucket.Valid && int(row.Bucket.Int64) < len(stats.Employees) {
+				stats.Employees[row.Bucket.Int64].Count = row.Count
+			}
+		case statsGroupingCreated:
+			stats.Created = append(stats.Created, &entity.CompanyStatsPeriod{
+				Start: row.Period.Time,
+				Count: row.Count,
+			})
+		case statsGroupingTotal:
+			stats.Total = row.Count
+		}
+	}
+	return stats
+}
+
+// employeeBucket numbers the bucket of the amount of employees of the company by the lower bounds,
+// see entity.NewCompanyStatsBuckets.
+func employeeBucket(buckets []int) sq.Sqlizer {
+	bounds := make([]int64, len(buckets))
+	for i, bound := range buckets {
+		bounds[i] = int64(bound)
+	}
+	return sq.Expr("width_bucket(companies.amount_of_employees, ?::int[])", bounds)
+}
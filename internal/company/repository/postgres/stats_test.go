@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/018bf/companies/pkg/utils"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestNewStatsRepository(t *testing.T) {
+	mockDB, _, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer mockDB.Close()
+	config := configs.NewMockConfig(t)
+	type args struct {
+		database *sqlx.DB
+		config   *configs.Config
+		logger   log.Logger
+	}
+	tests := []struct {
+		name string
+		args args
+		want *StatsRepository
+	}{
+		{
+			name: "ok",
+			args: args{
+				database: mockDB,
+				config:   config,
+			},
+			want: &StatsRepository{
+				database:       mockDB,
+				searchLanguage: "english",
+				source:         "table",
+				listTimeout:    5 * time.Second,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewStatsRepository(tt.args.database, tt.args.config, tt.args.logger); !reflect.DeepEqual(
+				got,
+				tt.want,
+			) {
+				t.Errorf("NewStatsRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatsRepository_Get(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	const (
+		outer  = "SELECT companies.type, companies.registered, companies.bucket, companies.period, GROUPING(companies.type, companies.registered, companies.bucket, companies.period) AS grouping, coalesce(sum(companies.weight), 0)::bigint AS count FROM (SELECT companies.type, companies.registered, (width_bucket(companies.amount_of_employees, $1::int[])) AS bucket, (date_trunc($2, companies.created_at)) AS period, "
+		groups = ") AS companies GROUP BY GROUPING SETS ((companies.type, companies.registered), (companies.bucket), (companies.period), ()) ORDER BY grouping, companies.type, companies.registered, companies.bucket, companies.period"
+	)
+	tableQuery := regexp.QuoteMeta(outer + "1 AS weight FROM public.companies WHERE companies.registered = $3" + groups)
+	viewQuery := regexp.QuoteMeta(outer + "companies.companies_count AS weight FROM public.companies_stats AS companies WHERE companies.registered = $3" + groups)
+	filter := &entity.CompanyStatsFilter{
+		CompanyFilter:   entity.CompanyFilter{Registered: utils.Pointer(true)},
+		EmployeeBuckets: []int{10, 100},
+		Interval:        utils.Pointer(entity.StatsIntervalMonth),
+	}
+	month := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"type", "registered", "bucket", "period", "grouping", "count"}).
+			AddRow(1, true, nil, nil, 3, 4).
+			AddRow(2, true, nil, nil, 3, 1).
+			AddRow(nil, nil, 0, nil, 13, 2).
+			AddRow(nil, nil, 2, nil, 13, 3).
+			AddRow(nil, nil, nil, month, 14, 5).
+			AddRow(nil, nil, nil, nil, 15, 5)
+	}
+	stats := &entity.CompanyStats{
+		Total: 5,
+		Groups: []*entity.CompanyStatsGroup{
+			{Type: entity.CompanyTypeCorporations, Registered: true, Count: 4},
+			{Type: entity.CompanyTypeNonProfit, Registered: true, Count: 1},
+		},
+		Employees: []*entity.CompanyStatsBucket{
+			{From: nil, To: utils.Pointer(10), Count: 2},
+			{From: utils.Pointer(10), To: utils.Pointer(100), Count: 0},
+			{From: utils.Pointer(100), To: nil, Count: 3},
+		},
+		Created: []*entity.CompanyStatsPeriod{
+			{Start: month, Count: 5},
+		},
+	}
+	type fields struct {
+		source string
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		filter  *entity.CompanyStatsFilter
+		want    *entity.CompanyStats
+		wantErr error
+	}{
+		{
+			name: "table",
+			setup: func() {
+				mock.ExpectQuery(tableQuery).
+					WithArgs([]int64{10, 100}, "month", true).
+					WillReturnRows(newRows())
+			},
+			fields:  fields{source: "table"},
+			filter:  filter,
+			want:    stats,
+			wantErr: nil,
+		},
+		{
+			name: "view",
+			setup: func() {
+				mock.ExpectQuery(viewQuery).
+					WithArgs([]int64{10, 100}, "month", true).
+					WillReturnRows(newRows())
+			},
+			fields:  fields{source: StatsSourceView},
+			filter:  filter,
+			want:    stats,
+			wantErr: nil,
+		},
+		{
+			name:   "view doesn't answer the filter expression",
+			fields: fields{source: StatsSourceView},
+			setup: func() {
+				mock.ExpectQuery(regexp.QuoteMeta("1 AS weight FROM public.companies WHERE companies.amount_of_employees > $3")).
+					WithArgs([]int64{10, 100}, "month", int64(5)).
+					WillReturnRows(newRows())
+			},
+			filter: &entity.CompanyStatsFilter{
+				CompanyFilter:   entity.CompanyFilter{Filter: utils.Pointer("amount_of_employees > 5")},
+				EmployeeBuckets: []int{10, 100},
+				Interval:        utils.Pointer(entity.StatsIntervalMonth),
+			},
+			want:    stats,
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(tableQuery).
+					WithArgs([]int64{10, 100}, "month", true).
+					WillReturnError(errors.New("test error"))
+			},
+			fields:  fields{source: "table"},
+			filter:  filter,
+			want:    nil,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &StatsRepository{
+				database: db,
+				logger:   logger,
+				source:   tt.fields.source,
+			}
+			got, err := r.Get(ctx, tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("StatsRepository.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StatsRepository.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatsRepository_Refresh(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	query := regexp.QuoteMeta("REFRESH MATERIALIZED VIEW CONCURRENTLY public.companies_stats")
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectExec(query).WillReturnError(errors.New("test error"))
+			},
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &StatsRepository{database: db}
+			if err := r.Refresh(context.Background()); !errors.Is(err, tt.wantErr) {
+				t.Errorf("StatsRepository.Refresh() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
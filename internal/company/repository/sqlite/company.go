@@ -0,0 +1,566 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/filtering"
+	"github.com/018bf/companies/pkg/textsearch"
+	"github.com/018bf/companies/pkg/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// timeLayout is the fixed width format of the times, so they are ordered and compared as text.
+const timeLayout = "2006-01-02 15:04:05.000000"
+
+var columns = []string{
+	"companies.id",
+	"companies.updated_at",
+	"companies.created_at",
+	"companies.name",
+	"companies.description",
+	"companies.amount_of_employees",
+	"companies.registered",
+	"companies.type",
+	"companies.version",
+}
+
+// CompanyRepository keeps the companies in SQLite, for the local runs without a database server.
+// It filters, orders and pages them as the Postgres repository does, the search matches the words
+// of the query as substrings and the estimates are exact.
+type CompanyRepository struct {
+	database        *sqlx.DB
+	employeeBuckets []int
+}
+
+func NewCompanyRepository(database *sqlx.DB, config *configs.Config) *CompanyRepository {
+	return &CompanyRepository{
+		database:        database,
+		employeeBuckets: config.Stats.EmployeeBuckets,
+	}
+}
+
+func (r *CompanyRepository) Create(ctx context.Context, company *entity.Company) error {
+	dto := NewCompanyDTOFromModel(company)
+	dto.ID = uuid.NewString()
+	dto.Version = 1
+	q := sq.Insert("companies").
+		Columns(
+			"id",
+			"updated_at",
+			"created_at",
+			"name",
+			"description",
+			"amount_of_employees",
+			"registered",
+			"type",
+			"version",
+		).
+		Values(
+			dto.ID,
+			dto.UpdatedAt,
+			dto.CreatedAt,
+			dto.Name,
+			dto.Description,
+			dto.AmountOfEmployees,
+			dto.Registered,
+			dto.Type,
+			dto.Version,
+		)
+	query, args := q.MustSql()
+	if _, err := r.database.ExecContext(ctx, query, args...); err != nil {
+		return fromError(err, company.Name)
+	}
+	company.ID = entity.UUID(dto.ID)
+	company.Version = dto.Version
+	return nil
+}
+
+func (r *CompanyRepository) Get(ctx context.Context, id entity.UUID) (*entity.Company, error) {
+	dto := &CompanyDTO{}
+	q := sq.Select(columns...).
+		From("companies").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+	query, args := q.MustSql()
+	if err := r.database.GetContext(ctx, dto, query, args...); err != nil {
+		return nil, fromError(err, "").WithParam("company_id", string(id))
+	}
+	return dto.ToModel()
+}
+
+// ListPage returns the page of the companies matching filter with the facets it requests.
+// The facets and the exact count are the queries of their own.
+func (r *CompanyRepository) ListPage(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+) (*entity.CompanyList, error) {
+	const pageSize = uint64(10)
+	expr, err := filter.Expression()
+	if err != nil {
+		return nil, err
+	}
+	if filter.PageSize == nil {
+		filter.PageSize = utils.Pointer(pageSize)
+	}
+	q := applyConditions(sq.Select(columns...).From("companies"), filter, expr)
+	var search textsearch.Query
+	if filter.Search != nil {
+		search = textsearch.NewQuery(*filter.Search)
+		q = q.Column(sq.Alias(rank(search), "score"))
+	}
+	if filter.PageNumber != nil && *filter.PageNumber > 1 {
+		q = q.Offset((*filter.PageNumber - 1) * *filter.PageSize)
+	}
+	q = q.Limit(*filter.PageSize).OrderBy(pageOrder(filter)...)
+	query, args := q.MustSql()
+	var dto CompanyListDTO
+	if err := r.database.SelectContext(ctx, &dto, query, args...); err != nil {
+		return nil, fromError(err, "")
+	}
+	companies, err := dto.ToModels()
+	if err != nil {
+		return nil, err
+	}
+	for _, company := range companies {
+		if company.Search != nil {
+			company.Search.NameHeadline = search.Headline(company.Name)
+			company.Search.DescriptionHeadline = search.Headline(company.Description)
+		}
+	}
+	list := &entity.CompanyList{Items: companies}
+	if filter.CountMode() == entity.CompanyCountExact {
+		count, err := r.count(ctx, filter, expr)
+		if err != nil {
+			return nil, err
+		}
+		list.Count = &count
+	}
+	if len(filter.Facets) > 0 {
+		list.Facets, err = r.facets(ctx, filter, expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// pageOrder returns the order of filter, by the rank of the search or by ID without one.
+func pageOrder(filter *entity.CompanyFilter) []string {
+	switch {
+	case len(filter.OrderBy) > 0:
+		return filter.OrderBy
+	case filter.Search != nil:
+		return []string{"score DESC", "companies.id"}
+	default:
+		return []string{"companies.id"}
+	}
+}
+
+// facets counts the companies matching filter without the dimension of each requested facet.
+func (r *CompanyRepository) facets(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+	expr filtering.Expr,
+) (*entity.CompanyFacets, error) {
+	facets := &entity.CompanyFacets{}
+	for _, facet := range filter.Facets {
+		without := *filter
+		var counts []*facetCountDTO
+		switch facet {
+		case entity.CompanyFacetType:
+			without.Types = nil
+			if err := r.countBy(ctx, "type", &without, filtering.Without(expr, "type"), &counts); err != nil {
+				return nil, err
+			}
+			facets.Types = make([]*entity.CompanyTypeFacet, len(counts))
+			for i, count := range counts {
+				facets.Types[i] = &entity.CompanyTypeFacet{Type: entity.CompanyType(count.Key), Count: count.Count}
+			}
+		case entity.CompanyFacetRegistered:
+			without.Registered = nil
+			if err := r.countBy(ctx, "registered", &without, filtering.Without(expr, "registered"), &counts); err != nil {
+				return nil, err
+			}
+			facets.Registered = make([]*entity.CompanyRegisteredFacet, len(counts))
+			for i, count := range counts {
+				facets.Registered[i] = &entity.CompanyRegisteredFacet{Registered: count.Key != 0, Count: count.Count}
+			}
+		case entity.CompanyFacetEmployees:
+			employeesExpr := filtering.Without(expr, "amount_of_employees")
+			if err := r.countBy(ctx, "amount_of_employees", &without, employeesExpr, &counts); err != nil {
+				return nil, err
+			}
+			facets.Employees = entity.NewCompanyStatsBuckets(r.employeeBuckets)
+			for _, count := range counts {
+				facets.Employees[entity.CompanyStatsBucketOf(r.employeeBuckets, int(count.Key))].Count += count.Count
+			}
+		}
+	}
+	return facets, nil
+}
+
+// countBy counts the companies matching filter by the values of the column.
+func (r *CompanyRepository) countBy(
+	ctx context.Context,
+	column string,
+	filter *entity.CompanyFilter,
+	expr filtering.Expr,
+	dest *[]*facetCountDTO,
+) error {
+	q := sq.Select("companies."+column+" AS key", "count(*) AS count").
+		From("companies").
+		GroupBy("key").
+		OrderBy("key")
+	query, args := applyConditions(q, filter, expr).MustSql()
+	if err := r.database.SelectContext(ctx, dest, query, args...); err != nil {
+		return fromError(err, "")
+	}
+	return nil
+}
+
+func (r *CompanyRepository) Count(ctx context.Context, filter *entity.CompanyFilter) (uint64, error) {
+	expr, err := filter.Expression()
+	if err != nil {
+		return 0, err
+	}
+	return r.count(ctx, filter, expr)
+}
+
+func (r *CompanyRepository) count(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+	expr filtering.Expr,
+) (uint64, error) {
+	q := applyConditions(sq.Select("count(*)").From("companies"), filter, expr)
+	query, args := q.MustSql()
+	var count uint64
+	if err := r.database.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fromError(err, "")
+	}
+	return count, nil
+}
+
+// Estimate counts the companies exactly, SQLite doesn't estimate the rows of a query.
+func (r *CompanyRepository) Estimate(ctx context.Context, filter *entity.CompanyFilter) (uint64, error) {
+	return r.Count(ctx, filter)
+}
+
+// ListAfter returns up to limit companies matching filter with ID greater than after, ordered by ID.
+func (r *CompanyRepository) ListAfter(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+	after entity.UUID,
+	limit uint64,
+) ([]*entity.Company, error) {
+	expr, err := filter.Expression()
+	if err != nil {
+		return nil, err
+	}
+	q := applyConditions(sq.Select(columns...).From("companies"), filter, expr).
+		OrderBy("companies.id").
+		Limit(limit)
+	if after != "" {
+		q = q.Where(sq.Gt{"id": after})
+	}
+	query, args := q.MustSql()
+	var dto CompanyListDTO
+	if err := r.database.SelectContext(ctx, &dto, query, args...); err != nil {
+		return nil, fromError(err, "")
+	}
+	return dto.ToModels()
+}
+
+// Suggest returns up to limit companies whose name starts with the query or is similar to it.
+// The companies matched by the prefix go first, then the most similar ones.
+// SQLite has no trigrams, so the names are compared in process.
+func (r *CompanyRepository) Suggest(
+	ctx context.Context,
+	query string,
+	threshold float64,
+	limit uint64,
+) ([]*entity.CompanySuggestion, error) {
+	var dto []*CompanySuggestionDTO
+	if err := r.database.SelectContext(
+		ctx,
+		&dto,
+		"SELECT companies.id, companies.name FROM companies",
+	); err != nil {
+		return nil, fromError(err, "")
+	}
+	suggestions := []*entity.CompanySuggestion{}
+	prefixed := map[entity.UUID]bool{}
+	for _, row := range dto {
+		suggestion := row.ToModel()
+		suggestion.Score = textsearch.WordSimilarity(query, suggestion.Name)
+		prefixed[suggestion.ID] = strings.HasPrefix(strings.ToLower(suggestion.Name), strings.ToLower(query))
+		if prefixed[suggestion.ID] || suggestion.Score >= threshold {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		switch {
+		case prefixed[a.ID] != prefixed[b.ID]:
+			return prefixed[a.ID]
+		case a.Score != b.Score:
+			return a.Score > b.Score
+		}
+		return a.Name < b.Name
+	})
+	if uint64(len(suggestions)) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+func (r *CompanyRepository) Update(ctx context.Context, company *entity.Company) error {
+	dto := NewCompanyDTOFromModel(company)
+	q := sq.Update("companies").Where(sq.Eq{"id": company.ID}).
+		Set("updated_at", dto.UpdatedAt).
+		Set("name", dto.Name).
+		Set("description", dto.Description).
+		Set("amount_of_employees", dto.AmountOfEmployees).
+		Set("registered", dto.Registered).
+		Set("type", dto.Type).
+		Set("version", sq.Expr("version + 1")).
+		Suffix("RETURNING version")
+	query, args := q.MustSql()
+	if err := r.database.QueryRowxContext(ctx, query, args...).Scan(&dto.Version); err != nil {
+		return fromError(err, company.Name).WithParam("company_id", string(company.ID))
+	}
+	company.Version = dto.Version
+	return nil
+}
+
+func (r *CompanyRepository) Delete(ctx context.Context, id entity.UUID) error {
+	q := sq.Delete("companies").Where(sq.Eq{"id": id})
+	query, args := q.MustSql()
+	result, err := r.database.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fromError(err, "").WithParam("company_id", string(id))
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fromError(err, "").WithParam("company_id", string(id))
+	}
+	if affected == 0 {
+		return errs.NewEntityNotFound().WithParam("company_id", string(id))
+	}
+	return nil
+}
+
+// fromError reports the violated unique name as Postgres does, name is the name written.
+// The other errors are mapped as the ones of Postgres: no rows, timeouts and so on.
+func fromError(err error, name string) *errs.Error {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return errs.NewInvalidFormError().
+			WithParam("companies_name_key", fmt.Sprintf("Key (name)=(%s) already exists.", name))
+	}
+	return errs.FromPostgresError(err)
+}
+
+// applyConditions restricts q by the parsed filter expression and the other conditions of filter.
+func applyConditions(q sq.SelectBuilder, filter *entity.CompanyFilter, expr filtering.Expr) sq.SelectBuilder {
+	if expr != nil {
+		q = q.Where(compileFilter(expr))
+	}
+	if filter.Search != nil {
+		q = q.Where(matchSearch(textsearch.NewQuery(*filter.Search)))
+	}
+	if len(filter.IDs) > 0 {
+		q = q.Where(sq.Eq{"companies.id": filter.IDs})
+	}
+	if len(filter.Types) > 0 {
+		q = q.Where(sq.Eq{"companies.type": filter.Types})
+	}
+	if filter.Registered != nil {
+		q = q.Where(sq.Eq{"companies.registered": *filter.Registered})
+	}
+	return q
+}
+
+var searchFields = []string{"companies.name", "companies.description"}
+
+// matchSearch matches the companies containing every word of the query in any field, see textsearch.Query.
+func matchSearch(query textsearch.Query) sq.Sqlizer {
+	if len(query.Terms) == 0 {
+		return sq.Expr("false")
+	}
+	words := sq.And{}
+	for _, term := range query.Terms {
+		fields := sq.Or{}
+		for _, field := range searchFields {
+			fields = append(fields, sq.Expr("instr(lower("+field+"), ?) > 0", term))
+		}
+		words = append(words, fields)
+	}
+	return words
+}
+
+// rank sums the weights of the fields containing each word of the query, as textsearch.Query.Rank does.
+func rank(query textsearch.Query) sq.Sqlizer {
+	parts := []string{"0"}
+	var args []any
+	for _, term := range query.Terms {
+		for i, field := range searchFields {
+			parts = append(parts, fmt.Sprintf("(instr(lower(%s), ?) > 0) * %v", field, textsearch.Weights[i]))
+			args = append(args, term)
+		}
+	}
+	return sq.Expr("("+strings.Join(parts, " + ")+")", args...)
+}
+
+// compileFilter compiles the filter expression, which fields are the columns of the companies.
+func compileFilter(expr filtering.Expr) sq.Sqlizer {
+	switch expr := expr.(type) {
+	case *filtering.And:
+		return sq.And{compileFilter(expr.Left), compileFilter(expr.Right)}
+	case *filtering.Or:
+		return sq.Or{compileFilter(expr.Left), compileFilter(expr.Right)}
+	case *filtering.Not:
+		return sq.Expr("NOT (?)", compileFilter(expr.Expr))
+	case *filtering.Restriction:
+		column := "companies." + expr.Field
+		predicates := make(sq.Or, len(expr.Values))
+		for i, value := range expr.Values {
+			predicates[i] = compileRestriction(column, expr.Operator, value.Value)
+		}
+		if len(predicates) == 1 {
+			return predicates[0]
+		}
+		return predicates
+	default:
+		panic(fmt.Sprintf("unexpected filter expression %T", expr))
+	}
+}
+
+func compileRestriction(column string, operator filtering.Operator, value any) sq.Sqlizer {
+	if t, ok := value.(time.Time); ok {
+		value = t.UTC().Format(timeLayout)
+	}
+	switch operator {
+	case filtering.OperatorHas:
+		if text, ok := value.(string); ok {
+			pattern := strings.ReplaceAll(escapeLike(text), "*", "%")
+			return sq.Expr(column+` LIKE ? ESCAPE '\'`, pattern)
+		}
+		return sq.Eq{column: value}
+	case filtering.OperatorNotEqual:
+		return sq.NotEq{column: value}
+	case filtering.OperatorLess:
+		return sq.Lt{column: value}
+	case filtering.OperatorLessEqual:
+		return sq.LtOrEq{column: value}
+	case filtering.OperatorGreater:
+		return sq.Gt{column: value}
+	case filtering.OperatorGreaterEqual:
+		return sq.GtOrEq{column: value}
+	default:
+		return sq.Eq{column: value}
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike escapes the LIKE wildcards, so the value is matched literally.
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+type CompanyDTO struct {
+	ID                string `db:"id"`
+	UpdatedAt         string `db:"updated_at"`
+	CreatedAt         string `db:"created_at"`
+	Name              string `db:"name"`
+	Description       string `db:"description"`
+	AmountOfEmployees int    `db:"amount_of_employees"`
+	Registered        bool   `db:"registered"`
+	Type              uint8  `db:"type"`
+	Version           uint64 `db:"version"`
+	// Score is selected only by the search.
+	Score *float64 `db:"score"`
+}
+
+type CompanyListDTO []*CompanyDTO
+
+func (list CompanyListDTO) ToModels() ([]*entity.Company, error) {
+	companies := make([]*entity.Company, len(list))
+	for i, dto := range list {
+		company, err := dto.ToModel()
+		if err != nil {
+			return nil, err
+		}
+		companies[i] = company
+	}
+	return companies, nil
+}
+
+func NewCompanyDTOFromModel(company *entity.Company) *CompanyDTO {
+	return &CompanyDTO{
+		ID:                string(company.ID),
+		UpdatedAt:         company.UpdatedAt.UTC().Format(timeLayout),
+		CreatedAt:         company.CreatedAt.UTC().Format(timeLayout),
+		Name:              company.Name,
+		Description:       company.Description,
+		AmountOfEmployees: company.AmountOfEmployees,
+		Registered:        company.Registered,
+		Type:              uint8(company.Type),
+		Version:           company.Version,
+	}
+}
+
+func (dto *CompanyDTO) ToModel() (*entity.Company, error) {
+	updatedAt, err := time.Parse(timeLayout, dto.UpdatedAt)
+	if err != nil {
+		return nil, errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	createdAt, err := time.Parse(timeLayout, dto.CreatedAt)
+	if err != nil {
+		return nil, errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	company := &entity.Company{
+		ID:                entity.UUID(dto.ID),
+		UpdatedAt:         updatedAt,
+		CreatedAt:         createdAt,
+		Name:              dto.Name,
+		Description:       dto.Description,
+		AmountOfEmployees: dto.AmountOfEmployees,
+		Registered:        dto.Registered,
+		Type:              entity.CompanyType(dto.Type),
+		Version:           dto.Version,
+	}
+	if dto.Score != nil {
+		company.Search = &entity.CompanySearch{Score: *dto.Score}
+	}
+	return company, nil
+}
+
+type facetCountDTO struct {
+	Key   int64  `db:"key"`
+	Count uint64 `db:"count"`
+}
+
+type CompanySuggestionDTO struct {
+	ID   string `db:"id"`
+	Name string `db:"name"`
+}
+
+func (dto *CompanySuggestionDTO) ToModel() *entity.CompanySuggestion {
+	return &entity.CompanySuggestion{
+		ID:   entity.UUID(dto.ID),
+		Name: dto.Name,
+	}
+}
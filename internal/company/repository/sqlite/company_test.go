@@ -0,0 +1,23 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/018bf/companies/internal/company/repository/conformance"
+	"github.com/018bf/companies/internal/configs"
+	sqliteInterface "github.com/018bf/companies/internal/interfaces/sqlite"
+)
+
+func TestCompanyRepository(t *testing.T) {
+	conformance.Run(t, func(t *testing.T) conformance.Repository {
+		config := configs.NewMockConfig(t)
+		config.Stats.EmployeeBuckets = conformance.EmployeeBuckets
+		config.Storage.SQLite.Path = ":memory:"
+		database, err := sqliteInterface.NewDatabase(config)
+		if err != nil {
+			t.Fatalf("NewDatabase() error = %v", err)
+		}
+		t.Cleanup(func() { _ = database.Close() })
+		return NewCompanyRepository(database, config)
+	})
+}
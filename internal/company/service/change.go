@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/clock"
+	"github.com/018bf/companies/pkg/log"
+)
+
+//go:generate mockgen -source=change.go -package=service -destination=change_mock.go
+
+type changeRepository interface {
+	List(
+		ctx context.Context,
+		filter *entity.CompanyFilter,
+		after uint64,
+		limit uint64,
+	) ([]*entity.CompanyChange, error)
+	ListLatest(ctx context.Context, after uint64, limit uint64) ([]*entity.CompanyChange, error)
+	Prune(ctx context.Context, before time.Time) (int64, error)
+	LastPosition(ctx context.Context) (uint64, error)
+}
+
+type changeListener interface {
+	Subscribe() (<-chan struct{}, func())
+}
+
+type ChangeService struct {
+	changeRepository changeRepository
+	changeListener   changeListener
+	clock            clock.Clock
+	logger           log.Logger
+	heartbeat        time.Duration
+	batchSize        uint64
+	retention        time.Duration
+}
+
+func NewChangeService(
+	changeRepository changeRepository,
+	changeListener changeListener,
+	config *configs.Config,
+	clock clock.Clock,
+	logger log.Logger,
+) *ChangeService {
+	return &ChangeService{
+		changeRepository: changeRepository,
+		changeListener:   changeListener,
+		clock:            clock,
+		logger:           logger,
+		heartbeat:        time.Duration(config.Changes.Heartbeat) * time.Second,
+		batchSize:        config.Changes.BatchSize,
+		retention:        time.Duration(config.Changes.Retention) * time.Second,
+	}
+}
+
+// List returns the next page of the change feed after the continuation token, or from the start without one.
+// A token older than the retention is rejected, since the tombstones after it may be pruned
+// and the client has to sync again from the start.
+func (u *ChangeService) List(ctx context.Context, since string) (*entity.CompanyChangeList, error) {
+	now := u.clock.Now().UTC()
+	var after uint64
+	if since != "" {
+		token, err := entity.ParseChangeToken(since)
+		if err != nil {
+			return nil, err
+		}
+		if now.Sub(token.IssuedAt) > u.retention {
+			return nil, errs.NewError(errs.ErrorCodeFailedPrecondition, "Change token has expired.").
+				WithParam("token", since)
+		}
+		after = token.Position
+	}
+	changes, err := u.changeRepository.ListLatest(ctx, after, u.batchSize+1)
+	if err != nil {
+		return nil, err
+	}
+	list := &entity.CompanyChangeList{Items: changes}
+	if uint64(len(changes)) > u.batchSize {
+		list.Items = changes[:u.batchSize]
+		list.HasMore = true
+	}
+	// The client is up to date with the feed at now only on the last page. In the middle of a sync,
+	// the tombstones after the page may be as old as its last change, so the token ages from it.
+	issuedAt := now
+	if len(list.Items) > 0 {
+		last := list.Items[len(list.Items)-1]
+		after = last.Position
+		if list.HasMore {
+			issuedAt = last.ChangedAt
+		}
+	}
+	list.Token = entity.ChangeToken{Position: after, IssuedAt: issuedAt}.String()
+	return list, nil
+}
+
+// Prune deletes the changes which are older than the retention and aren't needed to read the feed.
+func (u *ChangeService) Prune(ctx context.Context) (int64, error) {
+	return u.changeRepository.Prune(ctx, u.clock.Now().UTC().Add(-u.retention))
+}
+
+// Watch sends the changes matching filter to stream until ctx is done.
+// With a position it first sends the changes made after it, otherwise only the new ones.
+func (u *ChangeService) Watch(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+	position *uint64,
+	stream entity.CompanyChangeStream,
+) error {
+	// Subscribe before reading the changes, so a change made in between isn't missed.
+	wake, cancel := u.changeListener.Subscribe()
+	defer cancel()
+	var after uint64
+	if position != nil {
+		after = *position
+	} else {
+		last, err := u.changeRepository.LastPosition(ctx)
+		if err != nil {
+			return err
+		}
+		after = last
+	}
+	after, err := u.send(ctx, filter, after, stream)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(u.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-wake:
+			after, err = u.send(ctx, filter, after, stream)
+			if err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := stream.Heartbeat(after); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// send sends all changes after the position and returns the position of the last one.
+func (u *ChangeService) send(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+	after uint64,
+	stream entity.CompanyChangeStream,
+) (uint64, error) {
+	for {
+		changes, err := u.changeRepository.List(ctx, filter, after, u.batchSize)
+		if err != nil {
+			return after, err
+		}
+		for _, change := range changes {
+			if err := stream.Send(change); err != nil {
+				return after, err
+			}
+			after = change.Position
+		}
+		if uint64(len(changes)) < u.batchSize {
+			return after, nil
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: change.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockchangeRepository is a mock of changeRepository interface.
+type MockchangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockchangeRepositoryMockRecorder
+}
+
+// MockchangeRepositoryMockRecorder is the mock recorder for MockchangeRepository.
+type MockchangeRepositoryMockRecorder struct {
+	mock *MockchangeRepository
+}
+
+// NewMockchangeRepository creates a new mock instance.
+func NewMockchangeRepository(ctrl *gomock.Controller) *MockchangeRepository {
+	mock := &MockchangeRepository{ctrl: ctrl}
+	mock.recorder = &MockchangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockchangeRepository) EXPECT() *MockchangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// LastPosition mocks base method.
+func (m *MockchangeRepository) LastPosition(ctx context.Context) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastPosition", ctx)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastPosition indicates an expected call of LastPosition.
+func (mr *MockchangeRepositoryMockRecorder) LastPosition(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastPosition", reflect.TypeOf((*MockchangeRepository)(nil).LastPosition), ctx)
+}
+
+// List mocks base method.
+func (m *MockchangeRepository) List(ctx context.Context, filter *entity.CompanyFilter, after, limit uint64) ([]*entity.CompanyChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, after, limit)
+	ret0, _ := ret[0].([]*entity.CompanyChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockchangeRepositoryMockRecorder) List(ctx, filter, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockchangeRepository)(nil).List), ctx, filter, after, limit)
+}
+
+// ListLatest mocks base method.
+func (m *MockchangeRepository) ListLatest(ctx context.Context, after, limit uint64) ([]*entity.CompanyChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLatest", ctx, after, limit)
+	ret0, _ := ret[0].([]*entity.CompanyChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLatest indicates an expected call of ListLatest.
+func (mr *MockchangeRepositoryMockRecorder) ListLatest(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLatest", reflect.TypeOf((*MockchangeRepository)(nil).ListLatest), ctx, after, limit)
+}
+
+// Prune mocks base method.
+func (m *MockchangeRepository) Prune(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockchangeRepositoryMockRecorder) Prune(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockchangeRepository)(nil).Prune), ctx, before)
+}
+
+// MockchangeListener is a mock of changeListener interface.
+type MockchangeListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockchangeListenerMockRecorder
+}
+
+// MockchangeListenerMockRecorder is the mock recorder for MockchangeListener.
+type MockchangeListenerMockRecorder struct {
+	mock *MockchangeListener
+}
+
+// NewMockchangeListener creates a new mock instance.
+func NewMockchangeListener(ctrl *gomock.Controller) *MockchangeListener {
+	mock := &MockchangeListener{ctrl: ctrl}
+	mock.recorder = &MockchangeListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockchangeListener) EXPECT() *MockchangeListenerMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockchangeListener) Subscribe() (<-chan struct{}, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe")
+	ret0, _ := ret[0].(<-chan struct{})
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockchangeListenerMockRecorder) Subscribe() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockchangeListener)(nil).Subscribe))
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/018bf/companies/pkg/utils"
+	"github.com/golang/mock/gomock"
+)
+
+type changeStream struct {
+	changes    []*entity.CompanyChange
+	heartbeats []uint64
+	err        error
+	cancel     context.CancelFunc
+}
+
+func (s *changeStream) Send(change *entity.CompanyChange) error {
+	s.changes = append(s.changes, change)
+	return s.err
+}
+
+func (s *changeStream) Heartbeat(position uint64) error {
+	s.heartbeats = append(s.heartbeats, position)
+	s.cancel()
+	return nil
+}
+
+func TestNewChangeService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockChangeRepository := NewMockchangeRepository(ctrl)
+	mockChangeListener := NewMockchangeListener(ctrl)
+	mockClock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	config := configs.NewMockConfig(t)
+	want := &ChangeService{
+		changeRepository: mockChangeRepository,
+		changeListener:   mockChangeListener,
+		clock:            mockClock,
+		logger:           logger,
+		heartbeat:        15 * time.Second,
+		batchSize:        100,
+		retention:        7 * 24 * time.Hour,
+	}
+	got := NewChangeService(mockChangeRepository, mockChangeListener, config, mockClock, logger)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewChangeService() = %v, want %v", got, want)
+	}
+}
+
+func TestChangeService_Watch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockChangeRepository := NewMockchangeRepository(ctrl)
+	mockChangeListener := NewMockchangeListener(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	filter := &entity.CompanyFilter{}
+	first := mock_models.NewCompanyChange(t)
+	first.Position = 11
+	second := mock_models.NewCompanyChange(t)
+	second.Position = 12
+	third := mock_models.NewCompanyChange(t)
+	third.Position = 15
+	tests := []struct {
+		name           string
+		setup          func(ctx context.Context, wake chan struct{})
+		position       *uint64
+		streamErr      error
+		wantChanges    []*entity.CompanyChange
+		wantHeartbeats []uint64
+		wantErr        error
+	}{
+		{
+			name: "resume",
+			setup: func(ctx context.Context, wake chan struct{}) {
+				mockChangeRepository.EXPECT().List(ctx, filter, uint64(10), uint64(2)).
+					Return([]*entity.CompanyChange{first, second}, nil)
+				mockChangeRepository.EXPECT().List(ctx, filter, uint64(12), uint64(2)).
+					Return(nil, nil)
+			},
+			position:       utils.Pointer(uint64(10)),
+			wantChanges:    []*entity.CompanyChange{first, second},
+			wantHeartbeats: []uint64{12},
+			wantErr:        nil,
+		},
+		{
+			name: "new changes",
+			setup: func(ctx context.Context, wake chan struct{}) {
+				mockChangeRepository.EXPECT().LastPosition(ctx).Return(uint64(14), nil)
+				mockChangeRepository.EXPECT().List(ctx, filter, uint64(14), uint64(2)).
+					DoAndReturn(func(context.Context, *entity.CompanyFilter, uint64, uint64) ([]*entity.CompanyChange, error) {
+						wake <- struct{}{}
+						return nil, nil
+					})
+				mockChangeRepository.EXPECT().List(ctx, filter, uint64(14), uint64(2)).
+					Return([]*entity.CompanyChange{third}, nil)
+			},
+			position:       nil,
+			wantChanges:    []*entity.CompanyChange{third},
+			wantHeartbeats: []uint64{15},
+			wantErr:        nil,
+		},
+		{
+			name: "last position error",
+			setup: func(ctx context.Context, wake chan struct{}) {
+				mockChangeRepository.EXPECT().LastPosition(ctx).
+					Return(uint64(0), errs.NewUnexpectedBehaviorError("test error"))
+			},
+			position: nil,
+			wantErr:  errs.NewUnexpectedBehaviorError("test error"),
+		},
+		{
+			name: "list error",
+			setup: func(ctx context.Context, wake chan struct{}) {
+				mockChangeRepository.EXPECT().List(ctx, filter, uint64(10), uint64(2)).
+					Return(nil, errs.NewUnexpectedBehaviorError("test error"))
+			},
+			position: utils.Pointer(uint64(10)),
+			wantErr:  errs.NewUnexpectedBehaviorError("test error"),
+		},
+		{
+			name: "stream error",
+			setup: func(ctx context.Context, wake chan struct{}) {
+				mockChangeRepository.EXPECT().List(ctx, filter, uint64(10), uint64(2)).
+					Return([]*entity.CompanyChange{first, second}, nil)
+			},
+			position:    utils.Pointer(uint64(10)),
+			streamErr:   errs.NewUnexpectedBehaviorError("stream error"),
+			wantChanges: []*entity.CompanyChange{first},
+			wantErr:     errs.NewUnexpectedBehaviorError("stream error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			wake := make(chan struct{}, 1)
+			unsubscribed := false
+			mockChangeListener.EXPECT().Subscribe().Return(wake, func() { unsubscribed = true })
+			tt.setup(ctx, wake)
+			stream := &changeStream{err: tt.streamErr, cancel: cancel}
+			u := &ChangeService{
+				changeRepository: mockChangeRepository,
+				changeListener:   mockChangeListener,
+				logger:           logger,
+				heartbeat:        10 * time.Millisecond,
+				batchSize:        2,
+			}
+			err := u.Watch(ctx, filter, tt.position, stream)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Watch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(stream.changes, tt.wantChanges) {
+				t.Errorf("Watch() changes = %v, want %v", stream.changes, tt.wantChanges)
+			}
+			if !reflect.DeepEqual(stream.heartbeats, tt.wantHeartbeats) {
+				t.Errorf("Watch() heartbeats = %v, want %v", stream.heartbeats, tt.wantHeartbeats)
+			}
+			if !unsubscribed {
+				t.Error("Watch() didn't unsubscribe")
+			}
+		})
+	}
+}
+
+func TestChangeService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockChangeRepository := NewMockchangeRepository(ctrl)
+	mockClock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	first := mock_models.NewCompanyChange(t)
+	first.Position = 11
+	second := mock_models.NewCompanyChange(t)
+	second.Position = 15
+	second.ChangedAt = now.Add(-23 * time.Hour)
+	since := entity.ChangeToken{Position: 10, IssuedAt: now.Add(-time.Hour)}.String()
+	expired := entity.ChangeToken{Position: 10, IssuedAt: now.Add(-25 * time.Hour)}.String()
+	tests := []struct {
+		name    string
+		setup   func()
+		since   string
+		want    *entity.CompanyChangeList
+		wantErr error
+	}{
+		{
+			name: "from start",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+				mockChangeRepository.EXPECT().
+					ListLatest(ctx, uint64(0), uint64(3)).
+					Return([]*entity.CompanyChange{first}, nil)
+			},
+			since: "",
+			want: &entity.CompanyChangeList{
+				Items:   []*entity.CompanyChange{first},
+				Token:   entity.ChangeToken{Position: 11, IssuedAt: now}.String(),
+				HasMore: false,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "has more",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+				mockChangeRepository.EXPECT().
+					ListLatest(ctx, uint64(10), uint64(3)).
+					Return([]*entity.CompanyChange{first, second, mock_models.NewCompanyChange(t)}, nil)
+			},
+			since: since,
+			want: &entity.CompanyChangeList{
+				Items:   []*entity.CompanyChange{first, second},
+				Token:   entity.ChangeToken{Position: 15, IssuedAt: now.Add(-23 * time.Hour)}.String(),
+				HasMore: true,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "no changes",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+				mockChangeRepository.EXPECT().
+					ListLatest(ctx, uint64(10), uint64(3)).
+					Return([]*entity.CompanyChange{}, nil)
+			},
+			since: since,
+			want: &entity.CompanyChangeList{
+				Items:   []*entity.CompanyChange{},
+				Token:   entity.ChangeToken{Position: 10, IssuedAt: now}.String(),
+				HasMore: false,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "caught up",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+				mockChangeRepository.EXPECT().
+					ListLatest(ctx, uint64(10), uint64(3)).
+					Return([]*entity.CompanyChange{first, second}, nil)
+			},
+			since: since,
+			want: &entity.CompanyChangeList{
+				Items:   []*entity.CompanyChange{first, second},
+				Token:   entity.ChangeToken{Position: 15, IssuedAt: now}.String(),
+				HasMore: false,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "expired token",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+			},
+			since:   expired,
+			want:    nil,
+			wantErr: errs.NewError(errs.ErrorCodeFailedPrecondition, "Change token has expired.").WithParam("token", expired),
+		},
+		{
+			name: "invalid token",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+			},
+			since:   "bad token",
+			want:    nil,
+			wantErr: errs.NewInvalidParameter("Invalid change token.").WithParam("token", "bad token"),
+		},
+		{
+			name: "repository error",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+				mockChangeRepository.EXPECT().
+					ListLatest(ctx, uint64(10), uint64(3)).
+					Return(nil, errs.NewUnexpectedBehaviorError("l e"))
+			},
+			since:   since,
+			want:    nil,
+			wantErr: errs.NewUnexpectedBehaviorError("l e"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := &ChangeService{
+				changeRepository: mockChangeRepository,
+				clock:            mockClock,
+				logger:           logger,
+				batchSize:        2,
+				retention:        24 * time.Hour,
+			}
+			got, err := u.List(ctx, tt.since)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ChangeService.List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChangeService.List() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeService_Prune(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockChangeRepository := NewMockchangeRepository(ctrl)
+	mockClock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	now := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	mockClock.EXPECT().Now().Return(now)
+	mockChangeRepository.EXPECT().Prune(ctx, now.Add(-24*time.Hour)).Return(int64(5), nil)
+	u := &ChangeService{
+		changeRepository: mockChangeRepository,
+		clock:            mockClock,
+		logger:           logger,
+		retention:        24 * time.Hour,
+	}
+	got, err := u.Prune(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 5 {
+		t.Errorf("ChangeService.Prune() = %v, want 5", got)
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	"github.com/018bf/companies/internal/configs"
 	"github.com/018bf/companies/internal/entity"
 	"github.com/018bf/companies/pkg/clock"
 	"github.com/018bf/companies/pkg/log"
@@ -12,25 +13,46 @@ import (
 
 type companyRepository interface {
 	Get(ctx context.Context, id entity.UUID) (*entity.Company, error)
-	List(ctx context.Context, filter *entity.CompanyFilter) ([]*entity.Company, error) // deprecated
-	Count(ctx context.Context, filter *entity.CompanyFilter) (uint64, error)           // deprecated
+	ListPage(ctx context.Context, filter *entity.CompanyFilter) (*entity.CompanyList, error)
+	Estimate(ctx context.Context, filter *entity.CompanyFilter) (uint64, error)
 	Update(ctx context.Context, update *entity.Company) error
 	Create(ctx context.Context, create *entity.Company) error
 	Delete(ctx context.Context, id entity.UUID) error
+	Suggest(ctx context.Context, query string, threshold float64, limit uint64) ([]*entity.CompanySuggestion, error)
+}
+
+// txManager runs the operations in a transaction and retries them on the transient database errors.
+type txManager interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Retry(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type CompanyService struct {
 	companyRepository companyRepository
+	txManager         txManager
 	clock             clock.Clock
 	logger            log.Logger
+	suggestThreshold  float64
+	suggestLimit      uint64
+	suggestMaxLimit   uint64
 }
 
 func NewCompanyService(
 	companyRepository companyRepository,
+	txManager txManager,
+	config *configs.Config,
 	clock clock.Clock,
 	logger log.Logger,
 ) *CompanyService {
-	return &CompanyService{companyRepository: companyRepository, clock: clock, logger: logger}
+	return &CompanyService{
+		companyRepository: companyRepository,
+		txManager:         txManager,
+		clock:             clock,
+		logger:            logger,
+		suggestThreshold:  config.Suggest.Threshold,
+		suggestLimit:      config.Suggest.Limit,
+		suggestMaxLimit:   config.Suggest.MaxLimit,
+	}
 }
 
 func (u *CompanyService) Create(
@@ -60,65 +82,117 @@ func (u *CompanyService) Get(ctx context.Context, id entity.UUID) (*entity.Compa
 	if err := id.Validate(); err != nil {
 		return nil, err
 	}
-	company, err := u.companyRepository.Get(ctx, id)
+	var company *entity.Company
+	err := u.txManager.Retry(ctx, func(ctx context.Context) error {
+		var err error
+		company, err = u.companyRepository.Get(ctx, id)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 	return company, nil
 }
 
-// List
-// deprecated
+// List returns the page of the companies counted as the filter asks, the facets are counted along when requested.
 func (u *CompanyService) List(
 	ctx context.Context,
 	filter *entity.CompanyFilter,
-) ([]*entity.Company, uint64, error) {
+) (*entity.CompanyList, error) {
 	if err := filter.Validate(); err != nil {
-		return nil, 0, err
+		return nil, err
 	}
-	company, err := u.companyRepository.List(ctx, filter)
+	var list *entity.CompanyList
+	err := u.txManager.Retry(ctx, func(ctx context.Context) error {
+		var err error
+		list, err = u.companyRepository.ListPage(ctx, filter)
+		if err != nil || filter.CountMode() != entity.CompanyCountEstimated {
+			return err
+		}
+		count, err := u.companyRepository.Estimate(ctx, filter)
+		if err != nil {
+			return err
+		}
+		list.Count = &count
+		list.Estimated = true
+		return nil
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	return list, nil
+}
+
+// Suggest returns the companies whose names complete or resemble the query.
+// The limit defaults to the configured one and is capped by the maximum.
+func (u *CompanyService) Suggest(
+	ctx context.Context,
+	suggest *entity.CompanySuggest,
+) ([]*entity.CompanySuggestion, error) {
+	if err := suggest.Validate(); err != nil {
+		return nil, err
 	}
-	count, err := u.companyRepository.Count(ctx, filter)
+	limit := u.suggestLimit
+	if suggest.Limit != nil && *suggest.Limit > 0 {
+		limit = *suggest.Limit
+	}
+	if limit > u.suggestMaxLimit {
+		limit = u.suggestMaxLimit
+	}
+	var suggestions []*entity.CompanySuggestion
+	err := u.txManager.Retry(ctx, func(ctx context.Context) error {
+		var err error
+		suggestions, err = u.companyRepository.Suggest(ctx, suggest.Query, u.suggestThreshold, limit)
+		return err
+	})
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
-	return company, count, nil
+	return suggestions, nil
 }
 
+// Update reads and updates the company in one transaction, which is retried on a concurrent update.
+// It returns the company as it was read in the transaction and the updated one.
 func (u *CompanyService) Update(
 	ctx context.Context,
 	update *entity.CompanyUpdate,
-) (*entity.Company, error) {
+) (*entity.Company, *entity.Company, error) {
 	if err := update.Validate(); err != nil {
-		return nil, err
-	}
-	company, err := u.companyRepository.Get(ctx, update.ID)
+		return nil, nil, err
+	}
+	var before, company *entity.Company
+	err := u.txManager.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		company, err = u.companyRepository.Get(ctx, update.ID)
+		if err != nil {
+			return err
+		}
+		read := *company
+		before = &read
+		if update.Name != nil {
+			company.Name = *update.Name
+		}
+		if update.Description != nil {
+			company.Description = *update.Description
+		}
+		if update.AmountOfEmployees != nil {
+			company.AmountOfEmployees = *update.AmountOfEmployees
+		}
+		if update.Registered != nil {
+			company.Registered = *update.Registered
+		}
+		if update.Type != nil {
+			company.Type = *update.Type
+		}
+		company.UpdatedAt = u.clock.Now().UTC()
+		return u.companyRepository.Update(ctx, company)
+	})
 	if err != nil {
-		return nil, err
-	}
-	if update.Name != nil {
-		company.Name = *update.Name
-	}
-	if update.Description != nil {
-		company.Description = *update.Description
+		return nil, nil, err
 	}
-	if update.AmountOfEmployees != nil {
-		company.AmountOfEmployees = *update.AmountOfEmployees
-	}
-	if update.Registered != nil {
-		company.Registered = *update.Registered
-	}
-	if update.Type != nil {
-		company.Type = *update.Type
-	}
-	company.UpdatedAt = u.clock.Now().UTC()
-	if err := u.companyRepository.Update(ctx, company); err != nil {
-		return nil, err
-	}
-	return company, nil
+	return before, company, nil
 }
+
 func (u *CompanyService) Delete(ctx context.Context, id entity.UUID) error {
 	if err := u.companyRepository.Delete(ctx, id); err != nil {
 		return err
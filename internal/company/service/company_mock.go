@@ -8,7 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
-	models "github.com/018bf/companies/internal/entity"
+	entity "github.com/018bf/companies/internal/entity"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,23 +35,8 @@ func (m *MockcompanyRepository) EXPECT() *MockcompanyRepositoryMockRecorder {
 	return m.recorder
 }
 
-// Count mocks base method.
-func (m *MockcompanyRepository) Count(ctx context.Context, filter *models.CompanyFilter) (uint64, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Count", ctx, filter)
-	ret0, _ := ret[0].(uint64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// Count indicates an expected call of Count.
-func (mr *MockcompanyRepositoryMockRecorder) Count(ctx, filter interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockcompanyRepository)(nil).Count), ctx, filter)
-}
-
 // Create mocks base method.
-func (m *MockcompanyRepository) Create(ctx context.Context, create *models.Company) error {
+func (m *MockcompanyRepository) Create(ctx context.Context, create *entity.Company) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", ctx, create)
 	ret0, _ := ret[0].(error)
@@ -65,7 +50,7 @@ func (mr *MockcompanyRepositoryMockRecorder) Create(ctx, create interface{}) *go
 }
 
 // Delete mocks base method.
-func (m *MockcompanyRepository) Delete(ctx context.Context, id models.UUID) error {
+func (m *MockcompanyRepository) Delete(ctx context.Context, id entity.UUID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", ctx, id)
 	ret0, _ := ret[0].(error)
@@ -78,11 +63,26 @@ func (mr *MockcompanyRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockcompanyRepository)(nil).Delete), ctx, id)
 }
 
+// Estimate mocks base method.
+func (m *MockcompanyRepository) Estimate(ctx context.Context, filter *entity.CompanyFilter) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Estimate", ctx, filter)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Estimate indicates an expected call of Estimate.
+func (mr *MockcompanyRepositoryMockRecorder) Estimate(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Estimate", reflect.TypeOf((*MockcompanyRepository)(nil).Estimate), ctx, filter)
+}
+
 // Get mocks base method.
-func (m *MockcompanyRepository) Get(ctx context.Context, id models.UUID) (*models.Company, error) {
+func (m *MockcompanyRepository) Get(ctx context.Context, id entity.UUID) (*entity.Company, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", ctx, id)
-	ret0, _ := ret[0].(*models.Company)
+	ret0, _ := ret[0].(*entity.Company)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -93,23 +93,38 @@ func (mr *MockcompanyRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockcompanyRepository)(nil).Get), ctx, id)
 }
 
-// List mocks base method.
-func (m *MockcompanyRepository) List(ctx context.Context, filter *models.CompanyFilter) ([]*models.Company, error) {
+// ListPage mocks base method.
+func (m *MockcompanyRepository) ListPage(ctx context.Context, filter *entity.CompanyFilter) (*entity.CompanyList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, filter)
-	ret0, _ := ret[0].([]*models.Company)
+	ret := m.ctrl.Call(m, "ListPage", ctx, filter)
+	ret0, _ := ret[0].(*entity.CompanyList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// List indicates an expected call of List.
-func (mr *MockcompanyRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+// ListPage indicates an expected call of ListPage.
+func (mr *MockcompanyRepositoryMockRecorder) ListPage(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockcompanyRepository)(nil).List), ctx, filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPage", reflect.TypeOf((*MockcompanyRepository)(nil).ListPage), ctx, filter)
+}
+
+// Suggest mocks base method.
+func (m *MockcompanyRepository) Suggest(ctx context.Context, query string, threshold float64, limit uint64) ([]*entity.CompanySuggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, query, threshold, limit)
+	ret0, _ := ret[0].([]*entity.CompanySuggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockcompanyRepositoryMockRecorder) Suggest(ctx, query, threshold, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockcompanyRepository)(nil).Suggest), ctx, query, threshold, limit)
 }
 
 // Update mocks base method.
-func (m *MockcompanyRepository) Update(ctx context.Context, update *models.Company) error {
+func (m *MockcompanyRepository) Update(ctx context.Context, update *entity.Company) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", ctx, update)
 	ret0, _ := ret[0].(error)
@@ -121,3 +136,54 @@ func (mr *MockcompanyRepositoryMockRecorder) Update(ctx, update interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockcompanyRepository)(nil).Update), ctx, update)
 }
+
+// MocktxManager is a mock of txManager interface.
+type MocktxManager struct {
+	ctrl     *gomock.Controller
+	recorder *MocktxManagerMockRecorder
+}
+
+// MocktxManagerMockRecorder is the mock recorder for MocktxManager.
+type MocktxManagerMockRecorder struct {
+	mock *MocktxManager
+}
+
+// NewMocktxManager creates a new mock instance.
+func NewMocktxManager(ctrl *gomock.Controller) *MocktxManager {
+	mock := &MocktxManager{ctrl: ctrl}
+	mock.recorder = &MocktxManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktxManager) EXPECT() *MocktxManagerMockRecorder {
+	return m.recorder
+}
+
+// Retry mocks base method.
+func (m *MocktxManager) Retry(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MocktxManagerMockRecorder) Retry(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MocktxManager)(nil).Retry), ctx, fn)
+}
+
+// Transaction mocks base method.
+func (m *MocktxManager) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MocktxManagerMockRecorder) Transaction(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MocktxManager)(nil).Transaction), ctx, fn)
+}
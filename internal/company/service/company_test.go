@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/018bf/companies/internal/configs"
 	"github.com/018bf/companies/internal/entity"
 	mock_models "github.com/018bf/companies/internal/entity/mock"
 	"github.com/018bf/companies/internal/errs"
@@ -14,18 +15,32 @@ import (
 	mock_clock "github.com/018bf/companies/pkg/clock/mock"
 	"github.com/018bf/companies/pkg/log"
 	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/018bf/companies/pkg/utils"
 	"github.com/golang/mock/gomock"
 	"github.com/jaswdr/faker"
 )
 
+// newMockTxManager returns the txManager running the operations once in the caller's context.
+func newMockTxManager(ctrl *gomock.Controller) *MocktxManager {
+	mockTxManager := NewMocktxManager(ctrl)
+	run := func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }
+	mockTxManager.EXPECT().Transaction(gomock.Any(), gomock.Any()).DoAndReturn(run).AnyTimes()
+	mockTxManager.EXPECT().Retry(gomock.Any(), gomock.Any()).DoAndReturn(run).AnyTimes()
+	return mockTxManager
+}
+
 func TestNewCompanyService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockTxManager := NewMocktxManager(ctrl)
 	clockMock := mock_clock.NewMockClock(ctrl)
 	logger := mock_log.NewMockLogger(ctrl)
+	config := configs.NewMockConfig(t)
 	type args struct {
 		companyRepository companyRepository
+		txManager         txManager
+		config            *configs.Config
 		clock             clock.Clock
 		logger            log.Logger
 	}
@@ -41,20 +56,32 @@ func TestNewCompanyService(t *testing.T) {
 			},
 			args: args{
 				companyRepository: mockCompanyRepository,
+				txManager:         mockTxManager,
+				config:            config,
 				clock:             clockMock,
 				logger:            logger,
 			},
 			want: &CompanyService{
 				companyRepository: mockCompanyRepository,
+				txManager:         mockTxManager,
 				clock:             clockMock,
 				logger:            logger,
+				suggestThreshold:  0.3,
+				suggestLimit:      10,
+				suggestMaxLimit:   50,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			if got := NewCompanyService(tt.args.companyRepository, tt.args.clock, tt.args.logger); !reflect.DeepEqual(
+			if got := NewCompanyService(
+				tt.args.companyRepository,
+				tt.args.txManager,
+				tt.args.config,
+				tt.args.clock,
+				tt.args.logger,
+			); !reflect.DeepEqual(
 				got,
 				tt.want,
 			) {
@@ -68,6 +95,7 @@ func TestCompanyService_Get(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockTxManager := newMockTxManager(ctrl)
 	logger := mock_log.NewMockLogger(ctrl)
 	ctx := context.Background()
 	company := mock_models.NewCompany(t)
@@ -145,6 +173,7 @@ func TestCompanyService_Get(t *testing.T) {
 			tt.setup()
 			u := &CompanyService{
 				companyRepository: tt.fields.companyRepository,
+				txManager:         mockTxManager,
 				logger:            tt.fields.logger,
 			}
 			got, err := u.Get(tt.args.ctx, tt.args.id)
@@ -163,6 +192,7 @@ func TestCompanyService_List(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockTxManager := newMockTxManager(ctrl)
 	logger := mock_log.NewMockLogger(ctrl)
 	ctx := context.Background()
 	var listCompanies []*entity.Company
@@ -171,6 +201,12 @@ func TestCompanyService_List(t *testing.T) {
 		listCompanies = append(listCompanies, mock_models.NewCompany(t))
 	}
 	filter := mock_models.NewCompanyFilter(t)
+	facetedFilter := &entity.CompanyFilter{Facets: []entity.CompanyFacet{entity.CompanyFacetType}}
+	facets := &entity.CompanyFacets{
+		Types: []*entity.CompanyTypeFacet{{Type: entity.CompanyTypeNonProfit, Count: count}},
+	}
+	estimatedFilter := &entity.CompanyFilter{Count: utils.Pointer(entity.CompanyCountEstimated)}
+	estimate := faker.New().UInt64Between(1000, 1000000)
 	type fields struct {
 		companyRepository companyRepository
 		logger            log.Logger
@@ -184,15 +220,15 @@ func TestCompanyService_List(t *testing.T) {
 		setup   func()
 		fields  fields
 		args    args
-		want    []*entity.Company
-		want1   uint64
+		want    *entity.CompanyList
 		wantErr error
 	}{
 		{
 			name: "ok",
 			setup: func() {
-				mockCompanyRepository.EXPECT().List(ctx, filter).Return(listCompanies, nil)
-				mockCompanyRepository.EXPECT().Count(ctx, filter).Return(count, nil)
+				mockCompanyRepository.EXPECT().
+					ListPage(ctx, filter).
+					Return(&entity.CompanyList{Items: listCompanies, Count: utils.Pointer(count)}, nil)
 			},
 			fields: fields{
 				companyRepository: mockCompanyRepository,
@@ -202,8 +238,44 @@ func TestCompanyService_List(t *testing.T) {
 				ctx:    ctx,
 				filter: filter,
 			},
-			want:    listCompanies,
-			want1:   count,
+			want:    &entity.CompanyList{Items: listCompanies, Count: utils.Pointer(count)},
+			wantErr: nil,
+		},
+		{
+			name: "facets",
+			setup: func() {
+				mockCompanyRepository.EXPECT().
+					ListPage(ctx, facetedFilter).
+					Return(&entity.CompanyList{Items: listCompanies, Count: utils.Pointer(count), Facets: facets}, nil)
+			},
+			fields: fields{
+				companyRepository: mockCompanyRepository,
+				logger:            logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: facetedFilter,
+			},
+			want:    &entity.CompanyList{Items: listCompanies, Count: utils.Pointer(count), Facets: facets},
+			wantErr: nil,
+		},
+		{
+			name: "estimated count",
+			setup: func() {
+				mockCompanyRepository.EXPECT().
+					ListPage(ctx, estimatedFilter).
+					Return(&entity.CompanyList{Items: listCompanies}, nil)
+				mockCompanyRepository.EXPECT().Estimate(ctx, estimatedFilter).Return(estimate, nil)
+			},
+			fields: fields{
+				companyRepository: mockCompanyRepository,
+				logger:            logger,
+			},
+			args: args{
+				ctx:    ctx,
+				filter: estimatedFilter,
+			},
+			want:    &entity.CompanyList{Items: listCompanies, Count: utils.Pointer(estimate), Estimated: true},
 			wantErr: nil,
 		},
 		{
@@ -220,14 +292,13 @@ func TestCompanyService_List(t *testing.T) {
 				},
 			},
 			want:    nil,
-			want1:   0,
 			wantErr: errs.NewInvalidFormError().WithParam("ids", `0: {"code":3,"message":"must be a valid UUID","params":{}}.`),
 		},
 		{
 			name: "list error",
 			setup: func() {
 				mockCompanyRepository.EXPECT().
-					List(ctx, filter).
+					ListPage(ctx, filter).
 					Return(nil, errs.NewUnexpectedBehaviorError("test error"))
 			},
 			fields: fields{
@@ -239,15 +310,16 @@ func TestCompanyService_List(t *testing.T) {
 				filter: filter,
 			},
 			want:    nil,
-			want1:   0,
 			wantErr: errs.NewUnexpectedBehaviorError("test error"),
 		},
 		{
-			name: "count error",
+			name: "estimate error",
 			setup: func() {
-				mockCompanyRepository.EXPECT().List(ctx, filter).Return(listCompanies, nil)
 				mockCompanyRepository.EXPECT().
-					Count(ctx, filter).
+					ListPage(ctx, estimatedFilter).
+					Return(&entity.CompanyList{Items: listCompanies}, nil)
+				mockCompanyRepository.EXPECT().
+					Estimate(ctx, estimatedFilter).
 					Return(uint64(0), errs.NewUnexpectedBehaviorError("test error"))
 			},
 			fields: fields{
@@ -256,10 +328,9 @@ func TestCompanyService_List(t *testing.T) {
 			},
 			args: args{
 				ctx:    ctx,
-				filter: filter,
+				filter: estimatedFilter,
 			},
 			want:    nil,
-			want1:   0,
 			wantErr: errs.NewUnexpectedBehaviorError("test error"),
 		},
 	}
@@ -268,9 +339,10 @@ func TestCompanyService_List(t *testing.T) {
 			tt.setup()
 			u := &CompanyService{
 				companyRepository: tt.fields.companyRepository,
+				txManager:         mockTxManager,
 				logger:            tt.fields.logger,
 			}
-			got, got1, err := u.List(tt.args.ctx, tt.args.filter)
+			got, err := u.List(tt.args.ctx, tt.args.filter)
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("CompanyService.List() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -278,9 +350,6 @@ func TestCompanyService_List(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CompanyService.List() = %v, want %v", got, tt.want)
 			}
-			if got1 != tt.want1 {
-				t.Errorf("CompanyService.List() got1 = %v, want %v", got1, tt.want1)
-			}
 		})
 	}
 }
@@ -427,11 +496,18 @@ func TestCompanyService_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockTxManager := NewMocktxManager(ctrl)
 	logger := mock_log.NewMockLogger(ctrl)
 	ctx := context.Background()
 	company := mock_models.NewCompany(t)
 	clockMock := mock_clock.NewMockClock(ctrl)
 	update := mock_models.NewCompanyUpdate(t)
+	before := *company
+	// The company is read and updated in one transaction.
+	mockTxManager.EXPECT().
+		Transaction(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
 	now := company.UpdatedAt
 	type fields struct {
 		companyRepository companyRepository
@@ -443,12 +519,13 @@ func TestCompanyService_Update(t *testing.T) {
 		update *entity.CompanyUpdate
 	}
 	tests := []struct {
-		name    string
-		setup   func()
-		fields  fields
-		args    args
-		want    *entity.Company
-		wantErr error
+		name       string
+		setup      func()
+		fields     fields
+		args       args
+		wantBefore *entity.Company
+		want       *entity.Company
+		wantErr    error
 	}{
 		{
 			name: "ok",
@@ -468,8 +545,9 @@ func TestCompanyService_Update(t *testing.T) {
 				ctx:    ctx,
 				update: update,
 			},
-			want:    company,
-			wantErr: nil,
+			wantBefore: &before,
+			want:       company,
+			wantErr:    nil,
 		},
 		{
 			name: "update error",
@@ -491,8 +569,9 @@ func TestCompanyService_Update(t *testing.T) {
 				ctx:    ctx,
 				update: update,
 			},
-			want:    nil,
-			wantErr: errs.NewUnexpectedBehaviorError("test error"),
+			wantBefore: nil,
+			want:       nil,
+			wantErr:    errs.NewUnexpectedBehaviorError("test error"),
 		},
 		{
 			name: "Company not found",
@@ -508,8 +587,9 @@ func TestCompanyService_Update(t *testing.T) {
 				ctx:    ctx,
 				update: update,
 			},
-			want:    nil,
-			wantErr: errs.NewEntityNotFound(),
+			wantBefore: nil,
+			want:       nil,
+			wantErr:    errs.NewEntityNotFound(),
 		},
 		{
 			name: "invalid",
@@ -526,8 +606,9 @@ func TestCompanyService_Update(t *testing.T) {
 					ID: entity.UUID("baduuid"),
 				},
 			},
-			want:    nil,
-			wantErr: errs.NewInvalidFormError().WithParam("id", "must be a valid UUID"),
+			wantBefore: nil,
+			want:       nil,
+			wantErr:    errs.NewInvalidFormError().WithParam("id", "must be a valid UUID"),
 		},
 	}
 	for _, tt := range tests {
@@ -535,14 +616,18 @@ func TestCompanyService_Update(t *testing.T) {
 			tt.setup()
 			u := &CompanyService{
 				companyRepository: tt.fields.companyRepository,
+				txManager:         mockTxManager,
 				clock:             tt.fields.clock,
 				logger:            tt.fields.logger,
 			}
-			got, err := u.Update(tt.args.ctx, tt.args.update)
+			gotBefore, got, err := u.Update(tt.args.ctx, tt.args.update)
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("CompanyService.Update() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if !reflect.DeepEqual(gotBefore, tt.wantBefore) {
+				t.Errorf("CompanyService.Update() before = %v, want %v", gotBefore, tt.wantBefore)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CompanyService.Update() = %v, want %v", got, tt.want)
 			}
@@ -620,3 +705,142 @@ func TestCompanyService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestCompanyService_Suggest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockTxManager := newMockTxManager(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	var suggestions []*entity.CompanySuggestion
+	for i := 0; i < faker.New().IntBetween(1, 10); i++ {
+		suggestions = append(suggestions, mock_models.NewCompanySuggestion(t))
+	}
+	type fields struct {
+		companyRepository companyRepository
+		logger            log.Logger
+	}
+	type args struct {
+		ctx     context.Context
+		suggest *entity.CompanySuggest
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		want    []*entity.CompanySuggestion
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCompanyRepository.EXPECT().
+					Suggest(ctx, "acme", 0.3, uint64(5)).
+					Return(suggestions, nil)
+			},
+			fields: fields{
+				companyRepository: mockCompanyRepository,
+				logger:            logger,
+			},
+			args: args{
+				ctx:     ctx,
+				suggest: &entity.CompanySuggest{Query: "acme", Limit: utils.Pointer(uint64(5))},
+			},
+			want:    suggestions,
+			wantErr: nil,
+		},
+		{
+			name: "default limit",
+			setup: func() {
+				mockCompanyRepository.EXPECT().
+					Suggest(ctx, "acme", 0.3, uint64(10)).
+					Return(suggestions, nil)
+			},
+			fields: fields{
+				companyRepository: mockCompanyRepository,
+				logger:            logger,
+			},
+			args: args{
+				ctx:     ctx,
+				suggest: &entity.CompanySuggest{Query: "acme"},
+			},
+			want:    suggestions,
+			wantErr: nil,
+		},
+		{
+			name: "limit is capped",
+			setup: func() {
+				mockCompanyRepository.EXPECT().
+					Suggest(ctx, "acme", 0.3, uint64(50)).
+					Return(suggestions, nil)
+			},
+			fields: fields{
+				companyRepository: mockCompanyRepository,
+				logger:            logger,
+			},
+			args: args{
+				ctx:     ctx,
+				suggest: &entity.CompanySuggest{Query: "acme", Limit: utils.Pointer(uint64(1000))},
+			},
+			want:    suggestions,
+			wantErr: nil,
+		},
+		{
+			name:  "invalid query",
+			setup: func() {},
+			fields: fields{
+				companyRepository: mockCompanyRepository,
+				logger:            logger,
+			},
+			args: args{
+				ctx:     ctx,
+				suggest: &entity.CompanySuggest{Query: ""},
+			},
+			want: nil,
+			wantErr: errs.NewInvalidFormError().WithParams(map[string]string{
+				"q": "cannot be blank",
+			}),
+		},
+		{
+			name: "repository error",
+			setup: func() {
+				mockCompanyRepository.EXPECT().
+					Suggest(ctx, "acme", 0.3, uint64(10)).
+					Return(nil, errs.NewUnexpectedBehaviorError("test error"))
+			},
+			fields: fields{
+				companyRepository: mockCompanyRepository,
+				logger:            logger,
+			},
+			args: args{
+				ctx:     ctx,
+				suggest: &entity.CompanySuggest{Query: "acme"},
+			},
+			want:    nil,
+			wantErr: errs.NewUnexpectedBehaviorError("test error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := &CompanyService{
+				companyRepository: tt.fields.companyRepository,
+				txManager:         mockTxManager,
+				logger:            tt.fields.logger,
+				suggestThreshold:  0.3,
+				suggestLimit:      10,
+				suggestMaxLimit:   50,
+			}
+			got, err := u.Suggest(tt.args.ctx, tt.args.suggest)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CompanyService.Suggest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CompanyService.Suggest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
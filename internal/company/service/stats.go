@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/018bf/companies/pkg/utils"
+)
+
+//go:generate mockgen -source=stats.go -package=service -destination=stats_mock.go
+
+type statsRepository interface {
+	Get(ctx context.Context, filter *entity.CompanyStatsFilter) (*entity.CompanyStats, error)
+	Refresh(ctx context.Context) error
+}
+
+type StatsService struct {
+	statsRepository statsRepository
+	logger          log.Logger
+	employeeBuckets []int
+}
+
+func NewStatsService(statsRepository statsRepository, config *configs.Config, logger log.Logger) *StatsService {
+	return &StatsService{
+		statsRepository: statsRepository,
+		logger:          logger,
+		employeeBuckets: config.Stats.EmployeeBuckets,
+	}
+}
+
+// Get returns the statistics of the companies matching filter.
+// The histogram uses the configured buckets and the time series is monthly unless filter sets them.
+func (s *StatsService) Get(ctx context.Context, filter *entity.CompanyStatsFilter) (*entity.CompanyStats, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if len(filter.EmployeeBuckets) == 0 {
+		filter.EmployeeBuckets = s.employeeBuckets
+	}
+	if filter.Interval == nil {
+		filter.Interval = utils.Pointer(entity.StatsIntervalMonth)
+	}
+	stats, err := s.statsRepository.Get(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// Refresh recomputes the materialized statistics.
+func (s *StatsService) Refresh(ctx context.Context) error {
+	return s.statsRepository.Refresh(ctx)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stats.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockstatsRepository is a mock of statsRepository interface.
+type MockstatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockstatsRepositoryMockRecorder
+}
+
+// MockstatsRepositoryMockRecorder is the mock recorder for MockstatsRepository.
+type MockstatsRepositoryMockRecorder struct {
+	mock *MockstatsRepository
+}
+
+// NewMockstatsRepository creates a new mock instance.
+func NewMockstatsRepository(ctrl *gomock.Controller) *MockstatsRepository {
+	mock := &MockstatsRepository{ctrl: ctrl}
+	mock.recorder = &MockstatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstatsRepository) EXPECT() *MockstatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockstatsRepository) Get(ctx context.Context, filter *entity.CompanyStatsFilter) (*entity.CompanyStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, filter)
+	ret0, _ := ret[0].(*entity.CompanyStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockstatsRepositoryMockRecorder) Get(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockstatsRepository)(nil).Get), ctx, filter)
+}
+
+// Refresh mocks base method.
+func (m *MockstatsRepository) Refresh(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockstatsRepositoryMockRecorder) Refresh(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockstatsRepository)(nil).Refresh), ctx)
+}
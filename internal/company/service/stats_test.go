@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/018bf/companies/pkg/utils"
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewStatsService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStatsRepository := NewMockstatsRepository(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	config := configs.NewMockConfig(t)
+	type args struct {
+		statsRepository statsRepository
+		config          *configs.Config
+		logger          log.Logger
+	}
+	tests := []struct {
+		name string
+		args args
+		want *StatsService
+	}{
+		{
+			name: "ok",
+			args: args{
+				statsRepository: mockStatsRepository,
+				config:          config,
+				logger:          logger,
+			},
+			want: &StatsService{
+				statsRepository: mockStatsRepository,
+				logger:          logger,
+				employeeBuckets: []int{0, 10, 50, 250, 1000},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewStatsService(tt.args.statsRepository, tt.args.config, tt.args.logger); !reflect.DeepEqual(
+				got,
+				tt.want,
+			) {
+				t.Errorf("NewStatsService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatsService_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStatsRepository := NewMockstatsRepository(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	stats := &entity.CompanyStats{Total: 3}
+	tests := []struct {
+		name    string
+		setup   func()
+		filter  *entity.CompanyStatsFilter
+		want    *entity.CompanyStats
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockStatsRepository.EXPECT().
+					Get(ctx, &entity.CompanyStatsFilter{
+						EmployeeBuckets: []int{5},
+						Interval:        utils.Pointer(entity.StatsIntervalWeek),
+					}).
+					Return(stats, nil)
+			},
+			filter: &entity.CompanyStatsFilter{
+				EmployeeBuckets: []int{5},
+				Interval:        utils.Pointer(entity.StatsIntervalWeek),
+			},
+			want:    stats,
+			wantErr: nil,
+		},
+		{
+			name: "defaults",
+			setup: func() {
+				mockStatsRepository.EXPECT().
+					Get(ctx, &entity.CompanyStatsFilter{
+						EmployeeBuckets: []int{0, 10},
+						Interval:        utils.Pointer(entity.StatsIntervalMonth),
+					}).
+					Return(stats, nil)
+			},
+			filter:  &entity.CompanyStatsFilter{},
+			want:    stats,
+			wantErr: nil,
+		},
+		{
+			name:  "buckets aren't ascending",
+			setup: func() {},
+			filter: &entity.CompanyStatsFilter{
+				EmployeeBuckets: []int{10, 5},
+			},
+			want: nil,
+			wantErr: errs.NewInvalidFormError().WithParams(map[string]string{
+				"employee_buckets": "must be in ascending order",
+			}),
+		},
+		{
+			name: "repository error",
+			setup: func() {
+				mockStatsRepository.EXPECT().
+					Get(ctx, gomock.Any()).
+					Return(nil, errs.NewUnexpectedBehaviorError("test error"))
+			},
+			filter:  &entity.CompanyStatsFilter{},
+			want:    nil,
+			wantErr: errs.NewUnexpectedBehaviorError("test error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			s := &StatsService{
+				statsRepository: mockStatsRepository,
+				logger:          logger,
+				employeeBuckets: []int{0, 10},
+			}
+			got, err := s.Get(ctx, tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("StatsService.Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StatsService.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
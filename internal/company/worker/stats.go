@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/pkg/log"
+)
+
+//go:generate mockgen -source=stats.go -package=worker -destination=stats_mock.go
+
+type statsService interface {
+	Refresh(ctx context.Context) error
+}
+
+// StatsWorker refreshes the materialized company statistics on a schedule.
+type StatsWorker struct {
+	statsService statsService
+	logger       log.Logger
+	interval     time.Duration
+	done         chan struct{}
+	stop         sync.Once
+}
+
+func NewStatsWorker(statsService statsService, config *configs.Config, logger log.Logger) *StatsWorker {
+	return &StatsWorker{
+		statsService: statsService,
+		logger:       logger,
+		interval:     time.Duration(config.Stats.RefreshInterval) * time.Second,
+		done:         make(chan struct{}),
+	}
+}
+
+// Start refreshes the statistics right away and then every interval until ctx is done or the worker is stopped.
+func (w *StatsWorker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		if err := w.statsService.Refresh(ctx); err != nil {
+			w.logger.Error("can't refresh stats", log.Context(ctx), log.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.done:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *StatsWorker) Stop(_ context.Context) error {
+	w.stop.Do(func() {
+		close(w.done)
+	})
+	return nil
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: stats.go
+
+// Package worker is a generated GoMock package.
+package worker
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockstatsService is a mock of statsService interface.
+type MockstatsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockstatsServiceMockRecorder
+}
+
+// MockstatsServiceMockRecorder is the mock recorder for MockstatsService.
+type MockstatsServiceMockRecorder struct {
+	mock *MockstatsService
+}
+
+// NewMockstatsService creates a new mock instance.
+func NewMockstatsService(ctrl *gomock.Controller) *MockstatsService {
+	mock := &MockstatsService{ctrl: ctrl}
+	mock.recorder = &MockstatsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstatsService) EXPECT() *MockstatsServiceMockRecorder {
+	return m.recorder
+}
+
+// Refresh mocks base method.
+func (m *MockstatsService) Refresh(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockstatsServiceMockRecorder) Refresh(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockstatsService)(nil).Refresh), ctx)
+}
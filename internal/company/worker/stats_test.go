@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestStatsWorker_Start(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(service *MockstatsService, logger *mock_log.MockLogger, refreshed chan struct{})
+	}{
+		{
+			name: "refresh",
+			setup: func(service *MockstatsService, logger *mock_log.MockLogger, refreshed chan struct{}) {
+				gomock.InOrder(
+					service.EXPECT().Refresh(gomock.Any()).Return(nil),
+					service.EXPECT().Refresh(gomock.Any()).DoAndReturn(func(_ context.Context) error {
+						close(refreshed)
+						return nil
+					}),
+				)
+				service.EXPECT().Refresh(gomock.Any()).Return(nil).AnyTimes()
+			},
+		},
+		{
+			name: "error",
+			setup: func(service *MockstatsService, logger *mock_log.MockLogger, refreshed chan struct{}) {
+				service.EXPECT().
+					Refresh(gomock.Any()).
+					Return(errs.NewUnexpectedBehaviorError("test error")).
+					MinTimes(1)
+				logger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(string, ...log.Field) {
+					select {
+					case <-refreshed:
+					default:
+						close(refreshed)
+					}
+				}).MinTimes(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			service := NewMockstatsService(ctrl)
+			logger := mock_log.NewMockLogger(ctrl)
+			refreshed := make(chan struct{})
+			tt.setup(service, logger, refreshed)
+			w := &StatsWorker{
+				statsService: service,
+				logger:       logger,
+				interval:     time.Millisecond,
+				done:         make(chan struct{}),
+			}
+			stopped := make(chan error)
+			go func() {
+				stopped <- w.Start(context.Background())
+			}()
+			select {
+			case <-refreshed:
+			case <-time.After(time.Second):
+				t.Fatal("stats weren't refreshed")
+			}
+			if err := w.Stop(context.Background()); err != nil {
+				t.Errorf("Stop() error = %v", err)
+			}
+			if err := <-stopped; err != nil {
+				t.Errorf("Start() error = %v", err)
+			}
+		})
+	}
+}
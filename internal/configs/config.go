@@ -15,21 +15,186 @@ type auth struct {
 type database struct {
 	URI                string `env:"DATABASE_URI"                  toml:"uri"`
 	MaxOpenConnections int    `env:"DATABASE_MAX_OPEN_CONNECTIONS" toml:"max_open_connections" env-default:"50"`
-	MaxIDLEConnections int    `env:"DATABASE_MAX_IDLE_CONNECTIONS" toml:"max_idle_connections" env-default:"10"`
+	// MinConnections is how many connections the pool keeps open even when they're idle.
+	MinConnections int `env:"DATABASE_MIN_CONNECTIONS" toml:"min_connections" env-default:"10"`
+	// The pool closes the connections older than MaxConnLifetime or idle for MaxConnIdleTime
+	// and checks the idle ones every HealthCheckPeriod, all in seconds.
+	MaxConnLifetime   int64 `env:"DATABASE_MAX_CONN_LIFETIME"   toml:"max_conn_lifetime"   env-default:"3600"`
+	MaxConnIdleTime   int64 `env:"DATABASE_MAX_CONN_IDLE_TIME"  toml:"max_conn_idle_time"  env-default:"1800"`
+	HealthCheckPeriod int64 `env:"DATABASE_HEALTH_CHECK_PERIOD" toml:"health_check_period" env-default:"60"`
+	// StatementCacheCapacity is how many prepared statements each connection caches, 0 disables the cache.
+	StatementCacheCapacity int `env:"DATABASE_STATEMENT_CACHE_CAPACITY" toml:"statement_cache_capacity" env-default:"512"`
+	// ReplicaURIs are the read replicas, the reads go to the primary when there are none.
+	ReplicaURIs          []string `env:"DATABASE_REPLICA_URIS"           toml:"replica_uris"`
+	ReplicaCheckInterval int64    `env:"DATABASE_REPLICA_CHECK_INTERVAL" toml:"replica_check_interval" env-default:"5"`
+	// ReadYourWritesWindow is how long the reads of a caller stay on the primary after it writes.
+	ReadYourWritesWindow int64 `env:"DATABASE_READ_YOUR_WRITES_WINDOW" toml:"read_your_writes_window" env-default:"5"`
+	// The timeouts are in milliseconds: of the single company reads, of the listings and counts, and of the writes.
+	ReadTimeout  int64 `env:"DATABASE_READ_TIMEOUT"  toml:"read_timeout"  env-default:"1000"`
+	ListTimeout  int64 `env:"DATABASE_LIST_TIMEOUT"  toml:"list_timeout"  env-default:"5000"`
+	WriteTimeout int64 `env:"DATABASE_WRITE_TIMEOUT" toml:"write_timeout" env-default:"1000"`
+	// StatementTimeout is the statement_timeout of the connections in milliseconds, 0 disables it.
+	StatementTimeout int64 `env:"DATABASE_STATEMENT_TIMEOUT" toml:"statement_timeout" env-default:"0"`
+	// MaxRetries is how many times a transaction aborted by a concurrent one or a lost connection is retried.
+	MaxRetries int `env:"DATABASE_MAX_RETRIES" toml:"max_retries" env-default:"3"`
+	// MigrateOnStart applies the migrations when the services start, one instance at a time.
+	MigrateOnStart bool `env:"DATABASE_MIGRATE_ON_START" toml:"migrate_on_start" env-default:"false"`
+}
+
+type kafkaProducer struct {
+	Async        bool   `env:"KAFKA_PRODUCER_ASYNC"         toml:"async"         env-default:"true"`
+	QueueSize    int    `env:"KAFKA_PRODUCER_QUEUE_SIZE"    toml:"queue_size"    env-default:"10000"`
+	BatchSize    int    `env:"KAFKA_PRODUCER_BATCH_SIZE"    toml:"batch_size"    env-default:"100"`
+	Linger       int64  `env:"KAFKA_PRODUCER_LINGER"        toml:"linger"        env-default:"10"`
+	Compression  string `env:"KAFKA_PRODUCER_COMPRESSION"   toml:"compression"   env-default:"none"`
+	Idempotent   bool   `env:"KAFKA_PRODUCER_IDEMPOTENT"    toml:"idempotent"`
+	RetryMax     int    `env:"KAFKA_PRODUCER_RETRY_MAX"     toml:"retry_max"     env-default:"3"`
+	RetryBackoff int64  `env:"KAFKA_PRODUCER_RETRY_BACKOFF" toml:"retry_backoff" env-default:"100"`
+}
+
+type kafkaTLS struct {
+	Enabled            bool   `env:"KAFKA_TLS_ENABLED"              toml:"enabled"`
+	CAFile             string `env:"KAFKA_TLS_CA_FILE"              toml:"ca_file"`
+	CertFile           string `env:"KAFKA_TLS_CERT_FILE"            toml:"cert_file"`
+	KeyFile            string `env:"KAFKA_TLS_KEY_FILE"             toml:"key_file"`
+	InsecureSkipVerify bool   `env:"KAFKA_TLS_INSECURE_SKIP_VERIFY" toml:"insecure_skip_verify"`
+}
+
+type kafkaSASL struct {
+	Mechanism string `env:"KAFKA_SASL_MECHANISM" toml:"mechanism"`
+	Username  string `env:"KAFKA_SASL_USERNAME"  toml:"username"`
+	Password  string `env:"KAFKA_SASL_PASSWORD"  toml:"password"`
 }
 
 type kafka struct {
-	Host  string `env:"KAFKA_HOST" toml:"host"`
-	Port  int    `env:"KAFKA_PORT" toml:"port" `
-	Topic string `env:"KAFKA_TOPIC" toml:"topic" `
+	Brokers         []string      `env:"KAFKA_BROKERS"           toml:"brokers"`
+	Host            string        `env:"KAFKA_HOST"              toml:"host"`
+	Port            int           `env:"KAFKA_PORT"              toml:"port" `
+	ClientID        string        `env:"KAFKA_CLIENT_ID"         toml:"client_id"         env-default:"companies"`
+	Version         string        `env:"KAFKA_VERSION"           toml:"version"`
+	Topic           string        `env:"KAFKA_TOPIC"             toml:"topic" `
+	Source          string        `env:"KAFKA_SOURCE"            toml:"source"            env-default:"companies"`
+	CloudEventsMode string        `env:"KAFKA_CLOUD_EVENTS_MODE" toml:"cloud_events_mode" env-default:"structured"`
+	Format          string        `env:"KAFKA_FORMAT"            toml:"format"            env-default:"json"`
+	Producer        kafkaProducer `                              toml:"producer"`
+	TLS             kafkaTLS      `                              toml:"tls"`
+	SASL            kafkaSASL     `                              toml:"sasl"`
+}
+
+type schemaRegistry struct {
+	URL string `env:"SCHEMA_REGISTRY_URL" toml:"url"`
+}
+
+type eventsNATS struct {
+	URL             string `env:"EVENTS_NATS_URL"               toml:"url"               env-default:"nats://127.0.0.1:4222"`
+	Stream          string `env:"EVENTS_NATS_STREAM"            toml:"stream"            env-default:"COMPANIES"`
+	Subject         string `env:"EVENTS_NATS_SUBJECT"           toml:"subject"           env-default:"companies"`
+	Source          string `env:"EVENTS_NATS_SOURCE"            toml:"source"            env-default:"companies"`
+	CloudEventsMode string `env:"EVENTS_NATS_CLOUD_EVENTS_MODE" toml:"cloud_events_mode" env-default:"structured"`
+	Format          string `env:"EVENTS_NATS_FORMAT"            toml:"format"            env-default:"json"`
+}
+
+type eventsFile struct {
+	Path string `env:"EVENTS_FILE_PATH" toml:"path" env-default:"events.jsonl"`
+	Sync bool   `env:"EVENTS_FILE_SYNC" toml:"sync"`
+}
+
+type eventsMemory struct {
+	Capacity int `env:"EVENTS_MEMORY_CAPACITY" toml:"capacity" env-default:"1000"`
+}
+
+type events struct {
+	PayloadMode string       `env:"EVENTS_PAYLOAD_MODE" toml:"payload_mode" env-default:"full"`
+	Driver      string       `env:"EVENTS_DRIVER"       toml:"driver"       env-default:"kafka"`
+	NATS        eventsNATS   `                          toml:"nats"`
+	File        eventsFile   `                          toml:"file"`
+	Memory      eventsMemory `                          toml:"memory"`
+}
+
+type consumer struct {
+	Topic      string `env:"CONSUMER_TOPIC"       toml:"topic"       env-default:"companies-commands"`
+	Group      string `env:"CONSUMER_GROUP"       toml:"group"       env-default:"companies"`
+	DLQTopic   string `env:"CONSUMER_DLQ_TOPIC"   toml:"dlq_topic"   env-default:"companies-commands-dlq"`
+	ReplyTopic string `env:"CONSUMER_REPLY_TOPIC" toml:"reply_topic" env-default:"companies-replies"`
+	Token      string `env:"CONSUMER_TOKEN"       toml:"token"`
+}
+
+type webhooks struct {
+	Interval    int64  `env:"WEBHOOKS_INTERVAL"     toml:"interval"     env-default:"1"`
+	BatchSize   uint64 `env:"WEBHOOKS_BATCH_SIZE"   toml:"batch_size"   env-default:"100"`
+	Timeout     int64  `env:"WEBHOOKS_TIMEOUT"      toml:"timeout"      env-default:"10"`
+	MaxAttempts int    `env:"WEBHOOKS_MAX_ATTEMPTS" toml:"max_attempts" env-default:"10"`
+	MaxFailures int    `env:"WEBHOOKS_MAX_FAILURES" toml:"max_failures" env-default:"50"`
+	BackoffBase int64  `env:"WEBHOOKS_BACKOFF_BASE" toml:"backoff_base" env-default:"10"`
+	BackoffMax  int64  `env:"WEBHOOKS_BACKOFF_MAX"  toml:"backoff_max"  env-default:"3600"`
+}
+
+type changes struct {
+	Heartbeat int64  `env:"CHANGES_HEARTBEAT"  toml:"heartbeat"  env-default:"15"`
+	BatchSize uint64 `env:"CHANGES_BATCH_SIZE" toml:"batch_size" env-default:"100"`
+	Retention int64  `env:"CHANGES_RETENTION"  toml:"retention"  env-default:"604800"`
+}
+
+type search struct {
+	Language string `env:"SEARCH_LANGUAGE" toml:"language" env-default:"english"`
+}
+
+type suggest struct {
+	Threshold float64 `env:"SUGGEST_THRESHOLD" toml:"threshold" env-default:"0.3"`
+	Limit     uint64  `env:"SUGGEST_LIMIT"     toml:"limit"     env-default:"10"`
+	MaxLimit  uint64  `env:"SUGGEST_MAX_LIMIT" toml:"max_limit" env-default:"50"`
+}
+
+type stats struct {
+	Source          string `env:"STATS_SOURCE"           toml:"source"           env-default:"table"`
+	RefreshInterval int64  `env:"STATS_REFRESH_INTERVAL" toml:"refresh_interval" env-default:"300"`
+	EmployeeBuckets []int  `env:"STATS_EMPLOYEE_BUCKETS" toml:"employee_buckets" env-default:"0,10,50,250,1000"`
+}
+
+// cache configures the read-through cache of the companies.
+// The TTLs are in seconds, the driver is "none", "memory" or "redis".
+type cache struct {
+	Driver      string     `env:"CACHE_DRIVER"       toml:"driver"       env-default:"none"`
+	Size        int        `env:"CACHE_SIZE"         toml:"size"         env-default:"10000"`
+	TTL         int64      `env:"CACHE_TTL"          toml:"ttl"          env-default:"60"`
+	NegativeTTL int64      `env:"CACHE_NEGATIVE_TTL" toml:"negative_ttl" env-default:"5"`
+	Redis       cacheRedis `                         toml:"redis"`
+}
+
+type cacheRedis struct {
+	Addr     string `env:"CACHE_REDIS_ADDR"     toml:"addr"     env-default:"127.0.0.1:6379"`
+	Password string `env:"CACHE_REDIS_PASSWORD" toml:"password"`
+	DB       int    `env:"CACHE_REDIS_DB"       toml:"db"       env-default:"0"`
+	Prefix   string `env:"CACHE_REDIS_PREFIX"   toml:"prefix"   env-default:"companies:"`
+}
+
+// storage configures where the companies are stored: "postgres", "memory" or "sqlite".
+// The other data is kept in Postgres, which is connected on the first use with another driver.
+type storage struct {
+	Driver string        `env:"STORAGE_DRIVER" toml:"driver" env-default:"postgres"`
+	SQLite storageSQLite `                     toml:"sqlite"`
+}
+
+type storageSQLite struct {
+	Path string `env:"STORAGE_SQLITE_PATH" toml:"path" env-default:"companies.db"`
 }
 
 type Config struct {
-	BindAddr string   `env:"BIND_ADDR" toml:"bind_addr" env-default:":8000"`
-	LogLevel string   `env:"LOG_LEVEL" toml:"log_level" env-default:"debug"`
-	Database database `                toml:"database"`
-	Auth     auth     `                toml:"auth"`
-	Kafka    kafka    `                toml:"kafka"`
+	BindAddr       string         `env:"BIND_ADDR" toml:"bind_addr" env-default:":8000"`
+	LogLevel       string         `env:"LOG_LEVEL" toml:"log_level" env-default:"debug"`
+	Database       database       `                toml:"database"`
+	Auth           auth           `                toml:"auth"`
+	Kafka          kafka          `                toml:"kafka"`
+	SchemaRegistry schemaRegistry `                toml:"schema_registry"`
+	Events         events         `                toml:"events"`
+	Consumer       consumer       `                toml:"consumer"`
+	Webhooks       webhooks       `                toml:"webhooks"`
+	Changes        changes        `                toml:"changes"`
+	Search         search         `                toml:"search"`
+	Suggest        suggest        `                toml:"suggest"`
+	Stats          stats          `                toml:"stats"`
+	Cache          cache          `                toml:"cache"`
+	Storage        storage        `                toml:"storage"`
 }
 
 func ParseConfig(configPath string) (*Config, error) {
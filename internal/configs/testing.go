@@ -8,9 +8,22 @@ func NewMockConfig(t *testing.T) *Config {
 		BindAddr: ":8005",
 		LogLevel: "debug",
 		Database: database{
-			URI:                "",
-			MaxOpenConnections: 50,
-			MaxIDLEConnections: 10,
+			URI:                    "",
+			MaxOpenConnections:     50,
+			MinConnections:         10,
+			MaxConnLifetime:        3600,
+			MaxConnIdleTime:        1800,
+			HealthCheckPeriod:      60,
+			StatementCacheCapacity: 512,
+			ReplicaURIs:            nil,
+			ReplicaCheckInterval:   5,
+			ReadYourWritesWindow:   5,
+			ReadTimeout:            1000,
+			ListTimeout:            5000,
+			WriteTimeout:           1000,
+			StatementTimeout:       0,
+			MaxRetries:             3,
+			MigrateOnStart:         false,
 		},
 		Auth: auth{
 			PublicKey: `-----BEGIN PUBLIC KEY-----
@@ -38,5 +51,92 @@ gjOIscTm3jUVsz8bCkXrVlFsWRVCnvQwKx788Awq6mdw
 			RefreshTTL: 172800,
 			AccessTTL:  86400,
 		},
+		Kafka: kafka{
+			Host:            "127.0.0.1",
+			Port:            9092,
+			ClientID:        "companies",
+			Topic:           "companies",
+			Source:          "companies",
+			CloudEventsMode: "structured",
+			Format:          "json",
+			Producer: kafkaProducer{
+				Async:        true,
+				QueueSize:    10000,
+				BatchSize:    100,
+				Linger:       10,
+				Compression:  "none",
+				RetryMax:     3,
+				RetryBackoff: 100,
+			},
+		},
+		Events: events{
+			PayloadMode: "full",
+			Driver:      "kafka",
+			NATS: eventsNATS{
+				URL:             "nats://127.0.0.1:4222",
+				Stream:          "COMPANIES",
+				Subject:         "companies",
+				Source:          "companies",
+				CloudEventsMode: "structured",
+				Format:          "json",
+			},
+			File: eventsFile{
+				Path: "events.jsonl",
+			},
+			Memory: eventsMemory{
+				Capacity: 1000,
+			},
+		},
+		Consumer: consumer{
+			Topic:      "companies-commands",
+			Group:      "companies",
+			DLQTopic:   "companies-commands-dlq",
+			ReplyTopic: "companies-replies",
+			Token:      "",
+		},
+		Webhooks: webhooks{
+			Interval:    1,
+			BatchSize:   100,
+			Timeout:     10,
+			MaxAttempts: 10,
+			MaxFailures: 50,
+			BackoffBase: 10,
+			BackoffMax:  3600,
+		},
+		Changes: changes{
+			Heartbeat: 15,
+			BatchSize: 100,
+			Retention: 604800,
+		},
+		Search: search{
+			Language: "english",
+		},
+		Suggest: suggest{
+			Threshold: 0.3,
+			Limit:     10,
+			MaxLimit:  50,
+		},
+		Stats: stats{
+			Source:          "table",
+			RefreshInterval: 300,
+			EmployeeBuckets: []int{0, 10, 50, 250, 1000},
+		},
+		Cache: cache{
+			Driver:      "none",
+			Size:        10000,
+			TTL:         60,
+			NegativeTTL: 5,
+			Redis: cacheRedis{
+				Addr:   "127.0.0.1:6379",
+				DB:     0,
+				Prefix: "companies:",
+			},
+		},
+		Storage: storage{
+			Driver: "postgres",
+			SQLite: storageSQLite{
+				Path: "companies.db",
+			},
+		},
 	}
 }
@@ -0,0 +1,122 @@
+package containers
+
+import (
+	"context"
+	"time"
+
+	companyInvalidator "github.com/018bf/companies/internal/company/invalidator"
+	cacheRepository "github.com/018bf/companies/internal/company/repository/cache"
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/errs"
+	cacheInterface "github.com/018bf/companies/internal/interfaces/cache"
+	kafkaInterface "github.com/018bf/companies/internal/interfaces/kafka"
+	natsInterface "github.com/018bf/companies/internal/interfaces/nats"
+	"github.com/018bf/companies/pkg/clock"
+	"github.com/018bf/companies/pkg/log"
+	"go.uber.org/fx"
+)
+
+// Cache drivers selected by the cache.driver config key.
+const (
+	cacheDriverNone   = "none"
+	cacheDriverMemory = "memory"
+	cacheDriverRedis  = "redis"
+)
+
+type cacheStore interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+type cacheInvalidator interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// newCacheStore returns nil when the cache is disabled.
+func newCacheStore(
+	lifecycle fx.Lifecycle,
+	config *configs.Config,
+	clock clock.Clock,
+) (cacheStore, error) {
+	switch config.Cache.Driver {
+	case cacheDriverNone:
+		return nil, nil
+	case cacheDriverMemory:
+		store, err := cacheInterface.NewLRU(config, clock)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case cacheDriverRedis:
+		store := cacheInterface.NewRedis(config)
+		lifecycle.Append(fx.Hook{
+			OnStop: store.Close,
+		})
+		return store, nil
+	default:
+		return nil, errs.NewUnexpectedBehaviorError("unknown cache driver").
+			WithParam("driver", config.Cache.Driver)
+	}
+}
+
+// newCompanyCache returns nil when the cache is disabled.
+func newCompanyCache(
+	storage companyStorage,
+	store cacheStore,
+	config *configs.Config,
+	logger log.Logger,
+) *cacheRepository.CompanyRepository {
+	if store == nil {
+		return nil
+	}
+	return cacheRepository.NewCompanyRepository(storage, store, config, logger)
+}
+
+// newCacheInvalidator consumes the company events of the other instances.
+// It returns nil when the cache is disabled or the events don't reach the other instances.
+func newCacheInvalidator(
+	lifecycle fx.Lifecycle,
+	cache *cacheRepository.CompanyRepository,
+	config *configs.Config,
+	logger log.Logger,
+) (cacheInvalidator, error) {
+	if cache == nil {
+		return nil, nil
+	}
+	switch config.Events.Driver {
+	case eventDriverKafka:
+		consumer, err := kafkaInterface.NewConsumer(config)
+		if err != nil {
+			return nil, err
+		}
+		return companyInvalidator.NewKafkaInvalidator(consumer, cache, config, logger), nil
+	case eventDriverNATS:
+		conn, err := natsInterface.NewConnection(config)
+		if err != nil {
+			return nil, err
+		}
+		lifecycle.Append(fx.Hook{
+			OnStop: func(_ context.Context) error {
+				return conn.Drain()
+			},
+		})
+		return companyInvalidator.NewNATSInvalidator(conn, cache, config, logger), nil
+	default:
+		return nil, nil
+	}
+}
+
+// startCacheInvalidator runs the invalidator along with the container serving the companies.
+func startCacheInvalidator(ctx context.Context, lifecycle fx.Lifecycle, invalidator cacheInvalidator) {
+	if invalidator == nil {
+		return
+	}
+	lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			return invalidator.Start(ctx)
+		},
+		OnStop: invalidator.Stop,
+	})
+}
@@ -0,0 +1,98 @@
+package containers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/errs"
+	cacheInterface "github.com/018bf/companies/internal/interfaces/cache"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestNewCacheStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClock := mock_clock.NewMockClock(ctrl)
+	tests := []struct {
+		name     string
+		driver   string
+		wantType reflect.Type
+		wantErr  error
+	}{
+		{
+			name:     "none",
+			driver:   cacheDriverNone,
+			wantType: nil,
+		},
+		{
+			name:     "memory",
+			driver:   cacheDriverMemory,
+			wantType: reflect.TypeOf(&cacheInterface.LRU{}),
+		},
+		{
+			name:     "redis",
+			driver:   cacheDriverRedis,
+			wantType: reflect.TypeOf(&cacheInterface.Redis{}),
+		},
+		{
+			name:    "unknown driver",
+			driver:  "memcached",
+			wantErr: errs.NewUnexpectedBehaviorError("unknown cache driver").WithParam("driver", "memcached"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := configs.NewMockConfig(t)
+			config.Cache.Driver = tt.driver
+			lifecycle := fxtest.NewLifecycle(t)
+			got, err := newCacheStore(lifecycle, config, mockClock)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("newCacheStore() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if reflect.TypeOf(got) != tt.wantType {
+				t.Errorf("newCacheStore() = %T, want %v", got, tt.wantType)
+			}
+			lifecycle.RequireStart().RequireStop()
+		})
+	}
+}
+
+func TestNewCacheInvalidator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	config := configs.NewMockConfig(t)
+	config.Events.Driver = eventDriverMemory
+	store, err := cacheInterface.NewLRU(config, mock_clock.NewMockClock(ctrl))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		store cacheStore
+	}{
+		{
+			name:  "cache disabled",
+			store: nil,
+		},
+		{
+			name:  "events local to the instance",
+			store: store,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lifecycle := fxtest.NewLifecycle(t)
+			got, err := newCacheInvalidator(lifecycle, newCompanyCache(nil, tt.store, config, logger), config, logger)
+			if err != nil || got != nil {
+				t.Errorf("newCacheInvalidator() = %v, %v, want nil", got, err)
+			}
+		})
+	}
+}
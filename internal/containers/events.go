@@ -0,0 +1,152 @@
+package containers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	eventEncoding "github.com/018bf/companies/internal/event/encoding"
+	fileEventRepository "github.com/018bf/companies/internal/event/repositories/file"
+	kafkaEventRepository "github.com/018bf/companies/internal/event/repositories/kafka"
+	memoryEventRepository "github.com/018bf/companies/internal/event/repositories/memory"
+	natsEventRepository "github.com/018bf/companies/internal/event/repositories/nats"
+	noopEventRepository "github.com/018bf/companies/internal/event/repositories/noop"
+	kafkaInterface "github.com/018bf/companies/internal/interfaces/kafka"
+	natsInterface "github.com/018bf/companies/internal/interfaces/nats"
+	schemaRegistryInterface "github.com/018bf/companies/internal/interfaces/schemaregistry"
+	"github.com/018bf/companies/pkg/clock"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/Shopify/sarama"
+	"go.uber.org/fx"
+)
+
+// Event drivers selected by the events.driver config key.
+const (
+	eventDriverKafka  = "kafka"
+	eventDriverNATS   = "nats"
+	eventDriverFile   = "file"
+	eventDriverMemory = "memory"
+	eventDriverNoop   = "noop"
+)
+
+type eventRepository interface {
+	Send(ctx context.Context, event *entity.Event) error
+}
+
+// producerFactory connects to Kafka on the first use only,
+// so the containers which don't need a producer start without a broker.
+type producerFactory struct {
+	once     sync.Once
+	config   *configs.Config
+	producer sarama.SyncProducer
+	err      error
+}
+
+func newProducerFactory(lifecycle fx.Lifecycle, config *configs.Config) *producerFactory {
+	factory := &producerFactory{config: config}
+	lifecycle.Append(fx.Hook{
+		OnStop: func(_ context.Context) error {
+			if factory.producer == nil {
+				return nil
+			}
+			return factory.producer.Close()
+		},
+	})
+	return factory
+}
+
+func (f *producerFactory) Producer() (sarama.SyncProducer, error) {
+	f.once.Do(func() {
+		f.producer, f.err = kafkaInterface.NewProducer(f.config)
+	})
+	return f.producer, f.err
+}
+
+func newEventRepository(
+	lifecycle fx.Lifecycle,
+	producerFactory *producerFactory,
+	config *configs.Config,
+	clock clock.Clock,
+	logger log.Logger,
+) (eventRepository, error) {
+	switch config.Events.Driver {
+	case eventDriverKafka:
+		encoder, err := eventEncoding.NewEncoder(
+			config.Kafka.Format,
+			config.Kafka.Topic+"-value",
+			schemaRegistryInterface.NewClient(config),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if config.Kafka.Producer.Async {
+			producer, err := kafkaInterface.NewAsyncProducer(config, logger)
+			if err != nil {
+				return nil, err
+			}
+			// Hooks are stopped in reverse order, so the queue is flushed after the servers stop.
+			lifecycle.Append(fx.Hook{
+				OnStop: producer.Close,
+			})
+			repository, err := kafkaEventRepository.NewEventRepository(producer, encoder, config, clock, logger)
+			if err != nil {
+				return nil, err
+			}
+			return repository, nil
+		}
+		producer, err := producerFactory.Producer()
+		if err != nil {
+			return nil, err
+		}
+		repository, err := kafkaEventRepository.NewEventRepository(producer, encoder, config, clock, logger)
+		if err != nil {
+			return nil, err
+		}
+		return repository, nil
+	case eventDriverNATS:
+		conn, err := natsInterface.NewConnection(config)
+		if err != nil {
+			return nil, err
+		}
+		lifecycle.Append(fx.Hook{
+			OnStop: func(_ context.Context) error {
+				return conn.Drain()
+			},
+		})
+		js, err := natsInterface.NewJetStream(conn, config)
+		if err != nil {
+			return nil, err
+		}
+		encoder, err := eventEncoding.NewEncoder(
+			config.Events.NATS.Format,
+			config.Events.NATS.Subject+"-value",
+			schemaRegistryInterface.NewClient(config),
+		)
+		if err != nil {
+			return nil, err
+		}
+		repository, err := natsEventRepository.NewEventRepository(js, encoder, config, clock, logger)
+		if err != nil {
+			return nil, err
+		}
+		return repository, nil
+	case eventDriverFile:
+		repository, err := fileEventRepository.NewEventRepository(config)
+		if err != nil {
+			return nil, err
+		}
+		lifecycle.Append(fx.Hook{
+			OnStop: repository.Close,
+		})
+		return repository, nil
+	case eventDriverMemory:
+		return memoryEventRepository.NewEventRepository(config), nil
+	case eventDriverNoop:
+		return noopEventRepository.NewEventRepository(), nil
+	default:
+		return nil, errs.NewUnexpectedBehaviorError("unknown event driver").
+			WithParam("driver", config.Events.Driver)
+	}
+}
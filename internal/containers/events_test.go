@@ -0,0 +1,69 @@
+package containers
+
+import (
+	"errors"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/errs"
+	fileEventRepository "github.com/018bf/companies/internal/event/repositories/file"
+	memoryEventRepository "github.com/018bf/companies/internal/event/repositories/memory"
+	noopEventRepository "github.com/018bf/companies/internal/event/repositories/noop"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestNewEventRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	tests := []struct {
+		name     string
+		driver   string
+		wantType reflect.Type
+		wantErr  error
+	}{
+		{
+			name:     "file",
+			driver:   eventDriverFile,
+			wantType: reflect.TypeOf(&fileEventRepository.EventRepository{}),
+		},
+		{
+			name:     "memory",
+			driver:   eventDriverMemory,
+			wantType: reflect.TypeOf(&memoryEventRepository.EventRepository{}),
+		},
+		{
+			name:     "noop",
+			driver:   eventDriverNoop,
+			wantType: reflect.TypeOf(&noopEventRepository.EventRepository{}),
+		},
+		{
+			name:    "unknown driver",
+			driver:  "rabbitmq",
+			wantErr: errs.NewUnexpectedBehaviorError("unknown event driver").WithParam("driver", "rabbitmq"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := configs.NewMockConfig(t)
+			config.Events.Driver = tt.driver
+			config.Events.File.Path = path.Join(t.TempDir(), "events.jsonl")
+			lifecycle := fxtest.NewLifecycle(t)
+			got, err := newEventRepository(lifecycle, newProducerFactory(lifecycle, config), config, mockClock, logger)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("newEventRepository() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && reflect.TypeOf(got) != tt.wantType {
+				t.Errorf("newEventRepository() got = %T, want %v", got, tt.wantType)
+			}
+			lifecycle.RequireStart().RequireStop()
+		})
+	}
+}
@@ -5,24 +5,40 @@ import (
 	authInterceptor "github.com/018bf/companies/internal/auth/interceptor"
 	authRepository "github.com/018bf/companies/internal/auth/repository/jwt"
 	authService "github.com/018bf/companies/internal/auth/service"
+	commandInterceptor "github.com/018bf/companies/internal/command/interceptor"
+	commandKafka "github.com/018bf/companies/internal/command/kafka"
+	replyRepository "github.com/018bf/companies/internal/command/repository/kafka"
+	commandRepository "github.com/018bf/companies/internal/command/repository/postgres"
+	commandService "github.com/018bf/companies/internal/command/service"
 	companyGrpc "github.com/018bf/companies/internal/company/grpc"
 	companyInterceptor "github.com/018bf/companies/internal/company/interceptor"
+	cacheRepository "github.com/018bf/companies/internal/company/repository/cache"
 	companyRepository "github.com/018bf/companies/internal/company/repository/postgres"
 	companyService "github.com/018bf/companies/internal/company/service"
+	companyWorker "github.com/018bf/companies/internal/company/worker"
+	eventCheckpoint "github.com/018bf/companies/internal/event/repositories/checkpoint"
 	eventService "github.com/018bf/companies/internal/event/service"
+	webhookGrpc "github.com/018bf/companies/internal/webhook/grpc"
+	webhookInterceptor "github.com/018bf/companies/internal/webhook/interceptor"
+	webhookSender "github.com/018bf/companies/internal/webhook/repository/http"
+	webhookRepository "github.com/018bf/companies/internal/webhook/repository/postgres"
+	webhookService "github.com/018bf/companies/internal/webhook/service"
+	webhookWorker "github.com/018bf/companies/internal/webhook/worker"
 	companiespb "github.com/018bf/companies/pkg/companiespb/v1"
 	"github.com/Shopify/sarama"
 
 	"github.com/018bf/companies/internal/configs"
-	eventRepository "github.com/018bf/companies/internal/event/repositories/kafka"
+	"github.com/018bf/companies/internal/entity"
 	grpcInterface "github.com/018bf/companies/internal/interfaces/grpc"
 	kafkaInterface "github.com/018bf/companies/internal/interfaces/kafka"
 	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
 	restInterface "github.com/018bf/companies/internal/interfaces/rest"
 	"github.com/018bf/companies/pkg/clock"
 	"github.com/018bf/companies/pkg/log"
+	"github.com/jmoiron/sqlx"
 	"go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
+	"golang.org/x/time/rate"
 )
 
 var FXModule = fx.Options(
@@ -36,16 +52,30 @@ var FXModule = fx.Options(
 		context.Background,
 		configs.ParseConfig,
 		clock.NewRealClock,
+		func(lifecycle fx.Lifecycle, config *configs.Config) (*postgresInterface.Pool, error) {
+			pool, err := postgresInterface.NewPool(config)
+			if err != nil {
+				return nil, err
+			}
+			lifecycle.Append(fx.Hook{OnStop: pool.Close})
+			return pool, nil
+		},
 		postgresInterface.NewDatabase,
 		postgresInterface.NewMigrateManager,
-		kafkaInterface.NewProducer,
+		newProducerFactory,
+		func(producerFactory *producerFactory) (sarama.SyncProducer, error) {
+			return producerFactory.Producer()
+		},
+		kafkaInterface.NewConsumerGroup,
 		grpcInterface.NewServer,
 		grpcInterface.NewRequestIDMiddleware,
 		func(authInterceptor *authInterceptor.AuthInterceptor, logger log.Logger, config *configs.Config) *grpcInterface.AuthMiddleware {
 			return grpcInterface.NewAuthMiddleware(authInterceptor, logger, config)
 		},
 		restInterface.NewServer,
-		restInterface.NewAuthMiddleware,
+		func(authInterceptor *authInterceptor.AuthInterceptor) *restInterface.AuthMiddleware {
+			return restInterface.NewAuthMiddleware(authInterceptor)
+		},
 
 		authRepository.NewAuthRepository,
 		func(authRepository *authRepository.AuthRepository, logger log.Logger) *authService.AuthService {
@@ -59,27 +89,102 @@ var FXModule = fx.Options(
 			return authInterceptor.NewAuthInterceptor(authService, clock, logger)
 		},
 
-		eventRepository.NewEventRepository,
-		func(eventRepository *eventRepository.EventRepository, logger log.Logger) *eventService.EventService {
-			return eventService.NewEventService(eventRepository, logger)
+		newEventRepository,
+		func(
+			eventRepository eventRepository,
+			deliveryService *webhookService.DeliveryService,
+			config *configs.Config,
+			clock clock.Clock,
+			logger log.Logger,
+		) (*eventService.EventService, error) {
+			return eventService.NewEventService(eventRepository, deliveryService, config, clock, logger)
 		},
 
-		companyRepository.NewCompanyRepository,
 		func(
-			companyRepository *companyRepository.CompanyRepository,
+			lifecycle fx.Lifecycle,
+			database *sqlx.DB,
+			config *configs.Config,
+			clock clock.Clock,
+			logger log.Logger,
+		) (*postgresInterface.Router, error) {
+			router, err := postgresInterface.NewRouter(database, config, clock, logger)
+			if err != nil {
+				return nil, err
+			}
+			lifecycle.Append(fx.Hook{OnStop: router.Close})
+			return router, nil
+		},
+		func(
+			database *sqlx.DB,
+			router *postgresInterface.Router,
+			config *configs.Config,
+			logger log.Logger,
+		) *companyRepository.CompanyRepository {
+			return companyRepository.NewCompanyRepository(database, router, config, logger)
+		},
+		newCompanyStorage,
+		newTxManager,
+		companyRepository.NewChangeRepository,
+		companyRepository.NewStatsRepository,
+		newChangeStorage,
+		newChangeListener,
+		newStatsStorage,
+		func(
+			changeStorage changeStorage,
+			changeListener changeListener,
+			config *configs.Config,
+			clock clock.Clock,
+			logger log.Logger,
+		) *companyService.ChangeService {
+			return companyService.NewChangeService(changeStorage, changeListener, config, clock, logger)
+		},
+		newCacheStore,
+		newCompanyCache,
+		newCacheInvalidator,
+		func(
+			companyStorage companyStorage,
+			companyCache *cacheRepository.CompanyRepository,
+			txManager txManager,
+			config *configs.Config,
 			clock clock.Clock,
 			logger log.Logger,
 		) *companyService.CompanyService {
-			return companyService.NewCompanyService(companyRepository, clock, logger)
+			if companyCache != nil {
+				return companyService.NewCompanyService(companyCache, txManager, config, clock, logger)
+			}
+			return companyService.NewCompanyService(companyStorage, txManager, config, clock, logger)
+		},
+		func(
+			statsStorage statsStorage,
+			config *configs.Config,
+			logger log.Logger,
+		) *companyService.StatsService {
+			return companyService.NewStatsService(statsStorage, config, logger)
+		},
+		func(
+			statsService *companyService.StatsService,
+			config *configs.Config,
+			logger log.Logger,
+		) *companyWorker.StatsWorker {
+			return companyWorker.NewStatsWorker(statsService, config, logger)
 		},
 		func(
 			companyService *companyService.CompanyService,
+			changeService *companyService.ChangeService,
+			statsService *companyService.StatsService,
 			authService *authService.AuthService,
 			eventService *eventService.EventService,
 			clock clock.Clock,
 			logger log.Logger,
 		) *companyInterceptor.CompanyInterceptor {
-			return companyInterceptor.NewCompanyInterceptor(companyService, authService, eventService, logger)
+			return companyInterceptor.NewCompanyInterceptor(
+				companyService,
+				changeService,
+				statsService,
+				authService,
+				eventService,
+				logger,
+			)
 		},
 		fx.Annotate(
 			func(companyInterceptor *companyInterceptor.CompanyInterceptor, logger log.Logger) *companyGrpc.CompanyServiceServer {
@@ -87,53 +192,138 @@ var FXModule = fx.Options(
 			},
 			fx.As(new(companiespb.CompanyServiceServer)),
 		),
-	),
-	fx.Invoke(func(
-		lifecycle fx.Lifecycle,
-		logger log.Logger,
-		producer sarama.SyncProducer,
-		shutdowner fx.Shutdowner,
-	) {
-		lifecycle.Append(fx.Hook{
-			OnStop: func(_ context.Context) error {
-				return producer.Close()
+		func(companyInterceptor *companyInterceptor.CompanyInterceptor, logger log.Logger) *restInterface.CompanyHandler {
+			return restInterface.NewCompanyHandler(companyInterceptor, logger)
+		},
+
+		webhookRepository.NewWebhookRepository,
+		webhookRepository.NewDeliveryRepository,
+		newWebhookStorage,
+		newDeliveryStorage,
+		webhookSender.NewSender,
+		func(
+			webhookStorage webhookStorage,
+			clock clock.Clock,
+			logger log.Logger,
+		) *webhookService.WebhookService {
+			return webhookService.NewWebhookService(webhookStorage, clock, logger)
+		},
+		func(
+			deliveryStorage deliveryStorage,
+			webhookStorage webhookStorage,
+			sender *webhookSender.Sender,
+			config *configs.Config,
+			clock clock.Clock,
+			logger log.Logger,
+		) *webhookService.DeliveryService {
+			return webhookService.NewDeliveryService(deliveryStorage, webhookStorage, sender, config, clock, logger)
+		},
+		func(
+			webhookService *webhookService.WebhookService,
+			deliveryService *webhookService.DeliveryService,
+			authService *authService.AuthService,
+			logger log.Logger,
+		) *webhookInterceptor.WebhookInterceptor {
+			return webhookInterceptor.NewWebhookInterceptor(webhookService, deliveryService, authService, logger)
+		},
+		fx.Annotate(
+			func(webhookInterceptor *webhookInterceptor.WebhookInterceptor, logger log.Logger) *webhookGrpc.WebhookServiceServer {
+				return webhookGrpc.NewWebhookServiceServer(webhookInterceptor, logger)
 			},
-		})
-	}),
+			fx.As(new(companiespb.WebhookServiceServer)),
+		),
+		func(webhookInterceptor *webhookInterceptor.WebhookInterceptor, logger log.Logger) *restInterface.WebhookHandler {
+			return restInterface.NewWebhookHandler(webhookInterceptor, logger)
+		},
+		func(
+			deliveryService *webhookService.DeliveryService,
+			config *configs.Config,
+			logger log.Logger,
+		) *webhookWorker.Worker {
+			return webhookWorker.NewWorker(deliveryService, config, logger)
+		},
+
+		commandRepository.NewCommandRepository,
+		replyRepository.NewReplyRepository,
+		func(
+			commandRepository *commandRepository.CommandRepository,
+			replyRepository *replyRepository.ReplyRepository,
+			logger log.Logger,
+		) *commandService.CommandService {
+			return commandService.NewCommandService(commandRepository, replyRepository, logger)
+		},
+		func(
+			commandService *commandService.CommandService,
+			companyInterceptor *companyInterceptor.CompanyInterceptor,
+			txManager txManager,
+			config *configs.Config,
+			logger log.Logger,
+		) *commandInterceptor.CommandInterceptor {
+			return commandInterceptor.NewCommandInterceptor(
+				commandService,
+				companyInterceptor,
+				txManager,
+				config,
+				logger,
+			)
+		},
+		commandKafka.NewConsumer,
+	),
 )
 
-func NewMigrateContainer(config string) *fx.App {
+// NewMigrateContainer runs migrate with the migrations manager and exits.
+func NewMigrateContainer(
+	config string,
+	migrate func(ctx context.Context, manager *postgresInterface.MigrateManager) error,
+) *fx.App {
 	app := fx.New(
 		fx.Provide(func() string {
 			return config
 		}),
 		FXModule,
 		fx.Invoke(func(
+			ctx context.Context,
 			lifecycle fx.Lifecycle,
 			logger log.Logger,
 			manager *postgresInterface.MigrateManager,
 			shutdowner fx.Shutdowner,
 		) {
 			lifecycle.Append(fx.Hook{
-				OnStart: func(ctx context.Context) error {
-					err := manager.Up(ctx)
-					if err != nil {
-						logger.Error("shutdown", log.Any("error", err))
-					}
-					return shutdowner.Shutdown(fx.ExitCode(0))
+				OnStart: func(_ context.Context) error {
+					go func() {
+						if err := migrate(ctx, manager); err != nil {
+							logger.Error("shutdown", log.Any("error", err))
+							_ = shutdowner.Shutdown(fx.ExitCode(1))
+							return
+						}
+						_ = shutdowner.Shutdown(fx.ExitCode(0))
+					}()
+					return nil
 				},
-				OnStop: nil,
 			})
 		}),
 	)
 	return app
 }
+
+// migrateOnStart applies the migrations before the container starts when database.migrate_on_start is set.
+func migrateOnStart(lifecycle fx.Lifecycle, manager *postgresInterface.MigrateManager, config *configs.Config) {
+	if !config.Database.MigrateOnStart {
+		return
+	}
+	lifecycle.Append(fx.Hook{
+		OnStart: manager.UpLocked,
+	})
+}
+
 func NewGRPCContainer(config string) *fx.App {
 	app := fx.New(
 		fx.Provide(func() string {
 			return config
 		}),
 		FXModule,
+		fx.Invoke(migrateOnStart),
+		fx.Invoke(startCacheInvalidator),
 		fx.Invoke(func(
 			lifecycle fx.Lifecycle,
 			logger log.Logger,
@@ -164,6 +354,8 @@ func NewRESTContainer(config string) *fx.App {
 			return config
 		}),
 		FXModule,
+		fx.Invoke(migrateOnStart),
+		fx.Invoke(startCacheInvalidator),
 		fx.Invoke(func(
 			lifecycle fx.Lifecycle,
 			logger log.Logger,
@@ -187,3 +379,202 @@ func NewRESTContainer(config string) *fx.App {
 	)
 	return app
 }
+
+func NewConsumerContainer(config string) *fx.App {
+	app := fx.New(
+		fx.Provide(func() string {
+			return config
+		}),
+		FXModule,
+		fx.Invoke(requirePostgres),
+		fx.Invoke(migrateOnStart),
+		fx.Invoke(startCacheInvalidator),
+		fx.Invoke(func(
+			ctx context.Context,
+			lifecycle fx.Lifecycle,
+			logger log.Logger,
+			consumer *commandKafka.Consumer,
+			shutdowner fx.Shutdowner,
+		) {
+			lifecycle.Append(fx.Hook{
+				OnStart: func(_ context.Context) error {
+					go func() {
+						err := consumer.Start(ctx)
+						if err != nil {
+							logger.Error("shutdown", log.Any("error", err))
+							_ = shutdowner.Shutdown()
+						}
+					}()
+					return nil
+				},
+				OnStop: consumer.Stop,
+			})
+		}),
+	)
+	return app
+}
+
+func NewWebhooksContainer(config string) *fx.App {
+	app := fx.New(
+		fx.Provide(func() string {
+			return config
+		}),
+		FXModule,
+		fx.Invoke(requirePostgres),
+		fx.Invoke(migrateOnStart),
+		fx.Invoke(func(
+			ctx context.Context,
+			lifecycle fx.Lifecycle,
+			logger log.Logger,
+			worker *webhookWorker.Worker,
+			shutdowner fx.Shutdowner,
+		) {
+			lifecycle.Append(fx.Hook{
+				OnStart: func(_ context.Context) error {
+					go func() {
+						err := worker.Start(ctx)
+						if err != nil {
+							logger.Error("shutdown", log.Any("error", err))
+							_ = shutdowner.Shutdown()
+						}
+					}()
+					return nil
+				},
+				OnStop: worker.Stop,
+			})
+		}),
+	)
+	return app
+}
+
+func NewStatsRefreshContainer(config string) *fx.App {
+	app := fx.New(
+		fx.Provide(func() string {
+			return config
+		}),
+		FXModule,
+		fx.Invoke(requirePostgres),
+		fx.Invoke(func(
+			ctx context.Context,
+			lifecycle fx.Lifecycle,
+			logger log.Logger,
+			worker *companyWorker.StatsWorker,
+			shutdowner fx.Shutdowner,
+		) {
+			lifecycle.Append(fx.Hook{
+				OnStart: func(_ context.Context) error {
+					go func() {
+						err := worker.Start(ctx)
+						if err != nil {
+							logger.Error("shutdown", log.Any("error", err))
+							_ = shutdowner.Shutdown()
+						}
+					}()
+					return nil
+				},
+				OnStop: worker.Stop,
+			})
+		}),
+	)
+	return app
+}
+
+func NewPruneChangesContainer(config string) *fx.App {
+	app := fx.New(
+		fx.Provide(func() string {
+			return config
+		}),
+		FXModule,
+		fx.Invoke(requirePostgres),
+		fx.Invoke(func(
+			ctx context.Context,
+			lifecycle fx.Lifecycle,
+			logger log.Logger,
+			changeService *companyService.ChangeService,
+			shutdowner fx.Shutdowner,
+		) {
+			lifecycle.Append(fx.Hook{
+				OnStart: func(_ context.Context) error {
+					go func() {
+						count, err := changeService.Prune(ctx)
+						if err != nil {
+							logger.Error("shutdown", log.Any("error", err))
+							_ = shutdowner.Shutdown(fx.ExitCode(1))
+							return
+						}
+						logger.Info("changes pruned", log.Any("count", count))
+						_ = shutdowner.Shutdown(fx.ExitCode(0))
+					}()
+					return nil
+				},
+			})
+		}),
+	)
+	return app
+}
+
+// ReplayOptions configures the events replay.
+type ReplayOptions struct {
+	Filter     *entity.CompanyFilter
+	Rate       float64
+	BatchSize  uint64
+	Checkpoint string
+	// Topic overrides the Kafka topic the snapshots are published to.
+	Topic string
+}
+
+func NewReplayContainer(config string, options ReplayOptions) *fx.App {
+	app := fx.New(
+		fx.Provide(func() string {
+			return config
+		}),
+		FXModule,
+		fx.Decorate(func(config *configs.Config) *configs.Config {
+			if options.Topic != "" {
+				config.Kafka.Topic = options.Topic
+			}
+			return config
+		}),
+		fx.Provide(
+			func() *eventCheckpoint.CheckpointRepository {
+				return eventCheckpoint.NewCheckpointRepository(options.Checkpoint)
+			},
+			func(
+				companyStorage companyStorage,
+				eventRepository eventRepository,
+				checkpointRepository *eventCheckpoint.CheckpointRepository,
+				logger log.Logger,
+			) *eventService.ReplayService {
+				return eventService.NewReplayService(companyStorage, eventRepository, checkpointRepository, logger)
+			},
+		),
+		fx.Invoke(func(
+			ctx context.Context,
+			lifecycle fx.Lifecycle,
+			logger log.Logger,
+			replayService *eventService.ReplayService,
+			shutdowner fx.Shutdowner,
+		) {
+			lifecycle.Append(fx.Hook{
+				OnStart: func(_ context.Context) error {
+					go func() {
+						limit := rate.Inf
+						if options.Rate > 0 {
+							limit = rate.Limit(options.Rate)
+						}
+						count, err := replayService.Replay(ctx, options.Filter, limit, options.BatchSize)
+						if err != nil {
+							logger.Error("shutdown", log.Any("error", err), log.Any("count", count))
+							_ = shutdowner.Shutdown(fx.ExitCode(1))
+							return
+						}
+						logger.Info("replay finished", log.Any("count", count))
+						_ = shutdowner.Shutdown(fx.ExitCode(0))
+					}()
+					return nil
+				},
+			})
+		}),
+	)
+	return app
+}
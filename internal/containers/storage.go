@@ -0,0 +1,179 @@
+package containers
+
+import (
+	"context"
+	"time"
+
+	memoryCompanyRepository "github.com/018bf/companies/internal/company/repository/memory"
+	companyRepository "github.com/018bf/companies/internal/company/repository/postgres"
+	sqliteCompanyRepository "github.com/018bf/companies/internal/company/repository/sqlite"
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
+	sqliteInterface "github.com/018bf/companies/internal/interfaces/sqlite"
+	webhookRepository "github.com/018bf/companies/internal/webhook/repository/postgres"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/fx"
+)
+
+// Storage drivers selected by the storage.driver config key.
+const (
+	storageDriverPostgres = "postgres"
+	storageDriverMemory   = "memory"
+	storageDriverSQLite   = "sqlite"
+)
+
+type companyStorage interface {
+	Get(ctx context.Context, id entity.UUID) (*entity.Company, error)
+	ListPage(ctx context.Context, filter *entity.CompanyFilter) (*entity.CompanyList, error)
+	Estimate(ctx context.Context, filter *entity.CompanyFilter) (uint64, error)
+	ListAfter(ctx context.Context, filter *entity.CompanyFilter, after entity.UUID, limit uint64) ([]*entity.Company, error)
+	Suggest(ctx context.Context, query string, threshold float64, limit uint64) ([]*entity.CompanySuggestion, error)
+	Create(ctx context.Context, create *entity.Company) error
+	Update(ctx context.Context, update *entity.Company) error
+	Delete(ctx context.Context, id entity.UUID) error
+}
+
+// changeStorage, statsStorage, webhookStorage and deliveryStorage are kept by Postgres only.
+// With another storage driver they are unsupported, see unsupportedStorage.
+type changeStorage interface {
+	List(ctx context.Context, filter *entity.CompanyFilter, after uint64, limit uint64) ([]*entity.CompanyChange, error)
+	ListLatest(ctx context.Context, after uint64, limit uint64) ([]*entity.CompanyChange, error)
+	Prune(ctx context.Context, before time.Time) (int64, error)
+	LastPosition(ctx context.Context) (uint64, error)
+}
+
+type changeListener interface {
+	Subscribe() (<-chan struct{}, func())
+}
+
+type statsStorage interface {
+	Get(ctx context.Context, filter *entity.CompanyStatsFilter) (*entity.CompanyStats, error)
+	Refresh(ctx context.Context) error
+}
+
+type webhookStorage interface {
+	Get(ctx context.Context, id entity.UUID) (*entity.Webhook, error)
+	List(ctx context.Context, filter *entity.WebhookFilter) ([]*entity.Webhook, error)
+	Count(ctx context.Context, filter *entity.WebhookFilter) (uint64, error)
+	ListSubscribed(ctx context.Context, eventType entity.EventOperation) ([]*entity.Webhook, error)
+	Update(ctx context.Context, webhook *entity.Webhook) error
+	Create(ctx context.Context, webhook *entity.Webhook) error
+	Delete(ctx context.Context, id entity.UUID) error
+	RecordFailure(ctx context.Context, id entity.UUID, maxFailures int) (bool, error)
+	ResetFailures(ctx context.Context, id entity.UUID) error
+}
+
+type deliveryStorage interface {
+	Get(ctx context.Context, id entity.UUID) (*entity.WebhookDelivery, error)
+	List(ctx context.Context, filter *entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error)
+	Count(ctx context.Context, filter *entity.WebhookDeliveryFilter) (uint64, error)
+	Create(ctx context.Context, delivery *entity.WebhookDelivery) error
+	Update(ctx context.Context, delivery *entity.WebhookDelivery) error
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit uint64) ([]*entity.WebhookDelivery, error)
+}
+
+type txManager interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Retry(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+func newCompanyStorage(
+	lifecycle fx.Lifecycle,
+	repository *companyRepository.CompanyRepository,
+	config *configs.Config,
+) (companyStorage, error) {
+	switch config.Storage.Driver {
+	case storageDriverPostgres:
+		return repository, nil
+	case storageDriverMemory:
+		return memoryCompanyRepository.NewCompanyRepository(config), nil
+	case storageDriverSQLite:
+		database, err := sqliteInterface.NewDatabase(config)
+		if err != nil {
+			return nil, err
+		}
+		lifecycle.Append(fx.Hook{
+			OnStop: func(_ context.Context) error {
+				return database.Close()
+			},
+		})
+		return sqliteCompanyRepository.NewCompanyRepository(database, config), nil
+	default:
+		return nil, errs.NewUnexpectedBehaviorError("unknown storage driver").
+			WithParam("driver", config.Storage.Driver)
+	}
+}
+
+// requirePostgres fails the start of the containers working on what is kept by Postgres only.
+func requirePostgres(config *configs.Config) error {
+	if config.Storage.Driver != storageDriverPostgres {
+		return errs.NewUnexpectedBehaviorError("storage driver doesn't support the container").
+			WithParam("driver", config.Storage.Driver)
+	}
+	return nil
+}
+
+func newChangeStorage(repository *companyRepository.ChangeRepository, config *configs.Config) changeStorage {
+	if config.Storage.Driver == storageDriverPostgres {
+		return repository
+	}
+	return unsupportedStorage{driver: config.Storage.Driver}
+}
+
+// newChangeListener doesn't connect to Postgres with another storage driver, nothing notifies of the changes then.
+func newChangeListener(
+	lifecycle fx.Lifecycle,
+	pool *postgresInterface.Pool,
+	config *configs.Config,
+	logger log.Logger,
+) changeListener {
+	if config.Storage.Driver != storageDriverPostgres {
+		return unsupportedStorage{driver: config.Storage.Driver}
+	}
+	listener := postgresInterface.NewListener(pool, logger)
+	lifecycle.Append(fx.Hook{OnStop: listener.Close})
+	return listener
+}
+
+func newStatsStorage(repository *companyRepository.StatsRepository, config *configs.Config) statsStorage {
+	if config.Storage.Driver == storageDriverPostgres {
+		return repository
+	}
+	return unsupportedStorage{driver: config.Storage.Driver}
+}
+
+func newWebhookStorage(repository *webhookRepository.WebhookRepository, config *configs.Config) webhookStorage {
+	if config.Storage.Driver == storageDriverPostgres {
+		return repository
+	}
+	return unsupportedWebhookStorage{driver: config.Storage.Driver}
+}
+
+func newDeliveryStorage(repository *webhookRepository.DeliveryRepository, config *configs.Config) deliveryStorage {
+	if config.Storage.Driver == storageDriverPostgres {
+		return repository
+	}
+	return unsupportedDeliveryStorage{driver: config.Storage.Driver}
+}
+
+// newTxManager returns the transactions of Postgres when it keeps the companies.
+// The other storages apply each write on its own, so their operations run as they are.
+func newTxManager(database *sqlx.DB, config *configs.Config, logger log.Logger) txManager {
+	if config.Storage.Driver == storageDriverPostgres {
+		return postgresInterface.NewTxManager(database, config, logger)
+	}
+	return directTxManager{}
+}
+
+type directTxManager struct{}
+
+func (directTxManager) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (directTxManager) Retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
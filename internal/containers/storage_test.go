@@ -0,0 +1,110 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"path"
+	"reflect"
+	"testing"
+
+	memoryCompanyRepository "github.com/018bf/companies/internal/company/repository/memory"
+	companyRepository "github.com/018bf/companies/internal/company/repository/postgres"
+	sqliteCompanyRepository "github.com/018bf/companies/internal/company/repository/sqlite"
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	webhookRepository "github.com/018bf/companies/internal/webhook/repository/postgres"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestNewCompanyStorage(t *testing.T) {
+	repository := &companyRepository.CompanyRepository{}
+	tests := []struct {
+		name     string
+		driver   string
+		wantType reflect.Type
+		wantErr  error
+	}{
+		{
+			name:     "postgres",
+			driver:   storageDriverPostgres,
+			wantType: reflect.TypeOf(repository),
+		},
+		{
+			name:     "memory",
+			driver:   storageDriverMemory,
+			wantType: reflect.TypeOf(&memoryCompanyRepository.CompanyRepository{}),
+		},
+		{
+			name:     "sqlite",
+			driver:   storageDriverSQLite,
+			wantType: reflect.TypeOf(&sqliteCompanyRepository.CompanyRepository{}),
+		},
+		{
+			name:    "unknown driver",
+			driver:  "mongo",
+			wantErr: errs.NewUnexpectedBehaviorError("unknown storage driver").WithParam("driver", "mongo"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := configs.NewMockConfig(t)
+			config.Storage.Driver = tt.driver
+			config.Storage.SQLite.Path = path.Join(t.TempDir(), "companies.db")
+			lifecycle := fxtest.NewLifecycle(t)
+			got, err := newCompanyStorage(lifecycle, repository, config)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("newCompanyStorage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && reflect.TypeOf(got) != tt.wantType {
+				t.Errorf("newCompanyStorage() got = %T, want %v", got, tt.wantType)
+			}
+			lifecycle.RequireStart().RequireStop()
+		})
+	}
+}
+
+func TestRequirePostgres(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  string
+		wantErr error
+	}{
+		{
+			name:    "postgres",
+			driver:  storageDriverPostgres,
+			wantErr: nil,
+		},
+		{
+			name:    "memory",
+			driver:  storageDriverMemory,
+			wantErr: errs.NewUnexpectedBehaviorError("storage driver doesn't support the container").WithParam("driver", "memory"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := configs.NewMockConfig(t)
+			config.Storage.Driver = tt.driver
+			if err := requirePostgres(config); !errors.Is(err, tt.wantErr) {
+				t.Errorf("requirePostgres() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewWebhookStorage(t *testing.T) {
+	config := configs.NewMockConfig(t)
+	config.Storage.Driver = storageDriverSQLite
+	storage := newWebhookStorage(&webhookRepository.WebhookRepository{}, config)
+	// The events are dispatched to no webhooks, the webhooks can't be registered.
+	webhooks, err := storage.ListSubscribed(context.Background(), entity.EventTypeCreated)
+	if err != nil || len(webhooks) != 0 {
+		t.Errorf("ListSubscribed() = %v, %v, want none", webhooks, err)
+	}
+	wantErr := errs.NewError(errs.ErrorCodeUnimplemented, "Storage driver doesn't support it.").
+		WithParam("driver", storageDriverSQLite)
+	if err := storage.Create(context.Background(), &entity.Webhook{}); !errors.Is(err, wantErr) {
+		t.Errorf("Create() error = %v, wantErr %v", err, wantErr)
+	}
+}
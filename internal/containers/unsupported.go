@@ -0,0 +1,133 @@
+package containers
+
+import (
+	"context"
+	"time"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+)
+
+func unsupported(driver string) *errs.Error {
+	return errs.NewError(errs.ErrorCodeUnimplemented, "Storage driver doesn't support it.").
+		WithParam("driver", driver)
+}
+
+// unsupportedStorage stands for the changes and the statistics with a storage driver other than Postgres.
+// It fails without touching the database, the listener never wakes its subscribers.
+type unsupportedStorage struct {
+	driver string
+}
+
+func (s unsupportedStorage) List(
+	_ context.Context,
+	_ *entity.CompanyFilter,
+	_ uint64,
+	_ uint64,
+) ([]*entity.CompanyChange, error) {
+	return nil, unsupported(s.driver)
+}
+
+func (s unsupportedStorage) ListLatest(_ context.Context, _ uint64, _ uint64) ([]*entity.CompanyChange, error) {
+	return nil, unsupported(s.driver)
+}
+
+func (s unsupportedStorage) Prune(_ context.Context, _ time.Time) (int64, error) {
+	return 0, unsupported(s.driver)
+}
+
+func (s unsupportedStorage) LastPosition(_ context.Context) (uint64, error) {
+	return 0, unsupported(s.driver)
+}
+
+func (s unsupportedStorage) Subscribe() (<-chan struct{}, func()) {
+	return make(chan struct{}), func() {}
+}
+
+func (s unsupportedStorage) Get(_ context.Context, _ *entity.CompanyStatsFilter) (*entity.CompanyStats, error) {
+	return nil, unsupported(s.driver)
+}
+
+func (s unsupportedStorage) Refresh(_ context.Context) error {
+	return unsupported(s.driver)
+}
+
+// unsupportedWebhookStorage keeps no webhooks, so the events are dispatched to none.
+type unsupportedWebhookStorage struct {
+	driver string
+}
+
+func (s unsupportedWebhookStorage) Get(_ context.Context, _ entity.UUID) (*entity.Webhook, error) {
+	return nil, unsupported(s.driver)
+}
+
+func (s unsupportedWebhookStorage) List(_ context.Context, _ *entity.WebhookFilter) ([]*entity.Webhook, error) {
+	return nil, unsupported(s.driver)
+}
+
+func (s unsupportedWebhookStorage) Count(_ context.Context, _ *entity.WebhookFilter) (uint64, error) {
+	return 0, unsupported(s.driver)
+}
+
+func (s unsupportedWebhookStorage) ListSubscribed(
+	_ context.Context,
+	_ entity.EventOperation,
+) ([]*entity.Webhook, error) {
+	return nil, nil
+}
+
+func (s unsupportedWebhookStorage) Update(_ context.Context, _ *entity.Webhook) error {
+	return unsupported(s.driver)
+}
+
+func (s unsupportedWebhookStorage) Create(_ context.Context, _ *entity.Webhook) error {
+	return unsupported(s.driver)
+}
+
+func (s unsupportedWebhookStorage) Delete(_ context.Context, _ entity.UUID) error {
+	return unsupported(s.driver)
+}
+
+func (s unsupportedWebhookStorage) RecordFailure(_ context.Context, _ entity.UUID, _ int) (bool, error) {
+	return false, unsupported(s.driver)
+}
+
+func (s unsupportedWebhookStorage) ResetFailures(_ context.Context, _ entity.UUID) error {
+	return unsupported(s.driver)
+}
+
+type unsupportedDeliveryStorage struct {
+	driver string
+}
+
+func (s unsupportedDeliveryStorage) Get(_ context.Context, _ entity.UUID) (*entity.WebhookDelivery, error) {
+	return nil, unsupported(s.driver)
+}
+
+func (s unsupportedDeliveryStorage) List(
+	_ context.Context,
+	_ *entity.WebhookDeliveryFilter,
+) ([]*entity.WebhookDelivery, error) {
+	return nil, unsupported(s.driver)
+}
+
+func (s unsupportedDeliveryStorage) Count(_ context.Context, _ *entity.WebhookDeliveryFilter) (uint64, error) {
+	return 0, unsupported(s.driver)
+}
+
+func (s unsupportedDeliveryStorage) Create(_ context.Context, _ *entity.WebhookDelivery) error {
+	return unsupported(s.driver)
+}
+
+func (s unsupportedDeliveryStorage) Update(_ context.Context, _ *entity.WebhookDelivery) error {
+	return unsupported(s.driver)
+}
+
+func (s unsupportedDeliveryStorage) Claim(
+	_ context.Context,
+	_ time.Time,
+	_ time.Duration,
+	_ uint64,
+) ([]*entity.WebhookDelivery, error) {
+	return nil, unsupported(s.driver)
+}
@@ -1,5 +1,7 @@
 package entity
 
+import "context"
+
 type Token string
 
 func (t Token) String() string {
@@ -10,3 +12,16 @@ func NewToken(value string) *Token {
 	token := Token(value)
 	return &token
 }
+
+type callerKey struct{}
+
+// WithCaller returns ctx of the caller identified by the subject of its token.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the caller of ctx, an empty string without one.
+func CallerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/018bf/companies/internal/errs"
+)
+
+// CompanyChange is an entry of the companies change log.
+// Position increases in commit order, so it can be used to resume watching.
+type CompanyChange struct {
+	Position  uint64         `json:"position"`
+	Operation EventOperation `json:"operation"`
+	CompanyID UUID           `json:"company_id"`
+	ChangedAt time.Time      `json:"changed_at"`
+	// Company is the state after the change, or the last state for a deletion.
+	Company *Company `json:"company"`
+}
+
+// CompanyChangeStream receives the changes of a watch.
+// Heartbeat is sent periodically with the position of the last sent change, so idle connections stay open.
+type CompanyChangeStream interface {
+	Send(change *CompanyChange) error
+	Heartbeat(position uint64) error
+}
+
+// CompanyChangeList is a page of the change feed.
+// Token is passed back to continue after the last item.
+type CompanyChangeList struct {
+	Items   []*CompanyChange `json:"items"`
+	Token   string           `json:"token"`
+	HasMore bool             `json:"has_more"`
+}
+
+// ChangeToken is the continuation token of the change feed.
+// IssuedAt is the time the client was up to date with the feed at Position,
+// it tells whether the tombstones after Position may already be pruned.
+type ChangeToken struct {
+	Position uint64
+	IssuedAt time.Time
+}
+
+func (t ChangeToken) String() string {
+	value := strconv.FormatUint(t.Position, 10) + "." + strconv.FormatInt(t.IssuedAt.Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func ParseChangeToken(token string) (*ChangeToken, error) {
+	invalid := errs.NewInvalidParameter("Invalid change token.").WithParam("token", token)
+	value, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+	position, issuedAt, ok := strings.Cut(string(value), ".")
+	if !ok {
+		return nil, invalid
+	}
+	parsed := &ChangeToken{}
+	if parsed.Position, err = strconv.ParseUint(position, 10, 64); err != nil {
+		return nil, invalid
+	}
+	seconds, err := strconv.ParseInt(issuedAt, 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+	parsed.IssuedAt = time.Unix(seconds, 0).UTC()
+	return parsed, nil
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"github.com/018bf/companies/internal/errs"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+type CommandOperation string
+
+const (
+	CommandOperationCreate CommandOperation = "create"
+	CommandOperationUpdate CommandOperation = "update"
+	CommandOperationDelete CommandOperation = "delete"
+)
+
+// Command is a request to change a company received from the message broker.
+// ID is chosen by the sender and is used to deduplicate redeliveries.
+type Command struct {
+	ID        UUID             `json:"id"`
+	Operation CommandOperation `json:"operation"`
+	CompanyID UUID             `json:"company_id,omitempty"`
+	Create    *CompanyCreate   `json:"create,omitempty"`
+	Update    *CompanyUpdate   `json:"update,omitempty"`
+}
+
+func (m *Command) Validate() error {
+	err := validation.ValidateStruct(
+		m,
+		validation.Field(&m.ID, validation.Required, is.UUID),
+		validation.Field(&m.Operation, validation.Required, validation.In(
+			CommandOperationCreate,
+			CommandOperationUpdate,
+			CommandOperationDelete,
+		)),
+		validation.Field(
+			&m.CompanyID,
+			validation.When(m.Operation == CommandOperationDelete, validation.Required, is.UUID),
+		),
+		validation.Field(&m.Create, validation.When(m.Operation == CommandOperationCreate, validation.Required)),
+		validation.Field(&m.Update, validation.When(m.Operation == CommandOperationUpdate, validation.Required)),
+	)
+	if err != nil {
+		return errs.FromValidationError(err)
+	}
+	return nil
+}
+
+type CommandStatus string
+
+const (
+	CommandStatusSucceeded CommandStatus = "succeeded"
+	CommandStatusFailed    CommandStatus = "failed"
+)
+
+// CommandReply reports the result of the command back to its sender.
+type CommandReply struct {
+	CommandID UUID             `json:"command_id"`
+	Operation CommandOperation `json:"operation"`
+	Status    CommandStatus    `json:"status"`
+	Company   *Company         `json:"company,omitempty"`
+	Error     *errs.Error      `json:"error,omitempty"`
+}
@@ -1,9 +1,11 @@
 package entity
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/filtering"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 )
@@ -48,6 +50,16 @@ type Company struct {
 	AmountOfEmployees int         `json:"amount_of_employees"`
 	Registered        bool        `json:"registered"`
 	Type              CompanyType `json:"type"`
+	Version           uint64      `json:"version"`
+	// Search is set when the company is found by the full-text search.
+	Search *CompanySearch `json:"search,omitempty"`
+}
+
+// CompanySearch is the relevance of a found company and its fields with the matched words highlighted.
+type CompanySearch struct {
+	Score               float64 `json:"score"`
+	NameHeadline        string  `json:"name_headline"`
+	DescriptionHeadline string  `json:"description_headline"`
 }
 
 func (m *Company) Validate() error {
@@ -68,6 +80,42 @@ func (m *Company) Validate() error {
 	return nil
 }
 
+// ChangedFields returns names of the fields which differ in other.
+func (m *Company) ChangedFields(other *Company) []string {
+	var fields []string
+	if m.Name != other.Name {
+		fields = append(fields, "name")
+	}
+	if m.Description != other.Description {
+		fields = append(fields, "description")
+	}
+	if m.AmountOfEmployees != other.AmountOfEmployees {
+		fields = append(fields, "amount_of_employees")
+	}
+	if m.Registered != other.Registered {
+		fields = append(fields, "registered")
+	}
+	if m.Type != other.Type {
+		fields = append(fields, "type")
+	}
+	return fields
+}
+
+// Values returns the fields in their JSON representation.
+func (m *Company) Values(fields ...string) map[string]any {
+	data, _ := json.Marshal(m)
+	values := map[string]any{}
+	_ = json.Unmarshal(data, &values)
+	if len(fields) == 0 {
+		return values
+	}
+	selected := make(map[string]any, len(fields))
+	for _, field := range fields {
+		selected[field] = values[field]
+	}
+	return selected
+}
+
 type CompanyCreate struct {
 	Name              string      `json:"name"`
 	Description       string      `json:"description"`
@@ -116,14 +164,57 @@ func (m *CompanyUpdate) Validate() error {
 	return nil
 }
 
+// SearchLanguages are the text search languages built into PostgreSQL.
+var SearchLanguages = []string{
+	"simple", "arabic", "armenian", "basque", "catalan", "danish", "dutch", "english", "finnish",
+	"french", "german", "greek", "hindi", "hungarian", "indonesian", "irish", "italian", "lithuanian",
+	"nepali", "norwegian", "portuguese", "romanian", "russian", "serbian", "spanish", "swedish",
+	"tamil", "turkish", "yiddish",
+}
+
 type CompanyFilter struct {
-	IDs        []UUID        `json:"ids" form:"ids"`
-	PageSize   *uint64       `json:"page_size" form:"page_size"`
-	PageNumber *uint64       `json:"page_number" form:"page_number"`
-	OrderBy    []string      `json:"order_by" form:"order_by"`
-	Search     *string       `json:"search" form:"search"`
-	Types      []CompanyType `json:"types" form:"types"`
-	Registered *bool         `json:"registered" form:"registered"`
+	IDs            []UUID         `json:"ids" form:"ids"`
+	PageSize       *uint64        `json:"page_size" form:"page_size"`
+	PageNumber     *uint64        `json:"page_number" form:"page_number"`
+	OrderBy        []string       `json:"order_by" form:"order_by"`
+	Search         *string        `json:"search" form:"search"`
+	SearchLanguage *string        `json:"search_language" form:"search_language"`
+	Types          []CompanyType  `json:"types" form:"types"`
+	Registered     *bool          `json:"registered" form:"registered"`
+	Filter         *string        `json:"filter" form:"filter"`
+	Facets         []CompanyFacet `json:"facets" form:"facets"`
+	Count          *CompanyCount  `json:"count" form:"count"`
+}
+
+// CompanyCount is how the listing counts the companies matching the filter.
+type CompanyCount string
+
+const (
+	// CompanyCountExact counts the companies along the page, it is the default.
+	CompanyCountExact CompanyCount = "exact"
+	// CompanyCountEstimated takes the estimate of the planner, which is cheap on the large tables.
+	CompanyCountEstimated CompanyCount = "estimated"
+	// CompanyCountNone skips the count.
+	CompanyCountNone CompanyCount = "none"
+)
+
+func (c CompanyCount) Validate() error {
+	return validation.Validate(string(c), validation.In(
+		string(CompanyCountExact),
+		string(CompanyCountEstimated),
+		string(CompanyCountNone),
+	))
+}
+
+// CompanyFilterFields are the fields of the company available in the filter expression.
+var CompanyFilterFields = filtering.Fields{
+	"name":                filtering.FieldTypeString,
+	"description":         filtering.FieldTypeString,
+	"amount_of_employees": filtering.FieldTypeNumber,
+	"registered":          filtering.FieldTypeBool,
+	"type":                filtering.FieldTypeNumber,
+	"created_at":          filtering.FieldTypeTime,
+	"updated_at":          filtering.FieldTypeTime,
 }
 
 func (m *CompanyFilter) Validate() error {
@@ -143,11 +234,67 @@ func (m *CompanyFilter) Validate() error {
 			"type ASC", "type DESC",
 		))),
 		validation.Field(&m.Search),
+		validation.Field(&m.SearchLanguage, validation.In(searchLanguages()...)),
 		validation.Field(&m.Types),
 		validation.Field(&m.Registered),
+		validation.Field(&m.Filter, validation.RuneLength(0, 1000)),
+		validation.Field(&m.Facets),
+		validation.Field(&m.Count),
 	)
 	if err != nil {
 		return errs.FromValidationError(err)
 	}
+	if _, err := m.Expression(); err != nil {
+		return err
+	}
 	return nil
 }
+
+// CountMode returns how the companies are counted, exactly unless the filter asks otherwise.
+func (m *CompanyFilter) CountMode() CompanyCount {
+	if m.Count == nil || *m.Count == "" {
+		return CompanyCountExact
+	}
+	return *m.Count
+}
+
+// Expression returns the parsed filter expression, nil when there is none.
+func (m *CompanyFilter) Expression() (filtering.Expr, error) {
+	if m.Filter == nil {
+		return nil, nil
+	}
+	return filtering.Parse(*m.Filter, CompanyFilterFields)
+}
+
+// CompanySuggest is a query completed by the names of the companies as the user types it.
+type CompanySuggest struct {
+	Query string  `json:"q" form:"q"`
+	Limit *uint64 `json:"limit" form:"limit"`
+}
+
+func (m *CompanySuggest) Validate() error {
+	err := validation.ValidateStruct(
+		m,
+		validation.Field(&m.Query, validation.Required, validation.RuneLength(1, 100)),
+		validation.Field(&m.Limit),
+	)
+	if err != nil {
+		return errs.FromValidationError(err)
+	}
+	return nil
+}
+
+// CompanySuggestion is a company whose name matches a suggest query, by its prefix or by the similarity.
+type CompanySuggestion struct {
+	ID    UUID    `json:"id"`
+	Name  string  `json:"name"`
+	Score float64 `json:"score"`
+}
+
+func searchLanguages() []any {
+	languages := make([]any, len(SearchLanguages))
+	for i, language := range SearchLanguages {
+		languages[i] = language
+	}
+	return languages
+}
@@ -1,14 +1,35 @@
 package entity
 
+import "time"
+
 type EventOperation string
 
 const (
 	EventTypeCreated EventOperation = "created"
 	EventTypeUpdated EventOperation = "updated"
 	EventTypeDeleted EventOperation = "deleted"
+	// EventTypeSnapshot carries the current state of the company and is published by the events replay.
+	EventTypeSnapshot EventOperation = "snapshot"
+)
+
+type EventPayloadMode string
+
+const (
+	EventPayloadModeFull EventPayloadMode = "full"
+	EventPayloadModeDiff EventPayloadMode = "diff"
+	EventPayloadModeID   EventPayloadMode = "id"
 )
 
+// Event describes a change of the company.
+// Sequence increases monotonically per company, so consumers can discard stale events.
 type Event struct {
-	Operation EventOperation `json:"operation"`
-	Company   *Company       `json:"company,omitempty"`
+	Operation     EventOperation `json:"operation"`
+	CompanyID     UUID           `json:"company_id"`
+	Sequence      uint64         `json:"sequence"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	Company       *Company       `json:"company,omitempty"`
+	Before        *Company       `json:"before,omitempty"`
+	After         *Company       `json:"after,omitempty"`
+	ChangedFields []string       `json:"changed_fields,omitempty"`
+	Changes       map[string]any `json:"changes,omitempty"`
 }
@@ -0,0 +1,49 @@
+package entity
+
+import validation "github.com/go-ozzo/ozzo-validation/v4"
+
+// CompanyFacet is a dimension the listing counts the companies by.
+type CompanyFacet string
+
+const (
+	CompanyFacetType       CompanyFacet = "type"
+	CompanyFacetRegistered CompanyFacet = "registered"
+	CompanyFacetEmployees  CompanyFacet = "employees"
+)
+
+func (f CompanyFacet) Validate() error {
+	return validation.Validate(string(f), validation.In(
+		string(CompanyFacetType),
+		string(CompanyFacetRegistered),
+		string(CompanyFacetEmployees),
+	))
+}
+
+// CompanyList is a page of the companies with the number of all the matching ones.
+// Count is unset when the filter skips it, Estimated tells it is the estimate of the planner.
+// Facets are set when the filter requests them.
+type CompanyList struct {
+	Items     []*Company     `json:"items"`
+	Count     *uint64        `json:"count,omitempty"`
+	Estimated bool           `json:"estimated,omitempty"`
+	Facets    *CompanyFacets `json:"facets,omitempty"`
+}
+
+// CompanyFacets are the counts of the requested facets.
+// Each facet counts the companies matching the filter without its own dimension,
+// so that the counts show what choosing another value would give.
+type CompanyFacets struct {
+	Types      []*CompanyTypeFacet       `json:"types,omitempty"`
+	Registered []*CompanyRegisteredFacet `json:"registered,omitempty"`
+	Employees  []*CompanyStatsBucket     `json:"employees,omitempty"`
+}
+
+type CompanyTypeFacet struct {
+	Type  CompanyType `json:"type"`
+	Count uint64      `json:"count"`
+}
+
+type CompanyRegisteredFacet struct {
+	Registered bool   `json:"registered"`
+	Count      uint64 `json:"count"`
+}
@@ -0,0 +1,24 @@
+package mock_models // nolint:stylecheck
+
+import (
+	"testing"
+
+	"github.com/jaswdr/faker"
+
+	"github.com/018bf/companies/internal/entity"
+)
+
+func NewCompanyChange(t *testing.T) *entity.CompanyChange {
+	t.Helper()
+	company := NewCompany(t)
+	f := faker.New()
+	return &entity.CompanyChange{
+		Position: uint64(f.Int64Between(1, 1000000)),
+		Operation: entity.EventOperation(
+			f.RandomStringElement([]string{"created", "updated", "deleted"}),
+		),
+		CompanyID: company.ID,
+		ChangedAt: company.UpdatedAt,
+		Company:   company,
+	}
+}
@@ -0,0 +1,25 @@
+package mock_models // nolint:stylecheck
+
+import (
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/google/uuid"
+)
+
+func NewCommand(t *testing.T, operation entity.CommandOperation) *entity.Command {
+	t.Helper()
+	command := &entity.Command{
+		ID:        entity.UUID(uuid.NewString()),
+		Operation: operation,
+	}
+	switch operation {
+	case entity.CommandOperationCreate:
+		command.Create = NewCompanyCreate(t)
+	case entity.CommandOperationUpdate:
+		command.Update = NewCompanyUpdate(t)
+	case entity.CommandOperationDelete:
+		command.CompanyID = entity.UUID(uuid.NewString())
+	}
+	return command
+}
@@ -21,6 +21,7 @@ func NewCompany(t *testing.T) *entity.Company {
 		AmountOfEmployees: faker.New().Int(),
 		Registered:        faker.New().Bool(),
 		Type:              entity.CompanyType(faker.New().Int8Between(1, 4)),
+		Version:           uint64(faker.New().UInt32()),
 	}
 }
 func NewCompanyCreate(t *testing.T) *entity.CompanyCreate {
@@ -76,3 +77,18 @@ func NewCompanyFilter(t *testing.T) *entity.CompanyFilter {
 		Registered: utils.Pointer(faker.New().Bool()),
 	}
 }
+func NewCompanySuggest(t *testing.T) *entity.CompanySuggest {
+	t.Helper()
+	return &entity.CompanySuggest{
+		Query: faker.New().Lorem().Word(),
+		Limit: utils.Pointer(uint64(faker.New().IntBetween(1, 50))),
+	}
+}
+func NewCompanySuggestion(t *testing.T) *entity.CompanySuggestion {
+	t.Helper()
+	return &entity.CompanySuggestion{
+		ID:    entity.UUID(uuid.NewString()),
+		Name:  faker.New().Company().Name(),
+		Score: faker.New().Float64(2, 0, 1),
+	}
+}
@@ -9,10 +9,17 @@ import (
 
 func NewEvent(t *testing.T) *entity.Event {
 	t.Helper()
+	company := NewCompany(t)
 	return &entity.Event{
 		Operation: entity.EventOperation(
 			faker.New().RandomStringElement([]string{"created", "updated", "deleted"}),
 		),
-		Company: NewCompany(t),
+		CompanyID:     company.ID,
+		Sequence:      company.Version,
+		UpdatedAt:     company.UpdatedAt,
+		Company:       company,
+		After:         company,
+		ChangedFields: []string{"name", "description"},
+		Changes:       company.Values("name", "description"),
 	}
 }
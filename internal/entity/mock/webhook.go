@@ -0,0 +1,54 @@
+package mock_models // nolint:stylecheck
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/google/uuid"
+	"github.com/jaswdr/faker"
+)
+
+func NewWebhook(t *testing.T) *entity.Webhook {
+	t.Helper()
+	return &entity.Webhook{
+		ID:         entity.UUID(uuid.NewString()),
+		UpdatedAt:  faker.New().Time().Time(time.Now()),
+		CreatedAt:  faker.New().Time().Time(time.Now()),
+		URL:        "https://" + faker.New().Internet().Domain() + "/hooks",
+		Secret:     faker.New().Lorem().Text(32),
+		EventTypes: []entity.EventOperation{entity.EventTypeCreated, entity.EventTypeUpdated},
+		Active:     true,
+		Failures:   0,
+	}
+}
+
+func NewWebhookCreate(t *testing.T) *entity.WebhookCreate {
+	t.Helper()
+	return &entity.WebhookCreate{
+		URL:        "https://" + faker.New().Internet().Domain() + "/hooks",
+		Secret:     faker.New().Lorem().Text(32),
+		EventTypes: []entity.EventOperation{entity.EventTypeDeleted},
+	}
+}
+
+func NewWebhookDelivery(t *testing.T) *entity.WebhookDelivery {
+	t.Helper()
+	event := NewEvent(t)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &entity.WebhookDelivery{
+		ID:            entity.UUID(uuid.NewString()),
+		UpdatedAt:     faker.New().Time().Time(time.Now()),
+		CreatedAt:     faker.New().Time().Time(time.Now()),
+		WebhookID:     entity.UUID(uuid.NewString()),
+		EventType:     event.Operation,
+		Payload:       payload,
+		Status:        entity.WebhookDeliveryStatusPending,
+		Attempts:      0,
+		NextAttemptAt: faker.New().Time().Time(time.Now()),
+	}
+}
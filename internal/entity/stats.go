@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/utils"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type StatsInterval string
+
+const (
+	StatsIntervalDay   StatsInterval = "day"
+	StatsIntervalWeek  StatsInterval = "week"
+	StatsIntervalMonth StatsInterval = "month"
+)
+
+func (i StatsInterval) Validate() error {
+	return validation.Validate(string(i), validation.In(
+		string(StatsIntervalDay),
+		string(StatsIntervalWeek),
+		string(StatsIntervalMonth),
+	))
+}
+
+// CompanyStatsFilter selects the companies to aggregate and shapes the histogram and the time series.
+type CompanyStatsFilter struct {
+	CompanyFilter
+	// EmployeeBuckets are the ascending lower bounds of the employee-count histogram buckets.
+	EmployeeBuckets []int          `json:"employee_buckets" form:"employee_buckets"`
+	Interval        *StatsInterval `json:"interval" form:"interval"`
+}
+
+func (m *CompanyStatsFilter) Validate() error {
+	err := validation.ValidateStruct(
+		m,
+		validation.Field(&m.EmployeeBuckets, validation.Length(0, 20), validation.By(ascending)),
+		validation.Field(&m.Interval),
+	)
+	if err != nil {
+		return errs.FromValidationError(err)
+	}
+	return m.CompanyFilter.Validate()
+}
+
+func ascending(value any) error {
+	bounds, _ := value.([]int)
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i] <= bounds[i-1] {
+			return validation.NewError("validation_is_ascending", "must be in ascending order")
+		}
+	}
+	return nil
+}
+
+// CompanyStats are the counts and distributions of the filtered companies.
+type CompanyStats struct {
+	Total     uint64                `json:"total"`
+	Groups    []*CompanyStatsGroup  `json:"groups"`
+	Employees []*CompanyStatsBucket `json:"employees"`
+	Created   []*CompanyStatsPeriod `json:"created"`
+}
+
+// CompanyStatsGroup is the number of companies of the type and the registration.
+type CompanyStatsGroup struct {
+	Type       CompanyType `json:"type"`
+	Registered bool        `json:"registered"`
+	Count      uint64      `json:"count"`
+}
+
+// CompanyStatsBucket is the number of companies with amount of employees in [From, To),
+// the first bucket has no lower bound and the last one has no upper bound.
+type CompanyStatsBucket struct {
+	From  *int   `json:"from"`
+	To    *int   `json:"to"`
+	Count uint64 `json:"count"`
+}
+
+// NewCompanyStatsBuckets returns the empty buckets split by the lower bounds:
+// the amounts below the first bound are in the bucket 0 and the ones from the last bound in the bucket len(bounds).
+func NewCompanyStatsBuckets(bounds []int) []*CompanyStatsBucket {
+	buckets := make([]*CompanyStatsBucket, len(bounds)+1)
+	for i := range buckets {
+		bucket := &CompanyStatsBucket{}
+		if i > 0 {
+			bucket.From = utils.Pointer(bounds[i-1])
+		}
+		if i < len(bounds) {
+			bucket.To = utils.Pointer(bounds[i])
+		}
+		buckets[i] = bucket
+	}
+	return buckets
+}
+
+// CompanyStatsBucketOf returns the number of the bucket of the amount of employees, as width_bucket does.
+func CompanyStatsBucketOf(bounds []int, amount int) int {
+	bucket := 0
+	for bucket < len(bounds) && amount >= bounds[bucket] {
+		bucket++
+	}
+	return bucket
+}
+
+// CompanyStatsPeriod is the number of companies created in the interval starting at Start.
+type CompanyStatsPeriod struct {
+	Start time.Time `json:"start"`
+	Count uint64    `json:"count"`
+}
@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/018bf/companies/internal/errs"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+// Webhook's permissions.
+const (
+	PermissionIDWebhookList   PermissionID = "webhook_list"
+	PermissionIDWebhookDetail PermissionID = "webhook_detail"
+	PermissionIDWebhookCreate PermissionID = "webhook_create"
+	PermissionIDWebhookUpdate PermissionID = "webhook_update"
+	PermissionIDWebhookDelete PermissionID = "webhook_delete"
+)
+
+var eventOperations = []any{EventTypeCreated, EventTypeUpdated, EventTypeDeleted}
+
+// Webhook subscribes an HTTP endpoint to company events.
+// Empty EventTypes subscribes to all events.
+type Webhook struct {
+	ID         UUID             `json:"id"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+	CreatedAt  time.Time        `json:"created_at"`
+	URL        string           `json:"url"`
+	Secret     string           `json:"-"`
+	EventTypes []EventOperation `json:"event_types"`
+	Active     bool             `json:"active"`
+	Failures   int              `json:"failures"`
+}
+
+func (m *Webhook) Validate() error {
+	err := validation.ValidateStruct(
+		m,
+		validation.Field(&m.URL, validation.Required, is.URL),
+		validation.Field(&m.Secret, validation.Required, validation.RuneLength(16, 256)),
+		validation.Field(&m.EventTypes, validation.Each(validation.In(eventOperations...))),
+	)
+	if err != nil {
+		return errs.FromValidationError(err)
+	}
+	return nil
+}
+
+type WebhookCreate struct {
+	URL        string           `json:"url"`
+	Secret     string           `json:"secret"`
+	EventTypes []EventOperation `json:"event_types"`
+}
+
+func (m *WebhookCreate) Validate() error {
+	err := validation.ValidateStruct(
+		m,
+		validation.Field(&m.URL, validation.Required, is.URL),
+		validation.Field(&m.Secret, validation.Required, validation.RuneLength(16, 256)),
+		validation.Field(&m.EventTypes, validation.Each(validation.In(eventOperations...))),
+	)
+	if err != nil {
+		return errs.FromValidationError(err)
+	}
+	return nil
+}
+
+type WebhookUpdate struct {
+	ID         UUID              `json:"id"`
+	URL        *string           `json:"url"`
+	Secret     *string           `json:"secret"`
+	EventTypes *[]EventOperation `json:"event_types"`
+	Active     *bool             `json:"active"`
+}
+
+func (m *WebhookUpdate) Validate() error {
+	err := validation.ValidateStruct(
+		m,
+		validation.Field(&m.ID, validation.Required, is.UUID),
+		validation.Field(&m.URL, validation.NilOrNotEmpty, is.URL),
+		validation.Field(&m.Secret, validation.NilOrNotEmpty, validation.RuneLength(16, 256)),
+		validation.Field(&m.Active),
+	)
+	if err != nil {
+		return errs.FromValidationError(err)
+	}
+	return nil
+}
+
+type WebhookFilter struct {
+	PageSize   *uint64 `json:"page_size" form:"page_size"`
+	PageNumber *uint64 `json:"page_number" form:"page_number"`
+}
+
+func (m *WebhookFilter) Validate() error {
+	err := validation.ValidateStruct(
+		m,
+		validation.Field(&m.PageSize),
+		validation.Field(&m.PageNumber),
+	)
+	if err != nil {
+		return errs.FromValidationError(err)
+	}
+	return nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an entry of the delivery log.
+// Payload is the event encoded to JSON.
+type WebhookDelivery struct {
+	ID             UUID                  `json:"id"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+	WebhookID      UUID                  `json:"webhook_id"`
+	EventType      EventOperation        `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	ResponseStatus int                   `json:"response_status"`
+	LastError      string                `json:"last_error"`
+}
+
+type WebhookDeliveryFilter struct {
+	WebhookID  *UUID                   `json:"webhook_id" form:"webhook_id"`
+	Statuses   []WebhookDeliveryStatus `json:"statuses" form:"statuses"`
+	PageSize   *uint64                 `json:"page_size" form:"page_size"`
+	PageNumber *uint64                 `json:"page_number" form:"page_number"`
+}
+
+func (m *WebhookDeliveryFilter) Validate() error {
+	err := validation.ValidateStruct(
+		m,
+		validation.Field(&m.WebhookID, validation.NilOrNotEmpty, is.UUID),
+		validation.Field(&m.Statuses, validation.Each(validation.In(
+			WebhookDeliveryStatusPending,
+			WebhookDeliveryStatusSucceeded,
+			WebhookDeliveryStatusFailed,
+		))),
+		validation.Field(&m.PageSize),
+		validation.Field(&m.PageNumber),
+	)
+	if err != nil {
+		return errs.FromValidationError(err)
+	}
+	return nil
+}
@@ -2,15 +2,17 @@ package errs
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"reflect"
+	"strings"
 	"text/template"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/lib/pq"
+	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -151,20 +153,67 @@ func FromPostgresError(err error) *Error {
 		Message: "Unexpected behavior.",
 		Params:  map[string]string{"error": err.Error()},
 	}
-	var pqErr *pq.Error
-	if errors.As(err, &pqErr) {
-		e.AddParam("details", pqErr.Detail)
-		e.AddParam("message", pqErr.Message)
-		e.AddParam("postgres_code", fmt.Sprint(pqErr.Code))
-		if pqErr.Code == "23505" {
-			e = NewInvalidFormError().WithParam(pqErr.Constraint, pqErr.Detail)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		e.AddParam("details", pgErr.Detail)
+		e.AddParam("message", pgErr.Message)
+		e.AddParam("postgres_code", pgErr.Code)
+		switch {
+		case pgErr.Code == "23505":
+			e = NewInvalidFormError().WithParam(pgErr.ConstraintName, pgErr.Detail)
+		case pgErr.Code == "40001" || pgErr.Code == "40P01":
+			e.SetCode(ErrorCodeAborted)
+			e.SetMessage("Transaction is aborted by a concurrent one.")
+		case pgErr.Code == "57014":
+			e.SetCode(ErrorCodeDeadlineExceeded)
+			e.SetMessage("Query is canceled by the timeout.")
+		case strings.HasPrefix(pgErr.Code, "08"):
+			e.SetCode(ErrorCodeUnavailable)
+			e.SetMessage("Database is unavailable.")
 		}
 	}
-	if errors.Is(err, sql.ErrNoRows) {
+	var connectErr *pgconn.ConnectError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
 		e = NewEntityNotFound()
+	case errors.Is(err, context.DeadlineExceeded):
+		e.SetCode(ErrorCodeDeadlineExceeded)
+		e.SetMessage("Deadline is exceeded.")
+	case errors.Is(err, driver.ErrBadConn), errors.As(err, &connectErr):
+		e.SetCode(ErrorCodeUnavailable)
+		e.SetMessage("Database is unavailable.")
 	}
 	return e
 }
+
+// IsRetryable reports whether the failed database operation may succeed when retried from the start:
+// the transaction is aborted by a concurrent one or the connection is lost.
+func IsRetryable(err error) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.Code == ErrorCodeAborted || e.Code == ErrorCodeUnavailable
+}
+
+// IsTemporary reports whether the failed operation may succeed on retry.
+// The unknown errors are not temporary, so they aren't retried forever.
+func IsTemporary(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	switch e.Code {
+	case ErrorCodeCanceled, ErrorCodeDeadlineExceeded, ErrorCodeAborted, ErrorCodeUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
 func NewEntityNotFound() *Error {
 	return &Error{
 		Code:    ErrorCodeNotFound,
@@ -1,10 +1,14 @@
 package errs
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
 	"reflect"
 	"testing"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pkg/errors"
 )
 
@@ -546,3 +550,158 @@ func Test_renderErrorMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestFromPostgresError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		want      *Error
+		retryable bool
+	}{
+		{
+			name: "unexpected",
+			err:  errors.New("test error"),
+			want: &Error{
+				Code:    ErrorCodeInternal,
+				Message: "Unexpected behavior.",
+				Params:  map[string]string{"error": "test error"},
+			},
+		},
+		{
+			name: "no rows",
+			err:  sql.ErrNoRows,
+			want: NewEntityNotFound(),
+		},
+		{
+			name: "serialization failure",
+			err:  &pgconn.PgError{Severity: "ERROR", Code: "40001", Message: "could not serialize access"},
+			want: &Error{
+				Code:    ErrorCodeAborted,
+				Message: "Transaction is aborted by a concurrent one.",
+				Params: map[string]string{
+					"error":         "ERROR: could not serialize access (SQLSTATE 40001)",
+					"details":       "",
+					"message":       "could not serialize access",
+					"postgres_code": "40001",
+				},
+			},
+			retryable: true,
+		},
+		{
+			name: "statement timeout",
+			err:  &pgconn.PgError{Severity: "ERROR", Code: "57014", Message: "canceling statement due to statement timeout"},
+			want: &Error{
+				Code:    ErrorCodeDeadlineExceeded,
+				Message: "Query is canceled by the timeout.",
+				Params: map[string]string{
+					"error":         "ERROR: canceling statement due to statement timeout (SQLSTATE 57014)",
+					"details":       "",
+					"message":       "canceling statement due to statement timeout",
+					"postgres_code": "57014",
+				},
+			},
+		},
+		{
+			name: "unique violation",
+			err: &pgconn.PgError{
+				Severity:       "ERROR",
+				Code:           "23505",
+				Message:        `duplicate key value violates unique constraint "companies_name_key"`,
+				Detail:         "Key (name)=(Acme) already exists.",
+				ConstraintName: "companies_name_key",
+			},
+			want: NewInvalidFormError().WithParam("companies_name_key", "Key (name)=(Acme) already exists."),
+		},
+		{
+			name: "connection failure",
+			err:  &pgconn.PgError{Severity: "FATAL", Code: "08006", Message: "connection failure"},
+			want: &Error{
+				Code:    ErrorCodeUnavailable,
+				Message: "Database is unavailable.",
+				Params: map[string]string{
+					"error":         "FATAL: connection failure (SQLSTATE 08006)",
+					"details":       "",
+					"message":       "connection failure",
+					"postgres_code": "08006",
+				},
+			},
+			retryable: true,
+		},
+		{
+			name: "bad connection",
+			err:  driver.ErrBadConn,
+			want: &Error{
+				Code:    ErrorCodeUnavailable,
+				Message: "Database is unavailable.",
+				Params:  map[string]string{"error": driver.ErrBadConn.Error()},
+			},
+			retryable: true,
+		},
+		{
+			name: "deadline",
+			err:  context.DeadlineExceeded,
+			want: &Error{
+				Code:    ErrorCodeDeadlineExceeded,
+				Message: "Deadline is exceeded.",
+				Params:  map[string]string{"error": context.DeadlineExceeded.Error()},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromPostgresError(tt.err)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromPostgresError() = %v, want %v", got, tt.want)
+			}
+			if retryable := IsRetryable(got); retryable != tt.retryable {
+				t.Errorf("IsRetryable() = %v, want %v", retryable, tt.retryable)
+			}
+		})
+	}
+}
+
+func TestIsTemporary(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "unavailable",
+			err:  NewError(ErrorCodeUnavailable, "Database is unavailable."),
+			want: true,
+		},
+		{
+			name: "aborted",
+			err:  NewError(ErrorCodeAborted, "Transaction is aborted by a concurrent one."),
+			want: true,
+		},
+		{
+			name: "deadline exceeded",
+			err:  errors.Wrap(context.DeadlineExceeded, "query"),
+			want: true,
+		},
+		{
+			name: "canceled",
+			err:  context.Canceled,
+			want: true,
+		},
+		{
+			name: "invalid form",
+			err:  NewInvalidFormError(),
+			want: false,
+		},
+		{
+			name: "unknown",
+			err:  errors.New("test error"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTemporary(tt.err); got != tt.want {
+				t.Errorf("IsTemporary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
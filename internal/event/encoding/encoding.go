@@ -0,0 +1,234 @@
+package encoding
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	protodefinitions "github.com/018bf/companies/api/proto"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/interfaces/schemaregistry"
+	companiespb "github.com/018bf/companies/pkg/companiespb/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+//go:generate mockgen -source=encoding.go -package=encoding -destination=encoding_mock.go
+
+const (
+	FormatJSON     = "json"
+	FormatProtobuf = "protobuf"
+
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/protobuf"
+)
+
+// magicByte starts every message in the Confluent wire format.
+const magicByte = 0
+
+type schemaRegistry interface {
+	Register(ctx context.Context, subject string, schema *schemaregistry.Schema) (int, error)
+	LatestVersion(ctx context.Context, subject string) (int, error)
+	SchemaURL(id int) string
+}
+
+type Payload struct {
+	Data        []byte
+	ContentType string
+	DataSchema  string
+}
+
+type Encoder struct {
+	format   string
+	subject  string
+	registry schemaRegistry
+	mu       sync.Mutex
+	schemaID int
+}
+
+// NewEncoder returns the encoder of the format, the protobuf schema is registered under subject.
+func NewEncoder(format string, subject string, registry *schemaregistry.Client) (*Encoder, error) {
+	switch format {
+	case FormatJSON, FormatProtobuf:
+	default:
+		return nil, errs.NewUnexpectedBehaviorError("unknown event format").
+			WithParam("format", format)
+	}
+	return &Encoder{
+		format:   format,
+		subject:  subject,
+		registry: registry,
+	}, nil
+}
+
+func (e *Encoder) Encode(ctx context.Context, event *entity.Event) (*Payload, error) {
+	switch e.format {
+	case FormatProtobuf:
+		return e.encodeProtobuf(ctx, event)
+	default:
+		data, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		return &Payload{Data: data, ContentType: ContentTypeJSON}, nil
+	}
+}
+
+func (e *Encoder) encodeProtobuf(ctx context.Context, event *entity.Event) (*Payload, error) {
+	schemaID, err := e.register(ctx)
+	if err != nil {
+		return nil, err
+	}
+	message, err := decodeEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return nil, errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	wire := make([]byte, 5, 5+binary.MaxVarintLen64+len(data))
+	wire[0] = magicByte
+	binary.BigEndian.PutUint32(wire[1:5], uint32(schemaID))
+	wire = append(wire, messageIndexes(message.ProtoReflect().Descriptor())...)
+	wire = append(wire, data...)
+	return &Payload{
+		Data:        wire,
+		ContentType: ContentTypeProtobuf,
+		DataSchema:  e.registry.SchemaURL(schemaID),
+	}, nil
+}
+
+// register registers the event schema once and caches its ID.
+func (e *Encoder) register(ctx context.Context) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.schemaID != 0 {
+		return e.schemaID, nil
+	}
+	schemaID, err := e.registerFile(ctx, companiespb.File_companiespb_v1_event_proto, e.subject)
+	if err != nil {
+		return 0, err
+	}
+	e.schemaID = schemaID
+	return schemaID, nil
+}
+
+// registerFile registers file with all its imports as references.
+// Well-known types are built into the registry and skipped.
+func (e *Encoder) registerFile(
+	ctx context.Context,
+	file protoreflect.FileDescriptor,
+	subject string,
+) (int, error) {
+	var references []schemaregistry.Reference
+	imports := file.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		dependency := imports.Get(i).FileDescriptor
+		if strings.HasPrefix(dependency.Path(), "google/protobuf/") {
+			continue
+		}
+		if _, err := e.registerFile(ctx, dependency, dependency.Path()); err != nil {
+			return 0, err
+		}
+		version, err := e.registry.LatestVersion(ctx, dependency.Path())
+		if err != nil {
+			return 0, err
+		}
+		references = append(references, schemaregistry.Reference{
+			Name:    dependency.Path(),
+			Subject: dependency.Path(),
+			Version: version,
+		})
+	}
+	source, err := protodefinitions.FS.ReadFile(file.Path())
+	if err != nil {
+		return 0, errs.NewUnexpectedBehaviorError(err.Error()).WithParam("file", file.Path())
+	}
+	return e.registry.Register(ctx, subject, &schemaregistry.Schema{
+		SchemaType: schemaregistry.SchemaTypeProtobuf,
+		Schema:     string(source),
+		References: references,
+	})
+}
+
+// messageIndexes encodes the path to the message type within its file
+// as zigzag varints. The common case of the first message is a single zero.
+func messageIndexes(descriptor protoreflect.MessageDescriptor) []byte {
+	var path []int
+	for d := protoreflect.Descriptor(descriptor); ; d = d.Parent() {
+		if _, ok := d.(protoreflect.FileDescriptor); ok {
+			break
+		}
+		path = append([]int{d.Index()}, path...)
+	}
+	if len(path) == 1 && path[0] == 0 {
+		return []byte{0}
+	}
+	indexes := binary.AppendVarint(nil, int64(len(path)))
+	for _, index := range path {
+		indexes = binary.AppendVarint(indexes, int64(index))
+	}
+	return indexes
+}
+
+func decodeEvent(event *entity.Event) (*companiespb.CompanyEvent, error) {
+	message := &companiespb.CompanyEvent{
+		Operation:     decodeEventOperation(event.Operation),
+		Sequence:      event.Sequence,
+		UpdatedAt:     timestamppb.New(event.UpdatedAt),
+		CompanyId:     string(event.CompanyID),
+		ChangedFields: event.ChangedFields,
+	}
+	if event.Company != nil {
+		message.Company = decodeCompany(event.Company)
+	}
+	if event.Before != nil {
+		message.Before = decodeCompany(event.Before)
+	}
+	if event.After != nil {
+		message.After = decodeCompany(event.After)
+	}
+	if event.Changes != nil {
+		changes, err := structpb.NewStruct(event.Changes)
+		if err != nil {
+			return nil, errs.NewUnexpectedBehaviorError(err.Error())
+		}
+		message.Changes = changes
+	}
+	return message, nil
+}
+
+func decodeEventOperation(operation entity.EventOperation) companiespb.EventOperation {
+	switch operation {
+	case entity.EventTypeCreated:
+		return companiespb.EventOperation_EVENT_OPERATION_CREATED
+	case entity.EventTypeUpdated:
+		return companiespb.EventOperation_EVENT_OPERATION_UPDATED
+	case entity.EventTypeDeleted:
+		return companiespb.EventOperation_EVENT_OPERATION_DELETED
+	case entity.EventTypeSnapshot:
+		return companiespb.EventOperation_EVENT_OPERATION_SNAPSHOT
+	default:
+		return companiespb.EventOperation_EVENT_OPERATION_UNKNOWN
+	}
+}
+
+func decodeCompany(company *entity.Company) *companiespb.Company {
+	return &companiespb.Company{
+		Id:                string(company.ID),
+		UpdatedAt:         timestamppb.New(company.UpdatedAt),
+		CreatedAt:         timestamppb.New(company.CreatedAt),
+		Name:              company.Name,
+		Description:       company.Description,
+		AmountOfEmployees: int32(company.AmountOfEmployees),
+		Registered:        company.Registered,
+		Type:              companiespb.CompanyType(company.Type),
+		Version:           company.Version,
+	}
+}
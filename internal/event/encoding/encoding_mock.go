@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: encoding.go
+
+// Package encoding is a generated GoMock package.
+package encoding
+
+import (
+	context "context"
+	reflect "reflect"
+
+	schemaregistry "github.com/018bf/companies/internal/interfaces/schemaregistry"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockschemaRegistry is a mock of schemaRegistry interface.
+type MockschemaRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockschemaRegistryMockRecorder
+}
+
+// MockschemaRegistryMockRecorder is the mock recorder for MockschemaRegistry.
+type MockschemaRegistryMockRecorder struct {
+	mock *MockschemaRegistry
+}
+
+// NewMockschemaRegistry creates a new mock instance.
+func NewMockschemaRegistry(ctrl *gomock.Controller) *MockschemaRegistry {
+	mock := &MockschemaRegistry{ctrl: ctrl}
+	mock.recorder = &MockschemaRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockschemaRegistry) EXPECT() *MockschemaRegistryMockRecorder {
+	return m.recorder
+}
+
+// LatestVersion mocks base method.
+func (m *MockschemaRegistry) LatestVersion(ctx context.Context, subject string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestVersion", ctx, subject)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestVersion indicates an expected call of LatestVersion.
+func (mr *MockschemaRegistryMockRecorder) LatestVersion(ctx, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestVersion", reflect.TypeOf((*MockschemaRegistry)(nil).LatestVersion), ctx, subject)
+}
+
+// Register mocks base method.
+func (m *MockschemaRegistry) Register(ctx context.Context, subject string, schema *schemaregistry.Schema) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, subject, schema)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockschemaRegistryMockRecorder) Register(ctx, subject, schema interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockschemaRegistry)(nil).Register), ctx, subject, schema)
+}
+
+// SchemaURL mocks base method.
+func (m *MockschemaRegistry) SchemaURL(id int) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaURL", id)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SchemaURL indicates an expected call of SchemaURL.
+func (mr *MockschemaRegistryMockRecorder) SchemaURL(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaURL", reflect.TypeOf((*MockschemaRegistry)(nil).SchemaURL), id)
+}
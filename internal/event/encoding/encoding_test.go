@@ -0,0 +1,244 @@
+package encoding
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/interfaces/schemaregistry"
+	companiespb "github.com/018bf/companies/pkg/companiespb/v1"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestNewEncoder(t *testing.T) {
+	config := configs.NewMockConfig(t)
+	registry := schemaregistry.NewClient(config)
+	type args struct {
+		format   string
+		subject  string
+		registry *schemaregistry.Client
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *Encoder
+		wantErr error
+	}{
+		{
+			name: "ok",
+			args: args{
+				format:   FormatJSON,
+				subject:  "companies-value",
+				registry: registry,
+			},
+			want: &Encoder{
+				format:   FormatJSON,
+				subject:  "companies-value",
+				registry: registry,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "protobuf",
+			args: args{
+				format:   FormatProtobuf,
+				subject:  "companies.events-value",
+				registry: registry,
+			},
+			want: &Encoder{
+				format:   FormatProtobuf,
+				subject:  "companies.events-value",
+				registry: registry,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unknown format",
+			args: args{
+				format:   "xml",
+				subject:  "companies-value",
+				registry: registry,
+			},
+			want:    nil,
+			wantErr: errs.NewUnexpectedBehaviorError("unknown event format").WithParam("format", "xml"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewEncoder(tt.args.format, tt.args.subject, tt.args.registry)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewEncoder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewEncoder() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncoder_Encode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSchemaRegistry := NewMockschemaRegistry(ctrl)
+	fakeRegistry := schemaregistry.NewMockSchemaRegistry(t)
+	config := configs.NewMockConfig(t)
+	config.SchemaRegistry.URL = fakeRegistry.URL
+	client := schemaregistry.NewClient(config)
+	ctx := context.Background()
+	event := mock_models.NewEvent(t)
+	jsonData, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := decodeEvent(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	protobufData, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type fields struct {
+		format   string
+		registry schemaRegistry
+	}
+	type args struct {
+		ctx   context.Context
+		event *entity.Event
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		want    *Payload
+		wantErr error
+	}{
+		{
+			name:  "json",
+			setup: func() {},
+			fields: fields{
+				format:   FormatJSON,
+				registry: client,
+			},
+			args: args{
+				ctx:   ctx,
+				event: event,
+			},
+			want: &Payload{
+				Data:        jsonData,
+				ContentType: ContentTypeJSON,
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "protobuf",
+			setup: func() {},
+			fields: fields{
+				format:   FormatProtobuf,
+				registry: client,
+			},
+			args: args{
+				ctx:   ctx,
+				event: event,
+			},
+			want: &Payload{
+				Data:        append([]byte{0, 0, 0, 0, 2, 0}, protobufData...),
+				ContentType: ContentTypeProtobuf,
+				DataSchema:  fakeRegistry.URL + "/schemas/ids/2",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "registry error",
+			setup: func() {
+				mockSchemaRegistry.EXPECT().
+					Register(ctx, "companiespb/v1/company.proto", gomock.Any()).
+					Return(0, errs.NewUnexpectedBehaviorError("err 5"))
+			},
+			fields: fields{
+				format:   FormatProtobuf,
+				registry: mockSchemaRegistry,
+			},
+			args: args{
+				ctx:   ctx,
+				event: event,
+			},
+			want:    nil,
+			wantErr: errs.NewUnexpectedBehaviorError("err 5"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			e := &Encoder{
+				format:   tt.fields.format,
+				subject:  "companies-value",
+				registry: tt.fields.registry,
+			}
+			got, err := e.Encode(tt.args.ctx, tt.args.event)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Encode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Encode() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	subjects := fakeRegistry.Subjects()
+	if !reflect.DeepEqual(subjects["companies-value"], []int{2}) {
+		t.Errorf("companies-value = %v", subjects["companies-value"])
+	}
+	schema := fakeRegistry.Schema(2)
+	want := []schemaregistry.Reference{
+		{Name: "companiespb/v1/company.proto", Subject: "companiespb/v1/company.proto", Version: 1},
+	}
+	if schema == nil || !reflect.DeepEqual(schema.References, want) {
+		t.Errorf("references = %v, want %v", schema, want)
+	}
+}
+
+func Test_messageIndexes(t *testing.T) {
+	company := (&companiespb.Company{}).ProtoReflect().Descriptor()
+	type args struct {
+		descriptor protoreflect.MessageDescriptor
+	}
+	tests := []struct {
+		name string
+		args args
+		want []byte
+	}{
+		{
+			name: "first message",
+			args: args{
+				descriptor: (&companiespb.CompanyEvent{}).ProtoReflect().Descriptor(),
+			},
+			want: []byte{0},
+		},
+		{
+			name: "not first message",
+			args: args{
+				descriptor: company,
+			},
+			want: binary.AppendVarint(binary.AppendVarint(nil, 1), int64(company.Index())),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := messageIndexes(tt.args.descriptor); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("messageIndexes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+)
+
+// CheckpointRepository keeps the ID of the last replayed company in a file.
+// With an empty path checkpoints are disabled.
+type CheckpointRepository struct {
+	path string
+}
+
+func NewCheckpointRepository(path string) *CheckpointRepository {
+	return &CheckpointRepository{path: path}
+}
+
+// Load returns the saved ID or an empty one when there is no checkpoint yet.
+func (r *CheckpointRepository) Load(_ context.Context) (entity.UUID, error) {
+	if r.path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errs.NewUnexpectedBehaviorError(err.Error()).WithParam("path", r.path)
+	}
+	return entity.UUID(strings.TrimSpace(string(data))), nil
+}
+
+// Save replaces the checkpoint atomically, so an interrupted replay never leaves a partial ID.
+func (r *CheckpointRepository) Save(_ context.Context, id entity.UUID) error {
+	if r.path == "" {
+		return nil
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(id), 0o644); err != nil {
+		return errs.NewUnexpectedBehaviorError(err.Error()).WithParam("path", tmp)
+	}
+	if err := os.Rename(tmp, r.path); err != nil {
+		return errs.NewUnexpectedBehaviorError(err.Error()).WithParam("path", r.path)
+	}
+	return nil
+}
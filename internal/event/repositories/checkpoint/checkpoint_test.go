@@ -0,0 +1,50 @@
+package checkpoint
+
+import (
+	"context"
+	"path"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestCheckpointRepository(t *testing.T) {
+	ctx := context.Background()
+	id := entity.UUID(uuid.NewString())
+	tests := []struct {
+		name string
+		path string
+		want entity.UUID
+	}{
+		{
+			name: "ok",
+			path: path.Join(t.TempDir(), "checkpoint"),
+			want: id,
+		},
+		{
+			name: "disabled",
+			path: "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCheckpointRepository(tt.path)
+			got, err := r.Load(ctx)
+			if err != nil || got != "" {
+				t.Fatalf("Load() got = %v, error = %v", got, err)
+			}
+			if err := r.Save(ctx, id); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			got, err = r.Load(ctx)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Load() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+)
+
+// EventRepository appends events to a JSON Lines file.
+type EventRepository struct {
+	mu   sync.Mutex
+	file *os.File
+	sync bool
+}
+
+func NewEventRepository(config *configs.Config) (*EventRepository, error) {
+	file, err := os.OpenFile(config.Events.File.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, errs.NewUnexpectedBehaviorError(err.Error()).WithParam("path", config.Events.File.Path)
+	}
+	return &EventRepository{file: file, sync: config.Events.File.Sync}, nil
+}
+
+// Send writes the event as a single line.
+// With sync enabled the file is flushed to disk before returning.
+func (r *EventRepository) Send(_ context.Context, event *entity.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	data = append(data, '\n')
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := r.file.Write(data); err != nil {
+		return errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	if r.sync {
+		if err := r.file.Sync(); err != nil {
+			return errs.NewUnexpectedBehaviorError(err.Error())
+		}
+	}
+	return nil
+}
+
+func (r *EventRepository) Close(_ context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
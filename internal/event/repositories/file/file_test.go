@@ -0,0 +1,66 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+)
+
+func TestEventRepository_Send(t *testing.T) {
+	config := configs.NewMockConfig(t)
+	config.Events.File.Path = path.Join(t.TempDir(), "events.jsonl")
+	config.Events.File.Sync = true
+	events := []*entity.Event{mock_models.NewEvent(t), mock_models.NewEvent(t)}
+	r, err := NewEventRepository(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, event := range events {
+		if err := r.Send(context.Background(), event); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(config.Events.File.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var got []*entity.Event
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		event := &entity.Event{}
+		if err := json.Unmarshal(scanner.Bytes(), event); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, event)
+	}
+	if len(got) != len(events) {
+		t.Fatalf("got %d lines, want %d", len(got), len(events))
+	}
+	for i := range events {
+		want, _ := json.Marshal(events[i])
+		line, _ := json.Marshal(got[i])
+		if !reflect.DeepEqual(want, line) {
+			t.Errorf("line %d = %s, want %s", i, line, want)
+		}
+	}
+}
+
+func TestNewEventRepository(t *testing.T) {
+	config := configs.NewMockConfig(t)
+	config.Events.File.Path = path.Join(t.TempDir(), "missing", "events.jsonl")
+	if _, err := NewEventRepository(config); err == nil {
+		t.Error("NewEventRepository() error = nil, want error")
+	}
+}
@@ -3,37 +3,114 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+
 	"github.com/018bf/companies/internal/configs"
 	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/event/encoding"
+	"github.com/018bf/companies/pkg/clock"
+	"github.com/018bf/companies/pkg/cloudevents"
 	"github.com/018bf/companies/pkg/log"
 	"github.com/Shopify/sarama"
 )
 
+//go:generate mockgen -source=kafka.go -package=kafka -destination=kafka_mock.go
+
+type encoder interface {
+	Encode(ctx context.Context, event *entity.Event) (*encoding.Payload, error)
+}
+
+// messageProducer is implemented by sarama.SyncProducer and the non-blocking kafka.AsyncProducer.
+type messageProducer interface {
+	SendMessage(message *sarama.ProducerMessage) (partition int32, offset int64, err error)
+}
+
 type EventRepository struct {
-	producer sarama.SyncProducer
+	producer messageProducer
+	encoder  encoder
+	clock    clock.Clock
 	logger   log.Logger
 	topic    string
+	source   string
+	mode     string
 }
 
 func NewEventRepository(
-	producer sarama.SyncProducer,
+	producer messageProducer,
+	encoder *encoding.Encoder,
 	config *configs.Config,
+	clock clock.Clock,
 	logger log.Logger,
-) *EventRepository {
-	return &EventRepository{producer: producer, logger: logger, topic: config.Kafka.Topic}
+) (*EventRepository, error) {
+	switch config.Kafka.CloudEventsMode {
+	case cloudevents.ModeStructured, cloudevents.ModeBinary:
+	default:
+		return nil, errs.NewUnexpectedBehaviorError("unknown cloud events mode").
+			WithParam("cloud_events_mode", config.Kafka.CloudEventsMode)
+	}
+	return &EventRepository{
+		producer: producer,
+		encoder:  encoder,
+		clock:    clock,
+		logger:   logger,
+		topic:    config.Kafka.Topic,
+		source:   config.Kafka.Source,
+		mode:     config.Kafka.CloudEventsMode,
+	}, nil
 }
 
-func (r *EventRepository) Send(_ context.Context, event *entity.Event) error {
-	data, err := json.Marshal(event)
+func (r *EventRepository) Send(ctx context.Context, event *entity.Event) error {
+	payload, err := r.encoder.Encode(ctx, event)
 	if err != nil {
 		return err
 	}
+	cloudEvent := r.newCloudEvent(ctx, event, payload)
 	message := &sarama.ProducerMessage{
 		Topic: r.topic,
-		Value: sarama.ByteEncoder(data),
+		Key:   sarama.StringEncoder(event.CompanyID),
+	}
+	switch r.mode {
+	case cloudevents.ModeBinary:
+		for key, value := range cloudEvent.Headers() {
+			message.Headers = append(message.Headers, sarama.RecordHeader{
+				Key:   []byte(key),
+				Value: []byte(value),
+			})
+		}
+		message.Value = sarama.ByteEncoder(payload.Data)
+	default:
+		envelope, err := json.Marshal(cloudEvent)
+		if err != nil {
+			return err
+		}
+		message.Headers = []sarama.RecordHeader{
+			{
+				Key:   []byte(cloudevents.HeaderContentType),
+				Value: []byte(cloudevents.ContentTypeStructuredJSON),
+			},
+		}
+		message.Value = sarama.ByteEncoder(envelope)
 	}
 	if _, _, err := r.producer.SendMessage(message); err != nil {
 		return err
 	}
 	return nil
 }
+
+func (r *EventRepository) newCloudEvent(
+	ctx context.Context,
+	event *entity.Event,
+	payload *encoding.Payload,
+) cloudevents.Event {
+	cloudEvent := cloudevents.NewEvent(
+		ctx,
+		r.source,
+		cloudevents.TypePrefix+string(event.Operation),
+		string(event.CompanyID),
+		r.clock.Now(),
+	)
+	cloudEvent.DataContentType = payload.ContentType
+	cloudEvent.DataSchema = payload.DataSchema
+	cloudEvent.Data = payload.Data
+	return cloudEvent
+}
@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: kafka.go
+
+// Package kafka is a generated GoMock package.
+package kafka
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/018bf/companies/internal/entity"
+	encoding "github.com/018bf/companies/internal/event/encoding"
+	sarama "github.com/Shopify/sarama"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockencoder is a mock of encoder interface.
+type Mockencoder struct {
+	ctrl     *gomock.Controller
+	recorder *MockencoderMockRecorder
+}
+
+// MockencoderMockRecorder is the mock recorder for Mockencoder.
+type MockencoderMockRecorder struct {
+	mock *Mockencoder
+}
+
+// NewMockencoder creates a new mock instance.
+func NewMockencoder(ctrl *gomock.Controller) *Mockencoder {
+	mock := &Mockencoder{ctrl: ctrl}
+	mock.recorder = &MockencoderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockencoder) EXPECT() *MockencoderMockRecorder {
+	return m.recorder
+}
+
+// Encode mocks base method.
+func (m *Mockencoder) Encode(ctx context.Context, event *entity.Event) (*encoding.Payload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encode", ctx, event)
+	ret0, _ := ret[0].(*encoding.Payload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encode indicates an expected call of Encode.
+func (mr *MockencoderMockRecorder) Encode(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encode", reflect.TypeOf((*Mockencoder)(nil).Encode), ctx, event)
+}
+
+// MockmessageProducer is a mock of messageProducer interface.
+type MockmessageProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockmessageProducerMockRecorder
+}
+
+// MockmessageProducerMockRecorder is the mock recorder for MockmessageProducer.
+type MockmessageProducerMockRecorder struct {
+	mock *MockmessageProducer
+}
+
+// NewMockmessageProducer creates a new mock instance.
+func NewMockmessageProducer(ctrl *gomock.Controller) *MockmessageProducer {
+	mock := &MockmessageProducer{ctrl: ctrl}
+	mock.recorder = &MockmessageProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmessageProducer) EXPECT() *MockmessageProducerMockRecorder {
+	return m.recorder
+}
+
+// SendMessage mocks base method.
+func (m *MockmessageProducer) SendMessage(message *sarama.ProducerMessage) (int32, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", message)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockmessageProducerMockRecorder) SendMessage(message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockmessageProducer)(nil).SendMessage), message)
+}
@@ -2,18 +2,26 @@ package kafka
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"reflect"
+	"testing"
+	"time"
+
 	"github.com/018bf/companies/internal/configs"
 	"github.com/018bf/companies/internal/entity"
 	mock_models "github.com/018bf/companies/internal/entity/mock"
 	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/event/encoding"
 	mock_sarama "github.com/018bf/companies/internal/event/repositories/kafka/mock"
+	"github.com/018bf/companies/pkg/clock"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
+	"github.com/018bf/companies/pkg/cloudevents"
 	"github.com/018bf/companies/pkg/log"
 	mock_log "github.com/018bf/companies/pkg/log/mock"
 	"github.com/Shopify/sarama"
 	"github.com/golang/mock/gomock"
-	"reflect"
-	"testing"
 )
 
 //go:generate mockgen -build_flags=-mod=mod -destination mock/sarma.go github.com/Shopify/sarama SyncProducer
@@ -22,17 +30,34 @@ func TestEventRepository_Send(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	syncProducer := mock_sarama.NewMockSyncProducer(ctrl)
+	mockEncoder := NewMockencoder(ctrl)
+	mockClock := mock_clock.NewMockClock(ctrl)
 	logger := mock_log.NewMockLogger(ctrl)
 	config := configs.NewMockConfig(t)
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), log.RequestIDKey, "request-1")
 	event := mock_models.NewEvent(t)
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := &encoding.Payload{Data: data, ContentType: encoding.ContentTypeJSON}
+	protobufPayload := &encoding.Payload{
+		Data:        []byte{0, 0, 0, 0, 1, 0, 8, 1},
+		ContentType: encoding.ContentTypeProtobuf,
+		DataSchema:  "http://registry/schemas/ids/1",
+	}
+	now := time.Now().UTC()
 	type fields struct {
 		producer sarama.SyncProducer
+		encoder  encoder
+		clock    clock.Clock
 		logger   log.Logger
 		topic    string
+		source   string
+		mode     string
 	}
 	type args struct {
-		in0   context.Context
+		ctx   context.Context
 		event *entity.Event
 	}
 	tests := []struct {
@@ -43,33 +68,188 @@ func TestEventRepository_Send(t *testing.T) {
 		wantErr error
 	}{
 		{
-			name: "ok",
+			name: "structured",
 			setup: func() {
-				syncProducer.EXPECT().SendMessage(gomock.Any()).Return(int32(0), int64(0), nil)
+				mockEncoder.EXPECT().Encode(ctx, event).Return(payload, nil)
+				mockClock.EXPECT().Now().Return(now)
+				syncProducer.EXPECT().
+					SendMessage(gomock.Any()).
+					DoAndReturn(func(message *sarama.ProducerMessage) (int32, int64, error) {
+						headers := messageHeaders(message)
+						if key, _ := message.Key.Encode(); string(key) != string(event.CompanyID) {
+							t.Errorf("key = %s, want %s", key, event.CompanyID)
+						}
+						if headers[cloudevents.HeaderContentType] != cloudevents.ContentTypeStructuredJSON {
+							t.Errorf("content-type = %v", headers[cloudevents.HeaderContentType])
+						}
+						value, _ := message.Value.Encode()
+						envelope := map[string]any{}
+						if err := json.Unmarshal(value, &envelope); err != nil {
+							t.Fatal(err)
+						}
+						if envelope["type"] != "com.companies.company."+string(event.Operation) {
+							t.Errorf("type = %v", envelope["type"])
+						}
+						if envelope["subject"] != string(event.CompanyID) {
+							t.Errorf("subject = %v", envelope["subject"])
+						}
+						if envelope["source"] != config.Kafka.Source {
+							t.Errorf("source = %v", envelope["source"])
+						}
+						if envelope["requestid"] != "request-1" {
+							t.Errorf("requestid = %v", envelope["requestid"])
+						}
+						if _, ok := envelope["data"].(map[string]any); !ok {
+							t.Errorf("data = %v", envelope["data"])
+						}
+						return 0, 0, nil
+					})
 			},
 			fields: fields{
 				producer: syncProducer,
+				encoder:  mockEncoder,
+				clock:    mockClock,
 				logger:   logger,
 				topic:    config.Kafka.Topic,
+				source:   config.Kafka.Source,
+				mode:     cloudevents.ModeStructured,
 			},
 			args: args{
-				in0:   ctx,
+				ctx:   ctx,
 				event: event,
 			},
 			wantErr: nil,
 		},
+		{
+			name: "binary",
+			setup: func() {
+				mockEncoder.EXPECT().Encode(ctx, event).Return(payload, nil)
+				mockClock.EXPECT().Now().Return(now)
+				syncProducer.EXPECT().
+					SendMessage(gomock.Any()).
+					DoAndReturn(func(message *sarama.ProducerMessage) (int32, int64, error) {
+						headers := messageHeaders(message)
+						want := map[string]string{
+							"ce_specversion": cloudevents.SpecVersion,
+							"ce_source":      config.Kafka.Source,
+							"ce_type":        "com.companies.company." + string(event.Operation),
+							"ce_subject":     string(event.CompanyID),
+							"ce_time":        now.Format(time.RFC3339Nano),
+							"ce_requestid":   "request-1",
+							"content-type":   cloudevents.ContentTypeJSON,
+						}
+						for key, value := range want {
+							if headers[key] != value {
+								t.Errorf("header %s = %v, want %v", key, headers[key], value)
+							}
+						}
+						if headers["ce_id"] == "" {
+							t.Error("ce_id is empty")
+						}
+						value, _ := message.Value.Encode()
+						got := &entity.Event{}
+						if err := json.Unmarshal(value, got); err != nil {
+							t.Fatal(err)
+						}
+						if got.Operation != event.Operation {
+							t.Errorf("operation = %v, want %v", got.Operation, event.Operation)
+						}
+						return 0, 0, nil
+					})
+			},
+			fields: fields{
+				producer: syncProducer,
+				encoder:  mockEncoder,
+				clock:    mockClock,
+				logger:   logger,
+				topic:    config.Kafka.Topic,
+				source:   config.Kafka.Source,
+				mode:     cloudevents.ModeBinary,
+			},
+			args: args{
+				ctx:   ctx,
+				event: event,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "structured protobuf",
+			setup: func() {
+				mockEncoder.EXPECT().Encode(ctx, event).Return(protobufPayload, nil)
+				mockClock.EXPECT().Now().Return(now)
+				syncProducer.EXPECT().
+					SendMessage(gomock.Any()).
+					DoAndReturn(func(message *sarama.ProducerMessage) (int32, int64, error) {
+						value, _ := message.Value.Encode()
+						envelope := map[string]any{}
+						if err := json.Unmarshal(value, &envelope); err != nil {
+							t.Fatal(err)
+						}
+						if envelope["datacontenttype"] != encoding.ContentTypeProtobuf {
+							t.Errorf("datacontenttype = %v", envelope["datacontenttype"])
+						}
+						if envelope["dataschema"] != protobufPayload.DataSchema {
+							t.Errorf("dataschema = %v", envelope["dataschema"])
+						}
+						if envelope["data_base64"] != base64.StdEncoding.EncodeToString(protobufPayload.Data) {
+							t.Errorf("data_base64 = %v", envelope["data_base64"])
+						}
+						return 0, 0, nil
+					})
+			},
+			fields: fields{
+				producer: syncProducer,
+				encoder:  mockEncoder,
+				clock:    mockClock,
+				logger:   logger,
+				topic:    config.Kafka.Topic,
+				source:   config.Kafka.Source,
+				mode:     cloudevents.ModeStructured,
+			},
+			args: args{
+				ctx:   ctx,
+				event: event,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "encode error",
+			setup: func() {
+				mockEncoder.EXPECT().Encode(ctx, event).Return(nil, errs.NewUnexpectedBehaviorError("err 12"))
+			},
+			fields: fields{
+				producer: syncProducer,
+				encoder:  mockEncoder,
+				clock:    mockClock,
+				logger:   logger,
+				topic:    config.Kafka.Topic,
+				source:   config.Kafka.Source,
+				mode:     cloudevents.ModeStructured,
+			},
+			args: args{
+				ctx:   ctx,
+				event: event,
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("err 12"),
+		},
 		{
 			name: "send error",
 			setup: func() {
+				mockEncoder.EXPECT().Encode(ctx, event).Return(payload, nil)
+				mockClock.EXPECT().Now().Return(now)
 				syncProducer.EXPECT().SendMessage(gomock.Any()).Return(int32(0), int64(0), errs.NewUnexpectedBehaviorError("err 1234"))
 			},
 			fields: fields{
 				producer: syncProducer,
+				encoder:  mockEncoder,
+				clock:    mockClock,
 				logger:   logger,
 				topic:    config.Kafka.Topic,
+				source:   config.Kafka.Source,
+				mode:     cloudevents.ModeStructured,
 			},
 			args: args{
-				in0:   ctx,
+				ctx:   ctx,
 				event: event,
 			},
 			wantErr: errs.NewUnexpectedBehaviorError("err 1234"),
@@ -80,10 +260,14 @@ func TestEventRepository_Send(t *testing.T) {
 			tt.setup()
 			r := &EventRepository{
 				producer: tt.fields.producer,
+				encoder:  tt.fields.encoder,
+				clock:    tt.fields.clock,
 				logger:   tt.fields.logger,
 				topic:    tt.fields.topic,
+				source:   tt.fields.source,
+				mode:     tt.fields.mode,
 			}
-			if err := r.Send(tt.args.in0, tt.args.event); !errors.Is(err, tt.wantErr) {
+			if err := r.Send(tt.args.ctx, tt.args.event); !errors.Is(err, tt.wantErr) {
 				t.Errorf("Send() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -94,37 +278,102 @@ func TestNewEventRepository(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	syncProducer := mock_sarama.NewMockSyncProducer(ctrl)
+	mockClock := mock_clock.NewMockClock(ctrl)
 	logger := mock_log.NewMockLogger(ctrl)
 	config := configs.NewMockConfig(t)
+	eventEncoder, err := encoding.NewEncoder(config.Kafka.Format, config.Kafka.Topic+"-value", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
 	type args struct {
 		producer sarama.SyncProducer
+		encoder  *encoding.Encoder
 		config   *configs.Config
+		clock    clock.Clock
 		logger   log.Logger
 	}
+	binary := configs.NewMockConfig(t)
+	binary.Kafka.CloudEventsMode = "binary"
+	unknown := configs.NewMockConfig(t)
+	unknown.Kafka.CloudEventsMode = "envelope"
 	tests := []struct {
-		name string
-		args args
-		want *EventRepository
+		name    string
+		args    args
+		want    *EventRepository
+		wantErr error
 	}{
 		{
 			name: "ok",
 			args: args{
 				producer: syncProducer,
+				encoder:  eventEncoder,
 				config:   config,
+				clock:    mockClock,
+				logger:   logger,
+			},
+			want: &EventRepository{
+				producer: syncProducer,
+				encoder:  eventEncoder,
+				clock:    mockClock,
+				topic:    config.Kafka.Topic,
+				source:   config.Kafka.Source,
+				mode:     config.Kafka.CloudEventsMode,
+				logger:   logger,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "binary",
+			args: args{
+				producer: syncProducer,
+				encoder:  eventEncoder,
+				config:   binary,
+				clock:    mockClock,
 				logger:   logger,
 			},
 			want: &EventRepository{
 				producer: syncProducer,
+				encoder:  eventEncoder,
+				clock:    mockClock,
 				topic:    config.Kafka.Topic,
+				source:   config.Kafka.Source,
+				mode:     "binary",
+				logger:   logger,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "unknown mode",
+			args: args{
+				producer: syncProducer,
+				encoder:  eventEncoder,
+				config:   unknown,
+				clock:    mockClock,
 				logger:   logger,
 			},
+			want: nil,
+			wantErr: errs.NewUnexpectedBehaviorError("unknown cloud events mode").
+				WithParam("cloud_events_mode", "envelope"),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewEventRepository(tt.args.producer, tt.args.config, tt.args.logger); !reflect.DeepEqual(got, tt.want) {
+			got, err := NewEventRepository(tt.args.producer, tt.args.encoder, tt.args.config, tt.args.clock, tt.args.logger)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewEventRepository() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewEventRepository() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func messageHeaders(message *sarama.ProducerMessage) map[string]string {
+	headers := make(map[string]string, len(message.Headers))
+	for _, header := range message.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+	return headers
+}
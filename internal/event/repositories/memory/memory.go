@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+)
+
+// EventRepository keeps the latest events in memory.
+// It is meant for tests and local development.
+type EventRepository struct {
+	mu       sync.RWMutex
+	events   []*entity.Event
+	capacity int
+}
+
+func NewEventRepository(config *configs.Config) *EventRepository {
+	return &EventRepository{capacity: config.Events.Memory.Capacity}
+}
+
+// Send stores the event, dropping the oldest one when the capacity is exceeded.
+func (r *EventRepository) Send(_ context.Context, event *entity.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	if r.capacity > 0 && len(r.events) > r.capacity {
+		r.events = r.events[len(r.events)-r.capacity:]
+	}
+	return nil
+}
+
+// Events returns the stored events, oldest first.
+func (r *EventRepository) Events() []*entity.Event {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	events := make([]*entity.Event, len(r.events))
+	copy(events, r.events)
+	return events
+}
+
+func (r *EventRepository) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+)
+
+func TestEventRepository_Send(t *testing.T) {
+	first := mock_models.NewEvent(t)
+	second := mock_models.NewEvent(t)
+	third := mock_models.NewEvent(t)
+	tests := []struct {
+		name     string
+		capacity int
+		events   []*entity.Event
+		want     []*entity.Event
+	}{
+		{
+			name:     "ok",
+			capacity: 3,
+			events:   []*entity.Event{first, second},
+			want:     []*entity.Event{first, second},
+		},
+		{
+			name:     "capacity exceeded",
+			capacity: 2,
+			events:   []*entity.Event{first, second, third},
+			want:     []*entity.Event{second, third},
+		},
+		{
+			name:     "unbounded",
+			capacity: 0,
+			events:   []*entity.Event{first, second, third},
+			want:     []*entity.Event{first, second, third},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &EventRepository{capacity: tt.capacity}
+			for _, event := range tt.events {
+				if err := r.Send(context.Background(), event); err != nil {
+					t.Fatalf("Send() error = %v", err)
+				}
+			}
+			if got := r.Events(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Events() got = %v, want %v", got, tt.want)
+			}
+			r.Reset()
+			if got := r.Events(); len(got) != 0 {
+				t.Errorf("Events() after Reset() got = %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/event/encoding"
+	"github.com/018bf/companies/pkg/clock"
+	"github.com/018bf/companies/pkg/cloudevents"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/nats-io/nats.go"
+)
+
+//go:generate mockgen -source=nats.go -package=nats -destination=nats_mock.go
+
+type encoder interface {
+	Encode(ctx context.Context, event *entity.Event) (*encoding.Payload, error)
+}
+
+type publisher interface {
+	PublishMsg(message *nats.Msg, opts ...nats.PubOpt) (*nats.PubAck, error)
+}
+
+// EventRepository publishes events to a JetStream stream.
+// Every event is published to "<subject>.<operation>".
+type EventRepository struct {
+	publisher publisher
+	encoder   encoder
+	clock     clock.Clock
+	logger    log.Logger
+	subject   string
+	source    string
+	mode      string
+}
+
+func NewEventRepository(
+	js nats.JetStreamContext,
+	encoder *encoding.Encoder,
+	config *configs.Config,
+	clock clock.Clock,
+	logger log.Logger,
+) (*EventRepository, error) {
+	switch config.Events.NATS.CloudEventsMode {
+	case cloudevents.ModeStructured, cloudevents.ModeBinary:
+	default:
+		return nil, errs.NewUnexpectedBehaviorError("unknown cloud events mode").
+			WithParam("cloud_events_mode", config.Events.NATS.CloudEventsMode)
+	}
+	return &EventRepository{
+		publisher: js,
+		encoder:   encoder,
+		clock:     clock,
+		logger:    logger,
+		subject:   config.Events.NATS.Subject,
+		source:    config.Events.NATS.Source,
+		mode:      config.Events.NATS.CloudEventsMode,
+	}, nil
+}
+
+func (r *EventRepository) Send(ctx context.Context, event *entity.Event) error {
+	payload, err := r.encoder.Encode(ctx, event)
+	if err != nil {
+		return err
+	}
+	cloudEvent := r.newCloudEvent(ctx, event, payload)
+	message := nats.NewMsg(r.subject + "." + string(event.Operation))
+	switch r.mode {
+	case cloudevents.ModeBinary:
+		for key, value := range cloudEvent.HeadersWithPrefix(cloudevents.HeaderPrefixNATS) {
+			message.Header.Set(key, value)
+		}
+		message.Data = payload.Data
+	default:
+		envelope, err := json.Marshal(cloudEvent)
+		if err != nil {
+			return err
+		}
+		message.Header.Set(cloudevents.HeaderContentType, cloudevents.ContentTypeStructuredJSON)
+		message.Data = envelope
+	}
+	// The stream drops a message which is published again with the same ID.
+	message.Header.Set(nats.MsgIdHdr, cloudEvent.ID)
+	if _, err := r.publisher.PublishMsg(message, nats.Context(ctx)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *EventRepository) newCloudEvent(
+	ctx context.Context,
+	event *entity.Event,
+	payload *encoding.Payload,
+) cloudevents.Event {
+	cloudEvent := cloudevents.NewEvent(
+		ctx,
+		r.source,
+		cloudevents.TypePrefix+string(event.Operation),
+		string(event.CompanyID),
+		r.clock.Now(),
+	)
+	cloudEvent.DataContentType = payload.ContentType
+	cloudEvent.DataSchema = payload.DataSchema
+	cloudEvent.Data = payload.Data
+	return cloudEvent
+}
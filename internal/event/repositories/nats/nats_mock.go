@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: nats.go
+
+// Package nats is a generated GoMock package.
+package nats
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/018bf/companies/internal/entity"
+	encoding "github.com/018bf/companies/internal/event/encoding"
+	gomock "github.com/golang/mock/gomock"
+	nats "github.com/nats-io/nats.go"
+)
+
+// Mockencoder is a mock of encoder interface.
+type Mockencoder struct {
+	ctrl     *gomock.Controller
+	recorder *MockencoderMockRecorder
+}
+
+// MockencoderMockRecorder is the mock recorder for Mockencoder.
+type MockencoderMockRecorder struct {
+	mock *Mockencoder
+}
+
+// NewMockencoder creates a new mock instance.
+func NewMockencoder(ctrl *gomock.Controller) *Mockencoder {
+	mock := &Mockencoder{ctrl: ctrl}
+	mock.recorder = &MockencoderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockencoder) EXPECT() *MockencoderMockRecorder {
+	return m.recorder
+}
+
+// Encode mocks base method.
+func (m *Mockencoder) Encode(ctx context.Context, event *entity.Event) (*encoding.Payload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encode", ctx, event)
+	ret0, _ := ret[0].(*encoding.Payload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encode indicates an expected call of Encode.
+func (mr *MockencoderMockRecorder) Encode(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encode", reflect.TypeOf((*Mockencoder)(nil).Encode), ctx, event)
+}
+
+// Mockpublisher is a mock of publisher interface.
+type Mockpublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockpublisherMockRecorder
+}
+
+// MockpublisherMockRecorder is the mock recorder for Mockpublisher.
+type MockpublisherMockRecorder struct {
+	mock *Mockpublisher
+}
+
+// NewMockpublisher creates a new mock instance.
+func NewMockpublisher(ctrl *gomock.Controller) *Mockpublisher {
+	mock := &Mockpublisher{ctrl: ctrl}
+	mock.recorder = &MockpublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockpublisher) EXPECT() *MockpublisherMockRecorder {
+	return m.recorder
+}
+
+// PublishMsg mocks base method.
+func (m *Mockpublisher) PublishMsg(message *nats.Msg, opts ...nats.PubOpt) (*nats.PubAck, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{message}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PublishMsg", varargs...)
+	ret0, _ := ret[0].(*nats.PubAck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishMsg indicates an expected call of PublishMsg.
+func (mr *MockpublisherMockRecorder) PublishMsg(message interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{message}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMsg", reflect.TypeOf((*Mockpublisher)(nil).PublishMsg), varargs...)
+}
@@ -0,0 +1,214 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/event/encoding"
+	"github.com/018bf/companies/pkg/clock"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
+	"github.com/018bf/companies/pkg/cloudevents"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/nats-io/nats.go"
+)
+
+func TestEventRepository_Send(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockPublisher := NewMockpublisher(ctrl)
+	mockEncoder := NewMockencoder(ctrl)
+	mockClock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	config := configs.NewMockConfig(t)
+	ctx := context.WithValue(context.Background(), log.RequestIDKey, "request-1")
+	event := mock_models.NewEvent(t)
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := &encoding.Payload{Data: data, ContentType: encoding.ContentTypeJSON}
+	now := time.Now().UTC()
+	subject := config.Events.NATS.Subject + "." + string(event.Operation)
+	type fields struct {
+		publisher publisher
+		encoder   encoder
+		clock     clock.Clock
+		logger    log.Logger
+		subject   string
+		source    string
+		mode      string
+	}
+	type args struct {
+		ctx   context.Context
+		event *entity.Event
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "structured",
+			setup: func() {
+				mockEncoder.EXPECT().Encode(ctx, event).Return(payload, nil)
+				mockClock.EXPECT().Now().Return(now)
+				mockPublisher.EXPECT().
+					PublishMsg(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(message *nats.Msg, _ ...nats.PubOpt) (*nats.PubAck, error) {
+						if message.Subject != subject {
+							t.Errorf("subject = %s, want %s", message.Subject, subject)
+						}
+						if message.Header.Get(cloudevents.HeaderContentType) != cloudevents.ContentTypeStructuredJSON {
+							t.Errorf("content-type = %v", message.Header.Get(cloudevents.HeaderContentType))
+						}
+						envelope := map[string]any{}
+						if err := json.Unmarshal(message.Data, &envelope); err != nil {
+							t.Fatal(err)
+						}
+						if envelope["type"] != "com.companies.company."+string(event.Operation) {
+							t.Errorf("type = %v", envelope["type"])
+						}
+						if envelope["id"] != message.Header.Get(nats.MsgIdHdr) {
+							t.Errorf("%s = %v, want %v", nats.MsgIdHdr, message.Header.Get(nats.MsgIdHdr), envelope["id"])
+						}
+						if envelope["requestid"] != "request-1" {
+							t.Errorf("requestid = %v", envelope["requestid"])
+						}
+						return &nats.PubAck{}, nil
+					})
+			},
+			fields: fields{
+				publisher: mockPublisher,
+				encoder:   mockEncoder,
+				clock:     mockClock,
+				logger:    logger,
+				subject:   config.Events.NATS.Subject,
+				source:    config.Events.NATS.Source,
+				mode:      cloudevents.ModeStructured,
+			},
+			args: args{
+				ctx:   ctx,
+				event: event,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "binary",
+			setup: func() {
+				mockEncoder.EXPECT().Encode(ctx, event).Return(payload, nil)
+				mockClock.EXPECT().Now().Return(now)
+				mockPublisher.EXPECT().
+					PublishMsg(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(message *nats.Msg, _ ...nats.PubOpt) (*nats.PubAck, error) {
+						want := map[string]string{
+							"ce-specversion": cloudevents.SpecVersion,
+							"ce-source":      config.Events.NATS.Source,
+							"ce-type":        "com.companies.company." + string(event.Operation),
+							"ce-subject":     string(event.CompanyID),
+							"ce-time":        now.Format(time.RFC3339Nano),
+							"ce-requestid":   "request-1",
+							"content-type":   cloudevents.ContentTypeJSON,
+						}
+						for key, value := range want {
+							if message.Header.Get(key) != value {
+								t.Errorf("header %s = %v, want %v", key, message.Header.Get(key), value)
+							}
+						}
+						if id := message.Header.Get("ce-id"); id == "" || id != message.Header.Get(nats.MsgIdHdr) {
+							t.Errorf("ce-id = %v, %s = %v", id, nats.MsgIdHdr, message.Header.Get(nats.MsgIdHdr))
+						}
+						if string(message.Data) != string(payload.Data) {
+							t.Errorf("data = %s, want %s", message.Data, payload.Data)
+						}
+						return &nats.PubAck{}, nil
+					})
+			},
+			fields: fields{
+				publisher: mockPublisher,
+				encoder:   mockEncoder,
+				clock:     mockClock,
+				logger:    logger,
+				subject:   config.Events.NATS.Subject,
+				source:    config.Events.NATS.Source,
+				mode:      cloudevents.ModeBinary,
+			},
+			args: args{
+				ctx:   ctx,
+				event: event,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "encode error",
+			setup: func() {
+				mockEncoder.EXPECT().Encode(ctx, event).Return(nil, errs.NewUnexpectedBehaviorError("err 12"))
+			},
+			fields: fields{
+				publisher: mockPublisher,
+				encoder:   mockEncoder,
+				clock:     mockClock,
+				logger:    logger,
+				subject:   config.Events.NATS.Subject,
+				source:    config.Events.NATS.Source,
+				mode:      cloudevents.ModeStructured,
+			},
+			args: args{
+				ctx:   ctx,
+				event: event,
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("err 12"),
+		},
+		{
+			name: "publish error",
+			setup: func() {
+				mockEncoder.EXPECT().Encode(ctx, event).Return(payload, nil)
+				mockClock.EXPECT().Now().Return(now)
+				mockPublisher.EXPECT().
+					PublishMsg(gomock.Any(), gomock.Any()).
+					Return(nil, errs.NewUnexpectedBehaviorError("err 1234"))
+			},
+			fields: fields{
+				publisher: mockPublisher,
+				encoder:   mockEncoder,
+				clock:     mockClock,
+				logger:    logger,
+				subject:   config.Events.NATS.Subject,
+				source:    config.Events.NATS.Source,
+				mode:      cloudevents.ModeStructured,
+			},
+			args: args{
+				ctx:   ctx,
+				event: event,
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("err 1234"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &EventRepository{
+				publisher: tt.fields.publisher,
+				encoder:   tt.fields.encoder,
+				clock:     tt.fields.clock,
+				logger:    tt.fields.logger,
+				subject:   tt.fields.subject,
+				source:    tt.fields.source,
+				mode:      tt.fields.mode,
+			}
+			if err := r.Send(tt.args.ctx, tt.args.event); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
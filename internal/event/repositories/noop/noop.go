@@ -0,0 +1,18 @@
+package noop
+
+import (
+	"context"
+
+	"github.com/018bf/companies/internal/entity"
+)
+
+// EventRepository drops every event.
+type EventRepository struct{}
+
+func NewEventRepository() *EventRepository {
+	return &EventRepository{}
+}
+
+func (r *EventRepository) Send(_ context.Context, _ *entity.Event) error {
+	return nil
+}
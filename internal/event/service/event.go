@@ -2,54 +2,118 @@ package service
 
 import (
 	"context"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
 	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/clock"
 	"github.com/018bf/companies/pkg/log"
 )
 
-//go:generate mockgen -source=event.go -package=usecases -destination=event_mock.go
+//go:generate mockgen -source=event.go -package=service -destination=event_mock.go
 
 type eventRepository interface {
 	Send(ctx context.Context, event *entity.Event) error
 }
 
+type webhookService interface {
+	Dispatch(ctx context.Context, event *entity.Event) error
+}
+
 type EventService struct {
 	eventRepository eventRepository
+	webhookService  webhookService
+	payloadMode     entity.EventPayloadMode
+	clock           clock.Clock
 	logger          log.Logger
 }
 
-func NewEventService(eventRepository eventRepository, logger log.Logger) *EventService {
-	return &EventService{eventRepository: eventRepository, logger: logger}
+func NewEventService(
+	eventRepository eventRepository,
+	webhookService webhookService,
+	config *configs.Config,
+	clock clock.Clock,
+	logger log.Logger,
+) (*EventService, error) {
+	payloadMode := entity.EventPayloadMode(config.Events.PayloadMode)
+	switch payloadMode {
+	case entity.EventPayloadModeFull, entity.EventPayloadModeDiff, entity.EventPayloadModeID:
+	default:
+		return nil, errs.NewUnexpectedBehaviorError("unknown event payload mode").
+			WithParam("payload_mode", config.Events.PayloadMode)
+	}
+	return &EventService{
+		eventRepository: eventRepository,
+		webhookService:  webhookService,
+		payloadMode:     payloadMode,
+		clock:           clock,
+		logger:          logger,
+	}, nil
 }
 
 func (u *EventService) CompanyCreated(ctx context.Context, company *entity.Company) error {
-	event := &entity.Event{
-		Operation: entity.EventTypeCreated,
-		Company:   company,
-	}
-	if err := u.eventRepository.Send(ctx, event); err != nil {
-		return err
+	event := u.newEvent(entity.EventTypeCreated, company.ID, company.Version, company.UpdatedAt)
+	switch u.payloadMode {
+	case entity.EventPayloadModeFull:
+		event.Company = company
+		event.After = company
+	case entity.EventPayloadModeDiff:
+		event.Changes = company.Values()
 	}
-	return nil
+	return u.send(ctx, event)
 }
 
-func (u *EventService) CompanyUpdated(ctx context.Context, company *entity.Company) error {
-	event := &entity.Event{
-		Operation: entity.EventTypeUpdated,
-		Company:   company,
-	}
-	if err := u.eventRepository.Send(ctx, event); err != nil {
-		return err
+// CompanyUpdated sends the state of the company before and after the update.
+func (u *EventService) CompanyUpdated(ctx context.Context, before, after *entity.Company) error {
+	event := u.newEvent(entity.EventTypeUpdated, after.ID, after.Version, after.UpdatedAt)
+	switch u.payloadMode {
+	case entity.EventPayloadModeFull:
+		event.Company = after
+		event.Before = before
+		event.After = after
+		event.ChangedFields = before.ChangedFields(after)
+	case entity.EventPayloadModeDiff:
+		event.ChangedFields = before.ChangedFields(after)
+		event.Changes = after.Values(event.ChangedFields...)
 	}
-	return nil
+	return u.send(ctx, event)
 }
 
+// CompanyDeleted sends the last snapshot of the deleted company.
+// In the diff mode it is sent as before, so the consumers keeping no state still get it.
 func (u *EventService) CompanyDeleted(ctx context.Context, company *entity.Company) error {
-	event := &entity.Event{
-		Operation: entity.EventTypeDeleted,
-		Company:   company,
+	event := u.newEvent(entity.EventTypeDeleted, company.ID, company.Version+1, u.clock.Now().UTC())
+	switch u.payloadMode {
+	case entity.EventPayloadModeFull:
+		event.Company = company
+		event.Before = company
+	case entity.EventPayloadModeDiff:
+		event.Before = company
 	}
-	if err := u.eventRepository.Send(ctx, event); err != nil {
-		return err
+	return u.send(ctx, event)
+}
+
+// send publishes the event and queues it for webhooks.
+// Webhooks don't depend on the broker, so a failed publish doesn't skip them.
+func (u *EventService) send(ctx context.Context, event *entity.Event) error {
+	err := u.eventRepository.Send(ctx, event)
+	if dispatchErr := u.webhookService.Dispatch(ctx, event); dispatchErr != nil {
+		u.logger.Error("can't dispatch webhooks", log.Context(ctx), log.Error(dispatchErr))
+	}
+	return err
+}
+
+func (u *EventService) newEvent(
+	operation entity.EventOperation,
+	companyID entity.UUID,
+	sequence uint64,
+	updatedAt time.Time,
+) *entity.Event {
+	return &entity.Event{
+		Operation: operation,
+		CompanyID: companyID,
+		Sequence:  sequence,
+		UpdatedAt: updatedAt,
 	}
-	return nil
 }
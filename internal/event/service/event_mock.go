@@ -1,14 +1,14 @@
 // Code generated by MockGen. DO NOT EDIT.
 // Source: event.go
 
-// Package usecases is a generated GoMock package.
+// Package service is a generated GoMock package.
 package service
 
 import (
 	context "context"
 	reflect "reflect"
 
-	models "github.com/018bf/companies/internal/entity"
+	entity "github.com/018bf/companies/internal/entity"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -36,7 +36,7 @@ func (m *MockeventRepository) EXPECT() *MockeventRepositoryMockRecorder {
 }
 
 // Send mocks base method.
-func (m *MockeventRepository) Send(ctx context.Context, event *models.Event) error {
+func (m *MockeventRepository) Send(ctx context.Context, event *entity.Event) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Send", ctx, event)
 	ret0, _ := ret[0].(error)
@@ -48,3 +48,40 @@ func (mr *MockeventRepositoryMockRecorder) Send(ctx, event interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockeventRepository)(nil).Send), ctx, event)
 }
+
+// MockwebhookService is a mock of webhookService interface.
+type MockwebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookServiceMockRecorder
+}
+
+// MockwebhookServiceMockRecorder is the mock recorder for MockwebhookService.
+type MockwebhookServiceMockRecorder struct {
+	mock *MockwebhookService
+}
+
+// NewMockwebhookService creates a new mock instance.
+func NewMockwebhookService(ctrl *gomock.Controller) *MockwebhookService {
+	mock := &MockwebhookService{ctrl: ctrl}
+	mock.recorder = &MockwebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookService) EXPECT() *MockwebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// Dispatch mocks base method.
+func (m *MockwebhookService) Dispatch(ctx context.Context, event *entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Dispatch", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Dispatch indicates an expected call of Dispatch.
+func (mr *MockwebhookServiceMockRecorder) Dispatch(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dispatch", reflect.TypeOf((*MockwebhookService)(nil).Dispatch), ctx, event)
+}
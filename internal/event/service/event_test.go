@@ -3,25 +3,33 @@ package service
 import (
 	"context"
 	"errors"
+	"github.com/018bf/companies/internal/configs"
 	"github.com/018bf/companies/internal/entity"
 	mock_models "github.com/018bf/companies/internal/entity/mock"
 	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/clock"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
 	"github.com/018bf/companies/pkg/log"
 	mock_log "github.com/018bf/companies/pkg/log/mock"
 	"github.com/golang/mock/gomock"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestEventService_CompanyCreated(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockEventRepository := NewMockeventRepository(ctrl)
+	mockWebhookService := NewMockwebhookService(ctrl)
+	mockClock := mock_clock.NewMockClock(ctrl)
 	logger := mock_log.NewMockLogger(ctrl)
 	ctx := context.Background()
 	company := mock_models.NewCompany(t)
 	type fields struct {
 		eventRepository eventRepository
+		payloadMode     entity.EventPayloadMode
+		clock           clock.Clock
 		logger          log.Logger
 	}
 	type args struct {
@@ -40,11 +48,40 @@ func TestEventService_CompanyCreated(t *testing.T) {
 			setup: func() {
 				mockEventRepository.EXPECT().Send(ctx, &entity.Event{
 					Operation: entity.EventTypeCreated,
+					CompanyID: company.ID,
+					Sequence:  company.Version,
+					UpdatedAt: company.UpdatedAt,
 					Company:   company,
+					After:     company,
 				}).Return(nil)
 			},
 			fields: fields{
 				eventRepository: mockEventRepository,
+				payloadMode:     entity.EventPayloadModeFull,
+				clock:           mockClock,
+				logger:          logger,
+			},
+			args: args{
+				ctx:     ctx,
+				company: company,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "diff",
+			setup: func() {
+				mockEventRepository.EXPECT().Send(ctx, &entity.Event{
+					Operation: entity.EventTypeCreated,
+					CompanyID: company.ID,
+					Sequence:  company.Version,
+					UpdatedAt: company.UpdatedAt,
+					Changes:   company.Values(),
+				}).Return(nil)
+			},
+			fields: fields{
+				eventRepository: mockEventRepository,
+				payloadMode:     entity.EventPayloadModeDiff,
+				clock:           mockClock,
 				logger:          logger,
 			},
 			args: args{
@@ -59,12 +96,18 @@ func TestEventService_CompanyCreated(t *testing.T) {
 				mockEventRepository.EXPECT().
 					Send(ctx, &entity.Event{
 						Operation: entity.EventTypeCreated,
+						CompanyID: company.ID,
+						Sequence:  company.Version,
+						UpdatedAt: company.UpdatedAt,
 						Company:   company,
+						After:     company,
 					}).
 					Return(errs.NewUnexpectedBehaviorError("err 24"))
 			},
 			fields: fields{
 				eventRepository: mockEventRepository,
+				payloadMode:     entity.EventPayloadModeFull,
+				clock:           mockClock,
 				logger:          logger,
 			},
 			args: args{
@@ -77,8 +120,12 @@ func TestEventService_CompanyCreated(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
+			mockWebhookService.EXPECT().Dispatch(tt.args.ctx, gomock.Any()).Return(nil)
 			u := &EventService{
 				eventRepository: tt.fields.eventRepository,
+				webhookService:  mockWebhookService,
+				payloadMode:     tt.fields.payloadMode,
+				clock:           tt.fields.clock,
 				logger:          tt.fields.logger,
 			}
 			if err := u.CompanyCreated(tt.args.ctx, tt.args.company); !errors.Is(err, tt.wantErr) {
@@ -92,11 +139,16 @@ func TestEventService_CompanyDeleted(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockEventRepository := NewMockeventRepository(ctrl)
+	mockWebhookService := NewMockwebhookService(ctrl)
+	mockClock := mock_clock.NewMockClock(ctrl)
 	logger := mock_log.NewMockLogger(ctrl)
 	ctx := context.Background()
 	company := mock_models.NewCompany(t)
+	now := time.Now().UTC()
 	type fields struct {
 		eventRepository eventRepository
+		payloadMode     entity.EventPayloadMode
+		clock           clock.Clock
 		logger          log.Logger
 	}
 	type args struct {
@@ -113,13 +165,67 @@ func TestEventService_CompanyDeleted(t *testing.T) {
 		{
 			name: "ok",
 			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
 				mockEventRepository.EXPECT().Send(ctx, &entity.Event{
 					Operation: entity.EventTypeDeleted,
+					CompanyID: company.ID,
+					Sequence:  company.Version + 1,
+					UpdatedAt: now,
 					Company:   company,
+					Before:    company,
+				}).Return(nil)
+			},
+			fields: fields{
+				eventRepository: mockEventRepository,
+				payloadMode:     entity.EventPayloadModeFull,
+				clock:           mockClock,
+				logger:          logger,
+			},
+			args: args{
+				ctx:     ctx,
+				company: company,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "diff",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+				mockEventRepository.EXPECT().Send(ctx, &entity.Event{
+					Operation: entity.EventTypeDeleted,
+					CompanyID: company.ID,
+					Sequence:  company.Version + 1,
+					UpdatedAt: now,
+					Before:    company,
 				}).Return(nil)
 			},
 			fields: fields{
 				eventRepository: mockEventRepository,
+				payloadMode:     entity.EventPayloadModeDiff,
+				clock:           mockClock,
+				logger:          logger,
+			},
+			args: args{
+				ctx:     ctx,
+				company: company,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "id",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+				mockEventRepository.EXPECT().Send(ctx, &entity.Event{
+					Operation: entity.EventTypeDeleted,
+					CompanyID: company.ID,
+					Sequence:  company.Version + 1,
+					UpdatedAt: now,
+				}).Return(nil)
+			},
+			fields: fields{
+				eventRepository: mockEventRepository,
+				payloadMode:     entity.EventPayloadModeID,
+				clock:           mockClock,
 				logger:          logger,
 			},
 			args: args{
@@ -131,15 +237,22 @@ func TestEventService_CompanyDeleted(t *testing.T) {
 		{
 			name: "error",
 			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
 				mockEventRepository.EXPECT().
 					Send(ctx, &entity.Event{
 						Operation: entity.EventTypeDeleted,
+						CompanyID: company.ID,
+						Sequence:  company.Version + 1,
+						UpdatedAt: now,
 						Company:   company,
+						Before:    company,
 					}).
 					Return(errs.NewUnexpectedBehaviorError("err 24"))
 			},
 			fields: fields{
 				eventRepository: mockEventRepository,
+				payloadMode:     entity.EventPayloadModeFull,
+				clock:           mockClock,
 				logger:          logger,
 			},
 			args: args{
@@ -152,8 +265,12 @@ func TestEventService_CompanyDeleted(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
+			mockWebhookService.EXPECT().Dispatch(tt.args.ctx, gomock.Any()).Return(nil)
 			u := &EventService{
 				eventRepository: tt.fields.eventRepository,
+				webhookService:  mockWebhookService,
+				payloadMode:     tt.fields.payloadMode,
+				clock:           tt.fields.clock,
 				logger:          tt.fields.logger,
 			}
 			if err := u.CompanyDeleted(tt.args.ctx, tt.args.company); !errors.Is(err, tt.wantErr) {
@@ -167,16 +284,25 @@ func TestEventService_CompanyUpdated(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockEventRepository := NewMockeventRepository(ctrl)
+	mockWebhookService := NewMockwebhookService(ctrl)
+	mockClock := mock_clock.NewMockClock(ctrl)
 	logger := mock_log.NewMockLogger(ctrl)
 	ctx := context.Background()
-	company := mock_models.NewCompany(t)
+	before := mock_models.NewCompany(t)
+	after := &entity.Company{}
+	*after = *before
+	after.Name = before.Name + " updated"
+	after.Version = before.Version + 1
 	type fields struct {
 		eventRepository eventRepository
+		payloadMode     entity.EventPayloadMode
+		clock           clock.Clock
 		logger          log.Logger
 	}
 	type args struct {
-		ctx     context.Context
-		company *entity.Company
+		ctx    context.Context
+		before *entity.Company
+		after  *entity.Company
 	}
 	tests := []struct {
 		name    string
@@ -187,19 +313,76 @@ func TestEventService_CompanyUpdated(t *testing.T) {
 	}{
 		{
 			name: "ok",
+			setup: func() {
+				mockEventRepository.EXPECT().Send(ctx, &entity.Event{
+					Operation:     entity.EventTypeUpdated,
+					CompanyID:     after.ID,
+					Sequence:      after.Version,
+					UpdatedAt:     after.UpdatedAt,
+					Company:       after,
+					Before:        before,
+					After:         after,
+					ChangedFields: []string{"name"},
+				}).Return(nil)
+			},
+			fields: fields{
+				eventRepository: mockEventRepository,
+				payloadMode:     entity.EventPayloadModeFull,
+				clock:           mockClock,
+				logger:          logger,
+			},
+			args: args{
+				ctx:    ctx,
+				before: before,
+				after:  after,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "diff",
+			setup: func() {
+				mockEventRepository.EXPECT().Send(ctx, &entity.Event{
+					Operation:     entity.EventTypeUpdated,
+					CompanyID:     after.ID,
+					Sequence:      after.Version,
+					UpdatedAt:     after.UpdatedAt,
+					ChangedFields: []string{"name"},
+					Changes:       map[string]any{"name": after.Name},
+				}).Return(nil)
+			},
+			fields: fields{
+				eventRepository: mockEventRepository,
+				payloadMode:     entity.EventPayloadModeDiff,
+				clock:           mockClock,
+				logger:          logger,
+			},
+			args: args{
+				ctx:    ctx,
+				before: before,
+				after:  after,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "id",
 			setup: func() {
 				mockEventRepository.EXPECT().Send(ctx, &entity.Event{
 					Operation: entity.EventTypeUpdated,
-					Company:   company,
+					CompanyID: after.ID,
+					Sequence:  after.Version,
+					UpdatedAt: after.UpdatedAt,
 				}).Return(nil)
 			},
 			fields: fields{
 				eventRepository: mockEventRepository,
+				payloadMode:     entity.EventPayloadModeID,
+				clock:           mockClock,
 				logger:          logger,
 			},
 			args: args{
-				ctx:     ctx,
-				company: company,
+				ctx:    ctx,
+				before: before,
+				after:  after,
 			},
 			wantErr: nil,
 		},
@@ -207,19 +390,19 @@ func TestEventService_CompanyUpdated(t *testing.T) {
 			name: "error",
 			setup: func() {
 				mockEventRepository.EXPECT().
-					Send(ctx, &entity.Event{
-						Operation: entity.EventTypeUpdated,
-						Company:   company,
-					}).
+					Send(ctx, gomock.Any()).
 					Return(errs.NewUnexpectedBehaviorError("err 24"))
 			},
 			fields: fields{
 				eventRepository: mockEventRepository,
+				payloadMode:     entity.EventPayloadModeFull,
+				clock:           mockClock,
 				logger:          logger,
 			},
 			args: args{
-				ctx:     ctx,
-				company: company,
+				ctx:    ctx,
+				before: before,
+				after:  after,
 			},
 			wantErr: errs.NewUnexpectedBehaviorError("err 24"),
 		},
@@ -227,11 +410,15 @@ func TestEventService_CompanyUpdated(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
+			mockWebhookService.EXPECT().Dispatch(tt.args.ctx, gomock.Any()).Return(nil)
 			u := &EventService{
 				eventRepository: tt.fields.eventRepository,
+				webhookService:  mockWebhookService,
+				payloadMode:     tt.fields.payloadMode,
+				clock:           tt.fields.clock,
 				logger:          tt.fields.logger,
 			}
-			if err := u.CompanyUpdated(tt.args.ctx, tt.args.company); !errors.Is(err, tt.wantErr) {
+			if err := u.CompanyUpdated(tt.args.ctx, tt.args.before, tt.args.after); !errors.Is(err, tt.wantErr) {
 				t.Errorf("CompanyUpdated() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -242,33 +429,128 @@ func TestNewEventService(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockEventRepository := NewMockeventRepository(ctrl)
+	mockWebhookService := NewMockwebhookService(ctrl)
+	mockClock := mock_clock.NewMockClock(ctrl)
 	logger := mock_log.NewMockLogger(ctrl)
+	config := configs.NewMockConfig(t)
+	badConfig := configs.NewMockConfig(t)
+	badConfig.Events.PayloadMode = "partial"
 	type args struct {
 		eventRepository eventRepository
+		webhookService  webhookService
+		config          *configs.Config
+		clock           clock.Clock
 		logger          log.Logger
 	}
 	tests := []struct {
-		name string
-		args args
-		want *EventService
+		name    string
+		args    args
+		want    *EventService
+		wantErr error
 	}{
 		{
 			name: "ok",
 			args: args{
 				eventRepository: mockEventRepository,
+				webhookService:  mockWebhookService,
+				config:          config,
+				clock:           mockClock,
 				logger:          logger,
 			},
 			want: &EventService{
 				eventRepository: mockEventRepository,
+				webhookService:  mockWebhookService,
+				payloadMode:     entity.EventPayloadModeFull,
+				clock:           mockClock,
 				logger:          logger,
 			},
+			wantErr: nil,
+		},
+		{
+			name: "unknown payload mode",
+			args: args{
+				eventRepository: mockEventRepository,
+				webhookService:  mockWebhookService,
+				config:          badConfig,
+				clock:           mockClock,
+				logger:          logger,
+			},
+			want: nil,
+			wantErr: errs.NewUnexpectedBehaviorError("unknown event payload mode").
+				WithParam("payload_mode", "partial"),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewEventService(tt.args.eventRepository, tt.args.logger); !reflect.DeepEqual(got, tt.want) {
+			got, err := NewEventService(
+				tt.args.eventRepository,
+				tt.args.webhookService,
+				tt.args.config,
+				tt.args.clock,
+				tt.args.logger,
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewEventService() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewEventService() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestEventService_send(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockEventRepository := NewMockeventRepository(ctrl)
+	mockWebhookService := NewMockwebhookService(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	event := mock_models.NewEvent(t)
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockEventRepository.EXPECT().Send(ctx, event).Return(nil)
+				mockWebhookService.EXPECT().Dispatch(ctx, event).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "dispatch error",
+			setup: func() {
+				mockEventRepository.EXPECT().Send(ctx, event).Return(nil)
+				mockWebhookService.EXPECT().Dispatch(ctx, event).Return(errs.NewUnexpectedBehaviorError("test error"))
+				logger.EXPECT().Error("can't dispatch webhooks", gomock.Any(), gomock.Any())
+			},
+			wantErr: nil,
+		},
+		{
+			name: "send error",
+			setup: func() {
+				mockEventRepository.EXPECT().Send(ctx, event).Return(errs.NewUnexpectedBehaviorError("test error"))
+				mockWebhookService.EXPECT().Dispatch(ctx, event).Return(nil)
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("test error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := &EventService{
+				eventRepository: mockEventRepository,
+				webhookService:  mockWebhookService,
+				payloadMode:     entity.EventPayloadModeFull,
+				logger:          logger,
+			}
+			if err := u.send(ctx, event); !errors.Is(err, tt.wantErr) {
+				t.Errorf("send() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
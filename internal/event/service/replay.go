@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/pkg/log"
+	"golang.org/x/time/rate"
+)
+
+//go:generate mockgen -source=replay.go -package=service -destination=replay_mock.go
+
+type companyRepository interface {
+	ListAfter(
+		ctx context.Context,
+		filter *entity.CompanyFilter,
+		after entity.UUID,
+		limit uint64,
+	) ([]*entity.Company, error)
+}
+
+type checkpointRepository interface {
+	Load(ctx context.Context) (entity.UUID, error)
+	Save(ctx context.Context, id entity.UUID) error
+}
+
+// ReplayService publishes snapshots of the existing companies,
+// so new consumers can build their state from the topic.
+type ReplayService struct {
+	companyRepository    companyRepository
+	eventRepository      eventRepository
+	checkpointRepository checkpointRepository
+	logger               log.Logger
+}
+
+func NewReplayService(
+	companyRepository companyRepository,
+	eventRepository eventRepository,
+	checkpointRepository checkpointRepository,
+	logger log.Logger,
+) *ReplayService {
+	return &ReplayService{
+		companyRepository:    companyRepository,
+		eventRepository:      eventRepository,
+		checkpointRepository: checkpointRepository,
+		logger:               logger,
+	}
+}
+
+// Replay publishes a snapshot event for every company matching filter, at most limit events per second.
+// The checkpoint is saved after each batch, so a resumed replay may repeat up to one batch of snapshots.
+func (u *ReplayService) Replay(
+	ctx context.Context,
+	filter *entity.CompanyFilter,
+	limit rate.Limit,
+	batchSize uint64,
+) (uint64, error) {
+	after, err := u.checkpointRepository.Load(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if after != "" {
+		u.logger.Info("resuming replay", log.Context(ctx), log.String("after", string(after)))
+	}
+	limiter := rate.NewLimiter(limit, 1)
+	var count uint64
+	for {
+		companies, err := u.companyRepository.ListAfter(ctx, filter, after, batchSize)
+		if err != nil {
+			return count, err
+		}
+		for _, company := range companies {
+			if err := limiter.Wait(ctx); err != nil {
+				return count, err
+			}
+			if err := u.eventRepository.Send(ctx, newSnapshot(company)); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(companies) == 0 {
+			break
+		}
+		after = companies[len(companies)-1].ID
+		if err := u.checkpointRepository.Save(ctx, after); err != nil {
+			return count, err
+		}
+		u.logger.Info("companies replayed", log.Context(ctx), log.Any("count", count))
+		if uint64(len(companies)) < batchSize {
+			break
+		}
+	}
+	return count, nil
+}
+
+func newSnapshot(company *entity.Company) *entity.Event {
+	return &entity.Event{
+		Operation: entity.EventTypeSnapshot,
+		CompanyID: company.ID,
+		Sequence:  company.Version,
+		UpdatedAt: company.UpdatedAt,
+		Company:   company,
+		After:     company,
+	}
+}
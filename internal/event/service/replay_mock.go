@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: replay.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockcompanyRepository is a mock of companyRepository interface.
+type MockcompanyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcompanyRepositoryMockRecorder
+}
+
+// MockcompanyRepositoryMockRecorder is the mock recorder for MockcompanyRepository.
+type MockcompanyRepositoryMockRecorder struct {
+	mock *MockcompanyRepository
+}
+
+// NewMockcompanyRepository creates a new mock instance.
+func NewMockcompanyRepository(ctrl *gomock.Controller) *MockcompanyRepository {
+	mock := &MockcompanyRepository{ctrl: ctrl}
+	mock.recorder = &MockcompanyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcompanyRepository) EXPECT() *MockcompanyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListAfter mocks base method.
+func (m *MockcompanyRepository) ListAfter(ctx context.Context, filter *entity.CompanyFilter, after entity.UUID, limit uint64) ([]*entity.Company, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAfter", ctx, filter, after, limit)
+	ret0, _ := ret[0].([]*entity.Company)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAfter indicates an expected call of ListAfter.
+func (mr *MockcompanyRepositoryMockRecorder) ListAfter(ctx, filter, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAfter", reflect.TypeOf((*MockcompanyRepository)(nil).ListAfter), ctx, filter, after, limit)
+}
+
+// MockcheckpointRepository is a mock of checkpointRepository interface.
+type MockcheckpointRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcheckpointRepositoryMockRecorder
+}
+
+// MockcheckpointRepositoryMockRecorder is the mock recorder for MockcheckpointRepository.
+type MockcheckpointRepositoryMockRecorder struct {
+	mock *MockcheckpointRepository
+}
+
+// NewMockcheckpointRepository creates a new mock instance.
+func NewMockcheckpointRepository(ctrl *gomock.Controller) *MockcheckpointRepository {
+	mock := &MockcheckpointRepository{ctrl: ctrl}
+	mock.recorder = &MockcheckpointRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcheckpointRepository) EXPECT() *MockcheckpointRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method.
+func (m *MockcheckpointRepository) Load(ctx context.Context) (entity.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load", ctx)
+	ret0, _ := ret[0].(entity.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockcheckpointRepositoryMockRecorder) Load(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockcheckpointRepository)(nil).Load), ctx)
+}
+
+// Save mocks base method.
+func (m *MockcheckpointRepository) Save(ctx context.Context, id entity.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockcheckpointRepositoryMockRecorder) Save(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockcheckpointRepository)(nil).Save), ctx, id)
+}
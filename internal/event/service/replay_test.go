@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+	"golang.org/x/time/rate"
+)
+
+func TestReplayService_Replay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyRepository := NewMockcompanyRepository(ctrl)
+	mockEventRepository := NewMockeventRepository(ctrl)
+	mockCheckpointRepository := NewMockcheckpointRepository(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	filter := &entity.CompanyFilter{}
+	first := []*entity.Company{mock_models.NewCompany(t), mock_models.NewCompany(t)}
+	second := []*entity.Company{mock_models.NewCompany(t)}
+	checkpoint := entity.UUID("checkpoint")
+	type args struct {
+		ctx       context.Context
+		filter    *entity.CompanyFilter
+		limit     rate.Limit
+		batchSize uint64
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		args    args
+		want    uint64
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCheckpointRepository.EXPECT().Load(ctx).Return(entity.UUID(""), nil)
+				mockCompanyRepository.EXPECT().ListAfter(ctx, filter, entity.UUID(""), uint64(2)).Return(first, nil)
+				for _, company := range first {
+					mockEventRepository.EXPECT().Send(ctx, newSnapshot(company)).Return(nil)
+				}
+				mockCheckpointRepository.EXPECT().Save(ctx, first[1].ID).Return(nil)
+				mockCompanyRepository.EXPECT().ListAfter(ctx, filter, first[1].ID, uint64(2)).Return(second, nil)
+				mockEventRepository.EXPECT().Send(ctx, newSnapshot(second[0])).Return(nil)
+				mockCheckpointRepository.EXPECT().Save(ctx, second[0].ID).Return(nil)
+				logger.EXPECT().Info("companies replayed", gomock.Any(), gomock.Any()).Times(2)
+			},
+			args: args{
+				ctx:       ctx,
+				filter:    filter,
+				limit:     rate.Inf,
+				batchSize: 2,
+			},
+			want:    3,
+			wantErr: nil,
+		},
+		{
+			name: "resume",
+			setup: func() {
+				mockCheckpointRepository.EXPECT().Load(ctx).Return(checkpoint, nil)
+				logger.EXPECT().Info("resuming replay", gomock.Any(), gomock.Any())
+				mockCompanyRepository.EXPECT().ListAfter(ctx, filter, checkpoint, uint64(2)).Return(nil, nil)
+			},
+			args: args{
+				ctx:       ctx,
+				filter:    filter,
+				limit:     rate.Inf,
+				batchSize: 2,
+			},
+			want:    0,
+			wantErr: nil,
+		},
+		{
+			name: "checkpoint error",
+			setup: func() {
+				mockCheckpointRepository.EXPECT().Load(ctx).Return(entity.UUID(""), errs.NewUnexpectedBehaviorError("test error"))
+			},
+			args: args{
+				ctx:       ctx,
+				filter:    filter,
+				limit:     rate.Inf,
+				batchSize: 2,
+			},
+			want:    0,
+			wantErr: errs.NewUnexpectedBehaviorError("test error"),
+		},
+		{
+			name: "list error",
+			setup: func() {
+				mockCheckpointRepository.EXPECT().Load(ctx).Return(entity.UUID(""), nil)
+				mockCompanyRepository.EXPECT().
+					ListAfter(ctx, filter, entity.UUID(""), uint64(2)).
+					Return(nil, errs.NewUnexpectedBehaviorError("test error"))
+			},
+			args: args{
+				ctx:       ctx,
+				filter:    filter,
+				limit:     rate.Inf,
+				batchSize: 2,
+			},
+			want:    0,
+			wantErr: errs.NewUnexpectedBehaviorError("test error"),
+		},
+		{
+			name: "send error",
+			setup: func() {
+				mockCheckpointRepository.EXPECT().Load(ctx).Return(entity.UUID(""), nil)
+				mockCompanyRepository.EXPECT().ListAfter(ctx, filter, entity.UUID(""), uint64(2)).Return(first, nil)
+				mockEventRepository.EXPECT().Send(ctx, newSnapshot(first[0])).Return(nil)
+				mockEventRepository.EXPECT().
+					Send(ctx, newSnapshot(first[1])).
+					Return(errs.NewUnexpectedBehaviorError("test error"))
+			},
+			args: args{
+				ctx:       ctx,
+				filter:    filter,
+				limit:     rate.Inf,
+				batchSize: 2,
+			},
+			want:    1,
+			wantErr: errs.NewUnexpectedBehaviorError("test error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := &ReplayService{
+				companyRepository:    mockCompanyRepository,
+				eventRepository:      mockEventRepository,
+				checkpointRepository: mockCheckpointRepository,
+				logger:               logger,
+			}
+			got, err := u.Replay(tt.args.ctx, tt.args.filter, tt.args.limit, tt.args.batchSize)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Replay() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Replay() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/errs"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestLRU(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := configs.NewMockConfig(t)
+	config.Cache.Size = 2
+	ctx := context.Background()
+	type get struct {
+		key  string
+		want []byte
+	}
+	tests := []struct {
+		name  string
+		setup func(c *LRU, mockClock *mock_clock.MockClock)
+		gets  []get
+	}{
+		{
+			name: "hit and miss",
+			setup: func(c *LRU, mockClock *mock_clock.MockClock) {
+				mockClock.EXPECT().Now().Return(now).Times(3)
+				_ = c.Set(ctx, "first", []byte("1"), time.Minute)
+			},
+			gets: []get{
+				{key: "first", want: []byte("1")},
+				{key: "second", want: nil},
+				{key: "first", want: []byte("1")},
+			},
+		},
+		{
+			name: "expired",
+			setup: func(c *LRU, mockClock *mock_clock.MockClock) {
+				mockClock.EXPECT().Now().Return(now)
+				_ = c.Set(ctx, "first", []byte("1"), time.Minute)
+				mockClock.EXPECT().Now().Return(now.Add(time.Minute))
+			},
+			gets: []get{
+				{key: "first", want: nil},
+				{key: "first", want: nil},
+			},
+		},
+		{
+			name: "least recently used is evicted",
+			setup: func(c *LRU, mockClock *mock_clock.MockClock) {
+				mockClock.EXPECT().Now().Return(now).Times(6)
+				_ = c.Set(ctx, "first", []byte("1"), time.Minute)
+				_ = c.Set(ctx, "second", []byte("2"), time.Minute)
+				_, _, _ = c.Get(ctx, "first")
+				_ = c.Set(ctx, "third", []byte("3"), time.Minute)
+			},
+			gets: []get{
+				{key: "second", want: nil},
+				{key: "first", want: []byte("1")},
+				{key: "third", want: []byte("3")},
+			},
+		},
+		{
+			name: "deleted",
+			setup: func(c *LRU, mockClock *mock_clock.MockClock) {
+				mockClock.EXPECT().Now().Return(now).Times(3)
+				_ = c.Set(ctx, "first", []byte("1"), time.Minute)
+				_ = c.Set(ctx, "second", []byte("2"), time.Minute)
+				_ = c.Delete(ctx, "first", "unknown")
+			},
+			gets: []get{
+				{key: "first", want: nil},
+				{key: "second", want: []byte("2")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockClock := mock_clock.NewMockClock(ctrl)
+			c, err := NewLRU(config, mockClock)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.setup(c, mockClock)
+			for _, get := range tt.gets {
+				got, ok, err := c.Get(ctx, get.key)
+				if err != nil {
+					t.Fatalf("LRU.Get() error = %v", err)
+				}
+				if ok != (get.want != nil) || !reflect.DeepEqual(got, get.want) {
+					t.Errorf("LRU.Get(%q) = %s, %v, want %s", get.key, got, ok, get.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewLRU(t *testing.T) {
+	tests := []struct {
+		name    string
+		size    int
+		wantErr error
+	}{
+		{
+			name:    "ok",
+			size:    1,
+			wantErr: nil,
+		},
+		{
+			name:    "zero size",
+			size:    0,
+			wantErr: errs.NewUnexpectedBehaviorError("cache size must be positive").WithParam("size", "0"),
+		},
+		{
+			name:    "negative size",
+			size:    -1,
+			wantErr: errs.NewUnexpectedBehaviorError("cache size must be positive").WithParam("size", "-1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := configs.NewMockConfig(t)
+			config.Cache.Size = tt.size
+			if _, err := NewLRU(config, nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewLRU() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
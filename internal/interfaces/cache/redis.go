@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis keeps the values in Redis shared by the instances, the keys are prefixed to share the database.
+type Redis struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedis(config *configs.Config) *Redis {
+	return &Redis{
+		client: redis.NewClient(&redis.Options{
+			Addr:     config.Cache.Redis.Addr,
+			Password: config.Cache.Redis.Password,
+			DB:       config.Cache.Redis.DB,
+		}),
+		prefix: config.Cache.Redis.Prefix,
+	}
+}
+
+func (c *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (c *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, c.prefix+key, value, ttl).Err()
+}
+
+func (c *Redis) Delete(ctx context.Context, keys ...string) error {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+	return c.client.Del(ctx, prefixed...).Err()
+}
+
+func (c *Redis) Close(_ context.Context) error {
+	return c.client.Close()
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/alicebob/miniredis/v2"
+)
+
+func TestRedis(t *testing.T) {
+	server := miniredis.RunT(t)
+	config := configs.NewMockConfig(t)
+	config.Cache.Redis.Addr = server.Addr()
+	c := NewRedis(config)
+	defer c.Close(context.Background())
+	ctx := context.Background()
+	if err := c.Set(ctx, "first", []byte("1"), time.Minute); err != nil {
+		t.Fatalf("Redis.Set() error = %v", err)
+	}
+	if err := c.Set(ctx, "second", []byte("2"), time.Second); err != nil {
+		t.Fatalf("Redis.Set() error = %v", err)
+	}
+	if !server.Exists("companies:first") {
+		t.Error("Redis.Set() didn't prefix the key")
+	}
+	server.FastForward(time.Second)
+	if err := c.Delete(ctx, "third"); err != nil {
+		t.Fatalf("Redis.Delete() error = %v", err)
+	}
+	tests := []struct {
+		key  string
+		want []byte
+	}{
+		{key: "first", want: []byte("1")},
+		{key: "second", want: nil},
+		{key: "third", want: nil},
+	}
+	for _, tt := range tests {
+		got, ok, err := c.Get(ctx, tt.key)
+		if err != nil {
+			t.Fatalf("Redis.Get() error = %v", err)
+		}
+		if ok != (tt.want != nil) || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Redis.Get(%q) = %s, %v, want %s", tt.key, got, ok, tt.want)
+		}
+	}
+	if err := c.Delete(ctx, "first"); err != nil {
+		t.Fatalf("Redis.Delete() error = %v", err)
+	}
+	if _, ok, _ := c.Get(ctx, "first"); ok {
+		t.Error("Redis.Delete() kept the value")
+	}
+	server.Close()
+	if _, _, err := c.Get(ctx, "first"); err == nil {
+		t.Error("Redis.Get() error = nil on the closed server")
+	}
+}
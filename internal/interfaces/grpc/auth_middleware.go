@@ -7,6 +7,7 @@ import (
 	"github.com/018bf/companies/internal/configs"
 	"github.com/018bf/companies/internal/entity"
 	"github.com/018bf/companies/pkg/log"
+	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -47,6 +48,7 @@ func AuthFromMD(ctx context.Context) (string, error) {
 
 type authInterceptor interface {
 	ValidateToken(ctx context.Context, token *entity.Token) error
+	GetSubject(ctx context.Context, token *entity.Token) (string, error)
 }
 
 type AuthMiddleware struct {
@@ -76,8 +78,13 @@ func (m *AuthMiddleware) Auth(ctx context.Context) (context.Context, error) {
 	if err := m.authInterceptor.ValidateToken(ctx, token); err != nil {
 		return nil, DecodeError(err)
 	}
+	subject, err := m.authInterceptor.GetSubject(ctx, token)
+	if err != nil {
+		return nil, DecodeError(err)
+	}
+	// The reads of the caller follow its writes, the token itself isn't kept for it.
 	newCtx := context.WithValue(ctx, TokenKey, token)
-	return newCtx, nil
+	return entity.WithCaller(newCtx, subject), nil
 }
 
 func (m *AuthMiddleware) UnaryServerInterceptor(
@@ -92,3 +99,18 @@ func (m *AuthMiddleware) UnaryServerInterceptor(
 	}
 	return handler(newCtx, req)
 }
+
+func (m *AuthMiddleware) StreamServerInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	newCtx, err := m.Auth(stream.Context())
+	if err != nil {
+		return err
+	}
+	wrapped := grpcMiddleware.WrapServerStream(stream)
+	wrapped.WrappedContext = newCtx
+	return handler(srv, wrapped)
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -69,6 +70,7 @@ func TestAuthMiddleware_Auth(t *testing.T) {
 			name: "ok",
 			setup: func() {
 				mockAuthInterceptor.EXPECT().ValidateToken(ctxWithToken, token).Return(nil)
+				mockAuthInterceptor.EXPECT().GetSubject(ctxWithToken, token).Return("user", nil)
 			},
 			fields: fields{
 				authInterceptor: mockAuthInterceptor,
@@ -76,7 +78,7 @@ func TestAuthMiddleware_Auth(t *testing.T) {
 			args: args{
 				ctx: ctxWithToken,
 			},
-			want:    context.WithValue(ctxWithToken, TokenKey, token),
+			want:    entity.WithCaller(context.WithValue(ctxWithToken, TokenKey, token), "user"),
 			wantErr: nil,
 		},
 		{
@@ -95,6 +97,21 @@ func TestAuthMiddleware_Auth(t *testing.T) {
 			want:    nil,
 			wantErr: DecodeError(errs.NewBadToken()),
 		},
+		{
+			name: "subject error",
+			setup: func() {
+				mockAuthInterceptor.EXPECT().ValidateToken(ctxWithToken, token).Return(nil)
+				mockAuthInterceptor.EXPECT().GetSubject(ctxWithToken, token).Return("", errs.NewBadToken())
+			},
+			fields: fields{
+				authInterceptor: mockAuthInterceptor,
+			},
+			args: args{
+				ctx: ctxWithToken,
+			},
+			want:    nil,
+			wantErr: DecodeError(errs.NewBadToken()),
+		},
 		{
 			name:  "without token",
 			setup: func() {},
@@ -126,6 +143,69 @@ func TestAuthMiddleware_Auth(t *testing.T) {
 	}
 }
 
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAuthMiddleware_StreamServerInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthInterceptor := NewMockauthInterceptor(ctrl)
+	token := utils.Pointer(entity.Token("my_token"))
+	ctxWithToken := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"authorization": fmt.Sprintf("Bearer %s", token.String()),
+	}))
+	tests := []struct {
+		name      string
+		setup     func()
+		wantToken *entity.Token
+		wantErr   error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockAuthInterceptor.EXPECT().ValidateToken(ctxWithToken, token).Return(nil)
+				mockAuthInterceptor.EXPECT().GetSubject(ctxWithToken, token).Return("user", nil)
+			},
+			wantToken: token,
+			wantErr:   nil,
+		},
+		{
+			name: "bad token",
+			setup: func() {
+				mockAuthInterceptor.EXPECT().ValidateToken(ctxWithToken, token).Return(errs.NewBadToken())
+			},
+			wantErr: DecodeError(errs.NewBadToken()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			m := AuthMiddleware{
+				authInterceptor: mockAuthInterceptor,
+			}
+			var gotToken *entity.Token
+			handler := func(_ any, stream grpc.ServerStream) error {
+				gotToken = stream.Context().Value(TokenKey).(*entity.Token)
+				return nil
+			}
+			err := m.StreamServerInterceptor(nil, serverStream{ctx: ctxWithToken}, nil, handler)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("StreamServerInterceptor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotToken, tt.wantToken) {
+				t.Errorf("StreamServerInterceptor() token = %v, want %v", gotToken, tt.wantToken)
+			}
+		})
+	}
+}
+
 func TestNewAuthMiddleware(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -5,6 +5,7 @@ import (
 
 	"github.com/018bf/companies/pkg/log"
 	"github.com/google/uuid"
+	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 )
 
@@ -24,3 +25,14 @@ func (m *RequestIDMiddleware) UnaryServerInterceptor(
 	newCtx := context.WithValue(ctx, log.RequestIDKey, uuid.New().String())
 	return handler(newCtx, req)
 }
+
+func (m *RequestIDMiddleware) StreamServerInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	wrapped := grpcMiddleware.WrapServerStream(stream)
+	wrapped.WrappedContext = context.WithValue(stream.Context(), log.RequestIDKey, uuid.New().String())
+	return handler(srv, wrapped)
+}
@@ -34,9 +34,18 @@ func NewServer(
 	requestIDMiddleware *RequestIDMiddleware,
 	authMiddleware *AuthMiddleware,
 	companyHandler companiespb.CompanyServiceServer,
+	webhookHandler companiespb.WebhookServiceServer,
 ) *Server {
 	server := grpc.NewServer(
-		grpc.ChainStreamInterceptor(otelgrpc.StreamServerInterceptor()),
+		grpc.ChainStreamInterceptor(
+			otelgrpc.StreamServerInterceptor(),
+			requestIDMiddleware.StreamServerInterceptor,
+			grpcZap.StreamServerInterceptor(
+				logger.Logger(),
+				grpcZap.WithMessageProducer(DefaultMessageProducer),
+			),
+			authMiddleware.StreamServerInterceptor,
+		),
 		grpc.ChainUnaryInterceptor(
 			otelgrpc.UnaryServerInterceptor(),
 			requestIDMiddleware.UnaryServerInterceptor,
@@ -49,6 +58,7 @@ func NewServer(
 	)
 	reflection.Register(server)
 	companiespb.RegisterCompanyServiceServer(server, companyHandler)
+	companiespb.RegisterWebhookServiceServer(server, webhookHandler)
 	healthServer := health.NewServer()
 	for service := range server.GetServiceInfo() {
 		healthServer.SetServingStatus(service, grpc_health_v1.HealthCheckResponse_SERVING)
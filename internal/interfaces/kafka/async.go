@@ -0,0 +1,144 @@
+package kafka
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+)
+
+const meterName = "github.com/018bf/companies/internal/interfaces/kafka"
+
+// AsyncProducer sends messages without waiting for the broker.
+// Messages are buffered in memory up to the configured queue size;
+// delivery results are reported to logs and metrics.
+type AsyncProducer struct {
+	producer  sarama.AsyncProducer
+	logger    log.Logger
+	queueSize int64
+	pending   atomic.Int64
+	published instrument.Int64Counter
+	failed    instrument.Int64Counter
+	dropped   instrument.Int64Counter
+	done      sync.WaitGroup
+	// mu guards closed, so no message is sent to the input closed by Close.
+	mu     sync.Mutex
+	closed bool
+}
+
+func NewAsyncProducer(config *configs.Config, logger log.Logger) (*AsyncProducer, error) {
+	cfg, err := NewSaramaConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Producer.Return.Successes = true
+	cfg.Producer.Return.Errors = true
+	cfg.Producer.Flush.Messages = config.Kafka.Producer.BatchSize
+	cfg.Producer.Flush.Frequency = time.Duration(config.Kafka.Producer.Linger) * time.Millisecond
+	// The input channel holds the whole queue, so SendMessage never blocks.
+	cfg.ChannelBufferSize = config.Kafka.Producer.QueueSize
+	producer, err := sarama.NewAsyncProducer(brokers(config), cfg)
+	if err != nil {
+		return nil, err
+	}
+	return newAsyncProducer(producer, config.Kafka.Producer.QueueSize, logger)
+}
+
+func newAsyncProducer(producer sarama.AsyncProducer, queueSize int, logger log.Logger) (*AsyncProducer, error) {
+	meter := global.Meter(meterName)
+	published, err := meter.Int64Counter("kafka.producer.published")
+	if err != nil {
+		return nil, err
+	}
+	failed, err := meter.Int64Counter("kafka.producer.failed")
+	if err != nil {
+		return nil, err
+	}
+	dropped, err := meter.Int64Counter("kafka.producer.dropped")
+	if err != nil {
+		return nil, err
+	}
+	p := &AsyncProducer{
+		producer:  producer,
+		logger:    logger,
+		queueSize: int64(queueSize),
+		published: published,
+		failed:    failed,
+		dropped:   dropped,
+	}
+	p.done.Add(2)
+	go p.successes()
+	go p.errors()
+	return p, nil
+}
+
+// SendMessage enqueues message and returns immediately, it fails once the producer is closed.
+// The returned partition and offset are always -1, as they aren't known yet.
+func (p *AsyncProducer) SendMessage(message *sarama.ProducerMessage) (int32, int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return -1, -1, errs.NewError(errs.ErrorCodeUnavailable, "Event producer is closed.")
+	}
+	if p.pending.Add(1) > p.queueSize {
+		p.pending.Add(-1)
+		p.dropped.Add(context.Background(), 1, attribute.String("topic", message.Topic))
+		return -1, -1, errs.NewError(errs.ErrorCodeResourceExhausted, "Event queue is full.").
+			WithParam("queue_size", strconv.FormatInt(p.queueSize, 10))
+	}
+	p.producer.Input() <- message
+	return -1, -1, nil
+}
+
+// Close flushes the queue and waits for the delivery results until ctx is done.
+// It's safe to call it again, the producer is closed once.
+func (p *AsyncProducer) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		p.producer.AsyncClose()
+	}
+	p.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		p.done.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		p.logger.Warn("event queue isn't flushed", log.Int64("pending", p.pending.Load()))
+		return ctx.Err()
+	}
+}
+
+func (p *AsyncProducer) successes() {
+	defer p.done.Done()
+	for message := range p.producer.Successes() {
+		p.pending.Add(-1)
+		p.published.Add(context.Background(), 1, attribute.String("topic", message.Topic))
+	}
+}
+
+func (p *AsyncProducer) errors() {
+	defer p.done.Done()
+	for producerError := range p.producer.Errors() {
+		p.pending.Add(-1)
+		p.failed.Add(context.Background(), 1, attribute.String("topic", producerError.Msg.Topic))
+		p.logger.Error(
+			"can't send message",
+			log.Error(producerError.Err),
+			log.String("topic", producerError.Msg.Topic),
+		)
+	}
+}
@@ -0,0 +1,109 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/golang/mock/gomock"
+)
+
+func TestAsyncProducer_SendMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	cfg := mocks.NewTestConfig()
+	cfg.Producer.Return.Successes = true
+	producer := mocks.NewAsyncProducer(t, cfg)
+	producer.ExpectInputAndSucceed()
+	producer.ExpectInputAndFail(sarama.ErrOutOfBrokers)
+	logger.EXPECT().Error("can't send message", log.Error(sarama.ErrOutOfBrokers), log.String("topic", "companies"))
+	p, err := newAsyncProducer(producer, 2, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := &sarama.ProducerMessage{Topic: "companies", Value: sarama.StringEncoder("1")}
+	for i := 0; i < 2; i++ {
+		if _, _, err := p.SendMessage(message); err != nil {
+			t.Fatalf("SendMessage() error = %v", err)
+		}
+	}
+	if err := p.Close(context.Background()); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if pending := p.pending.Load(); pending != 0 {
+		t.Errorf("pending = %d, want 0", pending)
+	}
+}
+
+func TestAsyncProducer_SendMessage_queueFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	producer := mocks.NewAsyncProducer(t, nil)
+	p, err := newAsyncProducer(producer, 1, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The message occupies the queue until the broker responds.
+	p.pending.Add(1)
+	message := &sarama.ProducerMessage{Topic: "companies", Value: sarama.StringEncoder("1")}
+	want := errs.NewError(errs.ErrorCodeResourceExhausted, "Event queue is full.").WithParam("queue_size", "1")
+	if _, _, err := p.SendMessage(message); !errors.Is(err, want) {
+		t.Errorf("SendMessage() error = %v, wantErr %v", err, want)
+	}
+	if pending := p.pending.Load(); pending != 1 {
+		t.Errorf("pending = %d, want 1", pending)
+	}
+	if err := p.Close(context.Background()); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestAsyncProducer_SendMessage_closed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	producer := mocks.NewAsyncProducer(t, nil)
+	p, err := newAsyncProducer(producer, 1, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	message := &sarama.ProducerMessage{Topic: "companies", Value: sarama.StringEncoder("1")}
+	want := errs.NewError(errs.ErrorCodeUnavailable, "Event producer is closed.")
+	if _, _, err := p.SendMessage(message); !errors.Is(err, want) {
+		t.Errorf("SendMessage() error = %v, wantErr %v", err, want)
+	}
+	if pending := p.pending.Load(); pending != 0 {
+		t.Errorf("pending = %d, want 0", pending)
+	}
+	// The second Close doesn't close the producer again.
+	if err := p.Close(context.Background()); err != nil {
+		t.Errorf("Close() again error = %v", err)
+	}
+}
+
+func TestAsyncProducer_Close_deadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	producer := mocks.NewAsyncProducer(t, nil)
+	p := &AsyncProducer{producer: producer, logger: logger}
+	// A delivery result is never reported.
+	p.done.Add(1)
+	defer p.done.Done()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	logger.EXPECT().Warn("event queue isn't flushed", log.Int64("pending", 0))
+	if err := p.Close(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Close() error = %v, wantErr %v", err, context.Canceled)
+	}
+}
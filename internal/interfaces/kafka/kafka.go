@@ -1,20 +1,148 @@
 package kafka
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
+	"time"
+
 	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/errs"
 	"github.com/Shopify/sarama"
 )
 
-func NewProducer(config *configs.Config) (sarama.SyncProducer, error) {
+// NewSaramaConfig returns the client settings shared by producers and consumers.
+func NewSaramaConfig(config *configs.Config) (*sarama.Config, error) {
 	cfg := sarama.NewConfig()
+	cfg.ClientID = config.Kafka.ClientID
+	if config.Kafka.Version != "" {
+		version, err := sarama.ParseKafkaVersion(config.Kafka.Version)
+		if err != nil {
+			return nil, errs.NewUnexpectedBehaviorError("unknown kafka version").
+				WithParam("version", config.Kafka.Version)
+		}
+		cfg.Version = version
+	}
+	if config.Kafka.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Net.TLS.Enable = true
+		cfg.Net.TLS.Config = tlsConfig
+	}
+	if config.Kafka.SASL.Mechanism != "" {
+		cfg.Net.SASL.Enable = true
+		cfg.Net.SASL.User = config.Kafka.SASL.Username
+		cfg.Net.SASL.Password = config.Kafka.SASL.Password
+		switch sarama.SASLMechanism(config.Kafka.SASL.Mechanism) {
+		case sarama.SASLTypePlaintext:
+			cfg.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		case sarama.SASLTypeSCRAMSHA256:
+			cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA256
+			cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &scramClient{generator: sha256Generator}
+			}
+		case sarama.SASLTypeSCRAMSHA512:
+			cfg.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
+			cfg.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient {
+				return &scramClient{generator: sha512Generator}
+			}
+		default:
+			return nil, errs.NewUnexpectedBehaviorError("unknown sasl mechanism").
+				WithParam("mechanism", config.Kafka.SASL.Mechanism)
+		}
+	}
 	cfg.Producer.RequiredAcks = sarama.WaitForAll
+	cfg.Producer.Partitioner = sarama.NewHashPartitioner
+	cfg.Producer.Retry.Max = config.Kafka.Producer.RetryMax
+	cfg.Producer.Retry.Backoff = time.Duration(config.Kafka.Producer.RetryBackoff) * time.Millisecond
+	if err := cfg.Producer.Compression.UnmarshalText([]byte(config.Kafka.Producer.Compression)); err != nil {
+		return nil, errs.NewUnexpectedBehaviorError("unknown compression codec").
+			WithParam("compression", config.Kafka.Producer.Compression)
+	}
+	if config.Kafka.Producer.Idempotent {
+		// The idempotent producer requires a single in-flight request per connection.
+		cfg.Producer.Idempotent = true
+		cfg.Net.MaxOpenRequests = 1
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	return cfg, nil
+}
+
+func NewProducer(config *configs.Config) (sarama.SyncProducer, error) {
+	cfg, err := NewSaramaConfig(config)
+	if err != nil {
+		return nil, err
+	}
 	cfg.Producer.Return.Successes = true
-	cfg.Producer.Partitioner = sarama.NewRandomPartitioner
-	server := fmt.Sprintf("%s:%d", config.Kafka.Host, config.Kafka.Port)
-	producer, err := sarama.NewSyncProducer([]string{server}, cfg)
+	producer, err := sarama.NewSyncProducer(brokers(config), cfg)
 	if err != nil {
 		return nil, err
 	}
 	return producer, nil
 }
+
+func NewConsumerGroup(config *configs.Config) (sarama.ConsumerGroup, error) {
+	cfg, err := NewSaramaConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	group, err := sarama.NewConsumerGroup(brokers(config), config.Consumer.Group, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// NewConsumer returns a consumer of single partitions, which doesn't commit offsets.
+func NewConsumer(config *configs.Config) (sarama.Consumer, error) {
+	cfg, err := NewSaramaConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	consumer, err := sarama.NewConsumer(brokers(config), cfg)
+	if err != nil {
+		return nil, err
+	}
+	return consumer, nil
+}
+
+// brokers returns the configured broker list, falling back to a single host and port.
+func brokers(config *configs.Config) []string {
+	if len(config.Kafka.Brokers) > 0 {
+		return config.Kafka.Brokers
+	}
+	return []string{fmt.Sprintf("%s:%d", config.Kafka.Host, config.Kafka.Port)}
+}
+
+func newTLSConfig(config *configs.Config) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.Kafka.TLS.InsecureSkipVerify, // nolint:gosec
+	}
+	if config.Kafka.TLS.CAFile != "" {
+		ca, err := os.ReadFile(config.Kafka.TLS.CAFile)
+		if err != nil {
+			return nil, errs.NewUnexpectedBehaviorError(err.Error())
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errs.NewUnexpectedBehaviorError("can't parse kafka ca certificate").
+				WithParam("ca_file", config.Kafka.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if config.Kafka.TLS.CertFile != "" || config.Kafka.TLS.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.Kafka.TLS.CertFile, config.Kafka.TLS.KeyFile)
+		if err != nil {
+			return nil, errs.NewUnexpectedBehaviorError(err.Error())
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,210 @@
+package kafka
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/Shopify/sarama"
+)
+
+func TestNewSaramaConfig(t *testing.T) {
+	caFile := path.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, newCertificate(t), 0600); err != nil {
+		t.Fatal(err)
+	}
+	badCAFile := path.Join(t.TempDir(), "bad-ca.pem")
+	if err := os.WriteFile(badCAFile, []byte("certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		setup   func(config *configs.Config)
+		check   func(t *testing.T, cfg *sarama.Config)
+		wantErr error
+	}{
+		{
+			name:  "defaults",
+			setup: func(config *configs.Config) {},
+			check: func(t *testing.T, cfg *sarama.Config) {
+				if cfg.ClientID != "companies" {
+					t.Errorf("ClientID = %v", cfg.ClientID)
+				}
+				if cfg.Net.TLS.Enable || cfg.Net.SASL.Enable {
+					t.Errorf("TLS = %v, SASL = %v", cfg.Net.TLS.Enable, cfg.Net.SASL.Enable)
+				}
+				if cfg.Producer.Retry.Max != 3 || cfg.Producer.Retry.Backoff != 100*time.Millisecond {
+					t.Errorf("Retry = %+v", cfg.Producer.Retry)
+				}
+			},
+		},
+		{
+			name: "version and idempotent producer",
+			setup: func(config *configs.Config) {
+				config.Kafka.Version = "2.8.0"
+				config.Kafka.Producer.Idempotent = true
+				config.Kafka.Producer.Compression = "zstd"
+			},
+			check: func(t *testing.T, cfg *sarama.Config) {
+				if cfg.Version != sarama.V2_8_0_0 {
+					t.Errorf("Version = %v", cfg.Version)
+				}
+				if !cfg.Producer.Idempotent || cfg.Net.MaxOpenRequests != 1 {
+					t.Errorf("Idempotent = %v, MaxOpenRequests = %v", cfg.Producer.Idempotent, cfg.Net.MaxOpenRequests)
+				}
+				if cfg.Producer.Compression != sarama.CompressionZSTD {
+					t.Errorf("Compression = %v", cfg.Producer.Compression)
+				}
+			},
+		},
+		{
+			name: "sasl plain",
+			setup: func(config *configs.Config) {
+				config.Kafka.SASL.Mechanism = "PLAIN"
+				config.Kafka.SASL.Username = "user"
+				config.Kafka.SASL.Password = "secret"
+			},
+			check: func(t *testing.T, cfg *sarama.Config) {
+				if !cfg.Net.SASL.Enable || cfg.Net.SASL.Mechanism != sarama.SASLTypePlaintext {
+					t.Errorf("SASL = %+v", cfg.Net.SASL)
+				}
+				if cfg.Net.SASL.User != "user" || cfg.Net.SASL.Password != "secret" {
+					t.Errorf("User = %v, Password = %v", cfg.Net.SASL.User, cfg.Net.SASL.Password)
+				}
+			},
+		},
+		{
+			name: "sasl scram",
+			setup: func(config *configs.Config) {
+				config.Kafka.SASL.Mechanism = "SCRAM-SHA-512"
+				config.Kafka.SASL.Username = "user"
+				config.Kafka.SASL.Password = "secret"
+			},
+			check: func(t *testing.T, cfg *sarama.Config) {
+				if cfg.Net.SASL.Mechanism != sarama.SASLTypeSCRAMSHA512 {
+					t.Errorf("Mechanism = %v", cfg.Net.SASL.Mechanism)
+				}
+				if cfg.Net.SASL.SCRAMClientGeneratorFunc == nil {
+					t.Error("SCRAMClientGeneratorFunc is nil")
+				}
+			},
+		},
+		{
+			name: "tls",
+			setup: func(config *configs.Config) {
+				config.Kafka.TLS.Enabled = true
+				config.Kafka.TLS.CAFile = caFile
+			},
+			check: func(t *testing.T, cfg *sarama.Config) {
+				if !cfg.Net.TLS.Enable || cfg.Net.TLS.Config.RootCAs == nil {
+					t.Errorf("TLS = %+v", cfg.Net.TLS)
+				}
+			},
+		},
+		{
+			name: "bad version",
+			setup: func(config *configs.Config) {
+				config.Kafka.Version = "latest"
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("unknown kafka version").WithParam("version", "latest"),
+		},
+		{
+			name: "bad sasl mechanism",
+			setup: func(config *configs.Config) {
+				config.Kafka.SASL.Mechanism = "GSSAPI"
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("unknown sasl mechanism").WithParam("mechanism", "GSSAPI"),
+		},
+		{
+			name: "bad compression",
+			setup: func(config *configs.Config) {
+				config.Kafka.Producer.Compression = "brotli"
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("unknown compression codec").WithParam("compression", "brotli"),
+		},
+		{
+			name: "bad ca",
+			setup: func(config *configs.Config) {
+				config.Kafka.TLS.Enabled = true
+				config.Kafka.TLS.CAFile = badCAFile
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("can't parse kafka ca certificate").WithParam("ca_file", badCAFile),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := configs.NewMockConfig(t)
+			tt.setup(config)
+			got, err := NewSaramaConfig(config)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewSaramaConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func Test_brokers(t *testing.T) {
+	config := configs.NewMockConfig(t)
+	if got := brokers(config); !reflect.DeepEqual(got, []string{"127.0.0.1:9092"}) {
+		t.Errorf("brokers() got = %v", got)
+	}
+	config.Kafka.Brokers = []string{"kafka-1:9093", "kafka-2:9093"}
+	if got := brokers(config); !reflect.DeepEqual(got, config.Kafka.Brokers) {
+		t.Errorf("brokers() got = %v, want %v", got, config.Kafka.Brokers)
+	}
+}
+
+func Test_scramClient(t *testing.T) {
+	client := &scramClient{generator: sha512Generator}
+	if err := client.Begin("user", "secret", ""); err != nil {
+		t.Fatal(err)
+	}
+	first, err := client.Step("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(first, "n,,n=user,r=") {
+		t.Errorf("Step() got = %v", first)
+	}
+	if client.Done() {
+		t.Error("Done() got = true, want false")
+	}
+}
+
+func newCertificate(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "kafka"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
@@ -0,0 +1,35 @@
+package nats
+
+import (
+	"errors"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/nats-io/nats.go"
+)
+
+func NewConnection(config *configs.Config) (*nats.Conn, error) {
+	conn, err := nats.Connect(config.Events.NATS.URL, nats.Name("companies"))
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// NewJetStream returns a JetStream context and creates the events stream if it doesn't exist.
+func NewJetStream(conn *nats.Conn, config *configs.Config) (nats.JetStreamContext, error) {
+	js, err := conn.JetStream()
+	if err != nil {
+		return nil, err
+	}
+	_, err = js.StreamInfo(config.Events.NATS.Stream)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		_, err = js.AddStream(&nats.StreamConfig{
+			Name:     config.Events.NATS.Stream,
+			Subjects: []string{config.Events.NATS.Subject + ".>"},
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return js, nil
+}
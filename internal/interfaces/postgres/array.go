@@ -0,0 +1,13 @@
+package postgres
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// StringArray is a text array column.
+// pgx encodes it as a parameter natively, Scan decodes the text form the stdlib driver returns.
+type StringArray []string
+
+func (a *StringArray) Scan(src any) error {
+	return pgtype.NewMap().SQLScanner((*[]string)(a)).Scan(src)
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStringArray_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    StringArray
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			src:  `{created,"updated at"}`,
+			want: StringArray{"created", "updated at"},
+		},
+		{
+			name: "bytes",
+			src:  []byte("{}"),
+			want: StringArray{},
+		},
+		{
+			name: "null",
+			src:  nil,
+			want: nil,
+		},
+		{
+			name:    "invalid",
+			src:     "created",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got StringArray
+			if err := got.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("StringArray.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StringArray.Scan() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
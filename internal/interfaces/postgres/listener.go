@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/018bf/companies/pkg/log"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ChannelCompanyChanges is notified by the companies trigger with the position of every change.
+const ChannelCompanyChanges = "company_changes"
+
+const (
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+)
+
+// Listener keeps a single LISTEN connection and wakes up every subscriber on a notification.
+// Wake-ups are coalesced: a subscriber is expected to read all changes after its own position.
+type Listener struct {
+	config      *pgx.ConnConfig
+	logger      log.Logger
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewListener listens on a connection of its own configured as the ones of pool,
+// it connects in the background and reconnects until closed.
+func NewListener(pool *Pool, logger log.Logger) *Listener {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &Listener{
+		config:      pool.Config().ConnConfig,
+		logger:      logger,
+		subscribers: map[chan struct{}]struct{}{},
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}
+	notifications := make(chan *pgconn.Notification)
+	go l.listen(ctx, notifications)
+	go func() {
+		l.run(notifications)
+		close(l.done)
+	}()
+	return l
+}
+
+// Subscribe returns a channel receiving wake-ups and a function which cancels the subscription.
+func (l *Listener) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	l.mu.Lock()
+	l.subscribers[ch] = struct{}{}
+	l.mu.Unlock()
+	return ch, func() {
+		l.mu.Lock()
+		delete(l.subscribers, ch)
+		l.mu.Unlock()
+	}
+}
+
+func (l *Listener) Close(ctx context.Context) error {
+	l.cancel()
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// listen keeps the LISTEN connection until ctx is done, waiting longer after every failed attempt.
+// A nil notification is sent after a reconnect, when notifications could be lost.
+func (l *Listener) listen(ctx context.Context, notifications chan<- *pgconn.Notification) {
+	defer close(notifications)
+	delay := listenerMinReconnect
+	reconnect := false
+	for {
+		listened, err := l.receive(ctx, notifications, reconnect)
+		if ctx.Err() != nil {
+			return
+		}
+		l.logger.Warn("postgres listener is disconnected", log.Error(err))
+		if listened {
+			delay = listenerMinReconnect
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > listenerMaxReconnect {
+			delay = listenerMaxReconnect
+		}
+		reconnect = true
+	}
+}
+
+// receive connects, listens and passes the notifications until the connection fails,
+// listened reports whether it has listened before.
+func (l *Listener) receive(
+	ctx context.Context,
+	notifications chan<- *pgconn.Notification,
+	reconnect bool,
+) (listened bool, err error) {
+	conn, err := pgx.ConnectConfig(ctx, l.config)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, "LISTEN "+ChannelCompanyChanges); err != nil {
+		return false, err
+	}
+	if reconnect {
+		l.logger.Info("postgres listener is reconnected")
+		notifications <- nil
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+		notifications <- notification
+	}
+}
+
+// run wakes up the subscribers on every notification until notifications are closed.
+func (l *Listener) run(notifications <-chan *pgconn.Notification) {
+	for range notifications {
+		l.broadcast()
+	}
+}
+
+func (l *Listener) broadcast() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for ch := range l.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
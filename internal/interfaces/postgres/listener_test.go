@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestListener_run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	l := &Listener{logger: logger, subscribers: map[chan struct{}]struct{}{}}
+	first, cancelFirst := l.Subscribe()
+	second, cancelSecond := l.Subscribe()
+	cancelSecond()
+	notifications := make(chan *pgconn.Notification)
+	done := make(chan struct{})
+	go func() {
+		l.run(notifications)
+		close(done)
+	}()
+	// Both notifications are coalesced into a single wake-up.
+	notifications <- &pgconn.Notification{Channel: ChannelCompanyChanges, Payload: "1"}
+	notifications <- nil
+	close(notifications)
+	<-done
+	select {
+	case <-first:
+	case <-time.After(time.Second):
+		t.Fatal("subscriber isn't woken up")
+	}
+	select {
+	case <-first:
+		t.Error("wake-ups aren't coalesced")
+	default:
+	}
+	select {
+	case <-second:
+		t.Error("cancelled subscriber is woken up")
+	default:
+	}
+	cancelFirst()
+	if len(l.subscribers) != 0 {
+		t.Errorf("subscribers = %d, want 0", len(l.subscribers))
+	}
+}
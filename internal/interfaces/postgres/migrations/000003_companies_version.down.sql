@@ -0,0 +1,2 @@
+ALTER TABLE public.companies
+    DROP COLUMN version;
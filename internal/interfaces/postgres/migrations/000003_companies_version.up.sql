@@ -0,0 +1,2 @@
+ALTER TABLE public.companies
+    ADD COLUMN version bigint NOT NULL DEFAULT 1;
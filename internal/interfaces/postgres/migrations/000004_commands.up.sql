@@ -0,0 +1,7 @@
+CREATE TABLE public.commands
+(
+    id         uuid
+        CONSTRAINT commands_pk PRIMARY KEY,
+    operation  varchar(16) NOT NULL,
+    created_at timestamp   NOT NULL DEFAULT (now() at time zone 'utc')
+);
@@ -0,0 +1,46 @@
+CREATE TABLE public.webhook_subscriptions
+(
+    id          uuid                 DEFAULT uuid_generate_v4()
+        CONSTRAINT webhook_subscriptions_pk PRIMARY KEY,
+    url         text          NOT NULL,
+    secret      text          NOT NULL,
+    event_types varchar(16)[] NOT NULL DEFAULT '{}',
+    active      boolean       NOT NULL DEFAULT true,
+    failures    int           NOT NULL DEFAULT 0,
+    updated_at  timestamp     NOT NULL DEFAULT (now() at time zone 'utc'),
+    created_at  timestamp     NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+CREATE TABLE public.webhook_deliveries
+(
+    id              uuid                 DEFAULT uuid_generate_v4()
+        CONSTRAINT webhook_deliveries_pk PRIMARY KEY,
+    webhook_id      uuid        NOT NULL
+        CONSTRAINT webhook_deliveries_webhook_fk REFERENCES public.webhook_subscriptions ON DELETE CASCADE,
+    event_type      varchar(16) NOT NULL,
+    payload         jsonb       NOT NULL,
+    status          varchar(16) NOT NULL,
+    attempts        int         NOT NULL DEFAULT 0,
+    next_attempt_at timestamp   NOT NULL,
+    response_status int         NOT NULL DEFAULT 0,
+    last_error      text        NOT NULL DEFAULT '',
+    updated_at      timestamp   NOT NULL DEFAULT (now() at time zone 'utc'),
+    created_at      timestamp   NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+CREATE INDEX webhook_deliveries_due ON public.webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_webhook ON public.webhook_deliveries (webhook_id, created_at);
+
+CREATE TRIGGER update_webhook_subscriptions_updated_at
+    BEFORE UPDATE
+    ON
+        public.webhook_subscriptions
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_task();
+
+CREATE TRIGGER update_webhook_deliveries_updated_at
+    BEFORE UPDATE
+    ON
+        public.webhook_deliveries
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_task();
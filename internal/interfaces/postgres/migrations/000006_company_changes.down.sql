@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS companies_changes ON public.companies;
+DROP FUNCTION IF EXISTS company_changes_task();
+DROP TABLE IF EXISTS public.company_changes;
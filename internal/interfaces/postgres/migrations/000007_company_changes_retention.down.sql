@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS public.company_changes_changed_at_idx;
+DROP INDEX IF EXISTS public.company_changes_company_id_position_idx;
@@ -0,0 +1,9 @@
+CREATE INDEX company_changes_company_id_position_idx ON public.company_changes (company_id, position);
+CREATE INDEX company_changes_changed_at_idx ON public.company_changes (changed_at);
+
+-- The change feed starts from the log, so the companies created before it need an entry.
+INSERT INTO public.company_changes (company_id, operation, company, changed_at)
+SELECT companies.id, 'created', to_jsonb(companies), companies.updated_at
+FROM public.companies AS companies
+WHERE NOT EXISTS(SELECT 1 FROM public.company_changes AS changes WHERE changes.company_id = companies.id)
+ORDER BY companies.updated_at, companies.id;
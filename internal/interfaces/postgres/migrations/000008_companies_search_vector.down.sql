@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS public.companies_search;
+ALTER TABLE public.companies
+    DROP COLUMN IF EXISTS search_vector;
+DROP TEXT SEARCH CONFIGURATION IF EXISTS public.companies_search;
+
+CREATE INDEX companies_search
+    ON public.companies
+        USING GIN (to_tsvector('english', name || description));
@@ -0,0 +1,20 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- The search language is applied to this configuration by the migrate command,
+-- the comment keeps the language it's built for.
+CREATE TEXT SEARCH CONFIGURATION public.companies_search (COPY = pg_catalog.english);
+ALTER TEXT SEARCH CONFIGURATION public.companies_search
+    ALTER MAPPING FOR asciiword, asciihword, hword_asciipart, word, hword, hword_part
+        WITH unaccent, english_stem;
+COMMENT ON TEXT SEARCH CONFIGURATION public.companies_search IS 'english';
+
+DROP INDEX IF EXISTS public.companies_search;
+
+-- Adding a stored generated column rewrites the table, so the existing companies are backfilled.
+ALTER TABLE public.companies
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+            setweight(to_tsvector('public.companies_search', name), 'A') ||
+            setweight(to_tsvector('public.companies_search', description), 'B')
+        ) STORED;
+
+CREATE INDEX companies_search ON public.companies USING GIN (search_vector);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS public.companies_name_trgm;
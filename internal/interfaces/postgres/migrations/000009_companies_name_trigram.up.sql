@@ -0,0 +1,5 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX companies_name_trgm
+    ON public.companies
+        USING GIN (name gin_trgm_ops);
@@ -0,0 +1 @@
+DROP MATERIALIZED VIEW IF EXISTS public.companies_stats;
@@ -0,0 +1,14 @@
+-- The companies aggregated by the dimensions of the statistics at the day grain.
+-- It doesn't depend on the search vector, which is rebuilt when the search language changes.
+CREATE MATERIALIZED VIEW public.companies_stats AS
+SELECT type,
+       registered,
+       amount_of_employees,
+       date_trunc('day', created_at) AS created_at,
+       count(*)                      AS companies_count
+FROM public.companies
+GROUP BY type, registered, amount_of_employees, date_trunc('day', created_at);
+
+-- The unique index allows to refresh the view concurrently with the reads.
+CREATE UNIQUE INDEX companies_stats_pk
+    ON public.companies_stats (type, registered, amount_of_employees, created_at);
@@ -0,0 +1,46 @@
+DROP FUNCTION IF EXISTS company_changes_watermark();
+DROP TRIGGER IF EXISTS company_changes_position ON public.company_changes;
+DROP FUNCTION IF EXISTS company_changes_position_task();
+DROP TRIGGER IF EXISTS companies_changes ON public.companies;
+DROP FUNCTION IF EXISTS company_changes_task();
+
+DELETE FROM public.company_changes WHERE position IS NULL;
+DROP INDEX IF EXISTS public.company_changes_position_key;
+ALTER TABLE public.company_changes
+    DROP CONSTRAINT company_changes_pk;
+ALTER TABLE public.company_changes
+    DROP COLUMN id;
+ALTER TABLE public.company_changes
+    ALTER COLUMN position SET DEFAULT nextval('public.company_changes_position_seq'),
+    ADD CONSTRAINT company_changes_pk PRIMARY KEY (position);
+
+CREATE FUNCTION company_changes_task() RETURNS trigger
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    change_position bigint;
+BEGIN
+    -- Holding the lock until commit serializes the writers,
+    -- so positions are assigned in commit order and readers never skip a change.
+    PERFORM pg_advisory_xact_lock(hashtext('company_changes'));
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO public.company_changes (company_id, operation, company)
+        VALUES (OLD.id, 'deleted', to_jsonb(OLD))
+        RETURNING position INTO change_position;
+    ELSE
+        INSERT INTO public.company_changes (company_id, operation, company)
+        VALUES (NEW.id, CASE TG_OP WHEN 'INSERT' THEN 'created' ELSE 'updated' END, to_jsonb(NEW))
+        RETURNING position INTO change_position;
+    END IF;
+    PERFORM pg_notify('company_changes', change_position::text);
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER companies_changes
+    AFTER INSERT OR UPDATE OR DELETE
+    ON
+        public.companies
+    FOR EACH ROW
+EXECUTE PROCEDURE company_changes_task();
@@ -0,0 +1,82 @@
+-- The positions are taken when the writing transaction commits instead of the writers being serialized.
+-- A writer holds the shared lock from taking its positions until its commit ends,
+-- so the watermark read under the exclusive lock never passes an uncommitted change.
+DROP TRIGGER companies_changes ON public.companies;
+DROP FUNCTION company_changes_task();
+
+ALTER TABLE public.company_changes
+    DROP CONSTRAINT company_changes_pk;
+ALTER TABLE public.company_changes
+    ADD COLUMN id bigserial CONSTRAINT company_changes_pk PRIMARY KEY;
+ALTER TABLE public.company_changes
+    ALTER COLUMN position DROP NOT NULL,
+    ALTER COLUMN position DROP DEFAULT;
+CREATE UNIQUE INDEX company_changes_position_key ON public.company_changes (position);
+
+CREATE FUNCTION company_changes_task() RETURNS trigger
+    LANGUAGE plpgsql
+AS
+$$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO public.company_changes (company_id, operation, company)
+        VALUES (OLD.id, 'deleted', to_jsonb(OLD));
+    ELSE
+        INSERT INTO public.company_changes (company_id, operation, company)
+        VALUES (NEW.id, CASE TG_OP WHEN 'INSERT' THEN 'created' ELSE 'updated' END, to_jsonb(NEW));
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER companies_changes
+    AFTER INSERT OR UPDATE OR DELETE
+    ON
+        public.companies
+    FOR EACH ROW
+EXECUTE PROCEDURE company_changes_task();
+
+CREATE FUNCTION company_changes_position_task() RETURNS trigger
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    change_position bigint;
+BEGIN
+    PERFORM pg_advisory_xact_lock_shared(hashtext('company_changes'));
+    UPDATE public.company_changes
+    SET position = nextval('public.company_changes_position_seq')
+    WHERE id = NEW.id
+    RETURNING position INTO change_position;
+    IF change_position IS NOT NULL THEN
+        PERFORM pg_notify('company_changes', change_position::text);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+-- The deferred trigger runs at commit, in the order of the changes of the transaction.
+CREATE CONSTRAINT TRIGGER company_changes_position
+    AFTER INSERT
+    ON
+        public.company_changes
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW
+EXECUTE PROCEDURE company_changes_position_task();
+
+-- company_changes_watermark returns the position up to which all changes are committed.
+-- It has to run in its own transaction, the changes are read after it with a new snapshot.
+CREATE FUNCTION company_changes_watermark() RETURNS bigint
+    LANGUAGE plpgsql
+AS
+$$
+DECLARE
+    watermark bigint;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('company_changes'));
+    SELECT CASE WHEN is_called THEN last_value ELSE 0 END
+    INTO watermark
+    FROM public.company_changes_position_seq;
+    RETURN watermark;
+END;
+$$;
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+)
+
+const meterName = "github.com/018bf/companies/internal/interfaces/postgres"
+
+// Pool is the pool of the connections to a database, its statistics are exported as the metrics.
+// The repositories use it through NewDatabase, the listener connects with its config.
+type Pool struct {
+	*pgxpool.Pool
+	metrics metric.Registration
+}
+
+// NewPool creates the pool of the primary. When the companies are kept by another storage driver,
+// the connections are made on the first use, so the services not using Postgres start without it.
+func NewPool(config *configs.Config) (*Pool, error) {
+	lazy := config.Storage.Driver != "postgres"
+	pool, err := newPool(config.Database.URI, "primary", config, lazy)
+	if err != nil {
+		return nil, err
+	}
+	if lazy {
+		return pool, nil
+	}
+	if err := pool.Ping(context.Background()); err != nil {
+		_ = pool.Close(context.Background())
+		return nil, err
+	}
+	return pool, nil
+}
+
+func newPool(uri string, name string, config *configs.Config, lazy bool) (*Pool, error) {
+	poolConfig, err := newPoolConfig(uri, config)
+	if err != nil {
+		return nil, err
+	}
+	if lazy {
+		poolConfig.MinConns = 0
+	}
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := registerPoolMetrics(pool, name)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return &Pool{Pool: pool, metrics: metrics}, nil
+}
+
+// newPoolConfig configures the pool by the database config.
+// The statements are prepared and cached per connection unless the cache is disabled.
+func newPoolConfig(uri string, config *configs.Config) (*pgxpool.Config, error) {
+	source, err := dataSource(uri, config)
+	if err != nil {
+		return nil, err
+	}
+	poolConfig, err := pgxpool.ParseConfig(source)
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.MaxConns = int32(config.Database.MaxOpenConnections)
+	poolConfig.MinConns = int32(config.Database.MinConnections)
+	if poolConfig.MinConns > poolConfig.MaxConns {
+		poolConfig.MinConns = poolConfig.MaxConns
+	}
+	poolConfig.MaxConnLifetime = time.Duration(config.Database.MaxConnLifetime) * time.Second
+	poolConfig.MaxConnIdleTime = time.Duration(config.Database.MaxConnIdleTime) * time.Second
+	poolConfig.HealthCheckPeriod = time.Duration(config.Database.HealthCheckPeriod) * time.Second
+	if config.Database.StatementCacheCapacity > 0 {
+		poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+		poolConfig.ConnConfig.StatementCacheCapacity = config.Database.StatementCacheCapacity
+	} else {
+		poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeExec
+	}
+	return poolConfig, nil
+}
+
+// NewDatabase returns the pool as the database/sql connections of the repositories.
+func NewDatabase(pool *Pool) *sqlx.DB {
+	return sqlx.NewDb(stdlib.OpenDBFromPool(pool.Pool), "pgx")
+}
+
+func (p *Pool) Close(_ context.Context) error {
+	_ = p.metrics.Unregister()
+	p.Pool.Close()
+	return nil
+}
+
+// poolMetrics are the exported statistics of the pools, the gauges are the current values
+// and the others are the counters since the pool is created.
+var poolMetrics = []struct {
+	name  string
+	gauge bool
+	value func(stat *pgxpool.Stat) int64
+}{
+	{
+		name:  "postgres.pool.connections.total",
+		gauge: true,
+		value: func(stat *pgxpool.Stat) int64 { return int64(stat.TotalConns()) },
+	},
+	{
+		name:  "postgres.pool.connections.acquired",
+		gauge: true,
+		value: func(stat *pgxpool.Stat) int64 { return int64(stat.AcquiredConns()) },
+	},
+	{
+		name:  "postgres.pool.connections.idle",
+		gauge: true,
+		value: func(stat *pgxpool.Stat) int64 { return int64(stat.IdleConns()) },
+	},
+	{
+		name:  "postgres.pool.connections.constructing",
+		gauge: true,
+		value: func(stat *pgxpool.Stat) int64 { return int64(stat.ConstructingConns()) },
+	},
+	{
+		name:  "postgres.pool.connections.max",
+		gauge: true,
+		value: func(stat *pgxpool.Stat) int64 { return int64(stat.MaxConns()) },
+	},
+	{
+		name:  "postgres.pool.connections.created",
+		value: func(stat *pgxpool.Stat) int64 { return stat.NewConnsCount() },
+	},
+	{
+		name:  "postgres.pool.connections.lifetime_destroyed",
+		value: func(stat *pgxpool.Stat) int64 { return stat.MaxLifetimeDestroyCount() },
+	},
+	{
+		name:  "postgres.pool.connections.idle_destroyed",
+		value: func(stat *pgxpool.Stat) int64 { return stat.MaxIdleDestroyCount() },
+	},
+	{
+		name:  "postgres.pool.acquires",
+		value: func(stat *pgxpool.Stat) int64 { return stat.AcquireCount() },
+	},
+	{
+		name:  "postgres.pool.acquires.empty",
+		value: func(stat *pgxpool.Stat) int64 { return stat.EmptyAcquireCount() },
+	},
+	{
+		name:  "postgres.pool.acquires.canceled",
+		value: func(stat *pgxpool.Stat) int64 { return stat.CanceledAcquireCount() },
+	},
+	{
+		name:  "postgres.pool.acquires.duration",
+		value: func(stat *pgxpool.Stat) int64 { return stat.AcquireDuration().Milliseconds() },
+	},
+}
+
+// registerPoolMetrics exports the statistics of the pool until the registration is unregistered,
+// name tells the primary from the replicas.
+func registerPoolMetrics(pool *pgxpool.Pool, name string) (metric.Registration, error) {
+	meter := global.Meter(meterName)
+	instruments := make([]instrument.Int64Observable, len(poolMetrics))
+	for i, poolMetric := range poolMetrics {
+		var err error
+		if poolMetric.gauge {
+			instruments[i], err = meter.Int64ObservableGauge(poolMetric.name)
+		} else {
+			instruments[i], err = meter.Int64ObservableCounter(poolMetric.name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	asynchronous := make([]instrument.Asynchronous, len(instruments))
+	for i, observable := range instruments {
+		asynchronous[i] = observable
+	}
+	pooled := attribute.String("pool", name)
+	return meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		stat := pool.Stat()
+		for i, poolMetric := range poolMetrics {
+			observer.ObserveInt64(instruments[i], poolMetric.value(stat), pooled)
+		}
+		return nil
+	}, asynchronous...)
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/jackc/pgx/v5"
+)
+
+func Test_newPoolConfig(t *testing.T) {
+	tests := []struct {
+		name                   string
+		minConns               int
+		statementTimeout       int64
+		statementCacheCapacity int
+		wantMinConns           int32
+		wantExecMode           pgx.QueryExecMode
+		wantRuntimeParams      map[string]string
+	}{
+		{
+			name:                   "statement cache",
+			minConns:               10,
+			statementCacheCapacity: 512,
+			wantMinConns:           10,
+			wantExecMode:           pgx.QueryExecModeCacheStatement,
+			wantRuntimeParams:      map[string]string{},
+		},
+		{
+			name:                   "no statement cache",
+			minConns:               10,
+			statementCacheCapacity: 0,
+			wantMinConns:           10,
+			wantExecMode:           pgx.QueryExecModeExec,
+			wantRuntimeParams:      map[string]string{},
+		},
+		{
+			name:                   "min connections over the max",
+			minConns:               100,
+			statementCacheCapacity: 512,
+			wantMinConns:           50,
+			wantExecMode:           pgx.QueryExecModeCacheStatement,
+			wantRuntimeParams:      map[string]string{},
+		},
+		{
+			name:                   "statement timeout",
+			minConns:               10,
+			statementTimeout:       3000,
+			statementCacheCapacity: 512,
+			wantMinConns:           10,
+			wantExecMode:           pgx.QueryExecModeCacheStatement,
+			wantRuntimeParams:      map[string]string{"statement_timeout": "3000"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := configs.NewMockConfig(t)
+			config.Database.MinConnections = tt.minConns
+			config.Database.StatementTimeout = tt.statementTimeout
+			config.Database.StatementCacheCapacity = tt.statementCacheCapacity
+			got, err := newPoolConfig("postgres://@127.0.0.1/companies?sslmode=disable", config)
+			if err != nil {
+				t.Fatalf("newPoolConfig() error = %v", err)
+			}
+			if got.MaxConns != 50 || got.MinConns != tt.wantMinConns {
+				t.Errorf("newPoolConfig() conns = %d..%d, want %d..50", got.MinConns, got.MaxConns, tt.wantMinConns)
+			}
+			if got.MaxConnLifetime != time.Hour ||
+				got.MaxConnIdleTime != 30*time.Minute ||
+				got.HealthCheckPeriod != time.Minute {
+				t.Errorf(
+					"newPoolConfig() lifetime = %v, idle time = %v, health check = %v, want 1h, 30m, 1m",
+					got.MaxConnLifetime,
+					got.MaxConnIdleTime,
+					got.HealthCheckPeriod,
+				)
+			}
+			if got.ConnConfig.DefaultQueryExecMode != tt.wantExecMode {
+				t.Errorf("newPoolConfig() exec mode = %v, want %v", got.ConnConfig.DefaultQueryExecMode, tt.wantExecMode)
+			}
+			for key, want := range tt.wantRuntimeParams {
+				if got.ConnConfig.RuntimeParams[key] != want {
+					t.Errorf("newPoolConfig() %s = %q, want %q", key, got.ConnConfig.RuntimeParams[key], want)
+				}
+			}
+		})
+	}
+}
@@ -4,11 +4,20 @@ import (
 	"context"
 	"embed"
 	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/errs"
 	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 	"github.com/jmoiron/sqlx"
 )
@@ -16,16 +25,33 @@ import (
 //go:embed migrations/*.sql
 var MigrationsFS embed.FS
 
-func NewDatabase(config *configs.Config) (*sqlx.DB, error) {
-	database, err := sqlx.Connect("postgres", config.Database.URI)
+// dataSource returns uri with the configured statement_timeout,
+// pgx passes the unknown parameters of both the URL and the key-value forms to the server.
+func dataSource(uri string, config *configs.Config) (string, error) {
+	if config.Database.StatementTimeout <= 0 {
+		return uri, nil
+	}
+	timeout := strconv.FormatInt(config.Database.StatementTimeout, 10)
+	if !strings.HasPrefix(uri, "postgres://") && !strings.HasPrefix(uri, "postgresql://") {
+		return strings.TrimSpace(uri + " statement_timeout=" + timeout), nil
+	}
+	u, err := url.Parse(uri)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	database.SetMaxOpenConns(config.Database.MaxOpenConnections)
-	database.SetMaxIdleConns(config.Database.MaxIDLEConnections)
-	return database, nil
+	query := u.Query()
+	query.Set("statement_timeout", timeout)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
 }
 
+const (
+	// migrateLockID is the key of the advisory lock held while the migrations are applied on start.
+	migrateLockID = 7347116152
+	// searchVersion is the migration creating the search configuration the language is applied to.
+	searchVersion = 8
+)
+
 type MigrateManager struct {
 	database *sqlx.DB
 	config   *configs.Config
@@ -38,20 +64,202 @@ func NewMigrateManager(database *sqlx.DB, config *configs.Config) *MigrateManage
 	}
 }
 
-func (m MigrateManager) Up(_ context.Context) error {
-	source, err := iofs.New(MigrationsFS, "migrations")
+// Up applies the migrations and the configured search language.
+func (m MigrateManager) Up(ctx context.Context) error {
+	return m.migrate(ctx, func(instance *migrate.Migrate) error {
+		return instance.Up()
+	})
+}
+
+// UpLocked applies the migrations as Up does, holding the advisory lock,
+// so the instances starting together don't migrate at the same time.
+func (m MigrateManager) UpLocked(ctx context.Context) error {
+	return m.withLock(ctx, m.Up)
+}
+
+// Steps applies n migrations up, or rolls back -n migrations when n is negative.
+func (m MigrateManager) Steps(ctx context.Context, n int) error {
+	return m.migrate(ctx, func(instance *migrate.Migrate) error {
+		return instance.Steps(n)
+	})
+}
+
+// Down rolls back all the migrations.
+func (m MigrateManager) Down(ctx context.Context) error {
+	return m.migrate(ctx, func(instance *migrate.Migrate) error {
+		return instance.Down()
+	})
+}
+
+// Goto migrates up or down to the version.
+func (m MigrateManager) Goto(ctx context.Context, version uint) error {
+	return m.migrate(ctx, func(instance *migrate.Migrate) error {
+		return instance.Migrate(version)
+	})
+}
+
+// Force sets the version without migrating and clears the dirty state of a failed migration,
+// -1 means no migration is applied.
+func (m MigrateManager) Force(_ context.Context, version int) error {
+	instance, err := m.instance()
 	if err != nil {
 		return err
 	}
-	instance, err := migrate.NewWithSourceInstance("iofs", source, m.config.Database.URI)
+	defer closeInstance(instance)
+	return instance.Force(version)
+}
+
+// Status returns the applied version and the migrations of MigrationsFS.
+func (m MigrateManager) Status(_ context.Context) (*MigrateStatus, error) {
+	instance, err := m.instance()
+	if err != nil {
+		return nil, err
+	}
+	defer closeInstance(instance)
+	version, dirty, err := instance.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, err
+	}
+	return listMigrations(MigrationsFS, version, dirty)
+}
+
+// migrate runs fn and applies the search language, which isn't kept by the migrations.
+func (m MigrateManager) migrate(ctx context.Context, fn func(instance *migrate.Migrate) error) error {
+	instance, err := m.instance()
 	if err != nil {
 		return err
 	}
-	if err := instance.Up(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			return nil
-		}
+	defer closeInstance(instance)
+	if err := fn(instance); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	version, _, err := instance.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return nil
+	}
+	if err != nil {
 		return err
 	}
-	return nil
+	if version < searchVersion {
+		return nil
+	}
+	return ApplySearchLanguage(ctx, m.database, m.config.Search.Language)
+}
+
+func (m MigrateManager) instance() (*migrate.Migrate, error) {
+	source, err := iofs.New(MigrationsFS, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	uri, err := migrateURL(m.config.Database.URI)
+	if err != nil {
+		return nil, err
+	}
+	return migrate.NewWithSourceInstance("iofs", source, uri)
+}
+
+// migrateURL returns uri with the scheme of the pgx migrate driver, which connects with the postgres one.
+func migrateURL(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	u.Scheme = "pgx5"
+	return u.String(), nil
+}
+
+func closeInstance(instance *migrate.Migrate) {
+	_, _ = instance.Close()
+}
+
+// withLock runs fn holding the session advisory lock on a connection of its own.
+func (m MigrateManager) withLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	conn, err := m.database.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrateLockID); err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrateLockID)
+	}()
+	return fn(ctx)
+}
+
+// MigrateStatus is the applied version and the known migrations, Version is 0 when none is applied.
+// Dirty means the migration of Version failed and has to be fixed and forced.
+type MigrateStatus struct {
+	Version    uint
+	Dirty      bool
+	Migrations []*Migration
+}
+
+type Migration struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// listMigrations lists the up migrations of fsys, the ones up to version are applied.
+func listMigrations(fsys fs.FS, version uint, dirty bool) (*MigrateStatus, error) {
+	entries, err := fs.ReadDir(fsys, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	status := &MigrateStatus{Version: version, Dirty: dirty}
+	for _, entry := range entries {
+		migration, err := source.Parse(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if migration.Direction != source.Up {
+			continue
+		}
+		status.Migrations = append(status.Migrations, &Migration{
+			Version: migration.Version,
+			Name:    migration.Identifier,
+			Applied: migration.Version < version || migration.Version == version && !dirty,
+		})
+	}
+	return status, nil
+}
+
+var migrationName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// CreateMigration writes the empty up and down migrations following the last one of dir
+// and returns their paths.
+func CreateMigration(dir string, name string) ([]string, error) {
+	if !migrationName.MatchString(name) {
+		return nil, errs.NewInvalidParameter("name must contain only lowercase letters, digits and underscores").
+			WithParam("name", name)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var last uint
+	for _, entry := range entries {
+		migration, err := source.Parse(entry.Name())
+		if err != nil {
+			continue
+		}
+		if migration.Version > last {
+			last = migration.Version
+		}
+	}
+	var paths []string
+	for _, direction := range []source.Direction{source.Up, source.Down} {
+		path := filepath.Join(dir, fmt.Sprintf("%06d_%s.%s.sql", last+1, name, direction))
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		if err := file.Close(); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
 }
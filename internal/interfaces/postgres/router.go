@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/pkg/clock"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/jmoiron/sqlx"
+)
+
+const replicaPingTimeout = time.Second
+
+type primaryKey struct{}
+
+// WithPrimary returns ctx whose reads go to the primary, for the reads which mustn't lag behind the writes.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// PrimaryFromContext reports whether the reads of ctx go to the primary, see WithPrimary.
+func PrimaryFromContext(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// Router sends the reads to the healthy replicas in turn and the writes to the primary.
+// The reads fail over to the primary when no replica is healthy
+// and stay on it within the read-your-writes window after the caller writes, see entity.WithCaller.
+type Router struct {
+	primary  *sqlx.DB
+	replicas []*replica
+	next     atomic.Uint64
+	window   time.Duration
+	clock    clock.Clock
+	logger   log.Logger
+	mu       sync.Mutex
+	writes   map[string]time.Time
+	done     chan struct{}
+	stop     sync.Once
+	checks   sync.WaitGroup
+}
+
+type replica struct {
+	database *sqlx.DB
+	pool     *Pool
+	healthy  atomic.Bool
+}
+
+// NewRouter opens the replicas and checks their health every interval until closed.
+// The replicas are unhealthy until the first check passes.
+func NewRouter(primary *sqlx.DB, config *configs.Config, clock clock.Clock, logger log.Logger) (*Router, error) {
+	r := &Router{
+		primary: primary,
+		window:  time.Duration(config.Database.ReadYourWritesWindow) * time.Second,
+		clock:   clock,
+		logger:  logger,
+		writes:  map[string]time.Time{},
+		done:    make(chan struct{}),
+	}
+	for i, uri := range config.Database.ReplicaURIs {
+		pool, err := newPool(uri, fmt.Sprintf("replica-%d", i+1), config, true)
+		if err != nil {
+			_ = r.Close(context.Background())
+			return nil, err
+		}
+		r.replicas = append(r.replicas, &replica{database: NewDatabase(pool), pool: pool})
+	}
+	if len(r.replicas) > 0 {
+		r.checks.Add(1)
+		go r.run(time.Duration(config.Database.ReplicaCheckInterval) * time.Second)
+	}
+	return r, nil
+}
+
+// Primary returns the primary, the writes always go to it.
+func (r *Router) Primary() *sqlx.DB {
+	return r.primary
+}
+
+// Read returns the database to read from in ctx.
+func (r *Router) Read(ctx context.Context) *sqlx.DB {
+	if len(r.replicas) == 0 || PrimaryFromContext(ctx) || r.wroteRecently(ctx) {
+		return r.primary
+	}
+	start := r.next.Add(1)
+	for i := range r.replicas {
+		replica := r.replicas[(start+uint64(i))%uint64(len(r.replicas))]
+		if replica.healthy.Load() {
+			return replica.database
+		}
+	}
+	return r.primary
+}
+
+// Wrote starts the read-your-writes window of the caller of ctx.
+func (r *Router) Wrote(ctx context.Context) {
+	caller := entity.CallerFromContext(ctx)
+	if caller == "" || len(r.replicas) == 0 {
+		return
+	}
+	r.mu.Lock()
+	r.writes[caller] = r.clock.Now()
+	r.mu.Unlock()
+}
+
+func (r *Router) wroteRecently(ctx context.Context) bool {
+	caller := entity.CallerFromContext(ctx)
+	if caller == "" {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	wrote, ok := r.writes[caller]
+	return ok && r.clock.Now().Sub(wrote) < r.window
+}
+
+func (r *Router) Close(_ context.Context) error {
+	r.stop.Do(func() {
+		close(r.done)
+	})
+	r.checks.Wait()
+	for _, replica := range r.replicas {
+		_ = replica.database.Close()
+		if replica.pool != nil {
+			_ = replica.pool.Close(context.Background())
+		}
+	}
+	return nil
+}
+
+func (r *Router) run(interval time.Duration) {
+	defer r.checks.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		r.check()
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check pings the replicas and forgets the writes out of the window.
+func (r *Router) check() {
+	for _, replica := range r.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), replicaPingTimeout)
+		err := replica.database.PingContext(ctx)
+		cancel()
+		healthy := err == nil
+		if replica.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		if healthy {
+			r.logger.Info("postgres replica is healthy")
+		} else {
+			r.logger.Warn("postgres replica is unhealthy", log.Error(err))
+		}
+	}
+	now := r.clock.Now()
+	r.mu.Lock()
+	for caller, wrote := range r.writes {
+		if now.Sub(wrote) >= r.window {
+			delete(r.writes, caller)
+		}
+	}
+	r.mu.Unlock()
+}
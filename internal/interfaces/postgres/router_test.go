@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jmoiron/sqlx"
+)
+
+func newMockReplica(t *testing.T, healthy bool) (*replica, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	r := &replica{database: sqlx.NewDb(db, "sqlmock")}
+	r.healthy.Store(healthy)
+	return r, mock
+}
+
+func TestRouter_Read(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClock := mock_clock.NewMockClock(ctrl)
+	primary, _, err := NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primary.Close()
+	first, _ := newMockReplica(t, true)
+	second, _ := newMockReplica(t, true)
+	down, _ := newMockReplica(t, false)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	caller := entity.WithCaller(context.Background(), "caller")
+	tests := []struct {
+		name     string
+		setup    func()
+		replicas []*replica
+		writes   map[string]time.Time
+		ctx      context.Context
+		want     []*sqlx.DB
+	}{
+		{
+			name:     "round robin",
+			setup:    func() {},
+			replicas: []*replica{first, down, second},
+			ctx:      context.Background(),
+			want:     []*sqlx.DB{second.database, second.database, first.database},
+		},
+		{
+			name:     "no healthy replica",
+			setup:    func() {},
+			replicas: []*replica{down},
+			ctx:      context.Background(),
+			want:     []*sqlx.DB{primary},
+		},
+		{
+			name:     "no replicas",
+			setup:    func() {},
+			replicas: nil,
+			ctx:      caller,
+			want:     []*sqlx.DB{primary},
+		},
+		{
+			name: "within the window",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now.Add(4 * time.Second))
+			},
+			replicas: []*replica{first},
+			writes:   map[string]time.Time{"caller": now},
+			ctx:      caller,
+			want:     []*sqlx.DB{primary},
+		},
+		{
+			name: "after the window",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now.Add(5 * time.Second))
+			},
+			replicas: []*replica{first},
+			writes:   map[string]time.Time{"caller": now},
+			ctx:      caller,
+			want:     []*sqlx.DB{first.database},
+		},
+		{
+			name:     "primary",
+			setup:    func() {},
+			replicas: []*replica{first},
+			ctx:      WithPrimary(context.Background()),
+			want:     []*sqlx.DB{primary},
+		},
+		{
+			name:     "another caller",
+			setup:    func() {},
+			replicas: []*replica{first},
+			writes:   map[string]time.Time{"caller": now},
+			ctx:      entity.WithCaller(context.Background(), "another"),
+			want:     []*sqlx.DB{first.database},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &Router{
+				primary:  primary,
+				replicas: tt.replicas,
+				window:   5 * time.Second,
+				clock:    mockClock,
+				writes:   tt.writes,
+			}
+			for i, want := range tt.want {
+				if got := r.Read(tt.ctx); got != want {
+					t.Errorf("Router.Read() #%d = %p, want %p", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRouter_Wrote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClock := mock_clock.NewMockClock(ctrl)
+	healthy, _ := newMockReplica(t, true)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClock.EXPECT().Now().Return(now)
+	r := &Router{replicas: []*replica{healthy}, clock: mockClock, writes: map[string]time.Time{}}
+	r.Wrote(context.Background())
+	r.Wrote(entity.WithCaller(context.Background(), "caller"))
+	if len(r.writes) != 1 || !r.writes["caller"].Equal(now) {
+		t.Errorf("Router.Wrote() writes = %v, want the caller's write at %v", r.writes, now)
+	}
+}
+
+func TestRouter_check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	recovered, recoveredMock := newMockReplica(t, false)
+	failed, failedMock := newMockReplica(t, true)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	recoveredMock.ExpectPing()
+	failedMock.ExpectPing().WillReturnError(errors.New("test error"))
+	logger.EXPECT().Info("postgres replica is healthy")
+	logger.EXPECT().Warn("postgres replica is unhealthy", gomock.Any())
+	mockClock.EXPECT().Now().Return(now)
+	r := &Router{
+		replicas: []*replica{recovered, failed},
+		window:   5 * time.Second,
+		clock:    mockClock,
+		logger:   logger,
+		writes: map[string]time.Time{
+			"recent":  now.Add(-time.Second),
+			"expired": now.Add(-5 * time.Second),
+		},
+	}
+	r.check()
+	if !recovered.healthy.Load() {
+		t.Error("Router.check() recovered replica is unhealthy")
+	}
+	if failed.healthy.Load() {
+		t.Error("Router.check() failed replica is healthy")
+	}
+	if _, ok := r.writes["expired"]; ok || len(r.writes) != 1 {
+		t.Errorf("Router.check() writes = %v, want the recent one only", r.writes)
+	}
+}
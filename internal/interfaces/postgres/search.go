@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+)
+
+// SearchConfiguration is the text search configuration of the companies search vector.
+const SearchConfiguration = "public.companies_search"
+
+const searchVectorColumn = `ALTER TABLE public.companies
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+            setweight(to_tsvector('public.companies_search', name), 'A') ||
+            setweight(to_tsvector('public.companies_search', description), 'B')
+        ) STORED`
+
+// ApplySearchLanguage builds the companies search vector for the language.
+// The stored vector is recreated when the language changes, which rewrites the companies table.
+func ApplySearchLanguage(ctx context.Context, database *sqlx.DB, language string) error {
+	if !isSearchLanguage(language) {
+		return errs.NewUnexpectedBehaviorError("unknown search language").WithParam("language", language)
+	}
+	var current sql.NullString
+	if err := database.GetContext(
+		ctx,
+		&current,
+		"SELECT obj_description($1::regconfig, 'pg_ts_config')",
+		SearchConfiguration,
+	); err != nil {
+		return errs.FromPostgresError(err)
+	}
+	if current.String == language {
+		return nil
+	}
+	dictionary := language + "_stem"
+	if language == "simple" {
+		dictionary = "simple"
+	}
+	statements := []string{
+		fmt.Sprintf(
+			"ALTER TEXT SEARCH CONFIGURATION %s ALTER MAPPING FOR asciiword, asciihword, hword_asciipart, word, hword, hword_part WITH unaccent, %s",
+			SearchConfiguration,
+			pgx.Identifier{dictionary}.Sanitize(),
+		),
+		fmt.Sprintf("COMMENT ON TEXT SEARCH CONFIGURATION %s IS %s", SearchConfiguration, quoteLiteral(language)),
+		"DROP INDEX IF EXISTS public.companies_search",
+		"ALTER TABLE public.companies DROP COLUMN search_vector",
+		searchVectorColumn,
+		"CREATE INDEX companies_search ON public.companies USING GIN (search_vector)",
+	}
+	tx, err := database.BeginTxx(ctx, nil)
+	if err != nil {
+		return errs.FromPostgresError(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return errs.FromPostgresError(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errs.FromPostgresError(err)
+	}
+	return nil
+}
+
+func isSearchLanguage(language string) bool {
+	for _, known := range entity.SearchLanguages {
+		if known == language {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteLiteral quotes the string as an SQL literal, with standard conforming strings.
+func quoteLiteral(literal string) string {
+	return "'" + strings.ReplaceAll(literal, "'", "''") + "'"
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/018bf/companies/internal/errs"
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestApplySearchLanguage(t *testing.T) {
+	db, mock, err := NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	current := regexp.QuoteMeta("SELECT obj_description($1::regconfig, 'pg_ts_config')")
+	tests := []struct {
+		name     string
+		setup    func()
+		language string
+		wantErr  error
+	}{
+		{
+			name: "unchanged",
+			setup: func() {
+				mock.ExpectQuery(current).
+					WithArgs(SearchConfiguration).
+					WillReturnRows(sqlmock.NewRows([]string{"obj_description"}).AddRow("english"))
+			},
+			language: "english",
+			wantErr:  nil,
+		},
+		{
+			name: "changed",
+			setup: func() {
+				mock.ExpectQuery(current).
+					WithArgs(SearchConfiguration).
+					WillReturnRows(sqlmock.NewRows([]string{"obj_description"}).AddRow("english"))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`WITH unaccent, "german_stem"`)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta("COMMENT ON TEXT SEARCH CONFIGURATION public.companies_search IS 'german'")).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DROP INDEX").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DROP COLUMN search_vector").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("ADD COLUMN search_vector").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("CREATE INDEX").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			language: "german",
+			wantErr:  nil,
+		},
+		{
+			name: "rebuild error",
+			setup: func() {
+				mock.ExpectQuery(current).
+					WithArgs(SearchConfiguration).
+					WillReturnRows(sqlmock.NewRows([]string{"obj_description"}).AddRow("english"))
+				mock.ExpectBegin()
+				mock.ExpectExec("ALTER TEXT SEARCH CONFIGURATION").WillReturnError(errors.New("test error"))
+				mock.ExpectRollback()
+			},
+			language: "simple",
+			wantErr:  errs.FromPostgresError(errors.New("test error")),
+		},
+		{
+			name:     "unknown language",
+			setup:    func() {},
+			language: "klingon",
+			wantErr:  errs.NewUnexpectedBehaviorError("unknown search language").WithParam("language", "klingon"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			if err := ApplySearchLanguage(ctx, db, tt.language); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ApplySearchLanguage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
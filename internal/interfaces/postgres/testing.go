@@ -1,6 +1,8 @@
 package postgres
 
 import (
+	"database/sql/driver"
+	"reflect"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -9,10 +11,20 @@ import (
 
 func NewMockPostgreSQL(t *testing.T) (*sqlx.DB, sqlmock.Sqlmock, error) {
 	t.Helper()
-	mockDB, mock, err := sqlmock.New()
+	mockDB, mock, err := sqlmock.New(sqlmock.ValueConverterOption(valueConverter{}))
 	if err != nil {
 		return nil, nil, err
 	}
 	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
 	return sqlxDB, mock, nil
 }
+
+// valueConverter passes the slices through as pgx does, which encodes the arrays itself.
+type valueConverter struct{}
+
+func (valueConverter) ConvertValue(v any) (driver.Value, error) {
+	if value := reflect.ValueOf(v); value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+		return v, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(v)
+}
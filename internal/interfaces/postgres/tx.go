@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	retryBackoffBase = 10 * time.Millisecond
+	retryBackoffMax  = time.Second
+)
+
+type txKey struct{}
+
+type afterCommitKey struct{}
+
+// afterCommit collects the functions to run once the transaction is committed.
+type afterCommit struct {
+	fns []func(ctx context.Context)
+}
+
+// Querier is what the repositories query with: the database or the transaction of the context.
+type Querier interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+}
+
+// TxFromContext returns the transaction started by TxManager in ctx.
+func TxFromContext(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok
+}
+
+// QuerierFromContext returns the transaction of ctx or the database outside of a transaction.
+func QuerierFromContext(ctx context.Context, database *sqlx.DB) Querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+	return database
+}
+
+// AfterCommit runs fn when the transaction of ctx is committed, fn is dropped if it's rolled back.
+// fn is passed ctx without the transaction, outside of a transaction it runs at once.
+func AfterCommit(ctx context.Context, fn func(ctx context.Context)) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommit)
+	if !ok {
+		fn(ctx)
+		return
+	}
+	hooks.fns = append(hooks.fns, fn)
+}
+
+// WithTimeout returns ctx canceled after timeout, a zero timeout keeps the deadline of ctx only.
+func WithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// TxManager runs the operations atomically and retries them when they may succeed on a retry,
+// see errs.IsRetryable.
+type TxManager struct {
+	database   *sqlx.DB
+	logger     log.Logger
+	maxRetries int
+	sleep      func(ctx context.Context, d time.Duration) error
+}
+
+func NewTxManager(database *sqlx.DB, config *configs.Config, logger log.Logger) *TxManager {
+	return &TxManager{
+		database:   database,
+		logger:     logger,
+		maxRetries: config.Database.MaxRetries,
+		sleep:      sleep,
+	}
+}
+
+// Transaction runs fn in a repeatable read transaction passed in the context and commits it when fn succeeds.
+// A concurrent update of the rows read by fn aborts the transaction, which is then retried from the start.
+// Within a transaction already, fn joins it and the outermost one is retried.
+func (m *TxManager) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	return m.Retry(ctx, func(ctx context.Context) error {
+		tx, err := m.database.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+		if err != nil {
+			return errs.FromPostgresError(err)
+		}
+		hooks := &afterCommit{}
+		txCtx := context.WithValue(context.WithValue(ctx, txKey{}, tx), afterCommitKey{}, hooks)
+		if err := fn(txCtx); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return errs.FromPostgresError(err)
+		}
+		for _, hook := range hooks.fns {
+			hook(ctx)
+		}
+		return nil
+	})
+}
+
+// Retry runs fn again with a growing backoff while it fails with a retryable error, at most the configured times.
+// Within a transaction fn runs once, the transaction is retried as a whole.
+func (m *TxManager) Retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	backoff := retryBackoffBase
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= m.maxRetries || !errs.IsRetryable(err) {
+			return err
+		}
+		m.logger.Warn("retrying database operation", log.Context(ctx), log.Error(err), log.Int("attempt", attempt+1))
+		if err := m.sleep(ctx, backoff); err != nil {
+			return errs.FromPostgresError(err)
+		}
+		backoff *= 2
+		if backoff > retryBackoffMax {
+			backoff = retryBackoffMax
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,207 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/errs"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestTxManager_Transaction(t *testing.T) {
+	db, mock, err := NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	aborted := errs.NewError(errs.ErrorCodeAborted, "Transaction is aborted by a concurrent one.")
+	tests := []struct {
+		name      string
+		setup     func()
+		results   []error
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name: "commit",
+			setup: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			results:   []error{nil},
+			wantCalls: 1,
+			wantErr:   nil,
+		},
+		{
+			name: "rollback",
+			setup: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			results:   []error{errs.NewEntityNotFound()},
+			wantCalls: 1,
+			wantErr:   errs.NewEntityNotFound(),
+		},
+		{
+			name: "retry the aborted transaction",
+			setup: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+				logger.EXPECT().Warn("retrying database operation", gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			results:   []error{aborted, nil},
+			wantCalls: 2,
+			wantErr:   nil,
+		},
+		{
+			name: "retries are exhausted",
+			setup: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+				logger.EXPECT().Warn("retrying database operation", gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+			},
+			results:   []error{aborted, aborted, aborted},
+			wantCalls: 3,
+			wantErr:   aborted,
+		},
+		{
+			name: "commit error",
+			setup: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit().WillReturnError(errors.New("test error"))
+			},
+			results:   []error{nil},
+			wantCalls: 1,
+			wantErr:   errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			m := &TxManager{
+				database:   db,
+				logger:     logger,
+				maxRetries: 2,
+				sleep:      func(context.Context, time.Duration) error { return nil },
+			}
+			calls, committed := 0, 0
+			err := m.Transaction(context.Background(), func(ctx context.Context) error {
+				if _, ok := TxFromContext(ctx); !ok {
+					t.Error("TxManager.Transaction() has no transaction in the context")
+				}
+				AfterCommit(ctx, func(ctx context.Context) {
+					if _, ok := TxFromContext(ctx); ok {
+						t.Error("AfterCommit() passes the committed transaction in the context")
+					}
+					committed++
+				})
+				// A nested transaction joins the outer one.
+				if err := m.Transaction(ctx, func(context.Context) error { return nil }); err != nil {
+					t.Errorf("TxManager.Transaction() nested error = %v", err)
+				}
+				calls++
+				return tt.results[calls-1]
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TxManager.Transaction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("TxManager.Transaction() calls = %v, want %v", calls, tt.wantCalls)
+			}
+			// The hooks of the rolled back attempts are dropped.
+			wantCommitted := 0
+			if tt.wantErr == nil {
+				wantCommitted = 1
+			}
+			if committed != wantCommitted {
+				t.Errorf("TxManager.Transaction() after commit calls = %v, want %v", committed, wantCommitted)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestAfterCommit(t *testing.T) {
+	called := false
+	AfterCommit(context.Background(), func(context.Context) { called = true })
+	if !called {
+		t.Error("AfterCommit() outside of a transaction isn't run at once")
+	}
+}
+
+func TestTxManager_Retry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	unavailable := errs.NewError(errs.ErrorCodeUnavailable, "Database is unavailable.")
+	tests := []struct {
+		name      string
+		setup     func()
+		results   []error
+		sleepErr  error
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name: "lost connection",
+			setup: func() {
+				logger.EXPECT().Warn("retrying database operation", gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			results:   []error{unavailable, nil},
+			wantCalls: 2,
+			wantErr:   nil,
+		},
+		{
+			name:      "not retryable",
+			setup:     func() {},
+			results:   []error{errs.NewEntityNotFound()},
+			wantCalls: 1,
+			wantErr:   errs.NewEntityNotFound(),
+		},
+		{
+			name: "canceled while waiting",
+			setup: func() {
+				logger.EXPECT().Warn("retrying database operation", gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			results:   []error{unavailable},
+			sleepErr:  context.DeadlineExceeded,
+			wantCalls: 1,
+			wantErr:   errs.FromPostgresError(context.DeadlineExceeded),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			m := &TxManager{
+				logger:     logger,
+				maxRetries: 3,
+				sleep:      func(context.Context, time.Duration) error { return tt.sleepErr },
+			}
+			calls := 0
+			err := m.Retry(context.Background(), func(context.Context) error {
+				calls++
+				return tt.results[calls-1]
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TxManager.Retry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("TxManager.Retry() calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
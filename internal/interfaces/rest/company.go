@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
 	"github.com/018bf/companies/pkg/log"
+	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
 )
 
+const headerLastEventID = "Last-Event-ID"
+
 //go:generate mockgen -source=company.go -package=rest -destination=company_mock.go
 
 type companyInterceptor interface {
@@ -18,7 +23,7 @@ type companyInterceptor interface {
 		ctx context.Context,
 		filter *entity.CompanyFilter,
 		token *entity.Token,
-	) ([]*entity.Company, uint64, error) // deprecated
+	) (*entity.CompanyList, error) // deprecated
 	Update(
 		ctx context.Context,
 		update *entity.CompanyUpdate,
@@ -30,6 +35,24 @@ type companyInterceptor interface {
 		token *entity.Token,
 	) (*entity.Company, error)
 	Delete(ctx context.Context, id entity.UUID, token *entity.Token) error
+	Watch(
+		ctx context.Context,
+		filter *entity.CompanyFilter,
+		position *uint64,
+		stream entity.CompanyChangeStream,
+		token *entity.Token,
+	) error
+	ListChanges(ctx context.Context, since string, token *entity.Token) (*entity.CompanyChangeList, error)
+	Suggest(
+		ctx context.Context,
+		suggest *entity.CompanySuggest,
+		token *entity.Token,
+	) ([]*entity.CompanySuggestion, error)
+	Stats(
+		ctx context.Context,
+		filter *entity.CompanyStatsFilter,
+		token *entity.Token,
+	) (*entity.CompanyStats, error)
 }
 
 type CompanyHandler struct {
@@ -48,6 +71,10 @@ func (h *CompanyHandler) Register(router *gin.RouterGroup) {
 	group := router.Group("/companies")
 	group.POST("/", h.Create)
 	group.GET("/", h.List)
+	group.GET("/changes", h.ListChanges)
+	group.GET("/suggest", h.Suggest)
+	group.GET("/stats", h.Stats)
+	group.GET("/watch", h.Watch)
 	group.GET("/:id", h.Get)
 	group.PATCH("/:id", h.Update)
 	group.DELETE("/:id", h.Delete)
@@ -87,6 +114,9 @@ func (h *CompanyHandler) Create(ctx *gin.Context) {
 // @Tags         Company
 // @Produce      json
 // @Param        filter  query   entity.CompanyFilter false "Company filter"
+// @Description  With facets requested responds with entity.CompanyList, the page and the facets, instead.
+// @Description  The count header is omitted when the filter skips the count,
+// @Description  the count-estimated header is set when the count is the estimate of the planner.
 // @Success      200  {array}  entity.Company
 // @Failure      400   {object}  errs.Error
 // @Failure      401   {object}  errs.Error
@@ -102,7 +132,7 @@ func (h *CompanyHandler) List(ctx *gin.Context) {
 	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
 	filter := &entity.CompanyFilter{}
 	_ = ctx.Bind(filter)
-	listCompanies, count, err := h.companyInterceptor.List(
+	list, err := h.companyInterceptor.List(
 		ctx.Request.Context(),
 		filter,
 		token,
@@ -111,8 +141,191 @@ func (h *CompanyHandler) List(ctx *gin.Context) {
 		decodeError(ctx, err)
 		return
 	}
-	ctx.Header("count", fmt.Sprint(count))
-	ctx.JSON(http.StatusOK, listCompanies)
+	if list.Count != nil {
+		ctx.Header("count", fmt.Sprint(*list.Count))
+	}
+	if list.Estimated {
+		ctx.Header("count-estimated", "true")
+	}
+	// The plain array is kept for the clients that don't request the facets.
+	if list.Facets != nil {
+		ctx.JSON(http.StatusOK, list)
+		return
+	}
+	ctx.JSON(http.StatusOK, list.Items)
+}
+
+// ListChanges   godoc
+// @Summary      List Company changes
+// @Description  Returns the changed and deleted companies after the continuation token in commit order.
+// @Description  Each company is listed once with its latest state, deleted ones as tombstones.
+// @Description  Pass the returned token to continue; an expired token requires a sync from the start.
+// @Tags         Company
+// @Produce      json
+// @Param        since  query   string false "Continuation token"
+// @Success      200  {object}  entity.CompanyChangeList
+// @Failure      400   {object}  errs.Error
+// @Failure      401   {object}  errs.Error
+// @Failure      403   {object}  errs.Error
+// @Failure      500   {object}  errs.Error
+// @Router       /companies/changes [get]
+func (h *CompanyHandler) ListChanges(ctx *gin.Context) {
+	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
+	list, err := h.companyInterceptor.ListChanges(ctx.Request.Context(), ctx.Query("since"), token)
+	if err != nil {
+		decodeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// Stats         godoc
+// @Summary      Company statistics
+// @Description  Returns the counts of the filtered companies by type and registration,
+// @Description  the histogram of their amount of employees and the number created per interval.
+// @Tags         Company
+// @Produce      json
+// @Param        filter  query   entity.CompanyStatsFilter false "Company stats filter"
+// @Success      200  {object}  entity.CompanyStats
+// @Failure      400   {object}  errs.Error
+// @Failure      401   {object}  errs.Error
+// @Failure      403   {object}  errs.Error
+// @Failure      500   {object}  errs.Error
+// @Router       /companies/stats [get]
+func (h *CompanyHandler) Stats(ctx *gin.Context) {
+	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
+	filter := &entity.CompanyStatsFilter{}
+	_ = ctx.Bind(filter)
+	stats, err := h.companyInterceptor.Stats(ctx.Request.Context(), filter, token)
+	if err != nil {
+		decodeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// Suggest       godoc
+// @Summary      Suggest Company names
+// @Description  Returns the companies whose names start with the query or are similar to it, for autocomplete.
+// @Description  The prefix matches go first, then the most similar names.
+// @Tags         Company
+// @Produce      json
+// @Param        q  query   string true "Typed query"
+// @Param        limit  query   int false "Maximum number of suggestions"
+// @Success      200  {array}  entity.CompanySuggestion
+// @Failure      400   {object}  errs.Error
+// @Failure      401   {object}  errs.Error
+// @Failure      403   {object}  errs.Error
+// @Failure      500   {object}  errs.Error
+// @Router       /companies/suggest [get]
+func (h *CompanyHandler) Suggest(ctx *gin.Context) {
+	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
+	suggest := &entity.CompanySuggest{Query: ctx.Query("q")}
+	if value := ctx.Query("limit"); value != "" {
+		limit, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			decodeError(ctx, errs.NewInvalidParameter("Invalid limit.").WithParam("limit", value))
+			return
+		}
+		suggest.Limit = &limit
+	}
+	suggestions, err := h.companyInterceptor.Suggest(ctx.Request.Context(), suggest, token)
+	if err != nil {
+		decodeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, suggestions)
+}
+
+// Watch         godoc
+// @Summary      Watch Company changes
+// @Description  Streams the changes of the companies matching the filter as Server-Sent Events.
+// @Description  Each event has the change position as id, so a reconnecting client resumes with the Last-Event-ID header.
+// @Description  Heartbeat events are sent periodically with the last position.
+// @Tags         Company
+// @Produce      text/event-stream
+// @Param        filter  query   entity.CompanyFilter false "Company filter"
+// @Param        position  query   int false "Resume after the position"
+// @Param        Last-Event-ID  header   int false "Resume after the position"
+// @Success      200  {object}  entity.CompanyChange
+// @Failure      400   {object}  errs.Error
+// @Failure      401   {object}  errs.Error
+// @Failure      403   {object}  errs.Error
+// @Failure      500   {object}  errs.Error
+// @Router       /companies/watch [get]
+func (h *CompanyHandler) Watch(ctx *gin.Context) {
+	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
+	filter := &entity.CompanyFilter{}
+	_ = ctx.Bind(filter)
+	position, err := watchPosition(ctx)
+	if err != nil {
+		decodeError(ctx, err)
+		return
+	}
+	if err := h.companyInterceptor.Watch(
+		ctx.Request.Context(),
+		filter,
+		position,
+		&changeStream{ctx: ctx},
+		token,
+	); err != nil {
+		if ctx.Writer.Written() {
+			h.logger.Error("watch failed", log.Context(ctx.Request.Context()), log.Error(err))
+			return
+		}
+		decodeError(ctx, err)
+	}
+}
+
+// watchPosition returns the position to resume from, the Last-Event-ID header takes precedence over the query.
+func watchPosition(ctx *gin.Context) (*uint64, error) {
+	value := ctx.GetHeader(headerLastEventID)
+	if value == "" {
+		value = ctx.Query("position")
+	}
+	if value == "" {
+		return nil, nil
+	}
+	position, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, errs.NewInvalidParameter("Invalid position.").WithParam("position", value)
+	}
+	return &position, nil
+}
+
+// changeStream writes the watched changes as Server-Sent Events.
+type changeStream struct {
+	ctx *gin.Context
+}
+
+func (s *changeStream) Send(change *entity.CompanyChange) error {
+	return s.write(sse.Event{
+		Id:    strconv.FormatUint(change.Position, 10),
+		Event: string(change.Operation),
+		Data:  change,
+	})
+}
+
+func (s *changeStream) Heartbeat(position uint64) error {
+	return s.write(sse.Event{
+		Event: "heartbeat",
+		Data:  gin.H{"position": position},
+	})
+}
+
+func (s *changeStream) write(event sse.Event) error {
+	if !s.ctx.Writer.Written() {
+		header := s.ctx.Writer.Header()
+		header.Set("Cache-Control", "no-cache")
+		header.Set("Connection", "keep-alive")
+		header.Set("X-Accel-Buffering", "no")
+		s.ctx.Status(http.StatusOK)
+	}
+	if err := event.Render(s.ctx.Writer); err != nil {
+		return err
+	}
+	s.ctx.Writer.Flush()
+	return nil
 }
 
 // Get           godoc
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"github.com/018bf/companies/pkg/utils"
 	"io"
 	"net/http"
@@ -293,6 +294,18 @@ func TestCompanyHandler_List(t *testing.T) {
 	filter := &entity.CompanyFilter{}
 	listCompanies := []*entity.Company{mock_models.NewCompany(t)}
 	listCompaniesjson, _ := json.Marshal(listCompanies)
+	facetedList := &entity.CompanyList{
+		Items: listCompanies,
+		Count: utils.Pointer(uint64(1)),
+		Facets: &entity.CompanyFacets{
+			Types:      []*entity.CompanyTypeFacet{{Type: entity.CompanyTypeNonProfit, Count: 1}},
+			Registered: []*entity.CompanyRegisteredFacet{{Registered: true, Count: 1}},
+		},
+	}
+	facetedListJSON, _ := json.Marshal(facetedList)
+	facetedRequest := httptest.NewRequest(http.MethodGet, "/companies?facets=type&facets=registered", nil)
+	estimatedRequest := httptest.NewRequest(http.MethodGet, "/companies?count=estimated", nil)
+	skippedRequest := httptest.NewRequest(http.MethodGet, "/companies?count=none", nil)
 	type fields struct {
 		companyInterceptor companyInterceptor
 		logger             log.Logger
@@ -307,13 +320,14 @@ func TestCompanyHandler_List(t *testing.T) {
 		args       args
 		wantStatus int
 		wantBody   *bytes.Buffer
+		wantHeader http.Header
 	}{
 		{
 			name: "ok",
 			setup: func() {
 				mockCompanyInterceptor.EXPECT().
 					List(gomock.Any(), filter, utils.Pointer(entity.Token("good token"))).
-					Return(listCompanies, uint64(len(listCompanies)), nil)
+					Return(&entity.CompanyList{Items: listCompanies, Count: utils.Pointer(uint64(len(listCompanies)))}, nil)
 			},
 			fields: fields{
 				companyInterceptor: mockCompanyInterceptor,
@@ -326,13 +340,88 @@ func TestCompanyHandler_List(t *testing.T) {
 			},
 			wantBody:   bytes.NewBuffer(listCompaniesjson),
 			wantStatus: http.StatusOK,
+			wantHeader: http.Header{"Count": {"1"}},
+		},
+		{
+			name: "estimated count",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					List(
+						gomock.Any(),
+						&entity.CompanyFilter{Count: utils.Pointer(entity.CompanyCountEstimated)},
+						utils.Pointer(entity.Token("good token")),
+					).
+					Return(&entity.CompanyList{Items: listCompanies, Count: utils.Pointer(uint64(1000)), Estimated: true}, nil)
+			},
+			fields: fields{
+				companyInterceptor: mockCompanyInterceptor,
+				logger:             logger,
+			},
+			args: args{
+				request: estimatedRequest.WithContext(
+					context.WithValue(context.Background(), TokenContextKey, utils.Pointer(entity.Token("good token"))),
+				),
+			},
+			wantBody:   bytes.NewBuffer(listCompaniesjson),
+			wantStatus: http.StatusOK,
+			wantHeader: http.Header{"Count": {"1000"}, "Count-Estimated": {"true"}},
+		},
+		{
+			name: "count skipped",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					List(
+						gomock.Any(),
+						&entity.CompanyFilter{Count: utils.Pointer(entity.CompanyCountNone)},
+						utils.Pointer(entity.Token("good token")),
+					).
+					Return(&entity.CompanyList{Items: listCompanies}, nil)
+			},
+			fields: fields{
+				companyInterceptor: mockCompanyInterceptor,
+				logger:             logger,
+			},
+			args: args{
+				request: skippedRequest.WithContext(
+					context.WithValue(context.Background(), TokenContextKey, utils.Pointer(entity.Token("good token"))),
+				),
+			},
+			wantBody:   bytes.NewBuffer(listCompaniesjson),
+			wantStatus: http.StatusOK,
+			wantHeader: http.Header{},
+		},
+		{
+			name: "facets",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					List(
+						gomock.Any(),
+						&entity.CompanyFilter{
+							Facets: []entity.CompanyFacet{entity.CompanyFacetType, entity.CompanyFacetRegistered},
+						},
+						utils.Pointer(entity.Token("good token")),
+					).
+					Return(facetedList, nil)
+			},
+			fields: fields{
+				companyInterceptor: mockCompanyInterceptor,
+				logger:             logger,
+			},
+			args: args{
+				request: facetedRequest.WithContext(
+					context.WithValue(context.Background(), TokenContextKey, utils.Pointer(entity.Token("good token"))),
+				),
+			},
+			wantBody:   bytes.NewBuffer(facetedListJSON),
+			wantStatus: http.StatusOK,
+			wantHeader: http.Header{"Count": {"1"}},
 		},
 		{
 			name: "permission denied",
 			setup: func() {
 				mockCompanyInterceptor.EXPECT().
 					List(gomock.Any(), filter, utils.Pointer(entity.Token("good token"))).
-					Return(nil, uint64(0), errs.NewPermissionDenied())
+					Return(nil, errs.NewPermissionDenied())
 			},
 			fields: fields{
 				companyInterceptor: mockCompanyInterceptor,
@@ -366,6 +455,312 @@ func TestCompanyHandler_List(t *testing.T) {
 				t.Errorf("List() gotBody = %v, wantBody %v", w.Body, tt.wantBody)
 				return
 			}
+			for key := range tt.wantHeader {
+				if got := w.Header().Values(key); !reflect.DeepEqual(got, tt.wantHeader[key]) {
+					t.Errorf("List() header %s = %v, want %v", key, got, tt.wantHeader[key])
+				}
+			}
+			if tt.wantHeader != nil && tt.wantHeader.Get("Count") == "" && w.Header().Get("Count") != "" {
+				t.Errorf("List() header Count = %v, want none", w.Header().Get("Count"))
+			}
+		})
+	}
+}
+
+func TestCompanyHandler_ListChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyInterceptor := NewMockcompanyInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	token := utils.Pointer(entity.Token("good token"))
+	list := &entity.CompanyChangeList{
+		Items:   []*entity.CompanyChange{mock_models.NewCompanyChange(t)},
+		Token:   "next",
+		HasMore: true,
+	}
+	listJSON, _ := json.Marshal(list)
+	request := httptest.NewRequest(http.MethodGet, "/companies/changes?since=first", nil)
+	request = request.WithContext(context.WithValue(request.Context(), TokenContextKey, token))
+	tests := []struct {
+		name       string
+		setup      func()
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					ListChanges(gomock.Any(), "first", token).
+					Return(list, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   string(listJSON),
+		},
+		{
+			name: "expired token",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					ListChanges(gomock.Any(), "first", token).
+					Return(nil, errs.NewError(errs.ErrorCodeFailedPrecondition, "Change token has expired."))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   errs.NewError(errs.ErrorCodeFailedPrecondition, "Change token has expired.").Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			h := &CompanyHandler{
+				companyInterceptor: mockCompanyInterceptor,
+				logger:             logger,
+			}
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = request
+			h.ListChanges(ctx)
+			if w.Code != tt.wantStatus {
+				t.Errorf("ListChanges() gotStatus = %v, wantStatus %v", w.Code, tt.wantStatus)
+				return
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("ListChanges() gotBody = %v, wantBody %v", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestCompanyHandler_Stats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyInterceptor := NewMockcompanyInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	token := utils.Pointer(entity.Token("good token"))
+	stats := &entity.CompanyStats{
+		Total:  2,
+		Groups: []*entity.CompanyStatsGroup{{Type: entity.CompanyTypeNonProfit, Registered: false, Count: 2}},
+	}
+	statsJSON, _ := json.Marshal(stats)
+	filter := &entity.CompanyStatsFilter{
+		CompanyFilter:   entity.CompanyFilter{Registered: utils.Pointer(false)},
+		EmployeeBuckets: []int{10, 100},
+		Interval:        utils.Pointer(entity.StatsIntervalDay),
+	}
+	request := httptest.NewRequest(
+		http.MethodGet,
+		"/companies/stats?registered=false&employee_buckets=10&employee_buckets=100&interval=day",
+		nil,
+	)
+	request = request.WithContext(context.WithValue(request.Context(), TokenContextKey, token))
+	tests := []struct {
+		name       string
+		setup      func()
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().Stats(gomock.Any(), filter, token).Return(stats, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   string(statsJSON),
+		},
+		{
+			name: "interceptor error",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().Stats(gomock.Any(), filter, token).Return(nil, errs.NewPermissionDenied())
+			},
+			wantStatus: http.StatusForbidden,
+			wantBody:   errs.NewPermissionDenied().Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			h := &CompanyHandler{
+				companyInterceptor: mockCompanyInterceptor,
+				logger:             logger,
+			}
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = request
+			h.Stats(ctx)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Stats() gotStatus = %v, wantStatus %v", w.Code, tt.wantStatus)
+				return
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("Stats() gotBody = %v, wantBody %v", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestCompanyHandler_Suggest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyInterceptor := NewMockcompanyInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	token := utils.Pointer(entity.Token("good token"))
+	suggestions := []*entity.CompanySuggestion{mock_models.NewCompanySuggestion(t)}
+	suggestionsJSON, _ := json.Marshal(suggestions)
+	tests := []struct {
+		name       string
+		setup      func()
+		target     string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					Suggest(gomock.Any(), &entity.CompanySuggest{Query: "acm", Limit: utils.Pointer(uint64(5))}, token).
+					Return(suggestions, nil)
+			},
+			target:     "/companies/suggest?q=acm&limit=5",
+			wantStatus: http.StatusOK,
+			wantBody:   string(suggestionsJSON),
+		},
+		{
+			name:       "invalid limit",
+			setup:      func() {},
+			target:     "/companies/suggest?q=acm&limit=many",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   errs.NewInvalidParameter("Invalid limit.").WithParam("limit", "many").Error(),
+		},
+		{
+			name: "interceptor error",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					Suggest(gomock.Any(), &entity.CompanySuggest{Query: "acm"}, token).
+					Return(nil, errs.NewPermissionDenied())
+			},
+			target:     "/companies/suggest?q=acm",
+			wantStatus: http.StatusForbidden,
+			wantBody:   errs.NewPermissionDenied().Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			h := &CompanyHandler{
+				companyInterceptor: mockCompanyInterceptor,
+				logger:             logger,
+			}
+			request := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			request = request.WithContext(context.WithValue(request.Context(), TokenContextKey, token))
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = request
+			h.Suggest(ctx)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Suggest() gotStatus = %v, wantStatus %v", w.Code, tt.wantStatus)
+				return
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("Suggest() gotBody = %v, wantBody %v", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestCompanyHandler_Watch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCompanyInterceptor := NewMockcompanyInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	token := utils.Pointer(entity.Token("good token"))
+	filter := &entity.CompanyFilter{}
+	change := mock_models.NewCompanyChange(t)
+	changeJSON, _ := json.Marshal(change)
+	newRequest := func(target string, lastEventID string) *http.Request {
+		request := httptest.NewRequest(http.MethodGet, target, nil)
+		if lastEventID != "" {
+			request.Header.Set("Last-Event-ID", lastEventID)
+		}
+		return request.WithContext(context.WithValue(request.Context(), TokenContextKey, token))
+	}
+	tests := []struct {
+		name       string
+		setup      func()
+		request    *http.Request
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockCompanyInterceptor.EXPECT().
+					Watch(gomock.Any(), filter, utils.Pointer(uint64(7)), gomock.Any(), token).
+					DoAndReturn(func(
+						_ context.Context,
+						_ *entity.CompanyFilter,
+						_ *uint64,
+						stream entity.CompanyChangeStream,
+						_ *entity.Token,
+					) error {
+						if err := stream.Send(change); err != nil {
+							return err
+						}
+						return stream.Heartbeat(change.Position)
+					})
+			},
+			request:    newRequest("/companies/watch?position=3", "7"),
+			wantStatus: http.StatusOK,
+			wantBody: fmt.Sprintf(
+				"id:%d\nevent:%s\ndata:%s\n\nevent:heartbeat\ndata:{\"position\":%d}\n\n",
+				change.Position,
+				change.Operation,
+				changeJSON,
+				change.Position,
+			),
+		},
+		{
+			name:       "invalid position",
+			setup:      func() {},
+			request:    newRequest("/companies/watch?position=first", ""),
+			wantStatus: http.StatusBadRequest,
+			wantBody: errs.NewInvalidParameter("Invalid position.").
+				WithParam("position", "first").
+				Error(),
+		},
+		{
+			name: "permission denied",
+			setup: func() {
+				var position *uint64
+				mockCompanyInterceptor.EXPECT().
+					Watch(gomock.Any(), filter, position, gomock.Any(), token).
+					Return(errs.NewPermissionDenied())
+			},
+			request:    newRequest("/companies/watch", ""),
+			wantStatus: http.StatusForbidden,
+			wantBody:   errs.NewPermissionDenied().Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			h := &CompanyHandler{
+				companyInterceptor: mockCompanyInterceptor,
+				logger:             logger,
+			}
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = tt.request
+			h.Watch(ctx)
+			if w.Code != tt.wantStatus {
+				t.Errorf("Watch() gotStatus = %v, wantStatus %v", w.Code, tt.wantStatus)
+				return
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("Watch() gotBody = %v, wantBody %v", w.Body.String(), tt.wantBody)
+			}
 		})
 	}
 }
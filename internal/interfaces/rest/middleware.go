@@ -11,6 +11,7 @@ import (
 //go:generate mockgen -source=middleware.go -package=rest -destination=middleware_mock.go
 type authInterceptor interface {
 	ValidateToken(ctx context.Context, token *entity.Token) error
+	GetSubject(ctx context.Context, token *entity.Token) (string, error)
 }
 
 type AuthMiddleware struct {
@@ -33,6 +34,12 @@ func (m *AuthMiddleware) Middleware() gin.HandlerFunc {
 				decodeError(c, err)
 				return
 			}
+			subject, err := m.authService.GetSubject(ctx, token)
+			if err != nil {
+				decodeError(c, err)
+				return
+			}
+			ctx = entity.WithCaller(ctx, subject)
 		}
 		ctx = context.WithValue(ctx, TokenContextKey, token)
 		c.Request = c.Request.WithContext(ctx)
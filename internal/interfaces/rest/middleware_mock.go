@@ -8,7 +8,7 @@ import (
 	context "context"
 	reflect "reflect"
 
-	models "github.com/018bf/companies/internal/entity"
+	entity "github.com/018bf/companies/internal/entity"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -35,8 +35,23 @@ func (m *MockauthInterceptor) EXPECT() *MockauthInterceptorMockRecorder {
 	return m.recorder
 }
 
+// GetSubject mocks base method.
+func (m *MockauthInterceptor) GetSubject(ctx context.Context, token *entity.Token) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubject", ctx, token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubject indicates an expected call of GetSubject.
+func (mr *MockauthInterceptorMockRecorder) GetSubject(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubject", reflect.TypeOf((*MockauthInterceptor)(nil).GetSubject), ctx, token)
+}
+
 // ValidateToken mocks base method.
-func (m *MockauthInterceptor) ValidateToken(ctx context.Context, token *models.Token) error {
+func (m *MockauthInterceptor) ValidateToken(ctx context.Context, token *entity.Token) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ValidateToken", ctx, token)
 	ret0, _ := ret[0].(error)
@@ -43,6 +43,7 @@ func NewServer(
 	config *configs.Config,
 	authMiddleware *AuthMiddleware,
 	companyHandler *CompanyHandler,
+	webhookHandler *WebhookHandler,
 ) *Server {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
@@ -60,6 +61,7 @@ func NewServer(
 	})
 	apiV1 := router.Group("api").Group("v1")
 	companyHandler.Register(apiV1)
+	webhookHandler.Register(apiV1)
 	return &Server{
 		router: router,
 		config: config,
@@ -0,0 +1,245 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/gin-gonic/gin"
+)
+
+//go:generate mockgen -source=webhook.go -package=rest -destination=webhook_mock.go
+
+type webhookInterceptor interface {
+	Get(ctx context.Context, id entity.UUID, token *entity.Token) (*entity.Webhook, error)
+	List(
+		ctx context.Context,
+		filter *entity.WebhookFilter,
+		token *entity.Token,
+	) ([]*entity.Webhook, uint64, error)
+	Update(
+		ctx context.Context,
+		update *entity.WebhookUpdate,
+		token *entity.Token,
+	) (*entity.Webhook, error)
+	Create(
+		ctx context.Context,
+		create *entity.WebhookCreate,
+		token *entity.Token,
+	) (*entity.Webhook, error)
+	Delete(ctx context.Context, id entity.UUID, token *entity.Token) error
+	ListDeliveries(
+		ctx context.Context,
+		filter *entity.WebhookDeliveryFilter,
+		token *entity.Token,
+	) ([]*entity.WebhookDelivery, uint64, error)
+	ReplayDelivery(ctx context.Context, id entity.UUID, token *entity.Token) (*entity.WebhookDelivery, error)
+}
+
+type WebhookHandler struct {
+	webhookInterceptor webhookInterceptor
+	logger             log.Logger
+}
+
+func NewWebhookHandler(
+	webhookInterceptor webhookInterceptor,
+	logger log.Logger,
+) *WebhookHandler {
+	return &WebhookHandler{webhookInterceptor: webhookInterceptor, logger: logger}
+}
+
+func (h *WebhookHandler) Register(router *gin.RouterGroup) {
+	group := router.Group("/webhooks")
+	group.POST("/", h.Create)
+	group.GET("/", h.List)
+	group.GET("/:id", h.Get)
+	group.PATCH("/:id", h.Update)
+	group.DELETE("/:id", h.Delete)
+	deliveries := router.Group("/webhook-deliveries")
+	deliveries.GET("/", h.ListDeliveries)
+	deliveries.POST("/:id/replay", h.ReplayDelivery)
+}
+
+// Create        godoc
+// @Summary      Store a new Webhook
+// @Description  Subscribes an endpoint to company events. Return saved JSON.
+// @Tags         Webhook
+// @Produce      json
+// @Param        Webhook  body   entity.WebhookCreate  true  "Webhook JSON"
+// @Success      201   {object}  entity.Webhook
+// @Failure      400   {object}  errs.Error
+// @Failure      401   {object}  errs.Error
+// @Failure      403   {object}  errs.Error
+// @Failure      405   {object}  errs.Error
+// @Failure      500   {object}  errs.Error
+// @Failure      503   {object}  errs.Error
+// @Router       /webhooks/ [post]
+func (h *WebhookHandler) Create(ctx *gin.Context) {
+	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
+	create := &entity.WebhookCreate{}
+	_ = ctx.Bind(create)
+	webhook, err := h.webhookInterceptor.Create(ctx.Request.Context(), create, token)
+	if err != nil {
+		decodeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, webhook)
+}
+
+// List          godoc
+// @Summary      List Webhook array
+// @Description  Responds with the list of Webhook as JSON.
+// @Tags         Webhook
+// @Produce      json
+// @Param        filter  query   entity.WebhookFilter false "Webhook filter"
+// @Success      200  {array}  entity.Webhook
+// @Failure      400   {object}  errs.Error
+// @Failure      401   {object}  errs.Error
+// @Failure      403   {object}  errs.Error
+// @Failure      405   {object}  errs.Error
+// @Failure      500   {object}  errs.Error
+// @Failure      503   {object}  errs.Error
+// @Router       /webhooks [get]
+func (h *WebhookHandler) List(ctx *gin.Context) {
+	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
+	filter := &entity.WebhookFilter{}
+	_ = ctx.Bind(filter)
+	webhooks, count, err := h.webhookInterceptor.List(ctx.Request.Context(), filter, token)
+	if err != nil {
+		decodeError(ctx, err)
+		return
+	}
+	ctx.Header("count", fmt.Sprint(count))
+	ctx.JSON(http.StatusOK, webhooks)
+}
+
+// Get           godoc
+// @Summary      Get single Webhook by UUID
+// @Description  Returns the Webhook whose UUID value matches the UUID.
+// @Tags         Webhook
+// @Produce      json
+// @Param        uuid  path      string  true  "search Webhook by UUID"
+// @Success      200  {object}  entity.Webhook
+// @Failure      400   {object}  errs.Error
+// @Failure      401   {object}  errs.Error
+// @Failure      403   {object}  errs.Error
+// @Failure      404   {object}  errs.Error
+// @Failure      405   {object}  errs.Error
+// @Failure      500   {object}  errs.Error
+// @Failure      503   {object}  errs.Error
+// @Router       /webhooks/{uuid} [get]
+func (h *WebhookHandler) Get(ctx *gin.Context) {
+	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
+	webhook, err := h.webhookInterceptor.Get(ctx.Request.Context(), entity.UUID(ctx.Param("id")), token)
+	if err != nil {
+		decodeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+// Update        godoc
+// @Summary      Update Webhook by UUID
+// @Description  Returns the updated Webhook. Activating a disabled Webhook resets its failures.
+// @Tags         Webhook
+// @Produce      json
+// @Param        uuid  path      string  true  "update Webhook by UUID"
+// @Param        Webhook  body   entity.WebhookUpdate  true  "Webhook JSON"
+// @Success      200  {object}  entity.Webhook
+// @Failure      400   {object}  errs.Error
+// @Failure      401   {object}  errs.Error
+// @Failure      403   {object}  errs.Error
+// @Failure      404   {object}  errs.Error
+// @Failure      405   {object}  errs.Error
+// @Failure      500   {object}  errs.Error
+// @Failure      503   {object}  errs.Error
+// @Router       /webhooks/{uuid} [PATCH]
+func (h *WebhookHandler) Update(ctx *gin.Context) {
+	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
+	update := &entity.WebhookUpdate{}
+	_ = ctx.Bind(update)
+	update.ID = entity.UUID(ctx.Param("id"))
+	webhook, err := h.webhookInterceptor.Update(ctx.Request.Context(), update, token)
+	if err != nil {
+		decodeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, webhook)
+}
+
+// Delete        godoc
+// @Summary      Delete single Webhook by UUID
+// @Description  Delete the Webhook whose UUID value matches the UUID along with its deliveries.
+// @Tags         Webhook
+// @Param        uuid  path      string  true  "delete Webhook by UUID"
+// @Success      204
+// @Failure      400   {object}  errs.Error
+// @Failure      401   {object}  errs.Error
+// @Failure      403   {object}  errs.Error
+// @Failure      404   {object}  errs.Error
+// @Failure      405   {object}  errs.Error
+// @Failure      500   {object}  errs.Error
+// @Failure      503   {object}  errs.Error
+// @Router       /webhooks/{uuid} [delete]
+func (h *WebhookHandler) Delete(ctx *gin.Context) {
+	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
+	if err := h.webhookInterceptor.Delete(ctx.Request.Context(), entity.UUID(ctx.Param("id")), token); err != nil {
+		decodeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+// ListDeliveries godoc
+// @Summary      List WebhookDelivery array
+// @Description  Responds with the delivery log, newest first.
+// @Tags         Webhook
+// @Produce      json
+// @Param        filter  query   entity.WebhookDeliveryFilter false "WebhookDelivery filter"
+// @Success      200  {array}  entity.WebhookDelivery
+// @Failure      400   {object}  errs.Error
+// @Failure      401   {object}  errs.Error
+// @Failure      403   {object}  errs.Error
+// @Failure      405   {object}  errs.Error
+// @Failure      500   {object}  errs.Error
+// @Failure      503   {object}  errs.Error
+// @Router       /webhook-deliveries [get]
+func (h *WebhookHandler) ListDeliveries(ctx *gin.Context) {
+	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
+	filter := &entity.WebhookDeliveryFilter{}
+	_ = ctx.Bind(filter)
+	deliveries, count, err := h.webhookInterceptor.ListDeliveries(ctx.Request.Context(), filter, token)
+	if err != nil {
+		decodeError(ctx, err)
+		return
+	}
+	ctx.Header("count", fmt.Sprint(count))
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+// ReplayDelivery godoc
+// @Summary      Replay WebhookDelivery by UUID
+// @Description  Queues a copy of the delivery and returns it.
+// @Tags         Webhook
+// @Produce      json
+// @Param        uuid  path      string  true  "replay WebhookDelivery by UUID"
+// @Success      201  {object}  entity.WebhookDelivery
+// @Failure      400   {object}  errs.Error
+// @Failure      401   {object}  errs.Error
+// @Failure      403   {object}  errs.Error
+// @Failure      404   {object}  errs.Error
+// @Failure      405   {object}  errs.Error
+// @Failure      500   {object}  errs.Error
+// @Failure      503   {object}  errs.Error
+// @Router       /webhook-deliveries/{uuid}/replay [post]
+func (h *WebhookHandler) ReplayDelivery(ctx *gin.Context) {
+	token := ctx.Request.Context().Value(TokenContextKey).(*entity.Token)
+	delivery, err := h.webhookInterceptor.ReplayDelivery(ctx.Request.Context(), entity.UUID(ctx.Param("id")), token)
+	if err != nil {
+		decodeError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, delivery)
+}
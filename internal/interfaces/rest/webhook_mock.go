@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+
+// Package rest is a generated GoMock package.
+package rest
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockwebhookInterceptor is a mock of webhookInterceptor interface.
+type MockwebhookInterceptor struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookInterceptorMockRecorder
+}
+
+// MockwebhookInterceptorMockRecorder is the mock recorder for MockwebhookInterceptor.
+type MockwebhookInterceptorMockRecorder struct {
+	mock *MockwebhookInterceptor
+}
+
+// NewMockwebhookInterceptor creates a new mock instance.
+func NewMockwebhookInterceptor(ctrl *gomock.Controller) *MockwebhookInterceptor {
+	mock := &MockwebhookInterceptor{ctrl: ctrl}
+	mock.recorder = &MockwebhookInterceptorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookInterceptor) EXPECT() *MockwebhookInterceptorMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockwebhookInterceptor) Create(ctx context.Context, create *entity.WebhookCreate, token *entity.Token) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, create, token)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockwebhookInterceptorMockRecorder) Create(ctx, create, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockwebhookInterceptor)(nil).Create), ctx, create, token)
+}
+
+// Delete mocks base method.
+func (m *MockwebhookInterceptor) Delete(ctx context.Context, id entity.UUID, token *entity.Token) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockwebhookInterceptorMockRecorder) Delete(ctx, id, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockwebhookInterceptor)(nil).Delete), ctx, id, token)
+}
+
+// Get mocks base method.
+func (m *MockwebhookInterceptor) Get(ctx context.Context, id entity.UUID, token *entity.Token) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id, token)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockwebhookInterceptorMockRecorder) Get(ctx, id, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockwebhookInterceptor)(nil).Get), ctx, id, token)
+}
+
+// List mocks base method.
+func (m *MockwebhookInterceptor) List(ctx context.Context, filter *entity.WebhookFilter, token *entity.Token) ([]*entity.Webhook, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter, token)
+	ret0, _ := ret[0].([]*entity.Webhook)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockwebhookInterceptorMockRecorder) List(ctx, filter, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockwebhookInterceptor)(nil).List), ctx, filter, token)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockwebhookInterceptor) ListDeliveries(ctx context.Context, filter *entity.WebhookDeliveryFilter, token *entity.Token) ([]*entity.WebhookDelivery, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, filter, token)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockwebhookInterceptorMockRecorder) ListDeliveries(ctx, filter, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockwebhookInterceptor)(nil).ListDeliveries), ctx, filter, token)
+}
+
+// ReplayDelivery mocks base method.
+func (m *MockwebhookInterceptor) ReplayDelivery(ctx context.Context, id entity.UUID, token *entity.Token) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDelivery", ctx, id, token)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplayDelivery indicates an expected call of ReplayDelivery.
+func (mr *MockwebhookInterceptorMockRecorder) ReplayDelivery(ctx, id, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDelivery", reflect.TypeOf((*MockwebhookInterceptor)(nil).ReplayDelivery), ctx, id, token)
+}
+
+// Update mocks base method.
+func (m *MockwebhookInterceptor) Update(ctx context.Context, update *entity.WebhookUpdate, token *entity.Token) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, update, token)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockwebhookInterceptorMockRecorder) Update(ctx, update, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockwebhookInterceptor)(nil).Update), ctx, update, token)
+}
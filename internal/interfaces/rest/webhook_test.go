@@ -0,0 +1,172 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/018bf/companies/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+)
+
+func TestWebhookHandler_Create(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebhookInterceptor := NewMockwebhookInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	create := mock_models.NewWebhookCreate(t)
+	createjson, _ := json.Marshal(create)
+	webhook := mock_models.NewWebhook(t)
+	webhookjson, _ := json.Marshal(webhook)
+	token := utils.Pointer(entity.Token("good token"))
+	newRequest := func() *http.Request {
+		return (&http.Request{
+			Header: http.Header{
+				"Content-Type": []string{"application/json"},
+			},
+			Body: io.NopCloser(bytes.NewBuffer(createjson)),
+		}).WithContext(context.WithValue(context.Background(), TokenContextKey, token))
+	}
+	type fields struct {
+		webhookInterceptor webhookInterceptor
+		logger             log.Logger
+	}
+	tests := []struct {
+		name       string
+		setup      func()
+		fields     fields
+		wantBody   *bytes.Buffer
+		wantStatus int
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockWebhookInterceptor.EXPECT().Create(gomock.Any(), create, token).Return(webhook, nil)
+			},
+			fields: fields{
+				webhookInterceptor: mockWebhookInterceptor,
+				logger:             logger,
+			},
+			wantBody:   bytes.NewBuffer(webhookjson),
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "permission denied",
+			setup: func() {
+				mockWebhookInterceptor.EXPECT().
+					Create(gomock.Any(), create, token).
+					Return(nil, errs.NewPermissionDenied())
+			},
+			fields: fields{
+				webhookInterceptor: mockWebhookInterceptor,
+				logger:             logger,
+			},
+			wantBody:   bytes.NewBufferString(errs.NewPermissionDenied().Error()),
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			h := &WebhookHandler{
+				webhookInterceptor: tt.fields.webhookInterceptor,
+				logger:             tt.fields.logger,
+			}
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = newRequest()
+			h.Create(ctx)
+			if !reflect.DeepEqual(w.Code, tt.wantStatus) {
+				t.Errorf("Create() gotStatus = %v, wantStatus %v", w.Code, tt.wantStatus)
+				return
+			}
+			if !reflect.DeepEqual(w.Body, tt.wantBody) {
+				t.Errorf("Create() gotBody = %v, wantBody %v", w.Body, tt.wantBody)
+				return
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_ReplayDelivery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebhookInterceptor := NewMockwebhookInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	delivery := mock_models.NewWebhookDelivery(t)
+	deliveryjson, _ := json.Marshal(delivery)
+	token := utils.Pointer(entity.Token("good token"))
+	disabled := errs.NewError(errs.ErrorCodeFailedPrecondition, "Webhook is disabled.")
+	type fields struct {
+		webhookInterceptor webhookInterceptor
+		logger             log.Logger
+	}
+	tests := []struct {
+		name       string
+		setup      func()
+		fields     fields
+		wantBody   *bytes.Buffer
+		wantStatus int
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockWebhookInterceptor.EXPECT().ReplayDelivery(gomock.Any(), delivery.ID, token).Return(delivery, nil)
+			},
+			fields: fields{
+				webhookInterceptor: mockWebhookInterceptor,
+				logger:             logger,
+			},
+			wantBody:   bytes.NewBuffer(deliveryjson),
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "webhook disabled",
+			setup: func() {
+				mockWebhookInterceptor.EXPECT().ReplayDelivery(gomock.Any(), delivery.ID, token).Return(nil, disabled)
+			},
+			fields: fields{
+				webhookInterceptor: mockWebhookInterceptor,
+				logger:             logger,
+			},
+			wantBody:   bytes.NewBufferString(disabled.Error()),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			h := &WebhookHandler{
+				webhookInterceptor: tt.fields.webhookInterceptor,
+				logger:             tt.fields.logger,
+			}
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+			ctx.Request = (&http.Request{}).
+				WithContext(context.WithValue(context.Background(), TokenContextKey, token))
+			ctx.Params = gin.Params{{Key: "id", Value: string(delivery.ID)}}
+			h.ReplayDelivery(ctx)
+			if !reflect.DeepEqual(w.Code, tt.wantStatus) {
+				t.Errorf("ReplayDelivery() gotStatus = %v, wantStatus %v", w.Code, tt.wantStatus)
+				return
+			}
+			if !reflect.DeepEqual(w.Body, tt.wantBody) {
+				t.Errorf("ReplayDelivery() gotBody = %v, wantBody %v", w.Body, tt.wantBody)
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package schemaregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/errs"
+)
+
+const contentType = "application/vnd.schemaregistry.v1+json"
+
+const SchemaTypeProtobuf = "PROTOBUF"
+
+type Reference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+type Schema struct {
+	SchemaType string      `json:"schemaType"`
+	Schema     string      `json:"schema"`
+	References []Reference `json:"references,omitempty"`
+}
+
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewClient(config *configs.Config) *Client {
+	return &Client{
+		url:        strings.TrimSuffix(config.SchemaRegistry.URL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Register registers schema under the subject and returns the global schema ID.
+func (c *Client) Register(ctx context.Context, subject string, schema *Schema) (int, error) {
+	response := struct {
+		ID int `json:"id"`
+	}{}
+	path := fmt.Sprintf("/subjects/%s/versions", url.PathEscape(subject))
+	if err := c.do(ctx, http.MethodPost, path, schema, &response); err != nil {
+		return 0, err
+	}
+	return response.ID, nil
+}
+
+// LatestVersion returns the latest version number registered under the subject.
+func (c *Client) LatestVersion(ctx context.Context, subject string) (int, error) {
+	response := struct {
+		Version int `json:"version"`
+	}{}
+	path := fmt.Sprintf("/subjects/%s/versions/latest", url.PathEscape(subject))
+	if err := c.do(ctx, http.MethodGet, path, nil, &response); err != nil {
+		return 0, err
+	}
+	return response.Version, nil
+}
+
+// SchemaURL returns the URL of the schema with the given ID.
+func (c *Client) SchemaURL(id int) string {
+	return fmt.Sprintf("%s/schemas/ids/%d", c.url, id)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, result any) error {
+	var reader *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errs.NewUnexpectedBehaviorError(err.Error())
+		}
+		reader = bytes.NewReader(data)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, c.url+path, reader)
+	if err != nil {
+		return errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("Accept", contentType)
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return errs.NewError(errs.ErrorCodeUnavailable, "Schema registry is unavailable.").
+			WithParam("error", err.Error())
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusBadRequest {
+		registryError := struct {
+			ErrorCode int    `json:"error_code"`
+			Message   string `json:"message"`
+		}{}
+		_ = json.NewDecoder(response.Body).Decode(&registryError)
+		return errs.NewUnexpectedBehaviorError(registryError.Message).
+			WithParam("status", fmt.Sprint(response.StatusCode)).
+			WithParam("error_code", fmt.Sprint(registryError.ErrorCode))
+	}
+	if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+		return errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	return nil
+}
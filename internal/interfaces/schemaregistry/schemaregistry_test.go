@@ -0,0 +1,119 @@
+package schemaregistry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/errs"
+)
+
+func TestClient_Register(t *testing.T) {
+	registry := NewMockSchemaRegistry(t)
+	config := configs.NewMockConfig(t)
+	config.SchemaRegistry.URL = registry.URL
+	client := NewClient(config)
+	ctx := context.Background()
+	schema := &Schema{SchemaType: SchemaTypeProtobuf, Schema: "syntax = \"proto3\";"}
+	type args struct {
+		ctx     context.Context
+		subject string
+		schema  *Schema
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr error
+	}{
+		{
+			name: "ok",
+			args: args{
+				ctx:     ctx,
+				subject: "companies-value",
+				schema:  schema,
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "same schema under another subject",
+			args: args{
+				ctx:     ctx,
+				subject: "companiespb/v1/company.proto",
+				schema:  schema,
+			},
+			want:    1,
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.Register(tt.args.ctx, tt.args.subject, tt.args.schema)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Register() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Register() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_LatestVersion(t *testing.T) {
+	registry := NewMockSchemaRegistry(t)
+	config := configs.NewMockConfig(t)
+	config.SchemaRegistry.URL = registry.URL
+	client := NewClient(config)
+	ctx := context.Background()
+	for _, schema := range []string{"message A {}", "message B {}"} {
+		if _, err := client.Register(ctx, "companies-value", &Schema{Schema: schema}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	type args struct {
+		ctx     context.Context
+		subject string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr error
+	}{
+		{
+			name: "ok",
+			args: args{
+				ctx:     ctx,
+				subject: "companies-value",
+			},
+			want:    2,
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			args: args{
+				ctx:     ctx,
+				subject: "unknown",
+			},
+			want: 0,
+			wantErr: errs.NewUnexpectedBehaviorError("Subject not found.").
+				WithParam("status", "404").
+				WithParam("error_code", "40401"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.LatestVersion(tt.args.ctx, tt.args.subject)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("LatestVersion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("LatestVersion() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
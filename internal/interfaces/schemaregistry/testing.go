@@ -0,0 +1,111 @@
+package schemaregistry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// MockSchemaRegistry is an in-process fake of the Confluent schema registry API.
+type MockSchemaRegistry struct {
+	*httptest.Server
+	mu       sync.Mutex
+	schemas  []*Schema
+	subjects map[string][]int
+}
+
+func NewMockSchemaRegistry(t *testing.T) *MockSchemaRegistry {
+	t.Helper()
+	registry := &MockSchemaRegistry{subjects: map[string][]int{}}
+	registry.Server = httptest.NewServer(http.HandlerFunc(registry.handle))
+	t.Cleanup(registry.Close)
+	return registry
+}
+
+// Schema returns the schema registered with the ID.
+func (r *MockSchemaRegistry) Schema(id int) *Schema {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id < 1 || id > len(r.schemas) {
+		return nil
+	}
+	return r.schemas[id-1]
+}
+
+// Subjects returns registered subjects with the schema IDs of their versions.
+func (r *MockSchemaRegistry) Subjects() map[string][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subjects := make(map[string][]int, len(r.subjects))
+	for subject, ids := range r.subjects {
+		subjects[subject] = append([]int(nil), ids...)
+	}
+	return subjects
+}
+
+func (r *MockSchemaRegistry) handle(w http.ResponseWriter, request *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	parts := strings.Split(strings.Trim(request.URL.EscapedPath(), "/"), "/")
+	if len(parts) < 3 || parts[0] != "subjects" || parts[2] != "versions" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	subject, err := url.PathUnescape(parts[1])
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	switch {
+	case request.Method == http.MethodPost && len(parts) == 3:
+		schema := &Schema{}
+		if err := json.NewDecoder(request.Body).Decode(schema); err != nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		id := r.find(schema)
+		if id == 0 {
+			r.schemas = append(r.schemas, schema)
+			id = len(r.schemas)
+		}
+		if !contains(r.subjects[subject], id) {
+			r.subjects[subject] = append(r.subjects[subject], id)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]int{"id": id})
+	case request.Method == http.MethodGet && len(parts) == 4 && parts[3] == "latest":
+		versions := r.subjects[subject]
+		if len(versions) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error_code": 40401, "message": "Subject not found."})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]int{
+			"id":      versions[len(versions)-1],
+			"version": len(versions),
+		})
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *MockSchemaRegistry) find(schema *Schema) int {
+	for i, registered := range r.schemas {
+		if registered.Schema == schema.Schema && registered.SchemaType == schema.SchemaType {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func contains(ids []int, id int) bool {
+	for _, value := range ids {
+		if value == id {
+			return true
+		}
+	}
+	return false
+}
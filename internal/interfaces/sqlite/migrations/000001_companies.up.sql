@@ -0,0 +1,18 @@
+CREATE TABLE companies
+(
+    id                  TEXT    NOT NULL
+        CONSTRAINT companies_pk PRIMARY KEY,
+    name                TEXT    NOT NULL
+        CONSTRAINT companies_name_key UNIQUE,
+    description         TEXT    NOT NULL,
+    amount_of_employees INTEGER NOT NULL,
+    registered          INTEGER NOT NULL,
+    type                INTEGER NOT NULL,
+    -- The times are UTC in a fixed width format, so they are ordered and compared as text.
+    updated_at          TEXT    NOT NULL,
+    created_at          TEXT    NOT NULL,
+    version             INTEGER NOT NULL DEFAULT 1
+);
+
+CREATE INDEX companies_registered_filter ON companies (registered);
+CREATE INDEX companies_type_filter ON companies (type);
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"embed"
+	"errors"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/golang-migrate/migrate/v4"
+	migrateSQLite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+	_ "modernc.org/sqlite"
+)
+
+//go:embed migrations/*.sql
+var MigrationsFS embed.FS
+
+// NewDatabase opens the database file and applies the migrations, ":memory:" opens a database in memory.
+// The database has a single connection, so the writes don't conflict and the memory database is shared.
+func NewDatabase(config *configs.Config) (*sqlx.DB, error) {
+	database, err := sqlx.Open("sqlite", config.Storage.SQLite.Path)
+	if err != nil {
+		return nil, err
+	}
+	database.SetMaxOpenConns(1)
+	if err := migrateUp(database); err != nil {
+		_ = database.Close()
+		return nil, err
+	}
+	return database, nil
+}
+
+// migrateUp applies the migrations, it doesn't close the migrate instance, which would close the database.
+func migrateUp(database *sqlx.DB) error {
+	source, err := iofs.New(MigrationsFS, "migrations")
+	if err != nil {
+		return err
+	}
+	driver, err := migrateSQLite.WithInstance(database.DB, &migrateSQLite.Config{})
+	if err != nil {
+		return err
+	}
+	instance, err := migrate.NewWithInstance("iofs", source, "sqlite", driver)
+	if err != nil {
+		return err
+	}
+	if err := instance.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,309 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/018bf/companies/internal/entity"
+	grpc2 "github.com/018bf/companies/internal/interfaces/grpc"
+	companiespb "github.com/018bf/companies/pkg/companiespb/v1"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/018bf/companies/pkg/utils"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+//go:generate mockgen -source=webhook.go -package=grpc -destination=webhook_mock.go
+
+type webhookInterceptor interface {
+	Get(ctx context.Context, id entity.UUID, token *entity.Token) (*entity.Webhook, error)
+	List(
+		ctx context.Context,
+		filter *entity.WebhookFilter,
+		token *entity.Token,
+	) ([]*entity.Webhook, uint64, error)
+	Update(
+		ctx context.Context,
+		update *entity.WebhookUpdate,
+		token *entity.Token,
+	) (*entity.Webhook, error)
+	Create(
+		ctx context.Context,
+		create *entity.WebhookCreate,
+		token *entity.Token,
+	) (*entity.Webhook, error)
+	Delete(ctx context.Context, id entity.UUID, token *entity.Token) error
+	ListDeliveries(
+		ctx context.Context,
+		filter *entity.WebhookDeliveryFilter,
+		token *entity.Token,
+	) ([]*entity.WebhookDelivery, uint64, error)
+	ReplayDelivery(ctx context.Context, id entity.UUID, token *entity.Token) (*entity.WebhookDelivery, error)
+}
+
+type WebhookServiceServer struct {
+	companiespb.UnimplementedWebhookServiceServer
+	webhookInterceptor webhookInterceptor
+	logger             log.Logger
+}
+
+func NewWebhookServiceServer(
+	webhookInterceptor webhookInterceptor,
+	logger log.Logger,
+) *WebhookServiceServer {
+	return &WebhookServiceServer{webhookInterceptor: webhookInterceptor, logger: logger}
+}
+
+func (s *WebhookServiceServer) Create(
+	ctx context.Context,
+	input *companiespb.WebhookCreate,
+) (*companiespb.Webhook, error) {
+	webhook, err := s.webhookInterceptor.Create(
+		ctx,
+		encodeWebhookCreate(input),
+		ctx.Value(grpc2.TokenKey).(*entity.Token),
+	)
+	if err != nil {
+		return nil, grpc2.DecodeError(err)
+	}
+	return decodeWebhook(webhook), nil
+}
+
+func (s *WebhookServiceServer) Get(
+	ctx context.Context,
+	input *companiespb.WebhookGet,
+) (*companiespb.Webhook, error) {
+	webhook, err := s.webhookInterceptor.Get(
+		ctx,
+		entity.UUID(input.GetId()),
+		ctx.Value(grpc2.TokenKey).(*entity.Token),
+	)
+	if err != nil {
+		return nil, grpc2.DecodeError(err)
+	}
+	return decodeWebhook(webhook), nil
+}
+
+func (s *WebhookServiceServer) List(
+	ctx context.Context,
+	input *companiespb.WebhookFilter,
+) (*companiespb.ListWebhook, error) {
+	webhooks, count, err := s.webhookInterceptor.List(
+		ctx,
+		encodeWebhookFilter(input),
+		ctx.Value(grpc2.TokenKey).(*entity.Token),
+	)
+	if err != nil {
+		return nil, grpc2.DecodeError(err)
+	}
+	response := &companiespb.ListWebhook{
+		Items: make([]*companiespb.Webhook, 0, len(webhooks)),
+		Count: count,
+	}
+	for _, webhook := range webhooks {
+		response.Items = append(response.Items, decodeWebhook(webhook))
+	}
+	return response, nil
+}
+
+func (s *WebhookServiceServer) Update(
+	ctx context.Context,
+	input *companiespb.WebhookUpdate,
+) (*companiespb.Webhook, error) {
+	webhook, err := s.webhookInterceptor.Update(
+		ctx,
+		encodeWebhookUpdate(input),
+		ctx.Value(grpc2.TokenKey).(*entity.Token),
+	)
+	if err != nil {
+		return nil, grpc2.DecodeError(err)
+	}
+	return decodeWebhook(webhook), nil
+}
+
+func (s *WebhookServiceServer) Delete(
+	ctx context.Context,
+	input *companiespb.WebhookDelete,
+) (*emptypb.Empty, error) {
+	if err := s.webhookInterceptor.Delete(
+		ctx,
+		entity.UUID(input.GetId()),
+		ctx.Value(grpc2.TokenKey).(*entity.Token),
+	); err != nil {
+		return nil, grpc2.DecodeError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *WebhookServiceServer) ListDeliveries(
+	ctx context.Context,
+	input *companiespb.WebhookDeliveryFilter,
+) (*companiespb.ListWebhookDelivery, error) {
+	deliveries, count, err := s.webhookInterceptor.ListDeliveries(
+		ctx,
+		encodeWebhookDeliveryFilter(input),
+		ctx.Value(grpc2.TokenKey).(*entity.Token),
+	)
+	if err != nil {
+		return nil, grpc2.DecodeError(err)
+	}
+	response := &companiespb.ListWebhookDelivery{
+		Items: make([]*companiespb.WebhookDelivery, 0, len(deliveries)),
+		Count: count,
+	}
+	for _, delivery := range deliveries {
+		response.Items = append(response.Items, decodeWebhookDelivery(delivery))
+	}
+	return response, nil
+}
+
+func (s *WebhookServiceServer) ReplayDelivery(
+	ctx context.Context,
+	input *companiespb.WebhookDeliveryReplay,
+) (*companiespb.WebhookDelivery, error) {
+	delivery, err := s.webhookInterceptor.ReplayDelivery(
+		ctx,
+		entity.UUID(input.GetId()),
+		ctx.Value(grpc2.TokenKey).(*entity.Token),
+	)
+	if err != nil {
+		return nil, grpc2.DecodeError(err)
+	}
+	return decodeWebhookDelivery(delivery), nil
+}
+
+func encodeWebhookCreate(input *companiespb.WebhookCreate) *entity.WebhookCreate {
+	create := &entity.WebhookCreate{
+		URL:        input.GetUrl(),
+		Secret:     input.GetSecret(),
+		EventTypes: encodeEventOperations(input.GetEventTypes()),
+	}
+	return create
+}
+func encodeWebhookUpdate(input *companiespb.WebhookUpdate) *entity.WebhookUpdate {
+	update := &entity.WebhookUpdate{ID: entity.UUID(input.GetId())}
+	if input.GetUrl() != nil {
+		update.URL = utils.Pointer(input.GetUrl().GetValue())
+	}
+	if input.GetSecret() != nil {
+		update.Secret = utils.Pointer(input.GetSecret().GetValue())
+	}
+	if input.GetEventTypes() != nil {
+		update.EventTypes = utils.Pointer(encodeEventOperations(input.GetEventTypes().GetItems()))
+	}
+	if input.GetActive() != nil {
+		update.Active = utils.Pointer(input.GetActive().GetValue())
+	}
+	return update
+}
+func encodeWebhookFilter(input *companiespb.WebhookFilter) *entity.WebhookFilter {
+	filter := &entity.WebhookFilter{}
+	if input.GetPageSize() != nil {
+		filter.PageSize = utils.Pointer(input.GetPageSize().GetValue())
+	}
+	if input.GetPageNumber() != nil {
+		filter.PageNumber = utils.Pointer(input.GetPageNumber().GetValue())
+	}
+	return filter
+}
+func encodeWebhookDeliveryFilter(input *companiespb.WebhookDeliveryFilter) *entity.WebhookDeliveryFilter {
+	filter := &entity.WebhookDeliveryFilter{}
+	if input.GetPageSize() != nil {
+		filter.PageSize = utils.Pointer(input.GetPageSize().GetValue())
+	}
+	if input.GetPageNumber() != nil {
+		filter.PageNumber = utils.Pointer(input.GetPageNumber().GetValue())
+	}
+	if input.GetWebhookId() != nil {
+		filter.WebhookID = utils.Pointer(entity.UUID(input.GetWebhookId().GetValue()))
+	}
+	for _, status := range input.GetStatuses() {
+		filter.Statuses = append(filter.Statuses, encodeWebhookDeliveryStatus(status))
+	}
+	return filter
+}
+func encodeEventOperations(input []companiespb.EventOperation) []entity.EventOperation {
+	if len(input) == 0 {
+		return nil
+	}
+	operations := make([]entity.EventOperation, len(input))
+	for i, operation := range input {
+		switch operation {
+		case companiespb.EventOperation_EVENT_OPERATION_CREATED:
+			operations[i] = entity.EventTypeCreated
+		case companiespb.EventOperation_EVENT_OPERATION_UPDATED:
+			operations[i] = entity.EventTypeUpdated
+		case companiespb.EventOperation_EVENT_OPERATION_DELETED:
+			operations[i] = entity.EventTypeDeleted
+		default:
+			operations[i] = ""
+		}
+	}
+	return operations
+}
+func encodeWebhookDeliveryStatus(status companiespb.WebhookDeliveryStatus) entity.WebhookDeliveryStatus {
+	switch status {
+	case companiespb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING:
+		return entity.WebhookDeliveryStatusPending
+	case companiespb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED:
+		return entity.WebhookDeliveryStatusSucceeded
+	case companiespb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED:
+		return entity.WebhookDeliveryStatusFailed
+	default:
+		return ""
+	}
+}
+func decodeWebhook(webhook *entity.Webhook) *companiespb.Webhook {
+	response := &companiespb.Webhook{
+		Id:         string(webhook.ID),
+		UpdatedAt:  timestamppb.New(webhook.UpdatedAt),
+		CreatedAt:  timestamppb.New(webhook.CreatedAt),
+		Url:        webhook.URL,
+		EventTypes: make([]companiespb.EventOperation, 0, len(webhook.EventTypes)),
+		Active:     webhook.Active,
+		Failures:   int32(webhook.Failures),
+	}
+	for _, operation := range webhook.EventTypes {
+		response.EventTypes = append(response.EventTypes, decodeEventOperation(operation))
+	}
+	return response
+}
+func decodeEventOperation(operation entity.EventOperation) companiespb.EventOperation {
+	switch operation {
+	case entity.EventTypeCreated:
+		return companiespb.EventOperation_EVENT_OPERATION_CREATED
+	case entity.EventTypeUpdated:
+		return companiespb.EventOperation_EVENT_OPERATION_UPDATED
+	case entity.EventTypeDeleted:
+		return companiespb.EventOperation_EVENT_OPERATION_DELETED
+	default:
+		return companiespb.EventOperation_EVENT_OPERATION_UNKNOWN
+	}
+}
+func decodeWebhookDelivery(delivery *entity.WebhookDelivery) *companiespb.WebhookDelivery {
+	response := &companiespb.WebhookDelivery{
+		Id:             string(delivery.ID),
+		UpdatedAt:      timestamppb.New(delivery.UpdatedAt),
+		CreatedAt:      timestamppb.New(delivery.CreatedAt),
+		WebhookId:      string(delivery.WebhookID),
+		EventType:      decodeEventOperation(delivery.EventType),
+		Payload:        delivery.Payload,
+		Status:         decodeWebhookDeliveryStatus(delivery.Status),
+		Attempts:       int32(delivery.Attempts),
+		NextAttemptAt:  timestamppb.New(delivery.NextAttemptAt),
+		ResponseStatus: int32(delivery.ResponseStatus),
+		LastError:      delivery.LastError,
+	}
+	return response
+}
+func decodeWebhookDeliveryStatus(status entity.WebhookDeliveryStatus) companiespb.WebhookDeliveryStatus {
+	switch status {
+	case entity.WebhookDeliveryStatusPending:
+		return companiespb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING
+	case entity.WebhookDeliveryStatusSucceeded:
+		return companiespb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_SUCCEEDED
+	case entity.WebhookDeliveryStatusFailed:
+		return companiespb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED
+	default:
+		return companiespb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNKNOWN
+	}
+}
@@ -0,0 +1,200 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	grpc2 "github.com/018bf/companies/internal/interfaces/grpc"
+	companiespb "github.com/018bf/companies/pkg/companiespb/v1"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/018bf/companies/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestWebhookServiceServer_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebhookInterceptor := NewMockwebhookInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	user := utils.Pointer(mock_models.NewToken(t))
+	ctx := context.WithValue(context.Background(), grpc2.TokenKey, user)
+	webhook := mock_models.NewWebhook(t)
+	input := &companiespb.WebhookCreate{
+		Url:        webhook.URL,
+		Secret:     webhook.Secret,
+		EventTypes: []companiespb.EventOperation{companiespb.EventOperation_EVENT_OPERATION_DELETED},
+	}
+	create := &entity.WebhookCreate{
+		URL:        webhook.URL,
+		Secret:     webhook.Secret,
+		EventTypes: []entity.EventOperation{entity.EventTypeDeleted},
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *companiespb.Webhook
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockWebhookInterceptor.EXPECT().Create(ctx, create, user).Return(webhook, nil)
+			},
+			want:    decodeWebhook(webhook),
+			wantErr: nil,
+		},
+		{
+			name: "interceptor error",
+			setup: func() {
+				mockWebhookInterceptor.EXPECT().
+					Create(ctx, create, user).
+					Return(nil, errs.NewUnexpectedBehaviorError("interceptor error"))
+			},
+			want:    nil,
+			wantErr: grpc2.DecodeError(errs.NewUnexpectedBehaviorError("interceptor error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			s := NewWebhookServiceServer(mockWebhookInterceptor, logger)
+			got, err := s.Create(ctx, input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Create() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookServiceServer_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebhookInterceptor := NewMockwebhookInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	user := utils.Pointer(mock_models.NewToken(t))
+	ctx := context.WithValue(context.Background(), grpc2.TokenKey, user)
+	webhook := mock_models.NewWebhook(t)
+	input := &companiespb.WebhookUpdate{
+		Id:         string(webhook.ID),
+		EventTypes: &companiespb.WebhookEventTypes{},
+		Active:     wrapperspb.Bool(true),
+	}
+	update := &entity.WebhookUpdate{
+		ID:         webhook.ID,
+		EventTypes: utils.Pointer([]entity.EventOperation(nil)),
+		Active:     utils.Pointer(true),
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *companiespb.Webhook
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockWebhookInterceptor.EXPECT().Update(ctx, update, user).Return(webhook, nil)
+			},
+			want:    decodeWebhook(webhook),
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mockWebhookInterceptor.EXPECT().Update(ctx, update, user).Return(nil, errs.NewEntityNotFound())
+			},
+			want:    nil,
+			wantErr: grpc2.DecodeError(errs.NewEntityNotFound()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			s := NewWebhookServiceServer(mockWebhookInterceptor, logger)
+			got, err := s.Update(ctx, input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Update() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookServiceServer_ListDeliveries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockWebhookInterceptor := NewMockwebhookInterceptor(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	user := utils.Pointer(mock_models.NewToken(t))
+	ctx := context.WithValue(context.Background(), grpc2.TokenKey, user)
+	delivery := mock_models.NewWebhookDelivery(t)
+	input := &companiespb.WebhookDeliveryFilter{
+		PageSize:  wrapperspb.UInt64(5),
+		WebhookId: wrapperspb.String(string(delivery.WebhookID)),
+		Statuses: []companiespb.WebhookDeliveryStatus{
+			companiespb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED,
+		},
+	}
+	filter := &entity.WebhookDeliveryFilter{
+		PageSize:  utils.Pointer(uint64(5)),
+		WebhookID: utils.Pointer(delivery.WebhookID),
+		Statuses:  []entity.WebhookDeliveryStatus{entity.WebhookDeliveryStatusFailed},
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *companiespb.ListWebhookDelivery
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockWebhookInterceptor.EXPECT().
+					ListDeliveries(ctx, filter, user).
+					Return([]*entity.WebhookDelivery{delivery}, uint64(1), nil)
+			},
+			want: &companiespb.ListWebhookDelivery{
+				Items: []*companiespb.WebhookDelivery{decodeWebhookDelivery(delivery)},
+				Count: 1,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "permission denied",
+			setup: func() {
+				mockWebhookInterceptor.EXPECT().
+					ListDeliveries(ctx, filter, user).
+					Return(nil, uint64(0), errs.NewPermissionDenied())
+			},
+			want:    nil,
+			wantErr: grpc2.DecodeError(errs.NewPermissionDenied()),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			s := NewWebhookServiceServer(mockWebhookInterceptor, logger)
+			got, err := s.ListDeliveries(ctx, input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ListDeliveries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListDeliveries() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/pkg/log"
+)
+
+//go:generate mockgen -source=webhook.go -package=interceptor -destination=webhook_mock.go
+
+type authService interface {
+	HasPermission(ctx context.Context, token *entity.Token, permission entity.PermissionID) error
+	HasObjectPermission(
+		ctx context.Context,
+		token *entity.Token,
+		permission entity.PermissionID,
+		object any,
+	) error
+}
+
+type webhookService interface {
+	Get(ctx context.Context, id entity.UUID) (*entity.Webhook, error)
+	List(ctx context.Context, filter *entity.WebhookFilter) ([]*entity.Webhook, uint64, error)
+	Update(ctx context.Context, update *entity.WebhookUpdate) (*entity.Webhook, error)
+	Create(ctx context.Context, create *entity.WebhookCreate) (*entity.Webhook, error)
+	Delete(ctx context.Context, id entity.UUID) error
+}
+
+type deliveryService interface {
+	List(ctx context.Context, filter *entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, uint64, error)
+	Replay(ctx context.Context, id entity.UUID) (*entity.WebhookDelivery, error)
+}
+
+type WebhookInterceptor struct {
+	webhookService  webhookService
+	deliveryService deliveryService
+	authService     authService
+	logger          log.Logger
+}
+
+func NewWebhookInterceptor(
+	webhookService webhookService,
+	deliveryService deliveryService,
+	authService authService,
+	logger log.Logger,
+) *WebhookInterceptor {
+	return &WebhookInterceptor{
+		webhookService:  webhookService,
+		deliveryService: deliveryService,
+		authService:     authService,
+		logger:          logger,
+	}
+}
+
+func (i *WebhookInterceptor) Create(
+	ctx context.Context,
+	create *entity.WebhookCreate,
+	token *entity.Token,
+) (*entity.Webhook, error) {
+	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDWebhookCreate); err != nil {
+		return nil, err
+	}
+	if err := i.authService.HasObjectPermission(ctx, token, entity.PermissionIDWebhookCreate, create); err != nil {
+		return nil, err
+	}
+	webhook, err := i.webhookService.Create(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (i *WebhookInterceptor) Get(
+	ctx context.Context,
+	id entity.UUID,
+	token *entity.Token,
+) (*entity.Webhook, error) {
+	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDWebhookDetail); err != nil {
+		return nil, err
+	}
+	webhook, err := i.webhookService.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.authService.HasObjectPermission(ctx, token, entity.PermissionIDWebhookDetail, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (i *WebhookInterceptor) List(
+	ctx context.Context,
+	filter *entity.WebhookFilter,
+	token *entity.Token,
+) ([]*entity.Webhook, uint64, error) {
+	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDWebhookList); err != nil {
+		return nil, 0, err
+	}
+	if err := i.authService.HasObjectPermission(ctx, token, entity.PermissionIDWebhookList, filter); err != nil {
+		return nil, 0, err
+	}
+	webhooks, count, err := i.webhookService.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return webhooks, count, nil
+}
+
+func (i *WebhookInterceptor) Update(
+	ctx context.Context,
+	update *entity.WebhookUpdate,
+	token *entity.Token,
+) (*entity.Webhook, error) {
+	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDWebhookUpdate); err != nil {
+		return nil, err
+	}
+	webhook, err := i.webhookService.Get(ctx, update.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := i.authService.HasObjectPermission(ctx, token, entity.PermissionIDWebhookUpdate, webhook); err != nil {
+		return nil, err
+	}
+	updated, err := i.webhookService.Update(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (i *WebhookInterceptor) Delete(
+	ctx context.Context,
+	id entity.UUID,
+	token *entity.Token,
+) error {
+	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDWebhookDelete); err != nil {
+		return err
+	}
+	webhook, err := i.webhookService.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := i.authService.HasObjectPermission(ctx, token, entity.PermissionIDWebhookDelete, webhook); err != nil {
+		return err
+	}
+	if err := i.webhookService.Delete(ctx, id); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListDeliveries returns the delivery log.
+func (i *WebhookInterceptor) ListDeliveries(
+	ctx context.Context,
+	filter *entity.WebhookDeliveryFilter,
+	token *entity.Token,
+) ([]*entity.WebhookDelivery, uint64, error) {
+	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDWebhookDetail); err != nil {
+		return nil, 0, err
+	}
+	if err := i.authService.HasObjectPermission(ctx, token, entity.PermissionIDWebhookDetail, filter); err != nil {
+		return nil, 0, err
+	}
+	deliveries, count, err := i.deliveryService.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, count, nil
+}
+
+// ReplayDelivery queues the delivery to be sent again.
+func (i *WebhookInterceptor) ReplayDelivery(
+	ctx context.Context,
+	id entity.UUID,
+	token *entity.Token,
+) (*entity.WebhookDelivery, error) {
+	if err := i.authService.HasPermission(ctx, token, entity.PermissionIDWebhookUpdate); err != nil {
+		return nil, err
+	}
+	if err := i.authService.HasObjectPermission(ctx, token, entity.PermissionIDWebhookUpdate, id); err != nil {
+		return nil, err
+	}
+	delivery, err := i.deliveryService.Replay(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
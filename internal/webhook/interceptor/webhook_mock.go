@@ -0,0 +1,216 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+
+// Package interceptor is a generated GoMock package.
+package interceptor
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockauthService is a mock of authService interface.
+type MockauthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockauthServiceMockRecorder
+}
+
+// MockauthServiceMockRecorder is the mock recorder for MockauthService.
+type MockauthServiceMockRecorder struct {
+	mock *MockauthService
+}
+
+// NewMockauthService creates a new mock instance.
+func NewMockauthService(ctrl *gomock.Controller) *MockauthService {
+	mock := &MockauthService{ctrl: ctrl}
+	mock.recorder = &MockauthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockauthService) EXPECT() *MockauthServiceMockRecorder {
+	return m.recorder
+}
+
+// HasObjectPermission mocks base method.
+func (m *MockauthService) HasObjectPermission(ctx context.Context, token *entity.Token, permission entity.PermissionID, object any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasObjectPermission", ctx, token, permission, object)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HasObjectPermission indicates an expected call of HasObjectPermission.
+func (mr *MockauthServiceMockRecorder) HasObjectPermission(ctx, token, permission, object interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasObjectPermission", reflect.TypeOf((*MockauthService)(nil).HasObjectPermission), ctx, token, permission, object)
+}
+
+// HasPermission mocks base method.
+func (m *MockauthService) HasPermission(ctx context.Context, token *entity.Token, permission entity.PermissionID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPermission", ctx, token, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HasPermission indicates an expected call of HasPermission.
+func (mr *MockauthServiceMockRecorder) HasPermission(ctx, token, permission interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPermission", reflect.TypeOf((*MockauthService)(nil).HasPermission), ctx, token, permission)
+}
+
+// MockwebhookService is a mock of webhookService interface.
+type MockwebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookServiceMockRecorder
+}
+
+// MockwebhookServiceMockRecorder is the mock recorder for MockwebhookService.
+type MockwebhookServiceMockRecorder struct {
+	mock *MockwebhookService
+}
+
+// NewMockwebhookService creates a new mock instance.
+func NewMockwebhookService(ctrl *gomock.Controller) *MockwebhookService {
+	mock := &MockwebhookService{ctrl: ctrl}
+	mock.recorder = &MockwebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookService) EXPECT() *MockwebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockwebhookService) Create(ctx context.Context, create *entity.WebhookCreate) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, create)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockwebhookServiceMockRecorder) Create(ctx, create interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockwebhookService)(nil).Create), ctx, create)
+}
+
+// Delete mocks base method.
+func (m *MockwebhookService) Delete(ctx context.Context, id entity.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockwebhookServiceMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockwebhookService)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockwebhookService) Get(ctx context.Context, id entity.UUID) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockwebhookServiceMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockwebhookService)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockwebhookService) List(ctx context.Context, filter *entity.WebhookFilter) ([]*entity.Webhook, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Webhook)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockwebhookServiceMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockwebhookService)(nil).List), ctx, filter)
+}
+
+// Update mocks base method.
+func (m *MockwebhookService) Update(ctx context.Context, update *entity.WebhookUpdate) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, update)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockwebhookServiceMockRecorder) Update(ctx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockwebhookService)(nil).Update), ctx, update)
+}
+
+// MockdeliveryService is a mock of deliveryService interface.
+type MockdeliveryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeliveryServiceMockRecorder
+}
+
+// MockdeliveryServiceMockRecorder is the mock recorder for MockdeliveryService.
+type MockdeliveryServiceMockRecorder struct {
+	mock *MockdeliveryService
+}
+
+// NewMockdeliveryService creates a new mock instance.
+func NewMockdeliveryService(ctrl *gomock.Controller) *MockdeliveryService {
+	mock := &MockdeliveryService{ctrl: ctrl}
+	mock.recorder = &MockdeliveryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeliveryService) EXPECT() *MockdeliveryServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockdeliveryService) List(ctx context.Context, filter *entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockdeliveryServiceMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockdeliveryService)(nil).List), ctx, filter)
+}
+
+// Replay mocks base method.
+func (m *MockdeliveryService) Replay(ctx context.Context, id entity.UUID) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", ctx, id)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockdeliveryServiceMockRecorder) Replay(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockdeliveryService)(nil).Replay), ctx, id)
+}
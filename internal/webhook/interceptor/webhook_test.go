@@ -0,0 +1,207 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/018bf/companies/pkg/utils"
+	"github.com/golang/mock/gomock"
+)
+
+func TestWebhookInterceptor_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthService := NewMockauthService(ctrl)
+	mockWebhookService := NewMockwebhookService(ctrl)
+	mockDeliveryService := NewMockdeliveryService(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	token := utils.Pointer(mock_models.NewToken(t))
+	create := mock_models.NewWebhookCreate(t)
+	webhook := mock_models.NewWebhook(t)
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *entity.Webhook
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockAuthService.EXPECT().HasPermission(ctx, token, entity.PermissionIDWebhookCreate).Return(nil)
+				mockAuthService.EXPECT().
+					HasObjectPermission(ctx, token, entity.PermissionIDWebhookCreate, create).
+					Return(nil)
+				mockWebhookService.EXPECT().Create(ctx, create).Return(webhook, nil)
+			},
+			want:    webhook,
+			wantErr: nil,
+		},
+		{
+			name: "permission denied",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDWebhookCreate).
+					Return(errs.NewPermissionDenied())
+			},
+			want:    nil,
+			wantErr: errs.NewPermissionDenied(),
+		},
+		{
+			name: "service error",
+			setup: func() {
+				mockAuthService.EXPECT().HasPermission(ctx, token, entity.PermissionIDWebhookCreate).Return(nil)
+				mockAuthService.EXPECT().
+					HasObjectPermission(ctx, token, entity.PermissionIDWebhookCreate, create).
+					Return(nil)
+				mockWebhookService.EXPECT().Create(ctx, create).Return(nil, errs.NewInvalidFormError())
+			},
+			want:    nil,
+			wantErr: errs.NewInvalidFormError(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			i := NewWebhookInterceptor(mockWebhookService, mockDeliveryService, mockAuthService, logger)
+			got, err := i.Create(ctx, create, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Create() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookInterceptor_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthService := NewMockauthService(ctrl)
+	mockWebhookService := NewMockwebhookService(ctrl)
+	mockDeliveryService := NewMockdeliveryService(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	token := utils.Pointer(mock_models.NewToken(t))
+	webhook := mock_models.NewWebhook(t)
+	update := &entity.WebhookUpdate{ID: webhook.ID, Active: utils.Pointer(false)}
+	updated := mock_models.NewWebhook(t)
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *entity.Webhook
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockAuthService.EXPECT().HasPermission(ctx, token, entity.PermissionIDWebhookUpdate).Return(nil)
+				mockWebhookService.EXPECT().Get(ctx, webhook.ID).Return(webhook, nil)
+				mockAuthService.EXPECT().
+					HasObjectPermission(ctx, token, entity.PermissionIDWebhookUpdate, webhook).
+					Return(nil)
+				mockWebhookService.EXPECT().Update(ctx, update).Return(updated, nil)
+			},
+			want:    updated,
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mockAuthService.EXPECT().HasPermission(ctx, token, entity.PermissionIDWebhookUpdate).Return(nil)
+				mockWebhookService.EXPECT().Get(ctx, webhook.ID).Return(nil, errs.NewEntityNotFound())
+			},
+			want:    nil,
+			wantErr: errs.NewEntityNotFound(),
+		},
+		{
+			name: "object permission denied",
+			setup: func() {
+				mockAuthService.EXPECT().HasPermission(ctx, token, entity.PermissionIDWebhookUpdate).Return(nil)
+				mockWebhookService.EXPECT().Get(ctx, webhook.ID).Return(webhook, nil)
+				mockAuthService.EXPECT().
+					HasObjectPermission(ctx, token, entity.PermissionIDWebhookUpdate, webhook).
+					Return(errs.NewPermissionDenied())
+			},
+			want:    nil,
+			wantErr: errs.NewPermissionDenied(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			i := NewWebhookInterceptor(mockWebhookService, mockDeliveryService, mockAuthService, logger)
+			got, err := i.Update(ctx, update, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Update() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookInterceptor_ReplayDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockAuthService := NewMockauthService(ctrl)
+	mockWebhookService := NewMockwebhookService(ctrl)
+	mockDeliveryService := NewMockdeliveryService(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	token := utils.Pointer(mock_models.NewToken(t))
+	delivery := mock_models.NewWebhookDelivery(t)
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *entity.WebhookDelivery
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockAuthService.EXPECT().HasPermission(ctx, token, entity.PermissionIDWebhookUpdate).Return(nil)
+				mockAuthService.EXPECT().
+					HasObjectPermission(ctx, token, entity.PermissionIDWebhookUpdate, delivery.ID).
+					Return(nil)
+				mockDeliveryService.EXPECT().Replay(ctx, delivery.ID).Return(delivery, nil)
+			},
+			want:    delivery,
+			wantErr: nil,
+		},
+		{
+			name: "permission denied",
+			setup: func() {
+				mockAuthService.EXPECT().
+					HasPermission(ctx, token, entity.PermissionIDWebhookUpdate).
+					Return(errs.NewPermissionDenied())
+			},
+			want:    nil,
+			wantErr: errs.NewPermissionDenied(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			i := NewWebhookInterceptor(mockWebhookService, mockDeliveryService, mockAuthService, logger)
+			got, err := i.ReplayDelivery(ctx, delivery.ID, token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReplayDelivery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReplayDelivery() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/clock"
+	"github.com/018bf/companies/pkg/log"
+)
+
+const (
+	HeaderID        = "X-Webhook-ID"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Sign returns the signature of the payload sent at timestamp.
+// Receivers recompute it over "<timestamp>.<payload>" with the shared secret.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Sender posts deliveries to webhook endpoints.
+type Sender struct {
+	client *http.Client
+	clock  clock.Clock
+	logger log.Logger
+}
+
+func NewSender(config *configs.Config, clock clock.Clock, logger log.Logger) *Sender {
+	return &Sender{
+		client: &http.Client{Timeout: time.Duration(config.Webhooks.Timeout) * time.Second},
+		clock:  clock,
+		logger: logger,
+	}
+}
+
+// Send posts the delivery payload and returns the response status.
+// Network errors and non-2xx responses are reported as unavailable.
+func (s *Sender) Send(
+	ctx context.Context,
+	webhook *entity.Webhook,
+	delivery *entity.WebhookDelivery,
+) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, errs.NewInvalidParameter(err.Error()).WithParam("webhook_id", string(webhook.ID))
+	}
+	timestamp := s.clock.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderID, string(delivery.ID))
+	request.Header.Set(HeaderEvent, string(delivery.EventType))
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, delivery.Payload))
+	response, err := s.client.Do(request)
+	if err != nil {
+		e := errs.NewError(errs.ErrorCodeUnavailable, err.Error()).
+			WithParam("webhook_id", string(webhook.ID))
+		return 0, e
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 1<<16))
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		e := errs.NewError(errs.ErrorCodeUnavailable, "Webhook endpoint responded with an error.").
+			WithParam("webhook_id", string(webhook.ID)).
+			WithParam("status", fmt.Sprint(response.StatusCode))
+		return response.StatusCode, e
+	}
+	return response.StatusCode, nil
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestSign(t *testing.T) {
+	type args struct {
+		secret    string
+		timestamp int64
+		payload   []byte
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "ok",
+			args: args{
+				secret:    "secret",
+				timestamp: 1700000000,
+				payload:   []byte(`{"operation":"created"}`),
+			},
+			want: "sha256=db6d2f2d4b95c731427d30542ae6e6f982c0c43a91cc016599a665c8e1389371",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.args.secret, tt.args.timestamp, tt.args.payload); got != tt.want {
+				t.Errorf("Sign() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSender_Send(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	now := time.Now().UTC()
+	delivery := mock_models.NewWebhookDelivery(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		if r.Header.Get(HeaderSignature) != Sign(r.URL.Query().Get("secret"), timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get(HeaderID) != string(delivery.ID) ||
+			r.Header.Get(HeaderEvent) != string(delivery.EventType) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		status, _ := strconv.Atoi(r.URL.Query().Get("status"))
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	newWebhook := func(status int) *entity.Webhook {
+		webhook := mock_models.NewWebhook(t)
+		webhook.Secret = "0123456789abcdef"
+		webhook.URL = server.URL + "?secret=" + webhook.Secret + "&status=" + strconv.Itoa(status)
+		return webhook
+	}
+	okWebhook := newWebhook(http.StatusNoContent)
+	failedWebhook := newWebhook(http.StatusInternalServerError)
+	unreachable := mock_models.NewWebhook(t)
+	unreachable.URL = "http://127.0.0.1:1"
+	tests := []struct {
+		name    string
+		setup   func()
+		webhook *entity.Webhook
+		want    int
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+			},
+			webhook: okWebhook,
+			want:    http.StatusNoContent,
+			wantErr: nil,
+		},
+		{
+			name: "error status",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+			},
+			webhook: failedWebhook,
+			want:    http.StatusInternalServerError,
+			wantErr: errs.NewError(errs.ErrorCodeUnavailable, "Webhook endpoint responded with an error.").
+				WithParam("webhook_id", string(failedWebhook.ID)).
+				WithParam("status", "500"),
+		},
+		{
+			name: "unreachable",
+			setup: func() {
+				mockClock.EXPECT().Now().Return(now)
+			},
+			webhook: unreachable,
+			want:    0,
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			s := &Sender{
+				client: server.Client(),
+				clock:  mockClock,
+				logger: logger,
+			}
+			got, err := s.Send(context.Background(), tt.webhook, delivery)
+			if tt.name == "unreachable" {
+				if !errs.IsTemporary(err) {
+					t.Errorf("Send() error = %v, want temporary", err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Send() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Send() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/018bf/companies/pkg/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var deliveryColumns = []string{
+	"webhook_deliveries.id",
+	"webhook_deliveries.updated_at",
+	"webhook_deliveries.created_at",
+	"webhook_deliveries.webhook_id",
+	"webhook_deliveries.event_type",
+	"webhook_deliveries.payload",
+	"webhook_deliveries.status",
+	"webhook_deliveries.attempts",
+	"webhook_deliveries.next_attempt_at",
+	"webhook_deliveries.response_status",
+	"webhook_deliveries.last_error",
+}
+
+type DeliveryRepository struct {
+	database     *sqlx.DB
+	logger       log.Logger
+	readTimeout  time.Duration
+	listTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+func NewDeliveryRepository(database *sqlx.DB, config *configs.Config, logger log.Logger) *DeliveryRepository {
+	return &DeliveryRepository{
+		database:     database,
+		logger:       logger,
+		readTimeout:  time.Duration(config.Database.ReadTimeout) * time.Millisecond,
+		listTimeout:  time.Duration(config.Database.ListTimeout) * time.Millisecond,
+		writeTimeout: time.Duration(config.Database.WriteTimeout) * time.Millisecond,
+	}
+}
+
+func (r *DeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+	dto := NewDeliveryDTOFromModel(delivery)
+	q := sq.Insert("public.webhook_deliveries").
+		Columns(
+			"updated_at",
+			"created_at",
+			"webhook_id",
+			"event_type",
+			"payload",
+			"status",
+			"attempts",
+			"next_attempt_at",
+			"response_status",
+			"last_error",
+		).
+		Values(
+			dto.UpdatedAt,
+			dto.CreatedAt,
+			dto.WebhookID,
+			dto.EventType,
+			dto.Payload,
+			dto.Status,
+			dto.Attempts,
+			dto.NextAttemptAt,
+			dto.ResponseStatus,
+			dto.LastError,
+		).
+		Suffix("RETURNING id")
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.database.QueryRowxContext(ctx, query, args...).StructScan(dto); err != nil {
+		e := errs.FromPostgresError(err)
+		return e
+	}
+	delivery.ID = entity.UUID(dto.ID)
+	return nil
+}
+
+func (r *DeliveryRepository) Get(ctx context.Context, id entity.UUID) (*entity.WebhookDelivery, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+	dto := &DeliveryDTO{}
+	q := sq.Select(deliveryColumns...).
+		From("public.webhook_deliveries").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.database.GetContext(ctx, dto, query, args...); err != nil {
+		e := errs.FromPostgresError(err).WithParam("delivery_id", string(id))
+		return nil, e
+	}
+	return dto.ToModel(), nil
+}
+
+func (r *DeliveryRepository) List(
+	ctx context.Context,
+	filter *entity.WebhookDeliveryFilter,
+) ([]*entity.WebhookDelivery, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	var dto DeliveryListDTO
+	const pageSize = uint64(10)
+	if filter.PageSize == nil {
+		filter.PageSize = utils.Pointer(pageSize)
+	}
+	q := applyDeliveryFilter(sq.Select(deliveryColumns...).From("public.webhook_deliveries"), filter).
+		OrderBy("created_at DESC").
+		Limit(*filter.PageSize)
+	if filter.PageNumber != nil && *filter.PageNumber > 1 {
+		q = q.Offset((*filter.PageNumber - 1) * *filter.PageSize)
+	}
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.database.SelectContext(ctx, &dto, query, args...); err != nil {
+		e := errs.FromPostgresError(err)
+		return nil, e
+	}
+	return dto.ToModels(), nil
+}
+
+func (r *DeliveryRepository) Count(ctx context.Context, filter *entity.WebhookDeliveryFilter) (uint64, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	q := applyDeliveryFilter(sq.Select("count(id)").From("public.webhook_deliveries"), filter)
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	var count uint64
+	if err := r.database.QueryRowxContext(ctx, query, args...).Scan(&count); err != nil {
+		e := errs.FromPostgresError(err)
+		return 0, e
+	}
+	return count, nil
+}
+
+// Claim returns pending deliveries which are due at now.
+// Their next attempt is postponed until the lease expires,
+// so concurrent workers don't pick the same deliveries.
+func (r *DeliveryRepository) Claim(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit uint64,
+) ([]*entity.WebhookDelivery, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+	var dto DeliveryListDTO
+	due := sq.Select("id").
+		From("public.webhook_deliveries").
+		Where(sq.Eq{"status": entity.WebhookDeliveryStatusPending}).
+		Where(sq.LtOrEq{"next_attempt_at": now}).
+		OrderBy("next_attempt_at ASC").
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED")
+	q := sq.Update("public.webhook_deliveries").
+		Set("next_attempt_at", now.Add(lease)).
+		Where(sq.Expr("id IN (?)", due)).
+		Suffix("RETURNING " + strings.Join(deliveryColumns, ", "))
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.database.SelectContext(ctx, &dto, query, args...); err != nil {
+		e := errs.FromPostgresError(err)
+		return nil, e
+	}
+	return dto.ToModels(), nil
+}
+
+func (r *DeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+	dto := NewDeliveryDTOFromModel(delivery)
+	q := sq.Update("public.webhook_deliveries").Where(sq.Eq{"id": delivery.ID}).
+		Set("updated_at", dto.UpdatedAt).
+		Set("status", dto.Status).
+		Set("attempts", dto.Attempts).
+		Set("next_attempt_at", dto.NextAttemptAt).
+		Set("response_status", dto.ResponseStatus).
+		Set("last_error", dto.LastError)
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	result, err := r.database.ExecContext(ctx, query, args...)
+	if err != nil {
+		e := errs.FromPostgresError(err).WithParam("delivery_id", fmt.Sprint(delivery.ID))
+		return e
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		e := errs.FromPostgresError(err).WithParam("delivery_id", fmt.Sprint(delivery.ID))
+		return e
+	}
+	if affected == 0 {
+		e := errs.NewEntityNotFound().WithParam("delivery_id", fmt.Sprint(delivery.ID))
+		return e
+	}
+	return nil
+}
+
+func applyDeliveryFilter(q sq.SelectBuilder, filter *entity.WebhookDeliveryFilter) sq.SelectBuilder {
+	if filter.WebhookID != nil {
+		q = q.Where(sq.Eq{"webhook_id": *filter.WebhookID})
+	}
+	if len(filter.Statuses) > 0 {
+		q = q.Where(sq.Eq{"status": filter.Statuses})
+	}
+	return q
+}
+
+type DeliveryDTO struct {
+	ID             string    `db:"id,omitempty"`
+	UpdatedAt      time.Time `db:"updated_at,omitempty"`
+	CreatedAt      time.Time `db:"created_at,omitempty"`
+	WebhookID      string    `db:"webhook_id"`
+	EventType      string    `db:"event_type"`
+	Payload        []byte    `db:"payload"`
+	Status         string    `db:"status"`
+	Attempts       int       `db:"attempts"`
+	NextAttemptAt  time.Time `db:"next_attempt_at"`
+	ResponseStatus int       `db:"response_status"`
+	LastError      string    `db:"last_error"`
+}
+type DeliveryListDTO []*DeliveryDTO
+
+func (list DeliveryListDTO) ToModels() []*entity.WebhookDelivery {
+	deliveries := make([]*entity.WebhookDelivery, len(list))
+	for i := range list {
+		deliveries[i] = list[i].ToModel()
+	}
+	return deliveries
+}
+func NewDeliveryDTOFromModel(delivery *entity.WebhookDelivery) *DeliveryDTO {
+	dto := &DeliveryDTO{
+		ID:             string(delivery.ID),
+		UpdatedAt:      delivery.UpdatedAt,
+		CreatedAt:      delivery.CreatedAt,
+		WebhookID:      string(delivery.WebhookID),
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+	}
+	return dto
+}
+func (dto *DeliveryDTO) ToModel() *entity.WebhookDelivery {
+	model := &entity.WebhookDelivery{
+		ID:             entity.UUID(dto.ID),
+		UpdatedAt:      dto.UpdatedAt,
+		CreatedAt:      dto.CreatedAt,
+		WebhookID:      entity.UUID(dto.WebhookID),
+		EventType:      entity.EventOperation(dto.EventType),
+		Payload:        dto.Payload,
+		Status:         entity.WebhookDeliveryStatus(dto.Status),
+		Attempts:       dto.Attempts,
+		NextAttemptAt:  dto.NextAttemptAt,
+		ResponseStatus: dto.ResponseStatus,
+		LastError:      dto.LastError,
+	}
+	return model
+}
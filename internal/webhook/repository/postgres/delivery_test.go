@@ -0,0 +1,404 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/018bf/companies/pkg/utils"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jmoiron/sqlx"
+)
+
+func deliveryRows(deliveries ...*entity.WebhookDelivery) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		"id",
+		"updated_at",
+		"created_at",
+		"webhook_id",
+		"event_type",
+		"payload",
+		"status",
+		"attempts",
+		"next_attempt_at",
+		"response_status",
+		"last_error",
+	})
+	for _, delivery := range deliveries {
+		dto := NewDeliveryDTOFromModel(delivery)
+		rows.AddRow(
+			dto.ID,
+			dto.UpdatedAt,
+			dto.CreatedAt,
+			dto.WebhookID,
+			dto.EventType,
+			dto.Payload,
+			dto.Status,
+			dto.Attempts,
+			dto.NextAttemptAt,
+			dto.ResponseStatus,
+			dto.LastError,
+		)
+	}
+	return rows
+}
+
+func TestDeliveryRepository_Create(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	delivery := mock_models.NewWebhookDelivery(t)
+	query := "INSERT INTO public.webhook_deliveries"
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx      context.Context
+		delivery *entity.WebhookDelivery
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(
+						delivery.UpdatedAt,
+						delivery.CreatedAt,
+						string(delivery.WebhookID),
+						string(delivery.EventType),
+						[]byte(delivery.Payload),
+						string(delivery.Status),
+						delivery.Attempts,
+						delivery.NextAttemptAt,
+						delivery.ResponseStatus,
+						delivery.LastError,
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(delivery.ID))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:      context.Background(),
+				delivery: delivery,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WillReturnError(errors.New("test error"))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:      context.Background(),
+				delivery: delivery,
+			},
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &DeliveryRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			if err := r.Create(tt.args.ctx, tt.args.delivery); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeliveryRepository_List(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	webhookID := entity.UUID("0c4f6c1e-5b1f-4c1a-9f2c-4c7f1a6f1b2e")
+	deliveries := []*entity.WebhookDelivery{
+		mock_models.NewWebhookDelivery(t),
+		mock_models.NewWebhookDelivery(t),
+	}
+	query := "SELECT .+ FROM public.webhook_deliveries WHERE webhook_id = \\$1 AND status IN \\(\\$2\\) " +
+		"ORDER BY created_at DESC LIMIT 10 OFFSET 10"
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx    context.Context
+		filter *entity.WebhookDeliveryFilter
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		want    []*entity.WebhookDelivery
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(webhookID, entity.WebhookDeliveryStatusFailed).
+					WillReturnRows(deliveryRows(deliveries...))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx: context.Background(),
+				filter: &entity.WebhookDeliveryFilter{
+					WebhookID:  &webhookID,
+					Statuses:   []entity.WebhookDeliveryStatus{entity.WebhookDeliveryStatusFailed},
+					PageNumber: utils.Pointer(uint64(2)),
+				},
+			},
+			want:    deliveries,
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WillReturnError(errors.New("test error"))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx: context.Background(),
+				filter: &entity.WebhookDeliveryFilter{
+					WebhookID:  &webhookID,
+					Statuses:   []entity.WebhookDeliveryStatus{entity.WebhookDeliveryStatusFailed},
+					PageNumber: utils.Pointer(uint64(2)),
+				},
+			},
+			want:    nil,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &DeliveryRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			got, err := r.List(tt.args.ctx, tt.args.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeliveryRepository_Claim(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	now := time.Now().UTC()
+	lease := time.Minute
+	deliveries := []*entity.WebhookDelivery{mock_models.NewWebhookDelivery(t)}
+	query := "UPDATE public.webhook_deliveries SET next_attempt_at = \\$1 WHERE id IN \\(" +
+		"SELECT id FROM public.webhook_deliveries WHERE status = \\$2 AND next_attempt_at <= \\$3 " +
+		"ORDER BY next_attempt_at ASC LIMIT 5 FOR UPDATE SKIP LOCKED\\) RETURNING .+"
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx   context.Context
+		now   time.Time
+		lease time.Duration
+		limit uint64
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		want    []*entity.WebhookDelivery
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(now.Add(lease), entity.WebhookDeliveryStatusPending, now).
+					WillReturnRows(deliveryRows(deliveries...))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:   context.Background(),
+				now:   now,
+				lease: lease,
+				limit: 5,
+			},
+			want:    deliveries,
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(now.Add(lease), entity.WebhookDeliveryStatusPending, now).
+					WillReturnError(errors.New("test error"))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:   context.Background(),
+				now:   now,
+				lease: lease,
+				limit: 5,
+			},
+			want:    nil,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &DeliveryRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			got, err := r.Claim(tt.args.ctx, tt.args.now, tt.args.lease, tt.args.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Claim() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Claim() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeliveryRepository_Update(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	delivery := mock_models.NewWebhookDelivery(t)
+	query := "UPDATE public.webhook_deliveries SET updated_at = \\$1, status = \\$2, attempts = \\$3, " +
+		"next_attempt_at = \\$4, response_status = \\$5, last_error = \\$6 WHERE id = \\$7"
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx      context.Context
+		delivery *entity.WebhookDelivery
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectExec(query).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:      context.Background(),
+				delivery: delivery,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mock.ExpectExec(query).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:      context.Background(),
+				delivery: delivery,
+			},
+			wantErr: errs.NewEntityNotFound().WithParam("delivery_id", string(delivery.ID)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &DeliveryRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			if err := r.Update(tt.args.ctx, tt.args.delivery); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	postgresInterface "github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/log"
+	"github.com/018bf/companies/pkg/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var webhookColumns = []string{
+	"webhook_subscriptions.id",
+	"webhook_subscriptions.updated_at",
+	"webhook_subscriptions.created_at",
+	"webhook_subscriptions.url",
+	"webhook_subscriptions.secret",
+	"webhook_subscriptions.event_types",
+	"webhook_subscriptions.active",
+	"webhook_subscriptions.failures",
+}
+
+type WebhookRepository struct {
+	database     *sqlx.DB
+	logger       log.Logger
+	readTimeout  time.Duration
+	listTimeout  time.Duration
+	writeTimeout time.Duration
+}
+
+func NewWebhookRepository(database *sqlx.DB, config *configs.Config, logger log.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		database:     database,
+		logger:       logger,
+		readTimeout:  time.Duration(config.Database.ReadTimeout) * time.Millisecond,
+		listTimeout:  time.Duration(config.Database.ListTimeout) * time.Millisecond,
+		writeTimeout: time.Duration(config.Database.WriteTimeout) * time.Millisecond,
+	}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+	dto := NewWebhookDTOFromModel(webhook)
+	q := sq.Insert("public.webhook_subscriptions").
+		Columns(
+			"updated_at",
+			"created_at",
+			"url",
+			"secret",
+			"event_types",
+			"active",
+			"failures",
+		).
+		Values(
+			dto.UpdatedAt,
+			dto.CreatedAt,
+			dto.URL,
+			dto.Secret,
+			dto.EventTypes,
+			dto.Active,
+			dto.Failures,
+		).
+		Suffix("RETURNING id")
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.database.QueryRowxContext(ctx, query, args...).StructScan(dto); err != nil {
+		e := errs.FromPostgresError(err)
+		return e
+	}
+	webhook.ID = entity.UUID(dto.ID)
+	return nil
+}
+
+func (r *WebhookRepository) Get(ctx context.Context, id entity.UUID) (*entity.Webhook, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+	dto := &WebhookDTO{}
+	q := sq.Select(webhookColumns...).
+		From("public.webhook_subscriptions").
+		Where(sq.Eq{"id": id}).
+		Limit(1)
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.database.GetContext(ctx, dto, query, args...); err != nil {
+		e := errs.FromPostgresError(err).WithParam("webhook_id", string(id))
+		return nil, e
+	}
+	return dto.ToModel(), nil
+}
+
+func (r *WebhookRepository) List(
+	ctx context.Context,
+	filter *entity.WebhookFilter,
+) ([]*entity.Webhook, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	var dto WebhookListDTO
+	const pageSize = uint64(10)
+	if filter.PageSize == nil {
+		filter.PageSize = utils.Pointer(pageSize)
+	}
+	q := sq.Select(webhookColumns...).
+		From("public.webhook_subscriptions").
+		OrderBy("created_at ASC").
+		Limit(*filter.PageSize)
+	if filter.PageNumber != nil && *filter.PageNumber > 1 {
+		q = q.Offset((*filter.PageNumber - 1) * *filter.PageSize)
+	}
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.database.SelectContext(ctx, &dto, query, args...); err != nil {
+		e := errs.FromPostgresError(err)
+		return nil, e
+	}
+	return dto.ToModels(), nil
+}
+
+func (r *WebhookRepository) Count(ctx context.Context, _ *entity.WebhookFilter) (uint64, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	q := sq.Select("count(id)").From("public.webhook_subscriptions")
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	var count uint64
+	if err := r.database.QueryRowxContext(ctx, query, args...).Scan(&count); err != nil {
+		e := errs.FromPostgresError(err)
+		return 0, e
+	}
+	return count, nil
+}
+
+// ListSubscribed returns active webhooks subscribed to the event type.
+func (r *WebhookRepository) ListSubscribed(
+	ctx context.Context,
+	eventType entity.EventOperation,
+) ([]*entity.Webhook, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.listTimeout)
+	defer cancel()
+	var dto WebhookListDTO
+	q := sq.Select(webhookColumns...).
+		From("public.webhook_subscriptions").
+		Where(sq.Eq{"active": true}).
+		Where(sq.Expr("(cardinality(event_types) = 0 OR ? = ANY(event_types))", string(eventType)))
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if err := r.database.SelectContext(ctx, &dto, query, args...); err != nil {
+		e := errs.FromPostgresError(err)
+		return nil, e
+	}
+	return dto.ToModels(), nil
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, webhook *entity.Webhook) error {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+	dto := NewWebhookDTOFromModel(webhook)
+	q := sq.Update("public.webhook_subscriptions").Where(sq.Eq{"id": webhook.ID}).
+		Set("updated_at", dto.UpdatedAt).
+		Set("url", dto.URL).
+		Set("secret", dto.Secret).
+		Set("event_types", dto.EventTypes).
+		Set("active", dto.Active).
+		Set("failures", dto.Failures)
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	result, err := r.database.ExecContext(ctx, query, args...)
+	if err != nil {
+		e := errs.FromPostgresError(err).WithParam("webhook_id", fmt.Sprint(webhook.ID))
+		return e
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		e := errs.FromPostgresError(err).WithParam("webhook_id", fmt.Sprint(webhook.ID))
+		return e
+	}
+	if affected == 0 {
+		e := errs.NewEntityNotFound().WithParam("webhook_id", fmt.Sprint(webhook.ID))
+		return e
+	}
+	return nil
+}
+
+// RecordFailure counts a failed delivery attempt.
+// The webhook is deactivated once it fails maxFailures times in a row.
+// It returns whether the webhook is still active.
+func (r *WebhookRepository) RecordFailure(ctx context.Context, id entity.UUID, maxFailures int) (bool, error) {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+	q := sq.Update("public.webhook_subscriptions").Where(sq.Eq{"id": id}).
+		Set("failures", sq.Expr("failures + 1")).
+		Set("active", sq.Expr("active AND failures + 1 < ?", maxFailures)).
+		Suffix("RETURNING active")
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	var active bool
+	if err := r.database.QueryRowxContext(ctx, query, args...).Scan(&active); err != nil {
+		e := errs.FromPostgresError(err).WithParam("webhook_id", fmt.Sprint(id))
+		return false, e
+	}
+	return active, nil
+}
+
+// ResetFailures clears the count of failures in a row after a successful delivery.
+func (r *WebhookRepository) ResetFailures(ctx context.Context, id entity.UUID) error {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+	q := sq.Update("public.webhook_subscriptions").
+		Where(sq.Eq{"id": id}).
+		Where(sq.NotEq{"failures": 0}).
+		Set("failures", 0)
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	if _, err := r.database.ExecContext(ctx, query, args...); err != nil {
+		e := errs.FromPostgresError(err).WithParam("webhook_id", fmt.Sprint(id))
+		return e
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id entity.UUID) error {
+	ctx, cancel := postgresInterface.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+	q := sq.Delete("public.webhook_subscriptions").Where(sq.Eq{"id": id})
+	query, args := q.PlaceholderFormat(sq.Dollar).MustSql()
+	result, err := r.database.ExecContext(ctx, query, args...)
+	if err != nil {
+		e := errs.FromPostgresError(err).WithParam("webhook_id", fmt.Sprint(id))
+		return e
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		e := errs.FromPostgresError(err).WithParam("webhook_id", fmt.Sprint(id))
+		return e
+	}
+	if affected == 0 {
+		e := errs.NewEntityNotFound().WithParam("webhook_id", fmt.Sprint(id))
+		return e
+	}
+	return nil
+}
+
+type WebhookDTO struct {
+	ID         string                        `db:"id,omitempty"`
+	UpdatedAt  time.Time                     `db:"updated_at,omitempty"`
+	CreatedAt  time.Time                     `db:"created_at,omitempty"`
+	URL        string                        `db:"url"`
+	Secret     string                        `db:"secret"`
+	EventTypes postgresInterface.StringArray `db:"event_types"`
+	Active     bool                          `db:"active"`
+	Failures   int                           `db:"failures"`
+}
+type WebhookListDTO []*WebhookDTO
+
+func (list WebhookListDTO) ToModels() []*entity.Webhook {
+	webhooks := make([]*entity.Webhook, len(list))
+	for i := range list {
+		webhooks[i] = list[i].ToModel()
+	}
+	return webhooks
+}
+func NewWebhookDTOFromModel(webhook *entity.Webhook) *WebhookDTO {
+	dto := &WebhookDTO{
+		ID:         string(webhook.ID),
+		UpdatedAt:  webhook.UpdatedAt,
+		CreatedAt:  webhook.CreatedAt,
+		URL:        webhook.URL,
+		Secret:     webhook.Secret,
+		EventTypes: postgresInterface.StringArray{},
+		Active:     webhook.Active,
+		Failures:   webhook.Failures,
+	}
+	for _, eventType := range webhook.EventTypes {
+		dto.EventTypes = append(dto.EventTypes, string(eventType))
+	}
+	return dto
+}
+func (dto *WebhookDTO) ToModel() *entity.Webhook {
+	model := &entity.Webhook{
+		ID:         entity.UUID(dto.ID),
+		UpdatedAt:  dto.UpdatedAt,
+		CreatedAt:  dto.CreatedAt,
+		URL:        dto.URL,
+		Secret:     dto.Secret,
+		EventTypes: make([]entity.EventOperation, len(dto.EventTypes)),
+		Active:     dto.Active,
+		Failures:   dto.Failures,
+	}
+	for i, eventType := range dto.EventTypes {
+		model.EventTypes[i] = entity.EventOperation(eventType)
+	}
+	return model
+}
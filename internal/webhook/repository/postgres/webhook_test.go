@@ -0,0 +1,561 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/internal/interfaces/postgres"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jmoiron/sqlx"
+)
+
+func webhookRows(webhooks ...*entity.Webhook) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		"id",
+		"updated_at",
+		"created_at",
+		"url",
+		"secret",
+		"event_types",
+		"active",
+		"failures",
+	})
+	for _, webhook := range webhooks {
+		dto := NewWebhookDTOFromModel(webhook)
+		eventTypes := "{" + strings.Join(dto.EventTypes, ",") + "}"
+		rows.AddRow(
+			dto.ID,
+			dto.UpdatedAt,
+			dto.CreatedAt,
+			dto.URL,
+			dto.Secret,
+			eventTypes,
+			dto.Active,
+			dto.Failures,
+		)
+	}
+	return rows
+}
+
+func TestWebhookRepository_Create(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	query := "INSERT INTO public.webhook_subscriptions"
+	webhook := mock_models.NewWebhook(t)
+	ctx := context.Background()
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx     context.Context
+		webhook *entity.Webhook
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(
+						webhook.UpdatedAt,
+						webhook.CreatedAt,
+						webhook.URL,
+						webhook.Secret,
+						postgres.StringArray{"created", "updated"},
+						webhook.Active,
+						webhook.Failures,
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(webhook.ID))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:     ctx,
+				webhook: webhook,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WillReturnError(errors.New("test error"))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:     ctx,
+				webhook: webhook,
+			},
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &WebhookRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			if err := r.Create(tt.args.ctx, tt.args.webhook); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_Get(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	webhook := mock_models.NewWebhook(t)
+	query := "SELECT webhook_subscriptions.id, webhook_subscriptions.updated_at, webhook_subscriptions.created_at, " +
+		"webhook_subscriptions.url, webhook_subscriptions.secret, webhook_subscriptions.event_types, " +
+		"webhook_subscriptions.active, webhook_subscriptions.failures " +
+		"FROM public.webhook_subscriptions WHERE id = \\$1 LIMIT 1"
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx context.Context
+		id  entity.UUID
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		want    *entity.Webhook
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(webhook.ID).
+					WillReturnRows(webhookRows(webhook))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  webhook.ID,
+			},
+			want:    webhook,
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(webhook.ID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  webhook.ID,
+			},
+			want:    nil,
+			wantErr: errs.NewEntityNotFound().WithParam("webhook_id", string(webhook.ID)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &WebhookRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			got, err := r.Get(tt.args.ctx, tt.args.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Get() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Get() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_ListSubscribed(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	webhooks := []*entity.Webhook{mock_models.NewWebhook(t), mock_models.NewWebhook(t)}
+	query := "SELECT .+ FROM public.webhook_subscriptions " +
+		"WHERE active = \\$1 AND \\(cardinality\\(event_types\\) = 0 OR \\$2 = ANY\\(event_types\\)\\)"
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx       context.Context
+		eventType entity.EventOperation
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		want    []*entity.Webhook
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(true, "created").
+					WillReturnRows(webhookRows(webhooks...))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:       context.Background(),
+				eventType: entity.EventTypeCreated,
+			},
+			want:    webhooks,
+			wantErr: nil,
+		},
+		{
+			name: "database error",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(true, "deleted").
+					WillReturnError(errors.New("test error"))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:       context.Background(),
+				eventType: entity.EventTypeDeleted,
+			},
+			want:    nil,
+			wantErr: errs.FromPostgresError(errors.New("test error")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &WebhookRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			got, err := r.ListSubscribed(tt.args.ctx, tt.args.eventType)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ListSubscribed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListSubscribed() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_RecordFailure(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	webhook := mock_models.NewWebhook(t)
+	query := "UPDATE public.webhook_subscriptions SET failures = failures \\+ 1, " +
+		"active = active AND failures \\+ 1 < \\$1 WHERE id = \\$2 RETURNING active"
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx         context.Context
+		id          entity.UUID
+		maxFailures int
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "still active",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(5, webhook.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(true))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:         context.Background(),
+				id:          webhook.ID,
+				maxFailures: 5,
+			},
+			want:    true,
+			wantErr: nil,
+		},
+		{
+			name: "disabled",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(5, webhook.ID).
+					WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(false))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:         context.Background(),
+				id:          webhook.ID,
+				maxFailures: 5,
+			},
+			want:    false,
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mock.ExpectQuery(query).
+					WithArgs(5, webhook.ID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:         context.Background(),
+				id:          webhook.ID,
+				maxFailures: 5,
+			},
+			want:    false,
+			wantErr: errs.NewEntityNotFound().WithParam("webhook_id", string(webhook.ID)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &WebhookRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			got, err := r.RecordFailure(tt.args.ctx, tt.args.id, tt.args.maxFailures)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RecordFailure() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("RecordFailure() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_Update(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	webhook := mock_models.NewWebhook(t)
+	query := "UPDATE public.webhook_subscriptions SET updated_at = \\$1, url = \\$2, secret = \\$3, " +
+		"event_types = \\$4, active = \\$5, failures = \\$6 WHERE id = \\$7"
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx     context.Context
+		webhook *entity.Webhook
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectExec(query).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				webhook: webhook,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mock.ExpectExec(query).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx:     context.Background(),
+				webhook: webhook,
+			},
+			wantErr: errs.NewEntityNotFound().WithParam("webhook_id", string(webhook.ID)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &WebhookRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			if err := r.Update(tt.args.ctx, tt.args.webhook); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookRepository_Delete(t *testing.T) {
+	db, mock, err := postgres.NewMockPostgreSQL(t)
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+	defer db.Close()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logger := mock_log.NewMockLogger(ctrl)
+	webhook := mock_models.NewWebhook(t)
+	type fields struct {
+		database *sqlx.DB
+		logger   log.Logger
+	}
+	type args struct {
+		ctx context.Context
+		id  entity.UUID
+	}
+	tests := []struct {
+		name    string
+		setup   func()
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				mock.ExpectExec("DELETE FROM public.webhook_subscriptions WHERE id = \\$1").
+					WithArgs(webhook.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  webhook.ID,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				mock.ExpectExec("DELETE FROM public.webhook_subscriptions WHERE id = \\$1").
+					WithArgs(webhook.ID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			fields: fields{
+				database: db,
+				logger:   logger,
+			},
+			args: args{
+				ctx: context.Background(),
+				id:  webhook.ID,
+			},
+			wantErr: errs.NewEntityNotFound().WithParam("webhook_id", string(webhook.ID)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			r := &WebhookRepository{
+				database: tt.fields.database,
+				logger:   tt.fields.logger,
+			}
+			if err := r.Delete(tt.args.ctx, tt.args.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/clock"
+	"github.com/018bf/companies/pkg/log"
+)
+
+//go:generate mockgen -source=delivery.go -package=service -destination=delivery_mock.go
+
+type deliveryRepository interface {
+	Get(ctx context.Context, id entity.UUID) (*entity.WebhookDelivery, error)
+	List(ctx context.Context, filter *entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error)
+	Count(ctx context.Context, filter *entity.WebhookDeliveryFilter) (uint64, error)
+	Create(ctx context.Context, delivery *entity.WebhookDelivery) error
+	Update(ctx context.Context, delivery *entity.WebhookDelivery) error
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit uint64) ([]*entity.WebhookDelivery, error)
+}
+
+type sender interface {
+	Send(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) (int, error)
+}
+
+type DeliveryService struct {
+	deliveryRepository deliveryRepository
+	webhookRepository  webhookRepository
+	sender             sender
+	clock              clock.Clock
+	logger             log.Logger
+	batchSize          uint64
+	lease              time.Duration
+	maxAttempts        int
+	maxFailures        int
+	backoffBase        time.Duration
+	backoffMax         time.Duration
+}
+
+func NewDeliveryService(
+	deliveryRepository deliveryRepository,
+	webhookRepository webhookRepository,
+	sender sender,
+	config *configs.Config,
+	clock clock.Clock,
+	logger log.Logger,
+) *DeliveryService {
+	return &DeliveryService{
+		deliveryRepository: deliveryRepository,
+		webhookRepository:  webhookRepository,
+		sender:             sender,
+		clock:              clock,
+		logger:             logger,
+		batchSize:          config.Webhooks.BatchSize,
+		lease:              2 * time.Duration(config.Webhooks.Timeout) * time.Second,
+		maxAttempts:        config.Webhooks.MaxAttempts,
+		maxFailures:        config.Webhooks.MaxFailures,
+		backoffBase:        time.Duration(config.Webhooks.BackoffBase) * time.Second,
+		backoffMax:         time.Duration(config.Webhooks.BackoffMax) * time.Second,
+	}
+}
+
+// Dispatch queues the event for every webhook subscribed to it.
+func (u *DeliveryService) Dispatch(ctx context.Context, event *entity.Event) error {
+	webhooks, err := u.webhookRepository.ListSubscribed(ctx, event.Operation)
+	if err != nil {
+		return err
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	now := u.clock.Now().UTC()
+	for _, webhook := range webhooks {
+		delivery := &entity.WebhookDelivery{
+			UpdatedAt:     now,
+			CreatedAt:     now,
+			WebhookID:     webhook.ID,
+			EventType:     event.Operation,
+			Payload:       payload,
+			Status:        entity.WebhookDeliveryStatusPending,
+			NextAttemptAt: now,
+		}
+		if err := u.deliveryRepository.Create(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deliver sends a batch of due deliveries and returns its size.
+func (u *DeliveryService) Deliver(ctx context.Context) (int, error) {
+	deliveries, err := u.deliveryRepository.Claim(ctx, u.clock.Now().UTC(), u.lease, u.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, delivery := range deliveries {
+		if err := u.deliver(ctx, delivery); err != nil {
+			u.logger.Error(
+				"can't deliver webhook",
+				log.Context(ctx),
+				log.Any("delivery_id", delivery.ID),
+				log.Error(err),
+			)
+		}
+	}
+	return len(deliveries), nil
+}
+
+func (u *DeliveryService) deliver(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	webhook, err := u.webhookRepository.Get(ctx, delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+	if !webhook.Active {
+		delivery.Status = entity.WebhookDeliveryStatusFailed
+		delivery.LastError = "Webhook is disabled."
+		delivery.UpdatedAt = u.clock.Now().UTC()
+		return u.deliveryRepository.Update(ctx, delivery)
+	}
+	status, sendErr := u.sender.Send(ctx, webhook, delivery)
+	now := u.clock.Now().UTC()
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	delivery.UpdatedAt = now
+	if sendErr == nil {
+		delivery.Status = entity.WebhookDeliveryStatusSucceeded
+		delivery.LastError = ""
+		if webhook.Failures > 0 {
+			if err := u.webhookRepository.ResetFailures(ctx, webhook.ID); err != nil {
+				return err
+			}
+		}
+		return u.deliveryRepository.Update(ctx, delivery)
+	}
+	delivery.LastError = sendErr.Error()
+	active, err := u.webhookRepository.RecordFailure(ctx, webhook.ID, u.maxFailures)
+	if err != nil {
+		return err
+	}
+	if !active {
+		u.logger.Warn("webhook is disabled after repeated failures", log.Context(ctx), log.Any("webhook_id", webhook.ID))
+	}
+	if !active || delivery.Attempts >= u.maxAttempts {
+		delivery.Status = entity.WebhookDeliveryStatusFailed
+	} else {
+		delivery.NextAttemptAt = now.Add(u.backoff(delivery.Attempts))
+	}
+	return u.deliveryRepository.Update(ctx, delivery)
+}
+
+// backoff doubles the delay with every attempt up to the configured maximum.
+func (u *DeliveryService) backoff(attempts int) time.Duration {
+	delay := u.backoffBase
+	for i := 1; i < attempts && delay < u.backoffMax; i++ {
+		delay *= 2
+	}
+	if delay > u.backoffMax {
+		return u.backoffMax
+	}
+	return delay
+}
+
+func (u *DeliveryService) List(
+	ctx context.Context,
+	filter *entity.WebhookDeliveryFilter,
+) ([]*entity.WebhookDelivery, uint64, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+	deliveries, err := u.deliveryRepository.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := u.deliveryRepository.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, count, nil
+}
+
+// Replay queues a copy of the delivery as a new pending delivery.
+func (u *DeliveryService) Replay(ctx context.Context, id entity.UUID) (*entity.WebhookDelivery, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	original, err := u.deliveryRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := u.webhookRepository.Get(ctx, original.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !webhook.Active {
+		return nil, errs.NewError(errs.ErrorCodeFailedPrecondition, "Webhook is disabled.").
+			WithParam("webhook_id", string(webhook.ID))
+	}
+	now := u.clock.Now().UTC()
+	delivery := &entity.WebhookDelivery{
+		UpdatedAt:     now,
+		CreatedAt:     now,
+		WebhookID:     original.WebhookID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        entity.WebhookDeliveryStatusPending,
+		NextAttemptAt: now,
+	}
+	if err := u.deliveryRepository.Create(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
@@ -0,0 +1,163 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: delivery.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockdeliveryRepository is a mock of deliveryRepository interface.
+type MockdeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeliveryRepositoryMockRecorder
+}
+
+// MockdeliveryRepositoryMockRecorder is the mock recorder for MockdeliveryRepository.
+type MockdeliveryRepositoryMockRecorder struct {
+	mock *MockdeliveryRepository
+}
+
+// NewMockdeliveryRepository creates a new mock instance.
+func NewMockdeliveryRepository(ctrl *gomock.Controller) *MockdeliveryRepository {
+	mock := &MockdeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockdeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeliveryRepository) EXPECT() *MockdeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockdeliveryRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit uint64) ([]*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockdeliveryRepositoryMockRecorder) Claim(ctx, now, lease, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockdeliveryRepository)(nil).Claim), ctx, now, lease, limit)
+}
+
+// Count mocks base method.
+func (m *MockdeliveryRepository) Count(ctx context.Context, filter *entity.WebhookDeliveryFilter) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockdeliveryRepositoryMockRecorder) Count(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockdeliveryRepository)(nil).Count), ctx, filter)
+}
+
+// Create mocks base method.
+func (m *MockdeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockdeliveryRepositoryMockRecorder) Create(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockdeliveryRepository)(nil).Create), ctx, delivery)
+}
+
+// Get mocks base method.
+func (m *MockdeliveryRepository) Get(ctx context.Context, id entity.UUID) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockdeliveryRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockdeliveryRepository)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockdeliveryRepository) List(ctx context.Context, filter *entity.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockdeliveryRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockdeliveryRepository)(nil).List), ctx, filter)
+}
+
+// Update mocks base method.
+func (m *MockdeliveryRepository) Update(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockdeliveryRepositoryMockRecorder) Update(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockdeliveryRepository)(nil).Update), ctx, delivery)
+}
+
+// Mocksender is a mock of sender interface.
+type Mocksender struct {
+	ctrl     *gomock.Controller
+	recorder *MocksenderMockRecorder
+}
+
+// MocksenderMockRecorder is the mock recorder for Mocksender.
+type MocksenderMockRecorder struct {
+	mock *Mocksender
+}
+
+// NewMocksender creates a new mock instance.
+func NewMocksender(ctrl *gomock.Controller) *Mocksender {
+	mock := &Mocksender{ctrl: ctrl}
+	mock.recorder = &MocksenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocksender) EXPECT() *MocksenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *Mocksender) Send(ctx context.Context, webhook *entity.Webhook, delivery *entity.WebhookDelivery) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, webhook, delivery)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MocksenderMockRecorder) Send(ctx, webhook, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*Mocksender)(nil).Send), ctx, webhook, delivery)
+}
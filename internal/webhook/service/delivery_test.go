@@ -0,0 +1,328 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func newTestDeliveryService(
+	t *testing.T,
+	deliveryRepository deliveryRepository,
+	webhookRepository webhookRepository,
+	sender sender,
+	clockMock *mock_clock.MockClock,
+	logger *mock_log.MockLogger,
+) *DeliveryService {
+	t.Helper()
+	return NewDeliveryService(
+		deliveryRepository,
+		webhookRepository,
+		sender,
+		configs.NewMockConfig(t),
+		clockMock,
+		logger,
+	)
+}
+
+func TestDeliveryService_Dispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	deliveryRepository := NewMockdeliveryRepository(ctrl)
+	webhookRepository := NewMockwebhookRepository(ctrl)
+	senderMock := NewMocksender(ctrl)
+	clockMock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	event := mock_models.NewEvent(t)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	webhook := mock_models.NewWebhook(t)
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				webhookRepository.EXPECT().
+					ListSubscribed(ctx, event.Operation).
+					Return([]*entity.Webhook{webhook}, nil)
+				clockMock.EXPECT().Now().Return(now)
+				deliveryRepository.EXPECT().
+					Create(ctx, &entity.WebhookDelivery{
+						UpdatedAt:     now,
+						CreatedAt:     now,
+						WebhookID:     webhook.ID,
+						EventType:     event.Operation,
+						Payload:       payload,
+						Status:        entity.WebhookDeliveryStatusPending,
+						NextAttemptAt: now,
+					}).
+					Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "no subscribers",
+			setup: func() {
+				webhookRepository.EXPECT().ListSubscribed(ctx, event.Operation).Return(nil, nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "repository error",
+			setup: func() {
+				webhookRepository.EXPECT().
+					ListSubscribed(ctx, event.Operation).
+					Return(nil, errs.NewUnexpectedBehaviorError("test error"))
+			},
+			wantErr: errs.NewUnexpectedBehaviorError("test error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := newTestDeliveryService(t, deliveryRepository, webhookRepository, senderMock, clockMock, logger)
+			if err := u.Dispatch(ctx, event); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Dispatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeliveryService_Deliver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	deliveryRepository := NewMockdeliveryRepository(ctrl)
+	webhookRepository := NewMockwebhookRepository(ctrl)
+	senderMock := NewMocksender(ctrl)
+	clockMock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	config := configs.NewMockConfig(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	lease := 2 * time.Duration(config.Webhooks.Timeout) * time.Second
+	webhook := mock_models.NewWebhook(t)
+	webhook.Failures = 3
+	sendErr := errs.NewError(errs.ErrorCodeUnavailable, "Webhook endpoint responded with an error.")
+	tests := []struct {
+		name     string
+		setup    func(delivery *entity.WebhookDelivery)
+		attempts int
+		want     *entity.WebhookDelivery
+		wantErr  error
+	}{
+		{
+			name: "succeeded",
+			setup: func(delivery *entity.WebhookDelivery) {
+				webhookRepository.EXPECT().Get(ctx, delivery.WebhookID).Return(webhook, nil)
+				senderMock.EXPECT().Send(ctx, webhook, delivery).Return(204, nil)
+				webhookRepository.EXPECT().ResetFailures(ctx, webhook.ID).Return(nil)
+			},
+			attempts: 0,
+			want: &entity.WebhookDelivery{
+				Status:         entity.WebhookDeliveryStatusSucceeded,
+				Attempts:       1,
+				ResponseStatus: 204,
+			},
+		},
+		{
+			name: "retry with backoff",
+			setup: func(delivery *entity.WebhookDelivery) {
+				webhookRepository.EXPECT().Get(ctx, delivery.WebhookID).Return(webhook, nil)
+				senderMock.EXPECT().Send(ctx, webhook, delivery).Return(500, sendErr)
+				webhookRepository.EXPECT().
+					RecordFailure(ctx, webhook.ID, config.Webhooks.MaxFailures).
+					Return(true, nil)
+			},
+			attempts: 2,
+			want: &entity.WebhookDelivery{
+				Status:         entity.WebhookDeliveryStatusPending,
+				Attempts:       3,
+				ResponseStatus: 500,
+				NextAttemptAt:  now.Add(40 * time.Second),
+				LastError:      sendErr.Error(),
+			},
+		},
+		{
+			name: "max attempts",
+			setup: func(delivery *entity.WebhookDelivery) {
+				webhookRepository.EXPECT().Get(ctx, delivery.WebhookID).Return(webhook, nil)
+				senderMock.EXPECT().Send(ctx, webhook, delivery).Return(500, sendErr)
+				webhookRepository.EXPECT().
+					RecordFailure(ctx, webhook.ID, config.Webhooks.MaxFailures).
+					Return(true, nil)
+			},
+			attempts: 9,
+			want: &entity.WebhookDelivery{
+				Status:         entity.WebhookDeliveryStatusFailed,
+				Attempts:       10,
+				ResponseStatus: 500,
+				LastError:      sendErr.Error(),
+			},
+		},
+		{
+			name: "webhook disabled",
+			setup: func(delivery *entity.WebhookDelivery) {
+				webhookRepository.EXPECT().Get(ctx, delivery.WebhookID).Return(webhook, nil)
+				senderMock.EXPECT().Send(ctx, webhook, delivery).Return(0, sendErr)
+				webhookRepository.EXPECT().
+					RecordFailure(ctx, webhook.ID, config.Webhooks.MaxFailures).
+					Return(false, nil)
+				logger.EXPECT().Warn(gomock.Any(), gomock.Any(), gomock.Any())
+			},
+			attempts: 0,
+			want: &entity.WebhookDelivery{
+				Status:    entity.WebhookDeliveryStatusFailed,
+				Attempts:  1,
+				LastError: sendErr.Error(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := mock_models.NewWebhookDelivery(t)
+			delivery.WebhookID = webhook.ID
+			delivery.Attempts = tt.attempts
+			clockMock.EXPECT().Now().Return(now).Times(2)
+			deliveryRepository.EXPECT().
+				Claim(ctx, now, lease, config.Webhooks.BatchSize).
+				Return([]*entity.WebhookDelivery{delivery}, nil)
+			tt.setup(delivery)
+			deliveryRepository.EXPECT().Update(ctx, delivery).Return(nil)
+			u := newTestDeliveryService(t, deliveryRepository, webhookRepository, senderMock, clockMock, logger)
+			got, err := u.Deliver(ctx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Deliver() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != 1 {
+				t.Errorf("Deliver() got = %v, want 1", got)
+			}
+			if tt.want.NextAttemptAt.IsZero() {
+				tt.want.NextAttemptAt = delivery.NextAttemptAt
+			}
+			result := &entity.WebhookDelivery{
+				Status:         delivery.Status,
+				Attempts:       delivery.Attempts,
+				ResponseStatus: delivery.ResponseStatus,
+				NextAttemptAt:  delivery.NextAttemptAt,
+				LastError:      delivery.LastError,
+			}
+			if !reflect.DeepEqual(result, tt.want) {
+				t.Errorf("Deliver() delivery = %v, want %v", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeliveryService_backoff(t *testing.T) {
+	u := &DeliveryService{backoffBase: 10 * time.Second, backoffMax: time.Hour}
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 10 * time.Second},
+		{attempts: 2, want: 20 * time.Second},
+		{attempts: 5, want: 160 * time.Second},
+		{attempts: 20, want: time.Hour},
+	}
+	for _, tt := range tests {
+		if got := u.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestDeliveryService_Replay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	deliveryRepository := NewMockdeliveryRepository(ctrl)
+	webhookRepository := NewMockwebhookRepository(ctrl)
+	senderMock := NewMocksender(ctrl)
+	clockMock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	webhook := mock_models.NewWebhook(t)
+	disabled := mock_models.NewWebhook(t)
+	disabled.Active = false
+	original := mock_models.NewWebhookDelivery(t)
+	original.WebhookID = webhook.ID
+	original.Status = entity.WebhookDeliveryStatusFailed
+	original.Attempts = 10
+	tests := []struct {
+		name    string
+		setup   func()
+		want    *entity.WebhookDelivery
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				deliveryRepository.EXPECT().Get(ctx, original.ID).Return(original, nil)
+				webhookRepository.EXPECT().Get(ctx, webhook.ID).Return(webhook, nil)
+				clockMock.EXPECT().Now().Return(now)
+				deliveryRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			},
+			want: &entity.WebhookDelivery{
+				UpdatedAt:     now,
+				CreatedAt:     now,
+				WebhookID:     webhook.ID,
+				EventType:     original.EventType,
+				Payload:       original.Payload,
+				Status:        entity.WebhookDeliveryStatusPending,
+				NextAttemptAt: now,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "webhook disabled",
+			setup: func() {
+				deliveryRepository.EXPECT().Get(ctx, original.ID).Return(original, nil)
+				webhookRepository.EXPECT().Get(ctx, webhook.ID).Return(disabled, nil)
+			},
+			want: nil,
+			wantErr: errs.NewError(errs.ErrorCodeFailedPrecondition, "Webhook is disabled.").
+				WithParam("webhook_id", string(disabled.ID)),
+		},
+		{
+			name: "not found",
+			setup: func() {
+				deliveryRepository.EXPECT().Get(ctx, original.ID).Return(nil, errs.NewEntityNotFound())
+			},
+			want:    nil,
+			wantErr: errs.NewEntityNotFound(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := newTestDeliveryService(t, deliveryRepository, webhookRepository, senderMock, clockMock, logger)
+			got, err := u.Replay(ctx, original.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Replay() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Replay() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+
+	"github.com/018bf/companies/internal/entity"
+	"github.com/018bf/companies/pkg/clock"
+	"github.com/018bf/companies/pkg/log"
+)
+
+//go:generate mockgen -source=webhook.go -package=service -destination=webhook_mock.go
+
+type webhookRepository interface {
+	Get(ctx context.Context, id entity.UUID) (*entity.Webhook, error)
+	List(ctx context.Context, filter *entity.WebhookFilter) ([]*entity.Webhook, error)
+	Count(ctx context.Context, filter *entity.WebhookFilter) (uint64, error)
+	ListSubscribed(ctx context.Context, eventType entity.EventOperation) ([]*entity.Webhook, error)
+	Update(ctx context.Context, webhook *entity.Webhook) error
+	Create(ctx context.Context, webhook *entity.Webhook) error
+	Delete(ctx context.Context, id entity.UUID) error
+	RecordFailure(ctx context.Context, id entity.UUID, maxFailures int) (bool, error)
+	ResetFailures(ctx context.Context, id entity.UUID) error
+}
+
+type WebhookService struct {
+	webhookRepository webhookRepository
+	clock             clock.Clock
+	logger            log.Logger
+}
+
+func NewWebhookService(
+	webhookRepository webhookRepository,
+	clock clock.Clock,
+	logger log.Logger,
+) *WebhookService {
+	return &WebhookService{webhookRepository: webhookRepository, clock: clock, logger: logger}
+}
+
+func (u *WebhookService) Create(ctx context.Context, create *entity.WebhookCreate) (*entity.Webhook, error) {
+	if err := create.Validate(); err != nil {
+		return nil, err
+	}
+	now := u.clock.Now().UTC()
+	webhook := &entity.Webhook{
+		ID:         "",
+		UpdatedAt:  now,
+		CreatedAt:  now,
+		URL:        create.URL,
+		Secret:     create.Secret,
+		EventTypes: create.EventTypes,
+		Active:     true,
+		Failures:   0,
+	}
+	if err := u.webhookRepository.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (u *WebhookService) Get(ctx context.Context, id entity.UUID) (*entity.Webhook, error) {
+	if err := id.Validate(); err != nil {
+		return nil, err
+	}
+	webhook, err := u.webhookRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (u *WebhookService) List(
+	ctx context.Context,
+	filter *entity.WebhookFilter,
+) ([]*entity.Webhook, uint64, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, 0, err
+	}
+	webhooks, err := u.webhookRepository.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := u.webhookRepository.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return webhooks, count, nil
+}
+
+// Update applies the update to the webhook.
+// Re-activating a disabled webhook resets its failure counter.
+func (u *WebhookService) Update(ctx context.Context, update *entity.WebhookUpdate) (*entity.Webhook, error) {
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+	webhook, err := u.webhookRepository.Get(ctx, update.ID)
+	if err != nil {
+		return nil, err
+	}
+	if update.URL != nil {
+		webhook.URL = *update.URL
+	}
+	if update.Secret != nil {
+		webhook.Secret = *update.Secret
+	}
+	if update.EventTypes != nil {
+		webhook.EventTypes = *update.EventTypes
+	}
+	if update.Active != nil {
+		if *update.Active && !webhook.Active {
+			webhook.Failures = 0
+		}
+		webhook.Active = *update.Active
+	}
+	if err := webhook.Validate(); err != nil {
+		return nil, err
+	}
+	webhook.UpdatedAt = u.clock.Now().UTC()
+	if err := u.webhookRepository.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (u *WebhookService) Delete(ctx context.Context, id entity.UUID) error {
+	if err := u.webhookRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return nil
+}
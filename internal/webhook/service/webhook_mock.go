@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/018bf/companies/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockwebhookRepository is a mock of webhookRepository interface.
+type MockwebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockwebhookRepositoryMockRecorder
+}
+
+// MockwebhookRepositoryMockRecorder is the mock recorder for MockwebhookRepository.
+type MockwebhookRepositoryMockRecorder struct {
+	mock *MockwebhookRepository
+}
+
+// NewMockwebhookRepository creates a new mock instance.
+func NewMockwebhookRepository(ctrl *gomock.Controller) *MockwebhookRepository {
+	mock := &MockwebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockwebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwebhookRepository) EXPECT() *MockwebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockwebhookRepository) Count(ctx context.Context, filter *entity.WebhookFilter) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockwebhookRepositoryMockRecorder) Count(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockwebhookRepository)(nil).Count), ctx, filter)
+}
+
+// Create mocks base method.
+func (m *MockwebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockwebhookRepositoryMockRecorder) Create(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockwebhookRepository)(nil).Create), ctx, webhook)
+}
+
+// Delete mocks base method.
+func (m *MockwebhookRepository) Delete(ctx context.Context, id entity.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockwebhookRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockwebhookRepository)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockwebhookRepository) Get(ctx context.Context, id entity.UUID) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockwebhookRepositoryMockRecorder) Get(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockwebhookRepository)(nil).Get), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockwebhookRepository) List(ctx context.Context, filter *entity.WebhookFilter) ([]*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockwebhookRepositoryMockRecorder) List(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockwebhookRepository)(nil).List), ctx, filter)
+}
+
+// ListSubscribed mocks base method.
+func (m *MockwebhookRepository) ListSubscribed(ctx context.Context, eventType entity.EventOperation) ([]*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscribed", ctx, eventType)
+	ret0, _ := ret[0].([]*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscribed indicates an expected call of ListSubscribed.
+func (mr *MockwebhookRepositoryMockRecorder) ListSubscribed(ctx, eventType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscribed", reflect.TypeOf((*MockwebhookRepository)(nil).ListSubscribed), ctx, eventType)
+}
+
+// RecordFailure mocks base method.
+func (m *MockwebhookRepository) RecordFailure(ctx context.Context, id entity.UUID, maxFailures int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", ctx, id, maxFailures)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockwebhookRepositoryMockRecorder) RecordFailure(ctx, id, maxFailures interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockwebhookRepository)(nil).RecordFailure), ctx, id, maxFailures)
+}
+
+// ResetFailures mocks base method.
+func (m *MockwebhookRepository) ResetFailures(ctx context.Context, id entity.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetFailures", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetFailures indicates an expected call of ResetFailures.
+func (mr *MockwebhookRepositoryMockRecorder) ResetFailures(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetFailures", reflect.TypeOf((*MockwebhookRepository)(nil).ResetFailures), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockwebhookRepository) Update(ctx context.Context, webhook *entity.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockwebhookRepositoryMockRecorder) Update(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockwebhookRepository)(nil).Update), ctx, webhook)
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/entity"
+	mock_models "github.com/018bf/companies/internal/entity/mock"
+	"github.com/018bf/companies/internal/errs"
+	mock_clock "github.com/018bf/companies/pkg/clock/mock"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/018bf/companies/pkg/utils"
+	"github.com/golang/mock/gomock"
+)
+
+func TestWebhookService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	webhookRepository := NewMockwebhookRepository(ctrl)
+	clockMock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	create := mock_models.NewWebhookCreate(t)
+	tests := []struct {
+		name    string
+		setup   func()
+		create  *entity.WebhookCreate
+		want    *entity.Webhook
+		wantErr error
+	}{
+		{
+			name: "ok",
+			setup: func() {
+				clockMock.EXPECT().Now().Return(now)
+				webhookRepository.EXPECT().
+					Create(ctx, &entity.Webhook{
+						UpdatedAt:  now,
+						CreatedAt:  now,
+						URL:        create.URL,
+						Secret:     create.Secret,
+						EventTypes: create.EventTypes,
+						Active:     true,
+					}).
+					Return(nil)
+			},
+			create: create,
+			want: &entity.Webhook{
+				UpdatedAt:  now,
+				CreatedAt:  now,
+				URL:        create.URL,
+				Secret:     create.Secret,
+				EventTypes: create.EventTypes,
+				Active:     true,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "repository error",
+			setup: func() {
+				clockMock.EXPECT().Now().Return(now)
+				webhookRepository.EXPECT().
+					Create(ctx, gomock.Any()).
+					Return(errs.NewUnexpectedBehaviorError("test error"))
+			},
+			create:  create,
+			want:    nil,
+			wantErr: errs.NewUnexpectedBehaviorError("test error"),
+		},
+		{
+			name:  "invalid",
+			setup: func() {},
+			create: &entity.WebhookCreate{
+				URL:    "not a url",
+				Secret: "short",
+			},
+			want: nil,
+			wantErr: errs.NewInvalidFormError().WithParams(map[string]string{
+				"url":    "must be a valid URL",
+				"secret": "the length must be between 16 and 256",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := &WebhookService{
+				webhookRepository: webhookRepository,
+				clock:             clockMock,
+				logger:            logger,
+			}
+			got, err := u.Create(ctx, tt.create)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Create() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookService_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	webhookRepository := NewMockwebhookRepository(ctrl)
+	clockMock := mock_clock.NewMockClock(ctrl)
+	logger := mock_log.NewMockLogger(ctrl)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	disabled := mock_models.NewWebhook(t)
+	disabled.Active = false
+	disabled.Failures = 50
+	tests := []struct {
+		name    string
+		setup   func()
+		update  *entity.WebhookUpdate
+		want    *entity.Webhook
+		wantErr error
+	}{
+		{
+			name: "reactivate",
+			setup: func() {
+				webhookRepository.EXPECT().Get(ctx, disabled.ID).Return(disabled, nil)
+				clockMock.EXPECT().Now().Return(now)
+				webhookRepository.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+			},
+			update: &entity.WebhookUpdate{
+				ID:     disabled.ID,
+				URL:    utils.Pointer("https://example.com/hooks"),
+				Active: utils.Pointer(true),
+			},
+			want: &entity.Webhook{
+				ID:         disabled.ID,
+				UpdatedAt:  now,
+				CreatedAt:  disabled.CreatedAt,
+				URL:        "https://example.com/hooks",
+				Secret:     disabled.Secret,
+				EventTypes: disabled.EventTypes,
+				Active:     true,
+				Failures:   0,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "not found",
+			setup: func() {
+				webhookRepository.EXPECT().Get(ctx, disabled.ID).Return(nil, errs.NewEntityNotFound())
+			},
+			update: &entity.WebhookUpdate{
+				ID:     disabled.ID,
+				Active: utils.Pointer(true),
+			},
+			want:    nil,
+			wantErr: errs.NewEntityNotFound(),
+		},
+		{
+			name: "unknown event type",
+			setup: func() {
+				webhookRepository.EXPECT().Get(ctx, disabled.ID).Return(mock_models.NewWebhook(t), nil)
+			},
+			update: &entity.WebhookUpdate{
+				ID:         disabled.ID,
+				EventTypes: &[]entity.EventOperation{"renamed"},
+			},
+			want: nil,
+			wantErr: errs.NewInvalidFormError().WithParams(map[string]string{
+				"event_types": "0: must be a valid value.",
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			u := &WebhookService{
+				webhookRepository: webhookRepository,
+				clock:             clockMock,
+				logger:            logger,
+			}
+			got, err := u.Update(ctx, tt.update)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Update() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
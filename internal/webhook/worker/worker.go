@@ -0,0 +1,76 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/018bf/companies/internal/configs"
+	"github.com/018bf/companies/pkg/log"
+)
+
+//go:generate mockgen -source=worker.go -package=worker -destination=worker_mock.go
+
+type deliveryService interface {
+	Deliver(ctx context.Context) (int, error)
+}
+
+// Worker polls due webhook deliveries and sends them.
+type Worker struct {
+	deliveryService deliveryService
+	logger          log.Logger
+	interval        time.Duration
+	done            chan struct{}
+	stop            sync.Once
+}
+
+func NewWorker(deliveryService deliveryService, config *configs.Config, logger log.Logger) *Worker {
+	return &Worker{
+		deliveryService: deliveryService,
+		logger:          logger,
+		interval:        time.Duration(config.Webhooks.Interval) * time.Second,
+		done:            make(chan struct{}),
+	}
+}
+
+// Start delivers batches until ctx is done or the worker is stopped.
+// Every tick drains all due deliveries before waiting again.
+func (w *Worker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.done:
+			return nil
+		case <-ticker.C:
+			w.drain(ctx)
+		}
+	}
+}
+
+func (w *Worker) Stop(_ context.Context) error {
+	w.stop.Do(func() {
+		close(w.done)
+	})
+	return nil
+}
+
+func (w *Worker) drain(ctx context.Context) {
+	for {
+		select {
+		case <-w.done:
+			return
+		default:
+		}
+		delivered, err := w.deliveryService.Deliver(ctx)
+		if err != nil {
+			w.logger.Error("can't deliver webhooks", log.Context(ctx), log.Error(err))
+			return
+		}
+		if delivered == 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: worker.go
+
+// Package worker is a generated GoMock package.
+package worker
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockdeliveryService is a mock of deliveryService interface.
+type MockdeliveryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeliveryServiceMockRecorder
+}
+
+// MockdeliveryServiceMockRecorder is the mock recorder for MockdeliveryService.
+type MockdeliveryServiceMockRecorder struct {
+	mock *MockdeliveryService
+}
+
+// NewMockdeliveryService creates a new mock instance.
+func NewMockdeliveryService(ctrl *gomock.Controller) *MockdeliveryService {
+	mock := &MockdeliveryService{ctrl: ctrl}
+	mock.recorder = &MockdeliveryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeliveryService) EXPECT() *MockdeliveryServiceMockRecorder {
+	return m.recorder
+}
+
+// Deliver mocks base method.
+func (m *MockdeliveryService) Deliver(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockdeliveryServiceMockRecorder) Deliver(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockdeliveryService)(nil).Deliver), ctx)
+}
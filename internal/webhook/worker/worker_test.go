@@ -0,0 +1,80 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/errs"
+	"github.com/018bf/companies/pkg/log"
+	mock_log "github.com/018bf/companies/pkg/log/mock"
+	"github.com/golang/mock/gomock"
+)
+
+func TestWorker_Start(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(service *MockdeliveryService, logger *mock_log.MockLogger, delivered chan struct{})
+	}{
+		{
+			name: "drain",
+			setup: func(service *MockdeliveryService, logger *mock_log.MockLogger, delivered chan struct{}) {
+				gomock.InOrder(
+					service.EXPECT().Deliver(gomock.Any()).Return(100, nil),
+					service.EXPECT().Deliver(gomock.Any()).DoAndReturn(func(_ context.Context) (int, error) {
+						close(delivered)
+						return 0, nil
+					}),
+				)
+				service.EXPECT().Deliver(gomock.Any()).Return(0, nil).AnyTimes()
+			},
+		},
+		{
+			name: "error",
+			setup: func(service *MockdeliveryService, logger *mock_log.MockLogger, delivered chan struct{}) {
+				service.EXPECT().
+					Deliver(gomock.Any()).
+					Return(0, errs.NewUnexpectedBehaviorError("test error")).
+					MinTimes(1)
+				logger.EXPECT().Error(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(string, ...log.Field) {
+					select {
+					case <-delivered:
+					default:
+						close(delivered)
+					}
+				}).MinTimes(1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			service := NewMockdeliveryService(ctrl)
+			logger := mock_log.NewMockLogger(ctrl)
+			delivered := make(chan struct{})
+			tt.setup(service, logger, delivered)
+			w := &Worker{
+				deliveryService: service,
+				logger:          logger,
+				interval:        time.Millisecond,
+				done:            make(chan struct{}),
+			}
+			stopped := make(chan error)
+			go func() {
+				stopped <- w.Start(context.Background())
+			}()
+			select {
+			case <-delivered:
+			case <-time.After(time.Second):
+				t.Fatal("deliveries weren't sent")
+			}
+			if err := w.Stop(context.Background()); err != nil {
+				t.Errorf("Stop() error = %v", err)
+			}
+			if err := <-stopped; err != nil {
+				t.Errorf("Start() error = %v", err)
+			}
+		})
+	}
+}
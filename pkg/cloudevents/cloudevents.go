@@ -0,0 +1,129 @@
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/018bf/companies/pkg/log"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	SpecVersion = "1.0"
+
+	ModeStructured = "structured"
+	ModeBinary     = "binary"
+
+	ContentTypeStructuredJSON = "application/cloudevents+json"
+	ContentTypeJSON           = "application/json"
+
+	// HeaderPrefix is the attribute prefix of the Kafka protocol binding in binary mode.
+	HeaderPrefix = "ce_"
+	// HeaderPrefixNATS is the attribute prefix of the NATS protocol binding in binary mode.
+	HeaderPrefixNATS  = "ce-"
+	HeaderContentType = "content-type"
+
+	// TypePrefix is the prefix of the types of the company events, the operation follows it.
+	TypePrefix = "com.companies.company."
+)
+
+type Event struct {
+	ID              string
+	Source          string
+	Type            string
+	Subject         string
+	Time            time.Time
+	DataContentType string
+	DataSchema      string
+	Extensions      map[string]string
+	Data            []byte
+}
+
+// NewEvent returns the event with a new ID at t, the request ID and the trace context of ctx
+// are passed in its extensions.
+func NewEvent(ctx context.Context, source, eventType, subject string, t time.Time) Event {
+	event := Event{
+		ID:         uuid.NewString(),
+		Source:     source,
+		Type:       eventType,
+		Subject:    subject,
+		Time:       t.UTC(),
+		Extensions: map[string]string{},
+	}
+	if requestID := ctx.Value(log.RequestIDKey); requestID != nil {
+		event.Extensions["requestid"] = fmt.Sprint(requestID)
+	}
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	for key, value := range carrier {
+		event.Extensions[key] = value
+	}
+	return event
+}
+
+// MarshalJSON encodes event in the structured content mode.
+func (e Event) MarshalJSON() ([]byte, error) {
+	envelope := make(map[string]any, len(e.Extensions)+9)
+	for key, value := range e.Extensions {
+		envelope[key] = value
+	}
+	envelope["specversion"] = SpecVersion
+	envelope["id"] = e.ID
+	envelope["source"] = e.Source
+	envelope["type"] = e.Type
+	envelope["time"] = e.Time.Format(time.RFC3339Nano)
+	if e.Subject != "" {
+		envelope["subject"] = e.Subject
+	}
+	if e.DataSchema != "" {
+		envelope["dataschema"] = e.DataSchema
+	}
+	if e.DataContentType != "" {
+		envelope["datacontenttype"] = e.DataContentType
+	}
+	if e.Data != nil {
+		if isJSON(e.DataContentType) {
+			envelope["data"] = json.RawMessage(e.Data)
+		} else {
+			envelope["data_base64"] = e.Data
+		}
+	}
+	return json.Marshal(envelope)
+}
+
+// Headers returns event attributes in the binary content mode.
+func (e Event) Headers() map[string]string {
+	return e.HeadersWithPrefix(HeaderPrefix)
+}
+
+// HeadersWithPrefix returns event attributes in the binary content mode of a protocol binding
+// which uses prefix for attribute headers.
+func (e Event) HeadersWithPrefix(prefix string) map[string]string {
+	headers := make(map[string]string, len(e.Extensions)+8)
+	for key, value := range e.Extensions {
+		headers[prefix+key] = value
+	}
+	headers[prefix+"specversion"] = SpecVersion
+	headers[prefix+"id"] = e.ID
+	headers[prefix+"source"] = e.Source
+	headers[prefix+"type"] = e.Type
+	headers[prefix+"time"] = e.Time.Format(time.RFC3339Nano)
+	if e.Subject != "" {
+		headers[prefix+"subject"] = e.Subject
+	}
+	if e.DataSchema != "" {
+		headers[prefix+"dataschema"] = e.DataSchema
+	}
+	if e.DataContentType != "" {
+		headers[HeaderContentType] = e.DataContentType
+	}
+	return headers
+}
+
+func isJSON(contentType string) bool {
+	return contentType == "" || strings.HasSuffix(contentType, "json")
+}
@@ -7,7 +7,6 @@
 package v1
 
 import (
-	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
@@ -79,6 +78,110 @@ func (CompanyType) EnumDescriptor() ([]byte, []int) {
 	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{0}
 }
 
+type CompanyChangeOperation int32
+
+const (
+	CompanyChangeOperation_COMPANY_CHANGE_OPERATION_UNKNOWN CompanyChangeOperation = 0
+	CompanyChangeOperation_COMPANY_CHANGE_OPERATION_CREATED CompanyChangeOperation = 1
+	CompanyChangeOperation_COMPANY_CHANGE_OPERATION_UPDATED CompanyChangeOperation = 2
+	CompanyChangeOperation_COMPANY_CHANGE_OPERATION_DELETED CompanyChangeOperation = 3
+)
+
+// Enum value maps for CompanyChangeOperation.
+var (
+	CompanyChangeOperation_name = map[int32]string{
+		0: "COMPANY_CHANGE_OPERATION_UNKNOWN",
+		1: "COMPANY_CHANGE_OPERATION_CREATED",
+		2: "COMPANY_CHANGE_OPERATION_UPDATED",
+		3: "COMPANY_CHANGE_OPERATION_DELETED",
+	}
+	CompanyChangeOperation_value = map[string]int32{
+		"COMPANY_CHANGE_OPERATION_UNKNOWN": 0,
+		"COMPANY_CHANGE_OPERATION_CREATED": 1,
+		"COMPANY_CHANGE_OPERATION_UPDATED": 2,
+		"COMPANY_CHANGE_OPERATION_DELETED": 3,
+	}
+)
+
+func (x CompanyChangeOperation) Enum() *CompanyChangeOperation {
+	p := new(CompanyChangeOperation)
+	*p = x
+	return p
+}
+
+func (x CompanyChangeOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CompanyChangeOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_companiespb_v1_company_proto_enumTypes[1].Descriptor()
+}
+
+func (CompanyChangeOperation) Type() protoreflect.EnumType {
+	return &file_companiespb_v1_company_proto_enumTypes[1]
+}
+
+func (x CompanyChangeOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CompanyChangeOperation.Descriptor instead.
+func (CompanyChangeOperation) EnumDescriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{1}
+}
+
+type CompanyStatsInterval int32
+
+const (
+	CompanyStatsInterval_COMPANY_STATS_INTERVAL_UNKNOWN CompanyStatsInterval = 0
+	CompanyStatsInterval_COMPANY_STATS_INTERVAL_DAY     CompanyStatsInterval = 1
+	CompanyStatsInterval_COMPANY_STATS_INTERVAL_WEEK    CompanyStatsInterval = 2
+	CompanyStatsInterval_COMPANY_STATS_INTERVAL_MONTH   CompanyStatsInterval = 3
+)
+
+// Enum value maps for CompanyStatsInterval.
+var (
+	CompanyStatsInterval_name = map[int32]string{
+		0: "COMPANY_STATS_INTERVAL_UNKNOWN",
+		1: "COMPANY_STATS_INTERVAL_DAY",
+		2: "COMPANY_STATS_INTERVAL_WEEK",
+		3: "COMPANY_STATS_INTERVAL_MONTH",
+	}
+	CompanyStatsInterval_value = map[string]int32{
+		"COMPANY_STATS_INTERVAL_UNKNOWN": 0,
+		"COMPANY_STATS_INTERVAL_DAY":     1,
+		"COMPANY_STATS_INTERVAL_WEEK":    2,
+		"COMPANY_STATS_INTERVAL_MONTH":   3,
+	}
+)
+
+func (x CompanyStatsInterval) Enum() *CompanyStatsInterval {
+	p := new(CompanyStatsInterval)
+	*p = x
+	return p
+}
+
+func (x CompanyStatsInterval) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CompanyStatsInterval) Descriptor() protoreflect.EnumDescriptor {
+	return file_companiespb_v1_company_proto_enumTypes[2].Descriptor()
+}
+
+func (CompanyStatsInterval) Type() protoreflect.EnumType {
+	return &file_companiespb_v1_company_proto_enumTypes[2]
+}
+
+func (x CompanyStatsInterval) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CompanyStatsInterval.Descriptor instead.
+func (CompanyStatsInterval) EnumDescriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{2}
+}
+
 type CompanyCreate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -305,6 +408,9 @@ type Company struct {
 	AmountOfEmployees int32                  `protobuf:"varint,6,opt,name=amount_of_employees,json=amountOfEmployees,proto3" json:"amount_of_employees,omitempty"`
 	Registered        bool                   `protobuf:"varint,7,opt,name=registered,proto3" json:"registered,omitempty"`
 	Type              CompanyType            `protobuf:"varint,8,opt,name=type,proto3,enum=companiespb.v1.CompanyType" json:"type,omitempty"`
+	Version           uint64                 `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
+	// search is set when the company is found by the full-text search.
+	Search *CompanySearch `protobuf:"bytes,10,opt,name=search,proto3" json:"search,omitempty"`
 }
 
 func (x *Company) Reset() {
@@ -395,17 +501,32 @@ func (x *Company) GetType() CompanyType {
 	return CompanyType_COMPANY_TYPE_UNKNOWN
 }
 
-type ListCompany struct {
+func (x *Company) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Company) GetSearch() *CompanySearch {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+type CompanySearch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Items []*Company `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
-	Count uint64     `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Score               float64 `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	NameHeadline        string  `protobuf:"bytes,2,opt,name=name_headline,json=nameHeadline,proto3" json:"name_headline,omitempty"`
+	DescriptionHeadline string  `protobuf:"bytes,3,opt,name=description_headline,json=descriptionHeadline,proto3" json:"description_headline,omitempty"`
 }
 
-func (x *ListCompany) Reset() {
-	*x = ListCompany{}
+func (x *CompanySearch) Reset() {
+	*x = CompanySearch{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_companiespb_v1_company_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -413,13 +534,13 @@ func (x *ListCompany) Reset() {
 	}
 }
 
-func (x *ListCompany) String() string {
+func (x *CompanySearch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCompany) ProtoMessage() {}
+func (*CompanySearch) ProtoMessage() {}
 
-func (x *ListCompany) ProtoReflect() protoreflect.Message {
+func (x *CompanySearch) ProtoReflect() protoreflect.Message {
 	mi := &file_companiespb_v1_company_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -431,35 +552,48 @@ func (x *ListCompany) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCompany.ProtoReflect.Descriptor instead.
-func (*ListCompany) Descriptor() ([]byte, []int) {
+// Deprecated: Use CompanySearch.ProtoReflect.Descriptor instead.
+func (*CompanySearch) Descriptor() ([]byte, []int) {
 	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *ListCompany) GetItems() []*Company {
+func (x *CompanySearch) GetScore() float64 {
 	if x != nil {
-		return x.Items
+		return x.Score
 	}
-	return nil
+	return 0
 }
 
-func (x *ListCompany) GetCount() uint64 {
+func (x *CompanySearch) GetNameHeadline() string {
 	if x != nil {
-		return x.Count
+		return x.NameHeadline
 	}
-	return 0
+	return ""
 }
 
-type CompanyDelete struct {
+func (x *CompanySearch) GetDescriptionHeadline() string {
+	if x != nil {
+		return x.DescriptionHeadline
+	}
+	return ""
+}
+
+type ListCompany struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Items []*Company `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// count is 0 when the filter skips it.
+	Count uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// facets are set when the filter requests them.
+	Facets *CompanyFacets `protobuf:"bytes,3,opt,name=facets,proto3" json:"facets,omitempty"`
+	// estimated tells the count is the estimate of the planner.
+	Estimated bool `protobuf:"varint,4,opt,name=estimated,proto3" json:"estimated,omitempty"`
 }
 
-func (x *CompanyDelete) Reset() {
-	*x = CompanyDelete{}
+func (x *ListCompany) Reset() {
+	*x = ListCompany{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_companiespb_v1_company_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -467,13 +601,13 @@ func (x *CompanyDelete) Reset() {
 	}
 }
 
-func (x *CompanyDelete) String() string {
+func (x *ListCompany) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompanyDelete) ProtoMessage() {}
+func (*ListCompany) ProtoMessage() {}
 
-func (x *CompanyDelete) ProtoReflect() protoreflect.Message {
+func (x *ListCompany) ProtoReflect() protoreflect.Message {
 	mi := &file_companiespb_v1_company_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -485,34 +619,52 @@ func (x *CompanyDelete) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompanyDelete.ProtoReflect.Descriptor instead.
-func (*CompanyDelete) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListCompany.ProtoReflect.Descriptor instead.
+func (*ListCompany) Descriptor() ([]byte, []int) {
 	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CompanyDelete) GetId() string {
+func (x *ListCompany) GetItems() []*Company {
 	if x != nil {
-		return x.Id
+		return x.Items
 	}
-	return ""
+	return nil
 }
 
-type CompanyFilter struct {
+func (x *ListCompany) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ListCompany) GetFacets() *CompanyFacets {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *ListCompany) GetEstimated() bool {
+	if x != nil {
+		return x.Estimated
+	}
+	return false
+}
+
+// CompanyFacets count the companies matching the filter without the dimension of each facet.
+type CompanyFacets struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PageNumber *wrapperspb.UInt64Value `protobuf:"bytes,1,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
-	PageSize   *wrapperspb.UInt64Value `protobuf:"bytes,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	Search     *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
-	Registered *wrapperspb.BoolValue   `protobuf:"bytes,4,opt,name=registered,proto3" json:"registered,omitempty"`
-	OrderBy    []string                `protobuf:"bytes,5,rep,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
-	Ids        []string                `protobuf:"bytes,6,rep,name=ids,proto3" json:"ids,omitempty"`
-	Types      []CompanyType           `protobuf:"varint,7,rep,packed,name=types,proto3,enum=companiespb.v1.CompanyType" json:"types,omitempty"`
+	Types      []*CompanyTypeFacet       `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	Registered []*CompanyRegisteredFacet `protobuf:"bytes,2,rep,name=registered,proto3" json:"registered,omitempty"`
+	Employees  []*CompanyStatsBucket     `protobuf:"bytes,3,rep,name=employees,proto3" json:"employees,omitempty"`
 }
 
-func (x *CompanyFilter) Reset() {
-	*x = CompanyFilter{}
+func (x *CompanyFacets) Reset() {
+	*x = CompanyFacets{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_companiespb_v1_company_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -520,13 +672,13 @@ func (x *CompanyFilter) Reset() {
 	}
 }
 
-func (x *CompanyFilter) String() string {
+func (x *CompanyFacets) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CompanyFilter) ProtoMessage() {}
+func (*CompanyFacets) ProtoMessage() {}
 
-func (x *CompanyFilter) ProtoReflect() protoreflect.Message {
+func (x *CompanyFacets) ProtoReflect() protoreflect.Message {
 	mi := &file_companiespb_v1_company_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -538,285 +690,1660 @@ func (x *CompanyFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CompanyFilter.ProtoReflect.Descriptor instead.
-func (*CompanyFilter) Descriptor() ([]byte, []int) {
+// Deprecated: Use CompanyFacets.ProtoReflect.Descriptor instead.
+func (*CompanyFacets) Descriptor() ([]byte, []int) {
 	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CompanyFilter) GetPageNumber() *wrapperspb.UInt64Value {
+func (x *CompanyFacets) GetTypes() []*CompanyTypeFacet {
 	if x != nil {
-		return x.PageNumber
+		return x.Types
 	}
 	return nil
 }
 
-func (x *CompanyFilter) GetPageSize() *wrapperspb.UInt64Value {
+func (x *CompanyFacets) GetRegistered() []*CompanyRegisteredFacet {
 	if x != nil {
-		return x.PageSize
+		return x.Registered
 	}
 	return nil
 }
 
-func (x *CompanyFilter) GetSearch() *wrapperspb.StringValue {
+func (x *CompanyFacets) GetEmployees() []*CompanyStatsBucket {
 	if x != nil {
-		return x.Search
+		return x.Employees
 	}
 	return nil
 }
 
-func (x *CompanyFilter) GetRegistered() *wrapperspb.BoolValue {
-	if x != nil {
-		return x.Registered
+type CompanyTypeFacet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type  CompanyType `protobuf:"varint,1,opt,name=type,proto3,enum=companiespb.v1.CompanyType" json:"type,omitempty"`
+	Count uint64      `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *CompanyTypeFacet) Reset() {
+	*x = CompanyTypeFacet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *CompanyFilter) GetOrderBy() []string {
-	if x != nil {
-		return x.OrderBy
+func (x *CompanyTypeFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyTypeFacet) ProtoMessage() {}
+
+func (x *CompanyTypeFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *CompanyFilter) GetIds() []string {
+// Deprecated: Use CompanyTypeFacet.ProtoReflect.Descriptor instead.
+func (*CompanyTypeFacet) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CompanyTypeFacet) GetType() CompanyType {
 	if x != nil {
-		return x.Ids
+		return x.Type
 	}
-	return nil
+	return CompanyType_COMPANY_TYPE_UNKNOWN
 }
 
-func (x *CompanyFilter) GetTypes() []CompanyType {
+func (x *CompanyTypeFacet) GetCount() uint64 {
 	if x != nil {
-		return x.Types
+		return x.Count
 	}
-	return nil
+	return 0
 }
 
-var File_companiespb_v1_company_proto protoreflect.FileDescriptor
+type CompanyRegisteredFacet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_companiespb_v1_company_proto_rawDesc = []byte{
-	0x0a, 0x1c, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2f, 0x76, 0x31,
-	0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e,
-	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x1a, 0x1c,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d,
-	0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70,
-	0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc6, 0x01, 0x0a, 0x0d, 0x43,
-	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6f, 0x66, 0x5f,
-	0x65, 0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x11, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x66, 0x45, 0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65,
-	0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
-	0x65, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x22, 0x1c, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x47, 0x65,
-	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x22, 0xcb, 0x02, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72,
-	0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4b, 0x0a, 0x13, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
-	0x6f, 0x66, 0x5f, 0x65, 0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
-	0x11, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x66, 0x45, 0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65,
-	0x65, 0x73, 0x12, 0x3a, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x2f,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x63,
-	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
-	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22,
-	0xc6, 0x02, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x75,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x5f, 0x6f, 0x66, 0x5f, 0x65, 0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x65, 0x73, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x66, 0x45, 0x6d,
-	0x70, 0x6c, 0x6f, 0x79, 0x65, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65,
-	0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x52, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74,
-	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x12, 0x2d, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69,
-	0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x52,
-	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x1f, 0x0a, 0x0d,
-	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xdb, 0x02,
-	0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
-	0x3d, 0x0a, 0x0b, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x39,
-	0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
-	0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x73, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12,
-	0x3a, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
-	0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6f,
-	0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6f,
-	0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x31, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
-	0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2a, 0xa7, 0x01, 0x0a, 0x0b,
-	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x14, 0x43,
-	0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e,
-	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59,
-	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x52, 0x50, 0x4f, 0x52, 0x41, 0x54, 0x49, 0x4f,
-	0x4e, 0x53, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f,
-	0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x4f, 0x4e, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x54, 0x10,
-	0x02, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x54, 0x59, 0x50,
-	0x45, 0x5f, 0x43, 0x4f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x56, 0x45, 0x10, 0x03, 0x12,
-	0x24, 0x0a, 0x20, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
-	0x53, 0x4f, 0x4c, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x50, 0x52, 0x49, 0x45, 0x54, 0x4f, 0x52, 0x53,
-	0x48, 0x49, 0x50, 0x10, 0x04, 0x32, 0xe2, 0x02, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
-	0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x42, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x12, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x1a, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x03,
-	0x47, 0x65, 0x74, 0x12, 0x1a, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70,
-	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x47, 0x65, 0x74, 0x1a,
-	0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31,
-	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x06, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73,
-	0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x1a, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70,
-	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x22, 0x00, 0x12, 0x41,
-	0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61,
-	0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
-	0x79, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
-	0x00, 0x12, 0x47, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70,
-	0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
-	0x6e, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61,
-	0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f,
-	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x22, 0x03, 0x88, 0x02, 0x01, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x30, 0x31, 0x38, 0x62, 0x66, 0x2f, 0x63,
-	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x63, 0x6f, 0x6d,
-	0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	Registered bool   `protobuf:"varint,1,opt,name=registered,proto3" json:"registered,omitempty"`
+	Count      uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 }
 
-var (
-	file_companiespb_v1_company_proto_rawDescOnce sync.Once
-	file_companiespb_v1_company_proto_rawDescData = file_companiespb_v1_company_proto_rawDesc
-)
+func (x *CompanyRegisteredFacet) Reset() {
+	*x = CompanyRegisteredFacet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_companiespb_v1_company_proto_rawDescGZIP() []byte {
-	file_companiespb_v1_company_proto_rawDescOnce.Do(func() {
-		file_companiespb_v1_company_proto_rawDescData = protoimpl.X.CompressGZIP(file_companiespb_v1_company_proto_rawDescData)
-	})
-	return file_companiespb_v1_company_proto_rawDescData
+func (x *CompanyRegisteredFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_companiespb_v1_company_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_companiespb_v1_company_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_companiespb_v1_company_proto_goTypes = []interface{}{
-	(CompanyType)(0),               // 0: companiespb.v1.CompanyType
-	(*CompanyCreate)(nil),          // 1: companiespb.v1.CompanyCreate
-	(*CompanyGet)(nil),             // 2: companiespb.v1.CompanyGet
-	(*CompanyUpdate)(nil),          // 3: companiespb.v1.CompanyUpdate
-	(*Company)(nil),                // 4: companiespb.v1.Company
-	(*ListCompany)(nil),            // 5: companiespb.v1.ListCompany
-	(*CompanyDelete)(nil),          // 6: companiespb.v1.CompanyDelete
-	(*CompanyFilter)(nil),          // 7: companiespb.v1.CompanyFilter
-	(*wrapperspb.StringValue)(nil), // 8: google.protobuf.StringValue
-	(*wrapperspb.Int32Value)(nil),  // 9: google.protobuf.Int32Value
-	(*wrapperspb.BoolValue)(nil),   // 10: google.protobuf.BoolValue
-	(*timestamppb.Timestamp)(nil),  // 11: google.protobuf.Timestamp
-	(*wrapperspb.UInt64Value)(nil), // 12: google.protobuf.UInt64Value
-	(*emptypb.Empty)(nil),          // 13: google.protobuf.Empty
+func (*CompanyRegisteredFacet) ProtoMessage() {}
+
+func (x *CompanyRegisteredFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_companiespb_v1_company_proto_depIdxs = []int32{
-	0,  // 0: companiespb.v1.CompanyCreate.type:type_name -> companiespb.v1.CompanyType
-	8,  // 1: companiespb.v1.CompanyUpdate.name:type_name -> google.protobuf.StringValue
-	8,  // 2: companiespb.v1.CompanyUpdate.description:type_name -> google.protobuf.StringValue
-	9,  // 3: companiespb.v1.CompanyUpdate.amount_of_employees:type_name -> google.protobuf.Int32Value
-	10, // 4: companiespb.v1.CompanyUpdate.registered:type_name -> google.protobuf.BoolValue
-	0,  // 5: companiespb.v1.CompanyUpdate.type:type_name -> companiespb.v1.CompanyType
-	11, // 6: companiespb.v1.Company.updated_at:type_name -> google.protobuf.Timestamp
-	11, // 7: companiespb.v1.Company.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 8: companiespb.v1.Company.type:type_name -> companiespb.v1.CompanyType
-	4,  // 9: companiespb.v1.ListCompany.items:type_name -> companiespb.v1.Company
-	12, // 10: companiespb.v1.CompanyFilter.page_number:type_name -> google.protobuf.UInt64Value
-	12, // 11: companiespb.v1.CompanyFilter.page_size:type_name -> google.protobuf.UInt64Value
-	8,  // 12: companiespb.v1.CompanyFilter.search:type_name -> google.protobuf.StringValue
-	10, // 13: companiespb.v1.CompanyFilter.registered:type_name -> google.protobuf.BoolValue
-	0,  // 14: companiespb.v1.CompanyFilter.types:type_name -> companiespb.v1.CompanyType
-	1,  // 15: companiespb.v1.CompanyService.Create:input_type -> companiespb.v1.CompanyCreate
-	2,  // 16: companiespb.v1.CompanyService.Get:input_type -> companiespb.v1.CompanyGet
-	3,  // 17: companiespb.v1.CompanyService.Update:input_type -> companiespb.v1.CompanyUpdate
-	6,  // 18: companiespb.v1.CompanyService.Delete:input_type -> companiespb.v1.CompanyDelete
-	7,  // 19: companiespb.v1.CompanyService.List:input_type -> companiespb.v1.CompanyFilter
-	4,  // 20: companiespb.v1.CompanyService.Create:output_type -> companiespb.v1.Company
-	4,  // 21: companiespb.v1.CompanyService.Get:output_type -> companiespb.v1.Company
-	4,  // 22: companiespb.v1.CompanyService.Update:output_type -> companiespb.v1.Company
-	13, // 23: companiespb.v1.CompanyService.Delete:output_type -> google.protobuf.Empty
-	5,  // 24: companiespb.v1.CompanyService.List:output_type -> companiespb.v1.ListCompany
-	20, // [20:25] is the sub-list for method output_type
-	15, // [15:20] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+
+// Deprecated: Use CompanyRegisteredFacet.ProtoReflect.Descriptor instead.
+func (*CompanyRegisteredFacet) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{8}
 }
 
-func init() { file_companiespb_v1_company_proto_init() }
-func file_companiespb_v1_company_proto_init() {
-	if File_companiespb_v1_company_proto != nil {
-		return
+func (x *CompanyRegisteredFacet) GetRegistered() bool {
+	if x != nil {
+		return x.Registered
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_companiespb_v1_company_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompanyCreate); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_companiespb_v1_company_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompanyGet); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
+	return false
+}
+
+func (x *CompanyRegisteredFacet) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CompanyDelete struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CompanyDelete) Reset() {
+	*x = CompanyDelete{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyDelete) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyDelete) ProtoMessage() {}
+
+func (x *CompanyDelete) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyDelete.ProtoReflect.Descriptor instead.
+func (*CompanyDelete) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CompanyDelete) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CompanyFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageNumber     *wrapperspb.UInt64Value `protobuf:"bytes,1,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
+	PageSize       *wrapperspb.UInt64Value `protobuf:"bytes,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Search         *wrapperspb.StringValue `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	Registered     *wrapperspb.BoolValue   `protobuf:"bytes,4,opt,name=registered,proto3" json:"registered,omitempty"`
+	OrderBy        []string                `protobuf:"bytes,5,rep,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	Ids            []string                `protobuf:"bytes,6,rep,name=ids,proto3" json:"ids,omitempty"`
+	Types          []CompanyType           `protobuf:"varint,7,rep,packed,name=types,proto3,enum=companiespb.v1.CompanyType" json:"types,omitempty"`
+	SearchLanguage *wrapperspb.StringValue `protobuf:"bytes,8,opt,name=search_language,json=searchLanguage,proto3" json:"search_language,omitempty"`
+	// filter is an expression such as `amount_of_employees >= 50 AND type:(1 OR 3) AND name:"Acme*"`.
+	Filter *wrapperspb.StringValue `protobuf:"bytes,9,opt,name=filter,proto3" json:"filter,omitempty"`
+	// facets are counted along the page: "type", "registered" or "employees".
+	Facets []string `protobuf:"bytes,10,rep,name=facets,proto3" json:"facets,omitempty"`
+	// count is how the companies are counted: "exact", the default, "estimated" or "none".
+	Count *wrapperspb.StringValue `protobuf:"bytes,11,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *CompanyFilter) Reset() {
+	*x = CompanyFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyFilter) ProtoMessage() {}
+
+func (x *CompanyFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyFilter.ProtoReflect.Descriptor instead.
+func (*CompanyFilter) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CompanyFilter) GetPageNumber() *wrapperspb.UInt64Value {
+	if x != nil {
+		return x.PageNumber
+	}
+	return nil
+}
+
+func (x *CompanyFilter) GetPageSize() *wrapperspb.UInt64Value {
+	if x != nil {
+		return x.PageSize
+	}
+	return nil
+}
+
+func (x *CompanyFilter) GetSearch() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Search
+	}
+	return nil
+}
+
+func (x *CompanyFilter) GetRegistered() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.Registered
+	}
+	return nil
+}
+
+func (x *CompanyFilter) GetOrderBy() []string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *CompanyFilter) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *CompanyFilter) GetTypes() []CompanyType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *CompanyFilter) GetSearchLanguage() *wrapperspb.StringValue {
+	if x != nil {
+		return x.SearchLanguage
+	}
+	return nil
+}
+
+func (x *CompanyFilter) GetFilter() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *CompanyFilter) GetFacets() []string {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *CompanyFilter) GetCount() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Count
+	}
+	return nil
+}
+
+type CompanyWatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filter   *CompanyFilter          `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Position *wrapperspb.UInt64Value `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+}
+
+func (x *CompanyWatch) Reset() {
+	*x = CompanyWatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyWatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyWatch) ProtoMessage() {}
+
+func (x *CompanyWatch) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyWatch.ProtoReflect.Descriptor instead.
+func (*CompanyWatch) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CompanyWatch) GetFilter() *CompanyFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *CompanyWatch) GetPosition() *wrapperspb.UInt64Value {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+type CompanyChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Position  uint64                 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+	Operation CompanyChangeOperation `protobuf:"varint,2,opt,name=operation,proto3,enum=companiespb.v1.CompanyChangeOperation" json:"operation,omitempty"`
+	CompanyId string                 `protobuf:"bytes,3,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	ChangedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	Company   *Company               `protobuf:"bytes,5,opt,name=company,proto3" json:"company,omitempty"`
+}
+
+func (x *CompanyChange) Reset() {
+	*x = CompanyChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyChange) ProtoMessage() {}
+
+func (x *CompanyChange) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyChange.ProtoReflect.Descriptor instead.
+func (*CompanyChange) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CompanyChange) GetPosition() uint64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *CompanyChange) GetOperation() CompanyChangeOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return CompanyChangeOperation_COMPANY_CHANGE_OPERATION_UNKNOWN
+}
+
+func (x *CompanyChange) GetCompanyId() string {
+	if x != nil {
+		return x.CompanyId
+	}
+	return ""
+}
+
+func (x *CompanyChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *CompanyChange) GetCompany() *Company {
+	if x != nil {
+		return x.Company
+	}
+	return nil
+}
+
+type CompanyHeartbeat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Position uint64 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+}
+
+func (x *CompanyHeartbeat) Reset() {
+	*x = CompanyHeartbeat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyHeartbeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyHeartbeat) ProtoMessage() {}
+
+func (x *CompanyHeartbeat) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyHeartbeat.ProtoReflect.Descriptor instead.
+func (*CompanyHeartbeat) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CompanyHeartbeat) GetPosition() uint64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type CompanyWatchEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*CompanyWatchEvent_Change
+	//	*CompanyWatchEvent_Heartbeat
+	Event isCompanyWatchEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *CompanyWatchEvent) Reset() {
+	*x = CompanyWatchEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyWatchEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyWatchEvent) ProtoMessage() {}
+
+func (x *CompanyWatchEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyWatchEvent.ProtoReflect.Descriptor instead.
+func (*CompanyWatchEvent) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{14}
+}
+
+func (m *CompanyWatchEvent) GetEvent() isCompanyWatchEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *CompanyWatchEvent) GetChange() *CompanyChange {
+	if x, ok := x.GetEvent().(*CompanyWatchEvent_Change); ok {
+		return x.Change
+	}
+	return nil
+}
+
+func (x *CompanyWatchEvent) GetHeartbeat() *CompanyHeartbeat {
+	if x, ok := x.GetEvent().(*CompanyWatchEvent_Heartbeat); ok {
+		return x.Heartbeat
+	}
+	return nil
+}
+
+type isCompanyWatchEvent_Event interface {
+	isCompanyWatchEvent_Event()
+}
+
+type CompanyWatchEvent_Change struct {
+	Change *CompanyChange `protobuf:"bytes,1,opt,name=change,proto3,oneof"`
+}
+
+type CompanyWatchEvent_Heartbeat struct {
+	Heartbeat *CompanyHeartbeat `protobuf:"bytes,2,opt,name=heartbeat,proto3,oneof"`
+}
+
+func (*CompanyWatchEvent_Change) isCompanyWatchEvent_Event() {}
+
+func (*CompanyWatchEvent_Heartbeat) isCompanyWatchEvent_Event() {}
+
+type CompanyChangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Since string `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+}
+
+func (x *CompanyChangesRequest) Reset() {
+	*x = CompanyChangesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyChangesRequest) ProtoMessage() {}
+
+func (x *CompanyChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyChangesRequest.ProtoReflect.Descriptor instead.
+func (*CompanyChangesRequest) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CompanyChangesRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+type CompanyChangeList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items   []*CompanyChange `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Token   string           `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	HasMore bool             `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+}
+
+func (x *CompanyChangeList) Reset() {
+	*x = CompanyChangeList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyChangeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyChangeList) ProtoMessage() {}
+
+func (x *CompanyChangeList) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyChangeList.ProtoReflect.Descriptor instead.
+func (*CompanyChangeList) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CompanyChangeList) GetItems() []*CompanyChange {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CompanyChangeList) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CompanyChangeList) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type CompanyStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filter          *CompanyFilter       `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	EmployeeBuckets []int64              `protobuf:"varint,2,rep,packed,name=employee_buckets,json=employeeBuckets,proto3" json:"employee_buckets,omitempty"`
+	Interval        CompanyStatsInterval `protobuf:"varint,3,opt,name=interval,proto3,enum=companiespb.v1.CompanyStatsInterval" json:"interval,omitempty"`
+}
+
+func (x *CompanyStatsRequest) Reset() {
+	*x = CompanyStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyStatsRequest) ProtoMessage() {}
+
+func (x *CompanyStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyStatsRequest.ProtoReflect.Descriptor instead.
+func (*CompanyStatsRequest) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CompanyStatsRequest) GetFilter() *CompanyFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *CompanyStatsRequest) GetEmployeeBuckets() []int64 {
+	if x != nil {
+		return x.EmployeeBuckets
+	}
+	return nil
+}
+
+func (x *CompanyStatsRequest) GetInterval() CompanyStatsInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return CompanyStatsInterval_COMPANY_STATS_INTERVAL_UNKNOWN
+}
+
+type CompanyStatsGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type       CompanyType `protobuf:"varint,1,opt,name=type,proto3,enum=companiespb.v1.CompanyType" json:"type,omitempty"`
+	Registered bool        `protobuf:"varint,2,opt,name=registered,proto3" json:"registered,omitempty"`
+	Count      uint64      `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *CompanyStatsGroup) Reset() {
+	*x = CompanyStatsGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyStatsGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyStatsGroup) ProtoMessage() {}
+
+func (x *CompanyStatsGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyStatsGroup.ProtoReflect.Descriptor instead.
+func (*CompanyStatsGroup) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CompanyStatsGroup) GetType() CompanyType {
+	if x != nil {
+		return x.Type
+	}
+	return CompanyType_COMPANY_TYPE_UNKNOWN
+}
+
+func (x *CompanyStatsGroup) GetRegistered() bool {
+	if x != nil {
+		return x.Registered
+	}
+	return false
+}
+
+func (x *CompanyStatsGroup) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CompanyStatsBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From  *wrapperspb.Int64Value `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    *wrapperspb.Int64Value `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Count uint64                 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *CompanyStatsBucket) Reset() {
+	*x = CompanyStatsBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyStatsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyStatsBucket) ProtoMessage() {}
+
+func (x *CompanyStatsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyStatsBucket.ProtoReflect.Descriptor instead.
+func (*CompanyStatsBucket) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CompanyStatsBucket) GetFrom() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *CompanyStatsBucket) GetTo() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *CompanyStatsBucket) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CompanyStatsPeriod struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Count uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *CompanyStatsPeriod) Reset() {
+	*x = CompanyStatsPeriod{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyStatsPeriod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyStatsPeriod) ProtoMessage() {}
+
+func (x *CompanyStatsPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyStatsPeriod.ProtoReflect.Descriptor instead.
+func (*CompanyStatsPeriod) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CompanyStatsPeriod) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *CompanyStatsPeriod) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CompanyStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total     uint64                `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Groups    []*CompanyStatsGroup  `protobuf:"bytes,2,rep,name=groups,proto3" json:"groups,omitempty"`
+	Employees []*CompanyStatsBucket `protobuf:"bytes,3,rep,name=employees,proto3" json:"employees,omitempty"`
+	Created   []*CompanyStatsPeriod `protobuf:"bytes,4,rep,name=created,proto3" json:"created,omitempty"`
+}
+
+func (x *CompanyStats) Reset() {
+	*x = CompanyStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyStats) ProtoMessage() {}
+
+func (x *CompanyStats) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyStats.ProtoReflect.Descriptor instead.
+func (*CompanyStats) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CompanyStats) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *CompanyStats) GetGroups() []*CompanyStatsGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *CompanyStats) GetEmployees() []*CompanyStatsBucket {
+	if x != nil {
+		return x.Employees
+	}
+	return nil
+}
+
+func (x *CompanyStats) GetCreated() []*CompanyStatsPeriod {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+type CompanySuggest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query string                  `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit *wrapperspb.UInt64Value `protobuf:"bytes,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *CompanySuggest) Reset() {
+	*x = CompanySuggest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanySuggest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanySuggest) ProtoMessage() {}
+
+func (x *CompanySuggest) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanySuggest.ProtoReflect.Descriptor instead.
+func (*CompanySuggest) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CompanySuggest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *CompanySuggest) GetLimit() *wrapperspb.UInt64Value {
+	if x != nil {
+		return x.Limit
+	}
+	return nil
+}
+
+type CompanySuggestion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Score float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *CompanySuggestion) Reset() {
+	*x = CompanySuggestion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanySuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanySuggestion) ProtoMessage() {}
+
+func (x *CompanySuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanySuggestion.ProtoReflect.Descriptor instead.
+func (*CompanySuggestion) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CompanySuggestion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CompanySuggestion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CompanySuggestion) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type CompanySuggestionList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Items []*CompanySuggestion `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+}
+
+func (x *CompanySuggestionList) Reset() {
+	*x = CompanySuggestionList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_company_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanySuggestionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanySuggestionList) ProtoMessage() {}
+
+func (x *CompanySuggestionList) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_company_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanySuggestionList.ProtoReflect.Descriptor instead.
+func (*CompanySuggestionList) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_company_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CompanySuggestionList) GetItems() []*CompanySuggestion {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_companiespb_v1_company_proto protoreflect.FileDescriptor
+
+var file_companiespb_v1_company_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2f, 0x76, 0x31,
+	0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x1a, 0x1b,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72,
+	0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc6, 0x01, 0x0a,
+	0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6f,
+	0x66, 0x5f, 0x65, 0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x11, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x66, 0x45, 0x6d, 0x70, 0x6c, 0x6f,
+	0x79, 0x65, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x1c, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79,
+	0x47, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0xcb, 0x02, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x30, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3e, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4b, 0x0a, 0x13, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x6f, 0x66, 0x5f, 0x65, 0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x52, 0x11, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x66, 0x45, 0x6d, 0x70, 0x6c, 0x6f,
+	0x79, 0x65, 0x65, 0x73, 0x12, 0x3a, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64,
+	0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b,
+	0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x22, 0x97, 0x03, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x39, 0x0a,
+	0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x5f, 0x6f, 0x66, 0x5f, 0x65, 0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4f, 0x66,
+	0x45, 0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
+	0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73,
+	0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x22, 0x7d, 0x0a, 0x0d, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x6c,
+	0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x61, 0x6d, 0x65, 0x48,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x31, 0x0a, 0x14, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0xa7, 0x01, 0x0a, 0x0b, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x12, 0x2d, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x79, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x35, 0x0a, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x52, 0x06,
+	0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x65, 0x73, 0x74, 0x69, 0x6d,
+	0x61, 0x74, 0x65, 0x64, 0x22, 0xd1, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79,
+	0x46, 0x61, 0x63, 0x65, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65,
+	0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x54, 0x79,
+	0x70, 0x65, 0x46, 0x61, 0x63, 0x65, 0x74, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x12, 0x46,
+	0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x65, 0x64, 0x46, 0x61, 0x63, 0x65, 0x74, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x40, 0x0a, 0x09, 0x65, 0x6d, 0x70, 0x6c, 0x6f, 0x79,
+	0x65, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x09, 0x65,
+	0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x65, 0x73, 0x22, 0x59, 0x0a, 0x10, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x46, 0x61, 0x63, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x4e, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x46, 0x61, 0x63, 0x65, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x1f, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0xa4, 0x04, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x0b, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x49,
+	0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x36,
+	0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x34, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x3a, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f,
+	0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12,
+	0x31, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1b,
+	0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x05, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x12, 0x45, 0x0a, 0x0f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x6c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0e, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x66, 0x61, 0x63, 0x65, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x7f, 0x0a, 0x0c, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x35, 0x0a, 0x06, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x12, 0x38, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xfe, 0x01, 0x0a,
+	0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x09, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x49, 0x64, 0x12,
+	0x39, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x41, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x79, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x22, 0x2e, 0x0a,
+	0x10, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x97, 0x01,
+	0x0a, 0x11, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70,
+	0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x40, 0x0a, 0x09,
+	0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x48, 0x00, 0x52, 0x09, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x42, 0x07,
+	0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x2d, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0x79, 0x0a, 0x11, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
+	0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x05, 0x69,
+	0x74, 0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x5f, 0x6d, 0x6f,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x61, 0x73, 0x4d, 0x6f, 0x72,
+	0x65, 0x22, 0xb9, 0x01, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x35, 0x0a, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x12, 0x29, 0x0a, 0x10, 0x65, 0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x65, 0x5f, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0f, 0x65, 0x6d, 0x70, 0x6c,
+	0x6f, 0x79, 0x65, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x40, 0x0a, 0x08, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x7a, 0x0a,
+	0x11, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x88, 0x01, 0x0a, 0x12, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x2f, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x2b, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x5c, 0x0a, 0x12, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x30, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x22, 0xdf, 0x01, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x39, 0x0a, 0x06, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x12, 0x40, 0x0a, 0x09, 0x65, 0x6d, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
+	0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x09, 0x65, 0x6d, 0x70,
+	0x6c, 0x6f, 0x79, 0x65, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
+	0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x52, 0x07, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x22, 0x5a, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53,
+	0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x32, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x49,
+	0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x22, 0x4d, 0x0a, 0x11, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x75, 0x67, 0x67, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22,
+	0x50, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
+	0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79,
+	0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d,
+	0x73, 0x2a, 0xa7, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x43,
+	0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x52, 0x50,
+	0x4f, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x53, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f,
+	0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x4f, 0x4e, 0x5f, 0x50,
+	0x52, 0x4f, 0x46, 0x49, 0x54, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x43, 0x4f, 0x4d, 0x50, 0x41,
+	0x4e, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54,
+	0x49, 0x56, 0x45, 0x10, 0x03, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4f, 0x4c, 0x45, 0x5f, 0x50, 0x52, 0x4f, 0x50, 0x52,
+	0x49, 0x45, 0x54, 0x4f, 0x52, 0x53, 0x48, 0x49, 0x50, 0x10, 0x04, 0x2a, 0xb0, 0x01, 0x0a, 0x16,
+	0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e,
+	0x59, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x24, 0x0a, 0x20,
+	0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4f,
+	0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44,
+	0x10, 0x01, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x43, 0x48,
+	0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55,
+	0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x4d, 0x50,
+	0x41, 0x4e, 0x59, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x9d,
+	0x01, 0x0a, 0x14, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x49,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x22, 0x0a, 0x1e, 0x43, 0x4f, 0x4d, 0x50, 0x41,
+	0x4e, 0x59, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x53, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56, 0x41,
+	0x4c, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x43,
+	0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x53, 0x5f, 0x49, 0x4e, 0x54,
+	0x45, 0x52, 0x56, 0x41, 0x4c, 0x5f, 0x44, 0x41, 0x59, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x43,
+	0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x53, 0x5f, 0x49, 0x4e, 0x54,
+	0x45, 0x52, 0x56, 0x41, 0x4c, 0x5f, 0x57, 0x45, 0x45, 0x4b, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c,
+	0x43, 0x4f, 0x4d, 0x50, 0x41, 0x4e, 0x59, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x53, 0x5f, 0x49, 0x4e,
+	0x54, 0x45, 0x52, 0x56, 0x41, 0x4c, 0x5f, 0x4d, 0x4f, 0x4e, 0x54, 0x48, 0x10, 0x03, 0x32, 0xd0,
+	0x05, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x42, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x79, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x1a, 0x17, 0x2e, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x1a, 0x2e, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x47, 0x65, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
+	0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x1a, 0x17, 0x2e, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x12, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x04, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x1d, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x22, 0x03,
+	0x88, 0x02, 0x01, 0x12, 0x60, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x21, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x23, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
+	0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x00, 0x12, 0x5b, 0x0a,
+	0x10, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65,
+	0x73, 0x12, 0x1e, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73,
+	0x74, 0x1a, 0x25, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x53, 0x75, 0x67, 0x67, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x57, 0x61, 0x74, 0x63, 0x68, 0x1a, 0x21, 0x2e, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30,
+	0x01, 0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x30, 0x31, 0x38, 0x62, 0x66, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2f,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_companiespb_v1_company_proto_rawDescOnce sync.Once
+	file_companiespb_v1_company_proto_rawDescData = file_companiespb_v1_company_proto_rawDesc
+)
+
+func file_companiespb_v1_company_proto_rawDescGZIP() []byte {
+	file_companiespb_v1_company_proto_rawDescOnce.Do(func() {
+		file_companiespb_v1_company_proto_rawDescData = protoimpl.X.CompressGZIP(file_companiespb_v1_company_proto_rawDescData)
+	})
+	return file_companiespb_v1_company_proto_rawDescData
+}
+
+var file_companiespb_v1_company_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_companiespb_v1_company_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_companiespb_v1_company_proto_goTypes = []interface{}{
+	(CompanyType)(0),               // 0: companiespb.v1.CompanyType
+	(CompanyChangeOperation)(0),    // 1: companiespb.v1.CompanyChangeOperation
+	(CompanyStatsInterval)(0),      // 2: companiespb.v1.CompanyStatsInterval
+	(*CompanyCreate)(nil),          // 3: companiespb.v1.CompanyCreate
+	(*CompanyGet)(nil),             // 4: companiespb.v1.CompanyGet
+	(*CompanyUpdate)(nil),          // 5: companiespb.v1.CompanyUpdate
+	(*Company)(nil),                // 6: companiespb.v1.Company
+	(*CompanySearch)(nil),          // 7: companiespb.v1.CompanySearch
+	(*ListCompany)(nil),            // 8: companiespb.v1.ListCompany
+	(*CompanyFacets)(nil),          // 9: companiespb.v1.CompanyFacets
+	(*CompanyTypeFacet)(nil),       // 10: companiespb.v1.CompanyTypeFacet
+	(*CompanyRegisteredFacet)(nil), // 11: companiespb.v1.CompanyRegisteredFacet
+	(*CompanyDelete)(nil),          // 12: companiespb.v1.CompanyDelete
+	(*CompanyFilter)(nil),          // 13: companiespb.v1.CompanyFilter
+	(*CompanyWatch)(nil),           // 14: companiespb.v1.CompanyWatch
+	(*CompanyChange)(nil),          // 15: companiespb.v1.CompanyChange
+	(*CompanyHeartbeat)(nil),       // 16: companiespb.v1.CompanyHeartbeat
+	(*CompanyWatchEvent)(nil),      // 17: companiespb.v1.CompanyWatchEvent
+	(*CompanyChangesRequest)(nil),  // 18: companiespb.v1.CompanyChangesRequest
+	(*CompanyChangeList)(nil),      // 19: companiespb.v1.CompanyChangeList
+	(*CompanyStatsRequest)(nil),    // 20: companiespb.v1.CompanyStatsRequest
+	(*CompanyStatsGroup)(nil),      // 21: companiespb.v1.CompanyStatsGroup
+	(*CompanyStatsBucket)(nil),     // 22: companiespb.v1.CompanyStatsBucket
+	(*CompanyStatsPeriod)(nil),     // 23: companiespb.v1.CompanyStatsPeriod
+	(*CompanyStats)(nil),           // 24: companiespb.v1.CompanyStats
+	(*CompanySuggest)(nil),         // 25: companiespb.v1.CompanySuggest
+	(*CompanySuggestion)(nil),      // 26: companiespb.v1.CompanySuggestion
+	(*CompanySuggestionList)(nil),  // 27: companiespb.v1.CompanySuggestionList
+	(*wrapperspb.StringValue)(nil), // 28: google.protobuf.StringValue
+	(*wrapperspb.Int32Value)(nil),  // 29: google.protobuf.Int32Value
+	(*wrapperspb.BoolValue)(nil),   // 30: google.protobuf.BoolValue
+	(*timestamppb.Timestamp)(nil),  // 31: google.protobuf.Timestamp
+	(*wrapperspb.UInt64Value)(nil), // 32: google.protobuf.UInt64Value
+	(*wrapperspb.Int64Value)(nil),  // 33: google.protobuf.Int64Value
+	(*emptypb.Empty)(nil),          // 34: google.protobuf.Empty
+}
+var file_companiespb_v1_company_proto_depIdxs = []int32{
+	0,  // 0: companiespb.v1.CompanyCreate.type:type_name -> companiespb.v1.CompanyType
+	28, // 1: companiespb.v1.CompanyUpdate.name:type_name -> google.protobuf.StringValue
+	28, // 2: companiespb.v1.CompanyUpdate.description:type_name -> google.protobuf.StringValue
+	29, // 3: companiespb.v1.CompanyUpdate.amount_of_employees:type_name -> google.protobuf.Int32Value
+	30, // 4: companiespb.v1.CompanyUpdate.registered:type_name -> google.protobuf.BoolValue
+	0,  // 5: companiespb.v1.CompanyUpdate.type:type_name -> companiespb.v1.CompanyType
+	31, // 6: companiespb.v1.Company.updated_at:type_name -> google.protobuf.Timestamp
+	31, // 7: companiespb.v1.Company.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: companiespb.v1.Company.type:type_name -> companiespb.v1.CompanyType
+	7,  // 9: companiespb.v1.Company.search:type_name -> companiespb.v1.CompanySearch
+	6,  // 10: companiespb.v1.ListCompany.items:type_name -> companiespb.v1.Company
+	9,  // 11: companiespb.v1.ListCompany.facets:type_name -> companiespb.v1.CompanyFacets
+	10, // 12: companiespb.v1.CompanyFacets.types:type_name -> companiespb.v1.CompanyTypeFacet
+	11, // 13: companiespb.v1.CompanyFacets.registered:type_name -> companiespb.v1.CompanyRegisteredFacet
+	22, // 14: companiespb.v1.CompanyFacets.employees:type_name -> companiespb.v1.CompanyStatsBucket
+	0,  // 15: companiespb.v1.CompanyTypeFacet.type:type_name -> companiespb.v1.CompanyType
+	32, // 16: companiespb.v1.CompanyFilter.page_number:type_name -> google.protobuf.UInt64Value
+	32, // 17: companiespb.v1.CompanyFilter.page_size:type_name -> google.protobuf.UInt64Value
+	28, // 18: companiespb.v1.CompanyFilter.search:type_name -> google.protobuf.StringValue
+	30, // 19: companiespb.v1.CompanyFilter.registered:type_name -> google.protobuf.BoolValue
+	0,  // 20: companiespb.v1.CompanyFilter.types:type_name -> companiespb.v1.CompanyType
+	28, // 21: companiespb.v1.CompanyFilter.search_language:type_name -> google.protobuf.StringValue
+	28, // 22: companiespb.v1.CompanyFilter.filter:type_name -> google.protobuf.StringValue
+	28, // 23: companiespb.v1.CompanyFilter.count:type_name -> google.protobuf.StringValue
+	13, // 24: companiespb.v1.CompanyWatch.filter:type_name -> companiespb.v1.CompanyFilter
+	32, // 25: companiespb.v1.CompanyWatch.position:type_name -> google.protobuf.UInt64Value
+	1,  // 26: companiespb.v1.CompanyChange.operation:type_name -> companiespb.v1.CompanyChangeOperation
+	31, // 27: companiespb.v1.CompanyChange.changed_at:type_name -> google.protobuf.Timestamp
+	6,  // 28: companiespb.v1.CompanyChange.company:type_name -> companiespb.v1.Company
+	15, // 29: companiespb.v1.CompanyWatchEvent.change:type_name -> companiespb.v1.CompanyChange
+	16, // 30: companiespb.v1.CompanyWatchEvent.heartbeat:type_name -> companiespb.v1.CompanyHeartbeat
+	15, // 31: companiespb.v1.CompanyChangeList.items:type_name -> companiespb.v1.CompanyChange
+	13, // 32: companiespb.v1.CompanyStatsRequest.filter:type_name -> companiespb.v1.CompanyFilter
+	2,  // 33: companiespb.v1.CompanyStatsRequest.interval:type_name -> companiespb.v1.CompanyStatsInterval
+	0,  // 34: companiespb.v1.CompanyStatsGroup.type:type_name -> companiespb.v1.CompanyType
+	33, // 35: companiespb.v1.CompanyStatsBucket.from:type_name -> google.protobuf.Int64Value
+	33, // 36: companiespb.v1.CompanyStatsBucket.to:type_name -> google.protobuf.Int64Value
+	31, // 37: companiespb.v1.CompanyStatsPeriod.start:type_name -> google.protobuf.Timestamp
+	21, // 38: companiespb.v1.CompanyStats.groups:type_name -> companiespb.v1.CompanyStatsGroup
+	22, // 39: companiespb.v1.CompanyStats.employees:type_name -> companiespb.v1.CompanyStatsBucket
+	23, // 40: companiespb.v1.CompanyStats.created:type_name -> companiespb.v1.CompanyStatsPeriod
+	32, // 41: companiespb.v1.CompanySuggest.limit:type_name -> google.protobuf.UInt64Value
+	26, // 42: companiespb.v1.CompanySuggestionList.items:type_name -> companiespb.v1.CompanySuggestion
+	3,  // 43: companiespb.v1.CompanyService.Create:input_type -> companiespb.v1.CompanyCreate
+	4,  // 44: companiespb.v1.CompanyService.Get:input_type -> companiespb.v1.CompanyGet
+	5,  // 45: companiespb.v1.CompanyService.Update:input_type -> companiespb.v1.CompanyUpdate
+	12, // 46: companiespb.v1.CompanyService.Delete:input_type -> companiespb.v1.CompanyDelete
+	13, // 47: companiespb.v1.CompanyService.List:input_type -> companiespb.v1.CompanyFilter
+	18, // 48: companiespb.v1.CompanyService.ListCompanyChanges:input_type -> companiespb.v1.CompanyChangesRequest
+	20, // 49: companiespb.v1.CompanyService.GetCompanyStats:input_type -> companiespb.v1.CompanyStatsRequest
+	25, // 50: companiespb.v1.CompanyService.SuggestCompanies:input_type -> companiespb.v1.CompanySuggest
+	14, // 51: companiespb.v1.CompanyService.WatchCompanies:input_type -> companiespb.v1.CompanyWatch
+	6,  // 52: companiespb.v1.CompanyService.Create:output_type -> companiespb.v1.Company
+	6,  // 53: companiespb.v1.CompanyService.Get:output_type -> companiespb.v1.Company
+	6,  // 54: companiespb.v1.CompanyService.Update:output_type -> companiespb.v1.Company
+	34, // 55: companiespb.v1.CompanyService.Delete:output_type -> google.protobuf.Empty
+	8,  // 56: companiespb.v1.CompanyService.List:output_type -> companiespb.v1.ListCompany
+	19, // 57: companiespb.v1.CompanyService.ListCompanyChanges:output_type -> companiespb.v1.CompanyChangeList
+	24, // 58: companiespb.v1.CompanyService.GetCompanyStats:output_type -> companiespb.v1.CompanyStats
+	27, // 59: companiespb.v1.CompanyService.SuggestCompanies:output_type -> companiespb.v1.CompanySuggestionList
+	17, // 60: companiespb.v1.CompanyService.WatchCompanies:output_type -> companiespb.v1.CompanyWatchEvent
+	52, // [52:61] is the sub-list for method output_type
+	43, // [43:52] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
+}
+
+func init() { file_companiespb_v1_company_proto_init() }
+func file_companiespb_v1_company_proto_init() {
+	if File_companiespb_v1_company_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_companiespb_v1_company_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyCreate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyGet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
 			case 2:
 				return &v.unknownFields
 			default:
@@ -848,7 +2375,7 @@ func file_companiespb_v1_company_proto_init() {
 			}
 		}
 		file_companiespb_v1_company_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListCompany); i {
+			switch v := v.(*CompanySearch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -860,7 +2387,7 @@ func file_companiespb_v1_company_proto_init() {
 			}
 		}
 		file_companiespb_v1_company_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CompanyDelete); i {
+			switch v := v.(*ListCompany); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -872,6 +2399,54 @@ func file_companiespb_v1_company_proto_init() {
 			}
 		}
 		file_companiespb_v1_company_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyFacets); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyTypeFacet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyRegisteredFacet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyDelete); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CompanyFilter); i {
 			case 0:
 				return &v.state
@@ -883,14 +2458,186 @@ func file_companiespb_v1_company_proto_init() {
 				return nil
 			}
 		}
+		file_companiespb_v1_company_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyWatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyHeartbeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyWatchEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyChangesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyChangeList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyStatsGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyStatsBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyStatsPeriod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanySuggest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanySuggestion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_companiespb_v1_company_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanySuggestionList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_companiespb_v1_company_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*CompanyWatchEvent_Change)(nil),
+		(*CompanyWatchEvent_Heartbeat)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_companiespb_v1_company_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   7,
+			NumEnums:      3,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
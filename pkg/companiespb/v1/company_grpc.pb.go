@@ -29,6 +29,10 @@ type CompanyServiceClient interface {
 	Delete(ctx context.Context, in *CompanyDelete, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Deprecated: Do not use.
 	List(ctx context.Context, in *CompanyFilter, opts ...grpc.CallOption) (*ListCompany, error)
+	ListCompanyChanges(ctx context.Context, in *CompanyChangesRequest, opts ...grpc.CallOption) (*CompanyChangeList, error)
+	GetCompanyStats(ctx context.Context, in *CompanyStatsRequest, opts ...grpc.CallOption) (*CompanyStats, error)
+	SuggestCompanies(ctx context.Context, in *CompanySuggest, opts ...grpc.CallOption) (*CompanySuggestionList, error)
+	WatchCompanies(ctx context.Context, in *CompanyWatch, opts ...grpc.CallOption) (CompanyService_WatchCompaniesClient, error)
 }
 
 type companyServiceClient struct {
@@ -85,6 +89,65 @@ func (c *companyServiceClient) List(ctx context.Context, in *CompanyFilter, opts
 	return out, nil
 }
 
+func (c *companyServiceClient) ListCompanyChanges(ctx context.Context, in *CompanyChangesRequest, opts ...grpc.CallOption) (*CompanyChangeList, error) {
+	out := new(CompanyChangeList)
+	err := c.cc.Invoke(ctx, "/companiespb.v1.CompanyService/ListCompanyChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *companyServiceClient) GetCompanyStats(ctx context.Context, in *CompanyStatsRequest, opts ...grpc.CallOption) (*CompanyStats, error) {
+	out := new(CompanyStats)
+	err := c.cc.Invoke(ctx, "/companiespb.v1.CompanyService/GetCompanyStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *companyServiceClient) SuggestCompanies(ctx context.Context, in *CompanySuggest, opts ...grpc.CallOption) (*CompanySuggestionList, error) {
+	out := new(CompanySuggestionList)
+	err := c.cc.Invoke(ctx, "/companiespb.v1.CompanyService/SuggestCompanies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *companyServiceClient) WatchCompanies(ctx context.Context, in *CompanyWatch, opts ...grpc.CallOption) (CompanyService_WatchCompaniesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CompanyService_ServiceDesc.Streams[0], "/companiespb.v1.CompanyService/WatchCompanies", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &companyServiceWatchCompaniesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CompanyService_WatchCompaniesClient interface {
+	Recv() (*CompanyWatchEvent, error)
+	grpc.ClientStream
+}
+
+type companyServiceWatchCompaniesClient struct {
+	grpc.ClientStream
+}
+
+func (x *companyServiceWatchCompaniesClient) Recv() (*CompanyWatchEvent, error) {
+	m := new(CompanyWatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // CompanyServiceServer is the server API for CompanyService service.
 // All implementations should embed UnimplementedCompanyServiceServer
 // for forward compatibility
@@ -95,6 +158,10 @@ type CompanyServiceServer interface {
 	Delete(context.Context, *CompanyDelete) (*emptypb.Empty, error)
 	// Deprecated: Do not use.
 	List(context.Context, *CompanyFilter) (*ListCompany, error)
+	ListCompanyChanges(context.Context, *CompanyChangesRequest) (*CompanyChangeList, error)
+	GetCompanyStats(context.Context, *CompanyStatsRequest) (*CompanyStats, error)
+	SuggestCompanies(context.Context, *CompanySuggest) (*CompanySuggestionList, error)
+	WatchCompanies(*CompanyWatch, CompanyService_WatchCompaniesServer) error
 }
 
 // UnimplementedCompanyServiceServer should be embedded to have forward compatible implementations.
@@ -116,6 +183,18 @@ func (UnimplementedCompanyServiceServer) Delete(context.Context, *CompanyDelete)
 func (UnimplementedCompanyServiceServer) List(context.Context, *CompanyFilter) (*ListCompany, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedCompanyServiceServer) ListCompanyChanges(context.Context, *CompanyChangesRequest) (*CompanyChangeList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCompanyChanges not implemented")
+}
+func (UnimplementedCompanyServiceServer) GetCompanyStats(context.Context, *CompanyStatsRequest) (*CompanyStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompanyStats not implemented")
+}
+func (UnimplementedCompanyServiceServer) SuggestCompanies(context.Context, *CompanySuggest) (*CompanySuggestionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestCompanies not implemented")
+}
+func (UnimplementedCompanyServiceServer) WatchCompanies(*CompanyWatch, CompanyService_WatchCompaniesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCompanies not implemented")
+}
 
 // UnsafeCompanyServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to CompanyServiceServer will
@@ -218,6 +297,81 @@ func _CompanyService_List_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CompanyService_ListCompanyChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompanyChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompanyServiceServer).ListCompanyChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/companiespb.v1.CompanyService/ListCompanyChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompanyServiceServer).ListCompanyChanges(ctx, req.(*CompanyChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompanyService_GetCompanyStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompanyStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompanyServiceServer).GetCompanyStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/companiespb.v1.CompanyService/GetCompanyStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompanyServiceServer).GetCompanyStats(ctx, req.(*CompanyStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompanyService_SuggestCompanies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompanySuggest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompanyServiceServer).SuggestCompanies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/companiespb.v1.CompanyService/SuggestCompanies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompanyServiceServer).SuggestCompanies(ctx, req.(*CompanySuggest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompanyService_WatchCompanies_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CompanyWatch)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CompanyServiceServer).WatchCompanies(m, &companyServiceWatchCompaniesServer{stream})
+}
+
+type CompanyService_WatchCompaniesServer interface {
+	Send(*CompanyWatchEvent) error
+	grpc.ServerStream
+}
+
+type companyServiceWatchCompaniesServer struct {
+	grpc.ServerStream
+}
+
+func (x *companyServiceWatchCompaniesServer) Send(m *CompanyWatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // CompanyService_ServiceDesc is the grpc.ServiceDesc for CompanyService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -245,7 +399,25 @@ var CompanyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _CompanyService_List_Handler,
 		},
+		{
+			MethodName: "ListCompanyChanges",
+			Handler:    _CompanyService_ListCompanyChanges_Handler,
+		},
+		{
+			MethodName: "GetCompanyStats",
+			Handler:    _CompanyService_GetCompanyStats_Handler,
+		},
+		{
+			MethodName: "SuggestCompanies",
+			Handler:    _CompanyService_SuggestCompanies_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCompanies",
+			Handler:       _CompanyService_WatchCompanies_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "companiespb/v1/company.proto",
 }
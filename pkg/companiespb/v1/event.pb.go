@@ -0,0 +1,323 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: companiespb/v1/event.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EventOperation int32
+
+const (
+	EventOperation_EVENT_OPERATION_UNKNOWN EventOperation = 0
+	EventOperation_EVENT_OPERATION_CREATED EventOperation = 1
+	EventOperation_EVENT_OPERATION_UPDATED EventOperation = 2
+	EventOperation_EVENT_OPERATION_DELETED EventOperation = 3
+	// The current state of the company, published by the events replay.
+	EventOperation_EVENT_OPERATION_SNAPSHOT EventOperation = 4
+)
+
+// Enum value maps for EventOperation.
+var (
+	EventOperation_name = map[int32]string{
+		0: "EVENT_OPERATION_UNKNOWN",
+		1: "EVENT_OPERATION_CREATED",
+		2: "EVENT_OPERATION_UPDATED",
+		3: "EVENT_OPERATION_DELETED",
+		4: "EVENT_OPERATION_SNAPSHOT",
+	}
+	EventOperation_value = map[string]int32{
+		"EVENT_OPERATION_UNKNOWN":  0,
+		"EVENT_OPERATION_CREATED":  1,
+		"EVENT_OPERATION_UPDATED":  2,
+		"EVENT_OPERATION_DELETED":  3,
+		"EVENT_OPERATION_SNAPSHOT": 4,
+	}
+)
+
+func (x EventOperation) Enum() *EventOperation {
+	p := new(EventOperation)
+	*p = x
+	return p
+}
+
+func (x EventOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_companiespb_v1_event_proto_enumTypes[0].Descriptor()
+}
+
+func (EventOperation) Type() protoreflect.EnumType {
+	return &file_companiespb_v1_event_proto_enumTypes[0]
+}
+
+func (x EventOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventOperation.Descriptor instead.
+func (EventOperation) EnumDescriptor() ([]byte, []int) {
+	return file_companiespb_v1_event_proto_rawDescGZIP(), []int{0}
+}
+
+type CompanyEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operation EventOperation `protobuf:"varint,1,opt,name=operation,proto3,enum=companiespb.v1.EventOperation" json:"operation,omitempty"`
+	Company   *Company       `protobuf:"bytes,2,opt,name=company,proto3" json:"company,omitempty"`
+	// Increases monotonically per company.
+	Sequence      uint64                 `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CompanyId     string                 `protobuf:"bytes,5,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	Before        *Company               `protobuf:"bytes,6,opt,name=before,proto3" json:"before,omitempty"`
+	After         *Company               `protobuf:"bytes,7,opt,name=after,proto3" json:"after,omitempty"`
+	ChangedFields []string               `protobuf:"bytes,8,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	// New values of the changed fields, set in the diff payload mode.
+	Changes *structpb.Struct `protobuf:"bytes,9,opt,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *CompanyEvent) Reset() {
+	*x = CompanyEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_companiespb_v1_event_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyEvent) ProtoMessage() {}
+
+func (x *CompanyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_companiespb_v1_event_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyEvent.ProtoReflect.Descriptor instead.
+func (*CompanyEvent) Descriptor() ([]byte, []int) {
+	return file_companiespb_v1_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CompanyEvent) GetOperation() EventOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return EventOperation_EVENT_OPERATION_UNKNOWN
+}
+
+func (x *CompanyEvent) GetCompany() *Company {
+	if x != nil {
+		return x.Company
+	}
+	return nil
+}
+
+func (x *CompanyEvent) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *CompanyEvent) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *CompanyEvent) GetCompanyId() string {
+	if x != nil {
+		return x.CompanyId
+	}
+	return ""
+}
+
+func (x *CompanyEvent) GetBefore() *Company {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *CompanyEvent) GetAfter() *Company {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *CompanyEvent) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+func (x *CompanyEvent) GetChanges() *structpb.Struct {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+var File_companiespb_v1_event_proto protoreflect.FileDescriptor
+
+var file_companiespb_v1_event_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2f, 0x76, 0x31,
+	0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2f, 0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xaf, 0x03, 0x0a, 0x0c, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x09, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x79, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x5f, 0x69, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x49,
+	0x64, 0x12, 0x2f, 0x0a, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x52, 0x06, 0x62, 0x65, 0x66, 0x6f,
+	0x72, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x2a, 0xa2, 0x01, 0x0a, 0x0e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b,
+	0x0a, 0x17, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43,
+	0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x56, 0x45, 0x4e,
+	0x54, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x50, 0x44, 0x41,
+	0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4f,
+	0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44,
+	0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x4f, 0x50, 0x45, 0x52,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10, 0x04,
+	0x42, 0x2f, 0x5a, 0x2d, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x30,
+	0x31, 0x38, 0x62, 0x66, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x70, 0x62, 0x2f, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_companiespb_v1_event_proto_rawDescOnce sync.Once
+	file_companiespb_v1_event_proto_rawDescData = file_companiespb_v1_event_proto_rawDesc
+)
+
+func file_companiespb_v1_event_proto_rawDescGZIP() []byte {
+	file_companiespb_v1_event_proto_rawDescOnce.Do(func() {
+		file_companiespb_v1_event_proto_rawDescData = protoimpl.X.CompressGZIP(file_companiespb_v1_event_proto_rawDescData)
+	})
+	return file_companiespb_v1_event_proto_rawDescData
+}
+
+var file_companiespb_v1_event_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_companiespb_v1_event_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_companiespb_v1_event_proto_goTypes = []interface{}{
+	(EventOperation)(0),           // 0: companiespb.v1.EventOperation
+	(*CompanyEvent)(nil),          // 1: companiespb.v1.CompanyEvent
+	(*Company)(nil),               // 2: companiespb.v1.Company
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),       // 4: google.protobuf.Struct
+}
+var file_companiespb_v1_event_proto_depIdxs = []int32{
+	0, // 0: companiespb.v1.CompanyEvent.operation:type_name -> companiespb.v1.EventOperation
+	2, // 1: companiespb.v1.CompanyEvent.company:type_name -> companiespb.v1.Company
+	3, // 2: companiespb.v1.CompanyEvent.updated_at:type_name -> google.protobuf.Timestamp
+	2, // 3: companiespb.v1.CompanyEvent.before:type_name -> companiespb.v1.Company
+	2, // 4: companiespb.v1.CompanyEvent.after:type_name -> companiespb.v1.Company
+	4, // 5: companiespb.v1.CompanyEvent.changes:type_name -> google.protobuf.Struct
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_companiespb_v1_event_proto_init() }
+func file_companiespb_v1_event_proto_init() {
+	if File_companiespb_v1_event_proto != nil {
+		return
+	}
+	file_companiespb_v1_company_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_companiespb_v1_event_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_companiespb_v1_event_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_companiespb_v1_event_proto_goTypes,
+		DependencyIndexes: file_companiespb_v1_event_proto_depIdxs,
+		EnumInfos:         file_companiespb_v1_event_proto_enumTypes,
+		MessageInfos:      file_companiespb_v1_event_proto_msgTypes,
+	}.Build()
+	File_companiespb_v1_event_proto = out.File
+	file_companiespb_v1_event_proto_rawDesc = nil
+	file_companiespb_v1_event_proto_goTypes = nil
+	file_companiespb_v1_event_proto_depIdxs = nil
+}
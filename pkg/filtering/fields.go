@@ -0,0 +1,54 @@
+package filtering
+
+import (
+	"strconv"
+	"time"
+)
+
+type FieldType uint8
+
+const (
+	// FieldTypeString is compared by =, != and by : case-insensitively with * as a wildcard.
+	FieldTypeString FieldType = iota + 1
+	// FieldTypeNumber is an integer compared by any operator, : means =.
+	FieldTypeNumber
+	// FieldTypeBool is true or false compared by =, != and :.
+	FieldTypeBool
+	// FieldTypeTime is a RFC 3339 time or a date compared by any operator, : means =.
+	FieldTypeTime
+)
+
+func (t FieldType) allows(operator Operator) bool {
+	switch operator {
+	case OperatorHas, OperatorEqual, OperatorNotEqual:
+		return true
+	default:
+		return t == FieldTypeNumber || t == FieldTypeTime
+	}
+}
+
+func (t FieldType) convert(text string, position int) (any, error) {
+	switch t {
+	case FieldTypeNumber:
+		value, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, newError(position, "%q is not a number", text)
+		}
+		return value, nil
+	case FieldTypeBool:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, newError(position, "%q is not true or false", text)
+		}
+		return value, nil
+	case FieldTypeTime:
+		for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+			if value, err := time.Parse(layout, text); err == nil {
+				return value, nil
+			}
+		}
+		return nil, newError(position, "%q is not a time or a date", text)
+	default:
+		return text, nil
+	}
+}
@@ -0,0 +1,134 @@
+// Package filtering parses filter expressions in the AIP-160 style, for example
+//
+//	amount_of_employees >= 50 AND created_at > "2026-01-01" AND type:(1 OR 3) AND name:"Acme*"
+//
+// Restrictions compare a field with a value by one of the operators =, !=, <, <=, >, >= and :.
+// They are combined by AND, OR, NOT and parentheses, OR binds tighter than AND
+// and a sequence of restrictions without an operator means AND.
+package filtering
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/018bf/companies/internal/errs"
+)
+
+type Operator string
+
+const (
+	OperatorHas          Operator = ":"
+	OperatorEqual        Operator = "="
+	OperatorNotEqual     Operator = "!="
+	OperatorLess         Operator = "<"
+	OperatorLessEqual    Operator = "<="
+	OperatorGreater      Operator = ">"
+	OperatorGreaterEqual Operator = ">="
+)
+
+// Expr is a node of the parsed filter: And, Or, Not or Restriction.
+type Expr interface {
+	expr()
+}
+
+type And struct {
+	Left  Expr
+	Right Expr
+}
+
+type Or struct {
+	Left  Expr
+	Right Expr
+}
+
+type Not struct {
+	Expr Expr
+}
+
+// Restriction compares the field with the values, it matches when any of the values matches.
+type Restriction struct {
+	Field    string
+	Operator Operator
+	Values   []Value
+	Position int
+}
+
+// Value is a literal of the filter converted to the type of its field:
+// string, int64, bool or time.Time.
+type Value struct {
+	Value    any
+	Position int
+}
+
+func (And) expr()         {}
+func (Or) expr()          {}
+func (Not) expr()         {}
+func (Restriction) expr() {}
+
+// Fields is the whitelist of the fields that may be filtered by with their types.
+type Fields map[string]FieldType
+
+// Parse parses the filter and validates it against the fields.
+// An empty filter is parsed to nil.
+// The errors have the reason and the 1-based position of the offending character in params.
+func Parse(input string, fields Fields) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, fields: fields}
+	if p.peek().kind == tokenEOF {
+		return nil, nil
+	}
+	expr, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, newError(next.position, "unexpected %s", next)
+	}
+	return expr, nil
+}
+
+func newError(position int, format string, args ...any) *errs.Error {
+	return errs.NewInvalidParameter("Invalid filter.").WithParams(map[string]string{
+		"filter":   fmt.Sprintf(format, args...),
+		"position": strconv.Itoa(position),
+	})
+}
+
+// Without returns expr without its conjuncts that restrict only the field, such as `type:(1 OR 3)`
+// or `NOT registered = true`, it is nil when nothing is left.
+// The restrictions of the field combined with the other fields are kept.
+func Without(expr Expr, field string) Expr {
+	if only(expr, field) {
+		return nil
+	}
+	if and, ok := expr.(*And); ok {
+		left, right := Without(and.Left, field), Without(and.Right, field)
+		switch {
+		case left == nil:
+			return right
+		case right == nil:
+			return left
+		}
+		return &And{Left: left, Right: right}
+	}
+	return expr
+}
+
+// only reports whether expr restricts the field and nothing else.
+func only(expr Expr, field string) bool {
+	switch expr := expr.(type) {
+	case *And:
+		return only(expr.Left, field) && only(expr.Right, field)
+	case *Or:
+		return only(expr.Left, field) && only(expr.Right, field)
+	case *Not:
+		return only(expr.Expr, field)
+	case *Restriction:
+		return expr.Field == field
+	default:
+		return false
+	}
+}
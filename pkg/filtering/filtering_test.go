@@ -0,0 +1,123 @@
+package filtering
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithout(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		field string
+		want  string
+	}{
+		{
+			name:  "conjunct",
+			input: `registered = true AND type:(1 OR 3) AND amount_of_employees > 5`,
+			field: "type",
+			want:  `registered = true AND amount_of_employees > 5`,
+		},
+		{
+			name:  "negated disjunction",
+			input: `NOT (type = 1 OR type = 2) name:a`,
+			field: "type",
+			want:  `name:a`,
+		},
+		{
+			name:  "combined with another field",
+			input: `type = 1 OR registered = true`,
+			field: "type",
+			want:  `type = 1 OR registered = true`,
+		},
+		{
+			name:  "nothing is left",
+			input: `type = 1 AND -type = 2`,
+			field: "type",
+			want:  ``,
+		},
+		{
+			name:  "no expression",
+			input: ``,
+			field: "type",
+			want:  ``,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.input, testFields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := Parse(tt.want, testFields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := Without(expr, tt.field)
+			if !reflect.DeepEqual(positionless(got), positionless(want)) {
+				t.Errorf("Without() = %#v, want %#v", got, want)
+			}
+		})
+	}
+}
+
+// positionless clears the positions, which differ between the input and the expected filter.
+func positionless(expr Expr) Expr {
+	switch expr := expr.(type) {
+	case *And:
+		return &And{Left: positionless(expr.Left), Right: positionless(expr.Right)}
+	case *Or:
+		return &Or{Left: positionless(expr.Left), Right: positionless(expr.Right)}
+	case *Not:
+		return &Not{Expr: positionless(expr.Expr)}
+	case *Restriction:
+		values := make([]Value, len(expr.Values))
+		for i, value := range expr.Values {
+			values[i] = Value{Value: value.Value}
+		}
+		return &Restriction{Field: expr.Field, Operator: expr.Operator, Values: values}
+	default:
+		return expr
+	}
+}
+
+func TestMatch(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	values := map[string]any{
+		"name":                "Acme Corp",
+		"amount_of_employees": int64(50),
+		"registered":          true,
+		"created_at":          created,
+		"type":                int64(2),
+	}
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{input: ``, want: true},
+		{input: `name:"acme*"`, want: true},
+		{input: `name:"*corp"`, want: true},
+		{input: `name:acme`, want: false},
+		{input: `name = "acme corp"`, want: false},
+		{input: `name != "Acme"`, want: true},
+		{input: `amount_of_employees >= 50 AND amount_of_employees < 51`, want: true},
+		{input: `amount_of_employees > 50`, want: false},
+		{input: `type:(1 OR 2)`, want: true},
+		{input: `registered = false OR type = 3`, want: false},
+		{input: `NOT registered = false`, want: true},
+		{input: `created_at > "2026-03-01"`, want: true},
+		{input: `created_at <= "2026-03-01T11:59:59Z"`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			expr, err := Parse(tt.input, testFields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := Match(expr, values); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package filtering
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind uint8
+
+const (
+	tokenEOF tokenKind = iota
+	tokenText
+	tokenString
+	tokenComparator
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// position is the 1-based position of the first character of the token in the expression.
+	position int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of the filter"
+	case tokenString:
+		return `"` + t.text + `"`
+	default:
+		return t.text
+	}
+}
+
+func (t token) is(keyword string) bool {
+	return t.kind == tokenText && t.text == keyword
+}
+
+func (t token) isKeyword() bool {
+	return t.is(keywordAnd) || t.is(keywordOr) || t.is(keywordNot)
+}
+
+const (
+	keywordAnd = "AND"
+	keywordOr  = "OR"
+	keywordNot = "NOT"
+)
+
+// special are the characters ending an unquoted text.
+const special = `()"'<>=!:`
+
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		position := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", position: position})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", position: position})
+			i++
+		case r == ':' || r == '=':
+			tokens = append(tokens, token{kind: tokenComparator, text: string(r), position: position})
+			i++
+		case r == '<' || r == '>' || r == '!':
+			text := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				text += "="
+			}
+			if text == "!" {
+				return nil, newError(position, `unexpected "!", did you mean "!="`)
+			}
+			tokens = append(tokens, token{kind: tokenComparator, text: text, position: position})
+			i += len(text)
+		case r == '"' || r == '\'':
+			text, end, err := lexString(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, position: position})
+			i = end
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(special, runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenText, text: string(runes[start:i]), position: position})
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, position: len(runes) + 1}), nil
+}
+
+// lexString reads the quoted string starting at start and returns its unescaped text and the index after it.
+func lexString(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	var text strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 == len(runes) {
+				return "", 0, newError(i+1, "unterminated escape sequence")
+			}
+			i++
+			text.WriteRune(runes[i])
+		case quote:
+			return text.String(), i + 1, nil
+		default:
+			text.WriteRune(runes[i])
+		}
+	}
+	return "", 0, newError(start+1, "unterminated string")
+}
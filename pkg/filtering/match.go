@@ -0,0 +1,102 @@
+package filtering
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Match evaluates expr against the values of the fields, as a SQL database compiling it would:
+// a string matched by : ignores the case and * matches any characters, the other values are compared.
+// The values are string, int64, bool or time.Time like the literals of the filter, a nil expr matches anything.
+func Match(expr Expr, values map[string]any) bool {
+	switch expr := expr.(type) {
+	case nil:
+		return true
+	case *And:
+		return Match(expr.Left, values) && Match(expr.Right, values)
+	case *Or:
+		return Match(expr.Left, values) || Match(expr.Right, values)
+	case *Not:
+		return !Match(expr.Expr, values)
+	case *Restriction:
+		for _, value := range expr.Values {
+			if matchRestriction(values[expr.Field], expr.Operator, value.Value) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func matchRestriction(field any, operator Operator, value any) bool {
+	if text, ok := value.(string); ok && operator == OperatorHas {
+		field, ok := field.(string)
+		return ok && wildcard(text).MatchString(field)
+	}
+	order, ok := compare(field, value)
+	if !ok {
+		return false
+	}
+	switch operator {
+	case OperatorNotEqual:
+		return order != 0
+	case OperatorLess:
+		return order < 0
+	case OperatorLessEqual:
+		return order <= 0
+	case OperatorGreater:
+		return order > 0
+	case OperatorGreaterEqual:
+		return order >= 0
+	default:
+		return order == 0
+	}
+}
+
+// wildcard returns the case-insensitive pattern of the whole text with * matching any characters.
+func wildcard(text string) *regexp.Regexp {
+	parts := strings.Split(text, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("(?is)^" + strings.Join(parts, ".*") + "$")
+}
+
+// compare orders a and b of the same type, it reports false when they can't be compared.
+func compare(a, b any) (int, bool) {
+	switch a := a.(type) {
+	case string:
+		b, ok := b.(string)
+		return strings.Compare(a, b), ok
+	case int64:
+		b, ok := b.(int64)
+		switch {
+		case !ok:
+			return 0, false
+		case a < b:
+			return -1, true
+		case a > b:
+			return 1, true
+		}
+		return 0, true
+	case bool:
+		b, ok := b.(bool)
+		switch {
+		case !ok:
+			return 0, false
+		case a == b:
+			return 0, true
+		case b:
+			return -1, true
+		}
+		return 1, true
+	case time.Time:
+		b, ok := b.(time.Time)
+		return a.Compare(b), ok
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,192 @@
+package filtering
+
+import "strings"
+
+// maxDepth limits the nesting of the filter, so a crafted one cannot exhaust the stack.
+const maxDepth = 32
+
+// parser is a recursive descent parser of the grammar
+//
+//	expression  = sequence { "AND" sequence }
+//	sequence    = factor { factor }
+//	factor      = term { "OR" term }
+//	term        = [ "NOT" | "-" ] simple
+//	simple      = restriction | "(" expression ")"
+//	restriction = field comparator arg
+//	arg         = value | "(" value { "OR" value } ")"
+type parser struct {
+	tokens []token
+	index  int
+	depth  int
+	fields Fields
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.index]
+	if t.kind != tokenEOF {
+		p.index++
+	}
+	return t
+}
+
+func (p *parser) expect(kind tokenKind, name string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, newError(t.position, "expected %s, got %s", name, t)
+	}
+	return t, nil
+}
+
+func (p *parser) expression() (Expr, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, newError(p.peek().position, "filter is nested too deeply")
+	}
+	left, err := p.sequence()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is(keywordAnd) {
+		p.next()
+		right, err := p.sequence()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) sequence() (Expr, error) {
+	left, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for p.startsTerm() {
+		right, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) startsTerm() bool {
+	t := p.peek()
+	return t.kind == tokenLeftParen || t.is(keywordNot) || t.kind == tokenText && !t.isKeyword()
+}
+
+func (p *parser) factor() (Expr, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().is(keywordOr) {
+		p.next()
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) term() (Expr, error) {
+	t := p.peek()
+	switch {
+	case t.is(keywordNot):
+		p.next()
+	case t.kind == tokenText && len(t.text) > 1 && strings.HasPrefix(t.text, "-"):
+		// The minus is a part of the field token, it is cut off to parse the restriction.
+		p.tokens[p.index] = token{kind: tokenText, text: t.text[1:], position: t.position + 1}
+	default:
+		return p.simple()
+	}
+	expr, err := p.simple()
+	if err != nil {
+		return nil, err
+	}
+	return &Not{Expr: expr}, nil
+}
+
+func (p *parser) simple() (Expr, error) {
+	t := p.peek()
+	if t.kind == tokenLeftParen {
+		p.next()
+		expr, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenRightParen, `")"`); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+	if t.kind != tokenText || t.isKeyword() {
+		return nil, newError(t.position, "expected a field, got %s", t)
+	}
+	return p.restriction()
+}
+
+func (p *parser) restriction() (Expr, error) {
+	field := p.next()
+	fieldType, ok := p.fields[field.text]
+	if !ok {
+		return nil, newError(field.position, "unknown field %q", field.text)
+	}
+	comparator, err := p.expect(tokenComparator, "a comparator")
+	if err != nil {
+		return nil, err
+	}
+	operator := Operator(comparator.text)
+	if !fieldType.allows(operator) {
+		return nil, newError(comparator.position, "operator %q is not supported by field %q", operator, field.text)
+	}
+	restriction := &Restriction{Field: field.text, Operator: operator, Position: field.position}
+	if p.peek().kind != tokenLeftParen {
+		value, err := p.value(fieldType)
+		if err != nil {
+			return nil, err
+		}
+		restriction.Values = []Value{value}
+		return restriction, nil
+	}
+	list := p.next()
+	if operator != OperatorHas {
+		return nil, newError(list.position, "a list of values is supported by operator %q only", OperatorHas)
+	}
+	for {
+		value, err := p.value(fieldType)
+		if err != nil {
+			return nil, err
+		}
+		restriction.Values = append(restriction.Values, value)
+		if !p.peek().is(keywordOr) {
+			break
+		}
+		p.next()
+	}
+	if _, err := p.expect(tokenRightParen, `")"`); err != nil {
+		return nil, err
+	}
+	return restriction, nil
+}
+
+func (p *parser) value(fieldType FieldType) (Value, error) {
+	t := p.next()
+	if t.kind != tokenString && (t.kind != tokenText || t.isKeyword()) {
+		return Value{}, newError(t.position, "expected a value, got %s", t)
+	}
+	value, err := fieldType.convert(t.text, t.position)
+	if err != nil {
+		return Value{}, err
+	}
+	return Value{Value: value, Position: t.position}, nil
+}
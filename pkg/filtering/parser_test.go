@@ -0,0 +1,184 @@
+package filtering
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/018bf/companies/internal/errs"
+)
+
+var testFields = Fields{
+	"name":                FieldTypeString,
+	"amount_of_employees": FieldTypeNumber,
+	"registered":          FieldTypeBool,
+	"created_at":          FieldTypeTime,
+	"type":                FieldTypeNumber,
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Expr
+		wantErr error
+	}{
+		{
+			name:    "empty",
+			input:   "  ",
+			want:    nil,
+			wantErr: nil,
+		},
+		{
+			name:  "conjunction",
+			input: `amount_of_employees >= 50 AND created_at > "2026-01-01" AND type:(1 OR 3) AND name:"Acme*"`,
+			want: &And{
+				Left: &And{
+					Left: &And{
+						Left: &Restriction{
+							Field:    "amount_of_employees",
+							Operator: OperatorGreaterEqual,
+							Values:   []Value{{Value: int64(50), Position: 24}},
+							Position: 1,
+						},
+						Right: &Restriction{
+							Field:    "created_at",
+							Operator: OperatorGreater,
+							Values:   []Value{{Value: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Position: 44}},
+							Position: 31,
+						},
+					},
+					Right: &Restriction{
+						Field:    "type",
+						Operator: OperatorHas,
+						Values:   []Value{{Value: int64(1), Position: 67}, {Value: int64(3), Position: 72}},
+						Position: 61,
+					},
+				},
+				Right: &Restriction{
+					Field:    "name",
+					Operator: OperatorHas,
+					Values:   []Value{{Value: "Acme*", Position: 84}},
+					Position: 79,
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "or binds tighter than the implicit and",
+			input: `registered=true name:a OR name:b`,
+			want: &And{
+				Left: &Restriction{
+					Field:    "registered",
+					Operator: OperatorEqual,
+					Values:   []Value{{Value: true, Position: 12}},
+					Position: 1,
+				},
+				Right: &Or{
+					Left: &Restriction{
+						Field:    "name",
+						Operator: OperatorHas,
+						Values:   []Value{{Value: "a", Position: 22}},
+						Position: 17,
+					},
+					Right: &Restriction{
+						Field:    "name",
+						Operator: OperatorHas,
+						Values:   []Value{{Value: "b", Position: 32}},
+						Position: 27,
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "negation",
+			input: `NOT (type = 1) -name:x`,
+			want: &And{
+				Left: &Not{Expr: &Restriction{
+					Field:    "type",
+					Operator: OperatorEqual,
+					Values:   []Value{{Value: int64(1), Position: 13}},
+					Position: 6,
+				}},
+				Right: &Not{Expr: &Restriction{
+					Field:    "name",
+					Operator: OperatorHas,
+					Values:   []Value{{Value: "x", Position: 22}},
+					Position: 17,
+				}},
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "unknown field",
+			input:   `amount_of_employees > 1 AND owner = "me"`,
+			want:    nil,
+			wantErr: newError(29, `unknown field "owner"`),
+		},
+		{
+			name:    "unsupported operator",
+			input:   `name > "a"`,
+			want:    nil,
+			wantErr: newError(6, `operator ">" is not supported by field "name"`),
+		},
+		{
+			name:    "invalid value",
+			input:   `amount_of_employees >= many`,
+			want:    nil,
+			wantErr: newError(24, `"many" is not a number`),
+		},
+		{
+			name:    "missing comparator",
+			input:   `name AND type = 1`,
+			want:    nil,
+			wantErr: newError(6, "expected a comparator, got AND"),
+		},
+		{
+			name:    "unbalanced parentheses",
+			input:   `(type = 1`,
+			want:    nil,
+			wantErr: newError(10, `expected ")", got end of the filter`),
+		},
+		{
+			name:    "unterminated string",
+			input:   `name = "Acme`,
+			want:    nil,
+			wantErr: newError(8, "unterminated string"),
+		},
+		{
+			name:    "list with equality",
+			input:   `type = (1 OR 2)`,
+			want:    nil,
+			wantErr: newError(8, `a list of values is supported by operator ":" only`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input, testFields)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_depth(t *testing.T) {
+	input := ""
+	for i := 0; i < maxDepth; i++ {
+		input += "("
+	}
+	_, err := Parse(input+"type = 1", testFields)
+	want := errs.NewInvalidParameter("Invalid filter.").WithParams(map[string]string{
+		"filter":   "filter is nested too deeply",
+		"position": "33",
+	})
+	if !errors.Is(err, want) {
+		t.Errorf("Parse() error = %v, want %v", err, want)
+	}
+}
@@ -1,24 +1,77 @@
 package postgresql
 
 import (
-	"fmt"
 	"strings"
+
+	sq "github.com/Masterminds/squirrel"
 )
 
+var weights = []string{"A", "B", "C", "D"}
+
+// Search matches the fields against a query in the web search syntax:
+// quoted phrases, OR and negation with a leading minus.
+// The language and the query are bound parameters, so any user input is safe.
 type Search struct {
-	Lang   string
+	Lang string
+	// Fields are weighted A, B, C and D in their order.
 	Fields []string
-	Query  string
+	// Vector is a stored tsvector column used instead of the fields, so the query can use its index.
+	Vector string
+	// Unaccent removes the accents from the fields and the query.
+	Unaccent bool
+	Query    string
 }
 
 // nolint:stylecheck
 func (s Search) ToSql() (sql string, args []interface{}, err error) {
+	vector, vectorArgs := s.vector()
+	query, queryArgs := s.query()
+	return vector + " @@ " + query, append(vectorArgs, queryArgs...), nil
+}
+
+// Rank returns the relevance of the match, taking the weights and the proximity of the matched words into account.
+func (s Search) Rank() sq.Sqlizer {
+	vector, vectorArgs := s.vector()
+	query, queryArgs := s.query()
+	return sq.Expr("ts_rank_cd("+vector+", "+query+")", append(vectorArgs, queryArgs...)...)
+}
+
+// Headline returns the field with the matched words highlighted.
+func (s Search) Headline(field string) sq.Sqlizer {
+	query, queryArgs := s.query()
+	return sq.Expr("ts_headline(?::regconfig, "+field+", "+query+")", append([]any{s.lang()}, queryArgs...)...)
+}
+
+func (s Search) vector() (string, []any) {
+	if s.Vector != "" {
+		return s.Vector, nil
+	}
+	parts := make([]string, len(s.Fields))
+	args := make([]any, len(s.Fields))
+	for i, field := range s.Fields {
+		if s.Unaccent {
+			field = "unaccent(" + field + ")"
+		}
+		weight := weights[len(weights)-1]
+		if i < len(weights) {
+			weight = weights[i]
+		}
+		parts[i] = "setweight(to_tsvector(?::regconfig, " + field + "), '" + weight + "')"
+		args[i] = s.lang()
+	}
+	return "(" + strings.Join(parts, " || ") + ")", args
+}
+
+func (s Search) query() (string, []any) {
+	if s.Unaccent {
+		return "websearch_to_tsquery(?::regconfig, unaccent(?))", []any{s.lang(), s.Query}
+	}
+	return "websearch_to_tsquery(?::regconfig, ?)", []any{s.lang(), s.Query}
+}
+
+func (s Search) lang() string {
 	if s.Lang == "" {
-		s.Lang = "russian"
+		return "simple"
 	}
-	vector := "to_tsvector('%s', %s)"
-	vector = fmt.Sprintf(vector, s.Lang, strings.Join(s.Fields, " || ' ' || "))
-	query := "plainto_tsquery('%s', '%s')"
-	query = fmt.Sprintf(query, s.Lang, s.Query)
-	return fmt.Sprintf("%s @@ %s", vector, query), nil, nil
+	return s.Lang
 }
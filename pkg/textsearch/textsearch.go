@@ -0,0 +1,128 @@
+// Package textsearch approximates the PostgreSQL text search and trigram similarity in process,
+// for the storages without them.
+package textsearch
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Weights of the fields in their order, as the default weights A, B, C and D of ts_rank.
+var Weights = []float64{1, 0.4, 0.2, 0.1}
+
+// Query matches the fields containing all its words, ignoring the case.
+type Query struct {
+	Terms []string
+	words *regexp.Regexp
+}
+
+func NewQuery(query string) Query {
+	terms := words(query)
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = regexp.QuoteMeta(term)
+	}
+	q := Query{Terms: terms}
+	if len(parts) > 0 {
+		q.words = regexp.MustCompile("(?i)" + strings.Join(parts, "|"))
+	}
+	return q
+}
+
+// Matches reports whether every word of the query is contained in any of the fields.
+func (q Query) Matches(fields ...string) bool {
+	for _, term := range q.Terms {
+		found := false
+		for _, field := range fields {
+			if strings.Contains(strings.ToLower(field), term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return len(q.Terms) > 0
+}
+
+// Rank sums the weights of the fields containing each word of the query.
+func (q Query) Rank(fields ...string) float64 {
+	var rank float64
+	for _, term := range q.Terms {
+		for i, field := range fields {
+			if strings.Contains(strings.ToLower(field), term) {
+				rank += weight(i)
+			}
+		}
+	}
+	return rank
+}
+
+// Headline returns the field with the words of the query in bold, as ts_headline does.
+func (q Query) Headline(field string) string {
+	if q.words == nil {
+		return field
+	}
+	return q.words.ReplaceAllString(field, "<b>$0</b>")
+}
+
+func weight(i int) float64 {
+	if i < len(Weights) {
+		return Weights[i]
+	}
+	return Weights[len(Weights)-1]
+}
+
+// words returns the lower case words of the text, split by anything but the letters and the digits.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// WordSimilarity returns the greatest similarity of the trigrams of query
+// and a continuous extent of the trigrams of text, as word_similarity of pg_trgm does.
+func WordSimilarity(query, text string) float64 {
+	queryTrigrams := map[string]struct{}{}
+	for _, trigram := range trigrams(query) {
+		queryTrigrams[trigram] = struct{}{}
+	}
+	if len(queryTrigrams) == 0 {
+		return 0
+	}
+	textTrigrams := trigrams(text)
+	var best float64
+	for start := range textTrigrams {
+		extent := map[string]struct{}{}
+		shared := 0
+		for _, trigram := range textTrigrams[start:] {
+			if _, ok := extent[trigram]; ok {
+				continue
+			}
+			extent[trigram] = struct{}{}
+			if _, ok := queryTrigrams[trigram]; ok {
+				shared++
+			}
+			similarity := float64(shared) / float64(len(queryTrigrams)+len(extent)-shared)
+			if similarity > best {
+				best = similarity
+			}
+		}
+	}
+	return best
+}
+
+// trigrams returns the trigrams of the words of text in their order, each word padded by two spaces
+// in front and one behind.
+func trigrams(text string) []string {
+	var result []string
+	for _, word := range words(text) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			result = append(result, string(runes[i:i+3]))
+		}
+	}
+	return result
+}
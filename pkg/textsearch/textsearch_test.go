@@ -0,0 +1,86 @@
+package textsearch
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		fields       []string
+		wantMatches  bool
+		wantRank     float64
+		wantHeadline string
+	}{
+		{
+			name:         "all words",
+			query:        "Anvil, rocket",
+			fields:       []string{"Acme", "Rocket powered anvils"},
+			wantMatches:  true,
+			wantRank:     0.8,
+			wantHeadline: "Acme",
+		},
+		{
+			name:         "words in both fields",
+			query:        "acme",
+			fields:       []string{"Acme", "The ACME company"},
+			wantMatches:  true,
+			wantRank:     1.4,
+			wantHeadline: "<b>Acme</b>",
+		},
+		{
+			name:         "missing word",
+			query:        "acme rocket",
+			fields:       []string{"Acme", "Anvils"},
+			wantMatches:  false,
+			wantRank:     1,
+			wantHeadline: "<b>Acme</b>",
+		},
+		{
+			name:         "no words",
+			query:        " - ",
+			fields:       []string{"Acme", "Anvils"},
+			wantMatches:  false,
+			wantRank:     0,
+			wantHeadline: "Acme",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewQuery(tt.query)
+			if got := q.Matches(tt.fields...); got != tt.wantMatches {
+				t.Errorf("Matches() = %v, want %v", got, tt.wantMatches)
+			}
+			if got := q.Rank(tt.fields...); math.Abs(got-tt.wantRank) > 1e-9 {
+				t.Errorf("Rank() = %v, want %v", got, tt.wantRank)
+			}
+			if got := q.Headline(tt.fields[0]); got != tt.wantHeadline {
+				t.Errorf("Headline() = %q, want %q", got, tt.wantHeadline)
+			}
+		})
+	}
+}
+
+func TestWordSimilarity(t *testing.T) {
+	tests := []struct {
+		query string
+		text  string
+		want  float64
+	}{
+		{query: "word", text: "two words", want: 0.8},
+		{query: "acm", text: "Acme", want: 0.75},
+		{query: "acme", text: "acme", want: 1},
+		{query: "acm", text: "Bacme", want: 0.25},
+		{query: "", text: "Acme", want: 0},
+		{query: "xyz", text: "Acme", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query+" "+tt.text, func(t *testing.T) {
+			if got := WordSimilarity(tt.query, tt.text); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("WordSimilarity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}